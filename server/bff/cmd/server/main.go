@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -9,6 +12,7 @@ import (
 	"github.com/tadasy/mytodo202507/server/bff/internal/api/handlers"
 	customMiddleware "github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
 	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/jobs"
 )
 
 func main() {
@@ -25,13 +29,50 @@ func main() {
 	}
 	defer todoClient.Close()
 
+	// Access tokens are verified with the user service's public keys;
+	// session checks are cached briefly to spare the user service
+	keys := customMiddleware.NewKeyCache(userClient, 5*time.Minute)
+	sessions := customMiddleware.NewSessionCache(userClient, 30*time.Second)
+	tokens := customMiddleware.NewTokenCache(userClient, 30*time.Second)
+	preferences := customMiddleware.NewPreferencesCache(userClient, 5*time.Minute)
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(userClient)
-	todoHandler := handlers.NewTodoHandler(todoClient)
+	authHandler := handlers.NewAuthHandler(userClient, sessions)
+	sessionHandler := handlers.NewSessionHandler(userClient, sessions)
+	profileHandler := handlers.NewProfileHandler(userClient, sessions)
+	preferencesHandler := handlers.NewPreferencesHandler(userClient, todoClient, preferences)
+	twoFactorHandler := handlers.NewTwoFactorHandler(userClient)
+	tokenHandler := handlers.NewPersonalAccessTokenHandler(userClient, tokens)
+	jwksHandler := handlers.NewJWKSHandler(keys)
+	todoHandler := handlers.NewTodoHandler(todoClient, userClient)
+	commentHandler := handlers.NewCommentHandler(todoClient, userClient)
+	tagHandler := handlers.NewTagHandler(todoClient)
+	projectHandler := handlers.NewProjectHandler(todoClient, userClient, preferences)
+	viewHandler := handlers.NewViewHandler(todoClient)
+	adminHandler := handlers.NewAdminHandler(userClient, todoClient)
+	workspaceHandler := handlers.NewWorkspaceHandler(userClient, todoClient)
+
+	// Accounts are deleted once the grace period the user service gives
+	// them is over; ACCOUNT_PURGE_INTERVAL sets how often that is checked
+	purgeInterval := time.Hour
+	if env := os.Getenv("ACCOUNT_PURGE_INTERVAL"); env != "" {
+		purgeInterval, err = time.ParseDuration(env)
+		if err != nil || purgeInterval <= 0 {
+			log.Fatalf("Invalid ACCOUNT_PURGE_INTERVAL: %q", env)
+		}
+	}
+	go jobs.NewAccountPurger(userClient, todoClient, purgeInterval).Run(context.Background())
 
 	// Initialize Echo
 	e := echo.New()
 
+	// Client addresses are taken from X-Forwarded-For only when it was set
+	// by one of TRUSTED_PROXIES
+	e.IPExtractor, err = customMiddleware.IPExtractor(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
@@ -41,18 +82,121 @@ func main() {
 	// Public routes
 	e.POST("/api/auth/register", authHandler.Register)
 	e.POST("/api/auth/login", authHandler.Login)
+	e.POST("/api/auth/refresh", authHandler.Refresh)
+	e.POST("/api/auth/password-reset", authHandler.RequestPasswordReset)
+	e.POST("/api/auth/password-reset/confirm", authHandler.ConfirmPasswordReset)
+	e.POST("/api/auth/verify-email", authHandler.VerifyEmail)
+	e.POST("/api/auth/verify-email/resend", authHandler.ResendVerification)
+	e.POST("/api/auth/2fa/verify", authHandler.VerifyTwoFactor)
+	e.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	// Protected routes
 	api := e.Group("/api")
-	api.Use(customMiddleware.JWTMiddleware)
-
-	// Todo routes
-	api.POST("/todos", todoHandler.CreateTodo)
-	api.GET("/todos", todoHandler.ListTodos)
-	api.GET("/todos/:id", todoHandler.GetTodo)
-	api.PUT("/todos/:id", todoHandler.UpdateTodo)
-	api.PUT("/todos/:id/complete", todoHandler.MarkTodoComplete)
-	api.DELETE("/todos/:id", todoHandler.DeleteTodo)
+	api.Use(customMiddleware.JWTMiddleware(keys, sessions, tokens))
+
+	// Under the write policy unverified accounts can only read; the user
+	// service enforces the login policy itself
+	if os.Getenv("EMAIL_VERIFICATION_POLICY") == "write" {
+		api.Use(customMiddleware.RequireVerifiedEmail())
+	}
+
+	// The user's time zone and list defaults apply to every request
+	api.Use(customMiddleware.LoadPreferences(preferences))
+
+	// Personal access tokens can't manage the account
+	sessionOnly := customMiddleware.SessionOnly()
+
+	// Account routes; changing the email or password and deleting the
+	// account need the current password
+	api.GET("/me", profileHandler.GetProfile, sessionOnly)
+	api.PATCH("/me", profileHandler.UpdateProfile, sessionOnly)
+	api.DELETE("/me", profileHandler.DeleteAccount, sessionOnly)
+	api.POST("/me/deletion/cancel", profileHandler.CancelDeletion, sessionOnly)
+	api.GET("/me/preferences", preferencesHandler.GetPreferences, sessionOnly)
+	api.PATCH("/me/preferences", preferencesHandler.UpdatePreferences, sessionOnly)
+
+	// Session routes
+	api.POST("/auth/logout", authHandler.Logout, sessionOnly)
+	api.GET("/me/sessions", sessionHandler.ListSessions, sessionOnly)
+	api.DELETE("/me/sessions", sessionHandler.RevokeOtherSessions, sessionOnly)
+	api.DELETE("/me/sessions/:id", sessionHandler.RevokeSession, sessionOnly)
+
+	// Two-factor authentication routes
+	api.GET("/me/2fa", twoFactorHandler.GetStatus, sessionOnly)
+	api.POST("/me/2fa/enroll", twoFactorHandler.Enroll, sessionOnly)
+	api.POST("/me/2fa/confirm", twoFactorHandler.Confirm, sessionOnly)
+	api.POST("/me/2fa/disable", twoFactorHandler.Disable, sessionOnly)
+
+	// Personal access token routes
+	api.POST("/me/tokens", tokenHandler.CreateToken, sessionOnly)
+	api.GET("/me/tokens", tokenHandler.ListTokens, sessionOnly)
+	api.DELETE("/me/tokens/:id", tokenHandler.RevokeToken, sessionOnly)
+
+	// Workspace routes
+	api.POST("/workspaces", workspaceHandler.CreateWorkspace, sessionOnly)
+	api.GET("/workspaces", workspaceHandler.ListWorkspaces, sessionOnly)
+	api.GET("/workspaces/:workspace_id", workspaceHandler.GetWorkspace, sessionOnly)
+	api.POST("/workspaces/:workspace_id/invitations", workspaceHandler.InviteToWorkspace, sessionOnly)
+	api.POST("/workspaces/:workspace_id/leave", workspaceHandler.LeaveWorkspace, sessionOnly)
+	api.PUT("/workspaces/:workspace_id/members/:user_id/role", workspaceHandler.SetMemberRole, sessionOnly)
+	api.DELETE("/workspaces/:workspace_id/members/:user_id", workspaceHandler.RemoveMember, sessionOnly)
+	api.GET("/me/invitations", workspaceHandler.ListInvitations, sessionOnly)
+	api.POST("/me/invitations/:id/accept", workspaceHandler.AcceptInvitation, sessionOnly)
+
+	// Todos, comments, tags and projects are registered twice: for the
+	// user's own data and, under /workspaces/:workspace_id, for a shared
+	// workspace
+	ws := api.Group("/workspaces/:workspace_id", customMiddleware.InWorkspace(userClient))
+	for _, g := range []*echo.Group{api, ws} {
+		// Todo routes
+		g.POST("/todos", todoHandler.CreateTodo)
+		g.GET("/todos", todoHandler.ListTodos)
+		g.GET("/todos/search", todoHandler.SearchTodos)
+		g.GET("/todos/:id", todoHandler.GetTodo)
+		g.PUT("/todos/:id", todoHandler.UpdateTodo)
+		g.PUT("/todos/:id/complete", todoHandler.MarkTodoComplete)
+		g.PUT("/todos/:id/assignee", todoHandler.AssignTodo)
+		g.DELETE("/todos/:id/assignee", todoHandler.UnassignTodo)
+		g.DELETE("/todos/:id", todoHandler.DeleteTodo)
+
+		// Comment routes
+		g.POST("/todos/:id/comments", commentHandler.AddComment)
+		g.GET("/todos/:id/comments", commentHandler.ListComments)
+		g.PUT("/todos/:id/comments/:comment_id", commentHandler.EditComment)
+		g.DELETE("/todos/:id/comments/:comment_id", commentHandler.DeleteComment)
+
+		// Tag routes
+		g.POST("/tags", tagHandler.CreateTag)
+		g.GET("/tags", tagHandler.ListTags)
+		g.PUT("/tags/:id", tagHandler.UpdateTag)
+		g.DELETE("/tags/:id", tagHandler.DeleteTag)
+
+		// Project routes
+		g.POST("/projects", projectHandler.CreateProject)
+		g.GET("/projects", projectHandler.ListProjects)
+		g.GET("/projects/:id", projectHandler.GetProject)
+		g.PUT("/projects/:id", projectHandler.UpdateProject)
+		g.DELETE("/projects/:id", projectHandler.DeleteProject)
+	}
+
+	// Saved view routes
+	api.POST("/views", viewHandler.CreateView)
+	api.GET("/views", viewHandler.ListViews)
+	api.GET("/views/:id", viewHandler.GetView)
+	api.GET("/views/:id/todos", viewHandler.ListViewTodos)
+	api.PUT("/views/:id", viewHandler.UpdateView)
+	api.DELETE("/views/:id", viewHandler.DeleteView)
+
+	// Admin routes; personal access tokens carry no role, so they never
+	// have these permissions
+	readUsers := customMiddleware.RequirePermission(customMiddleware.PermissionUsersRead)
+	manageUsers := customMiddleware.RequirePermission(customMiddleware.PermissionUsersManage)
+	api.GET("/admin/users", adminHandler.ListUsers, readUsers)
+	api.GET("/admin/users/:id", adminHandler.GetUser, readUsers)
+	api.POST("/admin/users/:id/disable", adminHandler.DisableUser, manageUsers)
+	api.POST("/admin/users/:id/enable", adminHandler.EnableUser, manageUsers)
+	api.PUT("/admin/users/:id/role", adminHandler.SetRole, manageUsers)
+	api.POST("/admin/users/:id/password-reset", adminHandler.ForcePasswordReset, manageUsers)
 
 	// Start server
 	log.Println("BFF server starting on port 8080...")
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// maxUserPageLimit mirrors the page size limit of the user service
+const maxUserPageLimit = 200
+
+// AdminHandler serves the administration of other people's accounts. The
+// routes check the caller's permissions with middleware.RequirePermission.
+type AdminHandler struct {
+	userClient *clients.UserServiceClient
+	todoClient *clients.TodoServiceClient
+}
+
+func NewAdminHandler(userClient *clients.UserServiceClient, todoClient *clients.TodoServiceClient) *AdminHandler {
+	return &AdminHandler{
+		userClient: userClient,
+		todoClient: todoClient,
+	}
+}
+
+// ListUsers handles GET /api/admin/users?q=&limit=&cursor=. The listing is
+// always paged, 50 users per page by default.
+func (h *AdminHandler) ListUsers(c echo.Context) error {
+	page, err := pageOptions(c)
+	if err != nil {
+		return err
+	}
+	if page.Limit > maxUserPageLimit {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxUserPageLimit))
+	}
+
+	users, err := h.userClient.ListUsers(c.Request().Context(), c.QueryParam("q"), page)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := h.fillTodoCounts(c.Request().Context(), users.Users); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, users)
+}
+
+func (h *AdminHandler) GetUser(c echo.Context) error {
+	user, err := h.userClient.GetUser(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	adminUser := &models.AdminUser{User: *user}
+	if err := h.fillTodoCounts(c.Request().Context(), []*models.AdminUser{adminUser}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, adminUser)
+}
+
+// DisableUser signs the user out everywhere and refuses their password and
+// personal access tokens until EnableUser
+func (h *AdminHandler) DisableUser(c echo.Context) error {
+	id := c.Param("id")
+	if id == middleware.GetUserIDFromContext(c) {
+		return echo.NewHTTPError(http.StatusBadRequest, "you can't disable your own account")
+	}
+
+	return h.setDisabled(c, id, true)
+}
+
+func (h *AdminHandler) EnableUser(c echo.Context) error {
+	return h.setDisabled(c, c.Param("id"), false)
+}
+
+func (h *AdminHandler) setDisabled(c echo.Context, id string, disabled bool) error {
+	user, err := h.userClient.SetUserDisabled(c.Request().Context(), middleware.GetUserIDFromContext(c), id, disabled)
+	if errors.Is(err, clients.ErrLastAdmin) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// SetRole handles PUT /api/admin/users/:id/role. Administrators can't take
+// the role away from themselves or from the last active administrator.
+func (h *AdminHandler) SetRole(c echo.Context) error {
+	var req models.SetUserRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Role != middleware.RoleUser && req.Role != middleware.RoleAdmin {
+		return echo.NewHTTPError(http.StatusBadRequest, "role must be user or admin")
+	}
+
+	id := c.Param("id")
+	if id == middleware.GetUserIDFromContext(c) && req.Role != middleware.RoleAdmin {
+		return echo.NewHTTPError(http.StatusBadRequest, "you can't remove your own admin role")
+	}
+
+	user, err := h.userClient.SetUserRole(c.Request().Context(), middleware.GetUserIDFromContext(c), id, req.Role)
+	if errors.Is(err, clients.ErrLastAdmin) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// ForcePasswordReset makes the user's password stop working, signs them out
+// and mails them a password reset link
+func (h *AdminHandler) ForcePasswordReset(c echo.Context) error {
+	if err := h.userClient.ForcePasswordReset(c.Request().Context(), c.Param("id")); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password reset email sent"})
+}
+
+// fillTodoCounts asks the todo service for the todo counts of the users
+func (h *AdminHandler) fillTodoCounts(ctx context.Context, users []*models.AdminUser) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	counts, err := h.todoClient.CountTodosByUser(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		user.Todos = counts[user.ID]
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 
 	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
@@ -14,11 +16,13 @@ import (
 
 type AuthHandler struct {
 	userClient *clients.UserServiceClient
+	sessions   *middleware.SessionCache
 }
 
-func NewAuthHandler(userClient *clients.UserServiceClient) *AuthHandler {
+func NewAuthHandler(userClient *clients.UserServiceClient, sessions *middleware.SessionCache) *AuthHandler {
 	return &AuthHandler{
 		userClient: userClient,
+		sessions:   sessions,
 	}
 }
 
@@ -29,20 +33,28 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	}
 
 	user, err := h.userClient.CreateUser(c.Request().Context(), req.Email, req.Password)
+	var policyErr *clients.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return passwordRejected(c, policyErr.FieldErrors("password"))
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusConflict, err.Error())
 	}
 
-	// Generate JWT token
-	token, err := h.generateToken(user.ID, user.Email)
+	// Sign the new user in to obtain their tokens
+	auth, err := h.userClient.AuthenticateUser(c.Request().Context(), req.Email, req.Password, c.Request().UserAgent(), c.RealIP())
+	if errors.Is(err, clients.ErrEmailNotVerified) {
+		// The policy requires verification before the first sign-in
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"user":    user,
+			"message": "check your email to verify your address, then sign in",
+		})
+	}
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate token")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to issue tokens")
 	}
 
-	return c.JSON(http.StatusCreated, models.AuthResponse{
-		User:  *user,
-		Token: token,
-	})
+	return c.JSON(http.StatusCreated, auth)
 }
 
 func (h *AuthHandler) Login(c echo.Context) error {
@@ -51,33 +63,182 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
 
-	user, _, err := h.userClient.AuthenticateUser(c.Request().Context(), req.Email, req.Password)
+	auth, err := h.userClient.AuthenticateUser(c.Request().Context(), req.Email, req.Password, c.Request().UserAgent(), c.RealIP())
+	if errors.Is(err, clients.ErrAccountDisabled) {
+		return echo.NewHTTPError(http.StatusForbidden, "account disabled")
+	}
+	if errors.Is(err, clients.ErrEmailNotVerified) {
+		return echo.NewHTTPError(http.StatusForbidden, "email not verified")
+	}
+	// The password was right; the client continues at /api/auth/2fa/verify
+	var twoFactor *clients.TwoFactorRequiredError
+	if errors.As(err, &twoFactor) {
+		return c.JSON(http.StatusOK, twoFactor.Challenge)
+	}
+	var blocked *clients.LoginBlockedError
+	if errors.As(err, &blocked) {
+		return loginBlocked(c, blocked)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusUnauthorized, "invalid credentials")
 	}
 
-	// Generate JWT token
-	token, err := h.generateToken(user.ID, user.Email)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate token")
+	return c.JSON(http.StatusOK, auth)
+}
+
+// passwordRejected relays the password policy violations as field errors
+func passwordRejected(c echo.Context, errs []models.FieldError) error {
+	return c.JSON(http.StatusUnprocessableEntity, models.ValidationError{
+		Message: "password does not meet the password policy",
+		Errors:  errs,
+	})
+}
+
+// loginBlocked tells the client when it may try again, and whether the
+// account itself is locked so the UI can explain what happened
+func loginBlocked(c echo.Context, blocked *clients.LoginBlockedError) error {
+	message := "too many failed login attempts, try again later"
+	if blocked.AccountLocked {
+		message = "account temporarily locked after too many failed login attempts"
+	}
+
+	seconds := math.Ceil(time.Until(blocked.RetryAt).Seconds())
+	if seconds < 1 {
+		seconds = 1
 	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(int(seconds)))
 
-	return c.JSON(http.StatusOK, models.AuthResponse{
-		User:  *user,
-		Token: token,
+	return c.JSON(http.StatusTooManyRequests, models.LoginBlocked{
+		Message:       message,
+		AccountLocked: blocked.AccountLocked,
+		RetryAt:       blocked.RetryAt,
 	})
 }
 
-func (h *AuthHandler) generateToken(userID, email string) (string, error) {
-	claims := middleware.Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// VerifyTwoFactor completes a login that returned two_factor_required with a
+// TOTP code or a recovery code
+func (h *AuthHandler) VerifyTwoFactor(c echo.Context) error {
+	var req models.VerifyTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.TwoFactorToken == "" || req.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "two_factor_token and code are required")
+	}
+
+	auth, err := h.userClient.VerifyTwoFactor(c.Request().Context(), req.TwoFactorToken, req.Code)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, auth)
+}
+
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req models.RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.RefreshToken == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "refresh_token is required")
+	}
+
+	auth, err := h.userClient.RefreshToken(c.Request().Context(), req.RefreshToken, c.RealIP())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid refresh token")
+	}
+
+	return c.JSON(http.StatusOK, auth)
+}
+
+// RequestPasswordReset mails a reset link. The response is the same whether
+// or not the address belongs to an account.
+func (h *AuthHandler) RequestPasswordReset(c echo.Context) error {
+	var req models.PasswordResetRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Email == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "email is required")
+	}
+
+	if err := h.userClient.RequestPasswordReset(c.Request().Context(), req.Email); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to request password reset")
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "if the address belongs to an account, a reset link has been sent"})
+}
+
+// ConfirmPasswordReset sets a new password with a mailed token; every session
+// of the account is signed out
+func (h *AuthHandler) ConfirmPasswordReset(c echo.Context) error {
+	var req models.ConfirmPasswordResetRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	err := h.userClient.ConfirmPasswordReset(c.Request().Context(), req.Token, req.NewPassword)
+	var policyErr *clients.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return passwordRejected(c, policyErr.FieldErrors("new_password"))
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password reset successfully"})
+}
+
+// VerifyEmail confirms an address with a mailed token. Access tokens issued
+// before carry the old verification state until they are refreshed.
+func (h *AuthHandler) VerifyEmail(c echo.Context) error {
+	var req models.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Token == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "token is required")
+	}
+
+	user, err := h.userClient.VerifyEmail(c.Request().Context(), req.Token)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// ResendVerification mails a new verification link. The response is the same
+// whether or not the address belongs to an account.
+func (h *AuthHandler) ResendVerification(c echo.Context) error {
+	var req models.ResendVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Email == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "email is required")
+	}
+
+	if err := h.userClient.ResendVerificationEmail(c.Request().Context(), req.Email); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to resend verification email")
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "if the address needs verifying, a new link has been sent"})
+}
+
+// Logout ends the session of the request's access token, revoking its
+// refresh token as well
+func (h *AuthHandler) Logout(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	sessionID := middleware.GetSessionIDFromContext(c)
+	if userID == "" || sessionID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	if err := h.userClient.RevokeSession(c.Request().Context(), sessionID, userID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	h.sessions.Revoked(sessionID)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(middleware.JWTSecret)
+	return c.JSON(http.StatusOK, map[string]string{"message": "logged out successfully"})
 }
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+)
+
+// recordingUserService remembers the client IPs logins are throttled by
+type recordingUserService struct {
+	pb.UnimplementedUserServiceServer
+
+	mu  sync.Mutex
+	ips []string
+}
+
+func (s *recordingUserService) AuthenticateUser(ctx context.Context, req *pb.AuthenticateUserRequest) (*pb.AuthenticateUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ips = append(s.ips, req.IpAddress)
+	return &pb.AuthenticateUserResponse{Error: "invalid credentials"}, nil
+}
+
+func (s *recordingUserService) attempts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ips...)
+}
+
+func newLoginServer(t *testing.T, trustedProxies string) (*echo.Echo, *recordingUserService) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	users := &recordingUserService{}
+	s := grpc.NewServer()
+	pb.RegisterUserServiceServer(s, users)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	userClient, err := clients.NewUserServiceClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { userClient.Close() })
+
+	e := echo.New()
+	e.IPExtractor, err = middleware.IPExtractor(trustedProxies)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	e.POST("/api/auth/login", NewAuthHandler(userClient, nil).Login)
+	return e, users
+}
+
+func login(e *echo.Echo, remoteAddr string, headers map[string]string) {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"email":"a@example.com","password":"wrong"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	e.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestAuthHandler_Login_IgnoresSpoofedForwardingHeaders(t *testing.T) {
+	e, users := newLoginServer(t, "")
+
+	login(e, "203.0.113.7:51000", nil)
+	login(e, "203.0.113.7:51001", map[string]string{"X-Forwarded-For": "198.51.100.1"})
+	login(e, "203.0.113.7:51002", map[string]string{"X-Real-IP": "198.51.100.2"})
+
+	ips := users.attempts()
+	if len(ips) != 3 {
+		t.Fatalf("Expected 3 login attempts, got %d", len(ips))
+	}
+	for i, ip := range ips {
+		if ip != "203.0.113.7" {
+			t.Errorf("Attempt %d: expected the throttle key 203.0.113.7, got %q", i, ip)
+		}
+	}
+}
+
+func TestAuthHandler_Login_TrustedProxy(t *testing.T) {
+	e, users := newLoginServer(t, "10.0.0.1")
+
+	// Only the address the trusted proxy appended counts, not what the client wrote
+	login(e, "10.0.0.1:443", map[string]string{"X-Forwarded-For": "198.51.100.1, 203.0.113.7"})
+	// Headers from untrusted peers are ignored
+	login(e, "203.0.113.8:443", map[string]string{"X-Forwarded-For": "198.51.100.1"})
+
+	ips := users.attempts()
+	if len(ips) != 2 || ips[0] != "203.0.113.7" || ips[1] != "203.0.113.8" {
+		t.Errorf("Expected throttle keys [203.0.113.7 203.0.113.8], got %v", ips)
+	}
+}
+
+func TestIPExtractor_InvalidProxy(t *testing.T) {
+	if _, err := middleware.IPExtractor("10.0.0.1, not-an-ip"); err == nil {
+		t.Error("Expected an error for an invalid proxy")
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type CommentHandler struct {
+	todoClient *clients.TodoServiceClient
+	userClient *clients.UserServiceClient
+}
+
+func NewCommentHandler(todoClient *clients.TodoServiceClient, userClient *clients.UserServiceClient) *CommentHandler {
+	return &CommentHandler{
+		todoClient: todoClient,
+		userClient: userClient,
+	}
+}
+
+// AddComment handles POST /api/todos/:id/comments
+func (h *CommentHandler) AddComment(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	todoID := c.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "todo ID is required")
+	}
+
+	var req models.CommentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	comment, err := h.todoClient.AddComment(c.Request().Context(), todoID, userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.attachAuthorEmails(c.Request().Context(), comment)
+
+	return c.JSON(http.StatusCreated, comment)
+}
+
+// ListComments handles GET /api/todos/:id/comments
+func (h *CommentHandler) ListComments(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	todoID := c.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "todo ID is required")
+	}
+
+	comments, err := h.todoClient.ListComments(c.Request().Context(), todoID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	h.attachAuthorEmails(c.Request().Context(), comments...)
+
+	return c.JSON(http.StatusOK, comments)
+}
+
+// EditComment handles PUT /api/todos/:id/comments/:comment_id; only the
+// author can edit a comment
+func (h *CommentHandler) EditComment(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	todoID := c.Param("id")
+	commentID := c.Param("comment_id")
+	if todoID == "" || commentID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "todo ID and comment ID are required")
+	}
+
+	var req models.CommentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	comment, err := h.todoClient.EditComment(c.Request().Context(), commentID, todoID, userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.attachAuthorEmails(c.Request().Context(), comment)
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment handles DELETE /api/todos/:id/comments/:comment_id
+func (h *CommentHandler) DeleteComment(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	todoID := c.Param("id")
+	commentID := c.Param("comment_id")
+	if todoID == "" || commentID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "todo ID and comment ID are required")
+	}
+
+	err := h.todoClient.DeleteComment(c.Request().Context(), commentID, todoID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "comment deleted successfully"})
+}
+
+// attachAuthorEmails fills in the authors' email addresses, looking up each
+// author once however many comments they wrote
+func (h *CommentHandler) attachAuthorEmails(ctx context.Context, comments ...*models.Comment) {
+	var ids []string
+	for _, comment := range comments {
+		ids = append(ids, comment.AuthorID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	emails := h.userClient.GetUserEmails(ctx, ids)
+	for _, comment := range comments {
+		comment.AuthorEmail = emails[comment.AuthorID]
+	}
+}
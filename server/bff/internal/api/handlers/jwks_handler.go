@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+)
+
+type JWKSHandler struct {
+	keys *middleware.KeyCache
+}
+
+func NewJWKSHandler(keys *middleware.KeyCache) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS publishes the public keys that verify our access tokens so that
+// other services can check them without a shared secret
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	keys, err := h.keys.JWKS(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "signing keys unavailable")
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, map[string]interface{}{"keys": keys})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// PersonalAccessTokenHandler manages the signed-in user's tokens for scripts
+type PersonalAccessTokenHandler struct {
+	userClient *clients.UserServiceClient
+	tokens     *middleware.TokenCache
+}
+
+func NewPersonalAccessTokenHandler(userClient *clients.UserServiceClient, tokens *middleware.TokenCache) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		userClient: userClient,
+		tokens:     tokens,
+	}
+}
+
+// CreateToken returns the new token including its secret, which can't be
+// shown again
+func (h *PersonalAccessTokenHandler) CreateToken(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.CreatePersonalAccessTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	token, err := h.userClient.CreatePersonalAccessToken(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, token)
+}
+
+func (h *PersonalAccessTokenHandler) ListTokens(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	tokens, err := h.userClient.ListPersonalAccessTokens(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (h *PersonalAccessTokenHandler) RevokeToken(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	id := c.Param("id")
+	if err := h.userClient.RevokePersonalAccessToken(c.Request().Context(), id, userID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	h.tokens.Revoked(id)
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "token revoked successfully"})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type PreferencesHandler struct {
+	userClient  *clients.UserServiceClient
+	todoClient  *clients.TodoServiceClient
+	preferences *middleware.PreferencesCache
+}
+
+func NewPreferencesHandler(userClient *clients.UserServiceClient, todoClient *clients.TodoServiceClient, preferences *middleware.PreferencesCache) *PreferencesHandler {
+	return &PreferencesHandler{
+		userClient:  userClient,
+		todoClient:  todoClient,
+		preferences: preferences,
+	}
+}
+
+func (h *PreferencesHandler) GetPreferences(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	prefs, err := h.userClient.GetPreferences(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PATCH /api/me/preferences. The default project
+// must be one of the user's projects.
+func (h *PreferencesHandler) UpdatePreferences(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.UpdatePreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.DefaultProjectID != nil && *req.DefaultProjectID != "" {
+		if _, err := h.todoClient.GetProject(c.Request().Context(), *req.DefaultProjectID, userID); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "default_project_id: "+err.Error())
+		}
+	}
+
+	prefs, err := h.userClient.UpdatePreferences(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.preferences.Store(userID, prefs)
+
+	return c.JSON(http.StatusOK, prefs)
+}
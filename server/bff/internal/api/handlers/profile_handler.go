@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// ProfileHandler lets the signed-in user manage their own account
+type ProfileHandler struct {
+	userClient *clients.UserServiceClient
+	sessions   *middleware.SessionCache
+}
+
+func NewProfileHandler(userClient *clients.UserServiceClient, sessions *middleware.SessionCache) *ProfileHandler {
+	return &ProfileHandler{
+		userClient: userClient,
+		sessions:   sessions,
+	}
+}
+
+func (h *ProfileHandler) GetProfile(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	user, err := h.userClient.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile handles PATCH /api/me. The current password confirms the
+// change; a new email is mailed a verification link and replaces the old
+// one once opened, and a new password signs out the other sessions.
+func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Email == "" && req.Password == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "email or password is required")
+	}
+	if req.CurrentPassword == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "current_password is required")
+	}
+
+	user, err := h.userClient.UpdateUser(c.Request().Context(), userID, req.Email, req.Password, req.CurrentPassword)
+	if errors.Is(err, clients.ErrIncorrectPassword) {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+	var policyErr *clients.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return passwordRejected(c, policyErr.FieldErrors("password"))
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// The password is changed either way, so a failure here is only logged
+	if req.Password != "" {
+		if _, err := revokeOtherSessions(c.Request().Context(), h.userClient, h.sessions, userID, middleware.GetSessionIDFromContext(c)); err != nil {
+			log.Printf("Failed to revoke other sessions of user %s after a password change: %v", userID, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// DeleteAccount handles DELETE /api/me. Confirmed with the current password,
+// it schedules the deletion of the account and all of its todos after a
+// grace period, during which CancelDeletion keeps the account. The last
+// owner of a shared workspace has to hand it over first.
+func (h *ProfileHandler) DeleteAccount(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.DeleteAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.CurrentPassword == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "current_password is required")
+	}
+
+	user, err := h.userClient.ScheduleAccountDeletion(c.Request().Context(), userID, req.CurrentPassword)
+	if errors.Is(err, clients.ErrIncorrectPassword) {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+	if errors.Is(err, clients.ErrLastWorkspaceOwner) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, user)
+}
+
+// CancelDeletion keeps an account whose deletion is scheduled
+func (h *ProfileHandler) CancelDeletion(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	user, err := h.userClient.CancelAccountDeletion(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type ProjectHandler struct {
+	todoClient  *clients.TodoServiceClient
+	userClient  *clients.UserServiceClient
+	preferences *middleware.PreferencesCache
+}
+
+func NewProjectHandler(todoClient *clients.TodoServiceClient, userClient *clients.UserServiceClient, preferences *middleware.PreferencesCache) *ProjectHandler {
+	return &ProjectHandler{
+		todoClient:  todoClient,
+		userClient:  userClient,
+		preferences: preferences,
+	}
+}
+
+func (h *ProjectHandler) CreateProject(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	var req models.CreateProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	project, err := h.todoClient.CreateProject(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, project)
+}
+
+func (h *ProjectHandler) GetProject(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "project ID is required")
+	}
+
+	project, err := h.todoClient.GetProject(c.Request().Context(), projectID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+func (h *ProjectHandler) ListProjects(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	projects, err := h.todoClient.ListProjects(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, projects)
+}
+
+func (h *ProjectHandler) UpdateProject(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "project ID is required")
+	}
+
+	var req models.UpdateProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	project, err := h.todoClient.UpdateProject(c.Request().Context(), projectID, userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+func (h *ProjectHandler) DeleteProject(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "project ID is required")
+	}
+
+	err := h.todoClient.DeleteProject(c.Request().Context(), projectID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// The project is gone either way, so a failure here is only logged
+	if prefs := middleware.GetPreferencesFromContext(c); prefs != nil && prefs.DefaultProjectID == projectID {
+		if err := h.clearDefaultProject(c, userID); err != nil {
+			log.Printf("Failed to clear the default project of user %s: %v", userID, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "project deleted successfully"})
+}
+
+func (h *ProjectHandler) clearDefaultProject(c echo.Context, userID string) error {
+	none := ""
+	prefs, err := h.userClient.UpdatePreferences(c.Request().Context(), userID, &models.UpdatePreferencesRequest{DefaultProjectID: &none})
+	if err != nil {
+		return err
+	}
+	h.preferences.Store(userID, prefs)
+	return nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+)
+
+type SessionHandler struct {
+	userClient *clients.UserServiceClient
+	sessions   *middleware.SessionCache
+}
+
+func NewSessionHandler(userClient *clients.UserServiceClient, sessions *middleware.SessionCache) *SessionHandler {
+	return &SessionHandler{
+		userClient: userClient,
+		sessions:   sessions,
+	}
+}
+
+func (h *SessionHandler) ListSessions(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	sessions, err := h.userClient.ListSessions(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	currentID := middleware.GetSessionIDFromContext(c)
+	for _, session := range sessions {
+		session.Current = session.ID == currentID
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession signs out one of the user's sessions, e.g. a lost device
+func (h *SessionHandler) RevokeSession(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	id := c.Param("id")
+	if err := h.userClient.RevokeSession(c.Request().Context(), id, userID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	h.sessions.Revoked(id)
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "session revoked successfully"})
+}
+
+// RevokeOtherSessions signs out every session except the current one
+func (h *SessionHandler) RevokeOtherSessions(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	revoked, err := revokeOtherSessions(c.Request().Context(), h.userClient, h.sessions, userID, middleware.GetSessionIDFromContext(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{"revoked": revoked})
+}
+
+// revokeOtherSessions signs the user out everywhere except currentID and
+// returns the number of sessions revoked
+func revokeOtherSessions(ctx context.Context, userClient *clients.UserServiceClient, cache *middleware.SessionCache, userID, currentID string) (int, error) {
+	// Remember the sessions so this instance rejects them right away
+	sessions, err := userClient.ListSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := userClient.RevokeAllSessions(ctx, userID, currentID)
+	for _, session := range sessions {
+		if session.ID != currentID {
+			cache.Revoked(session.ID)
+		}
+	}
+	return revoked, err
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type TagHandler struct {
+	todoClient *clients.TodoServiceClient
+}
+
+func NewTagHandler(todoClient *clients.TodoServiceClient) *TagHandler {
+	return &TagHandler{
+		todoClient: todoClient,
+	}
+}
+
+func (h *TagHandler) CreateTag(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	var req models.CreateTagRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	tag, err := h.todoClient.CreateTag(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+func (h *TagHandler) ListTags(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	tags, err := h.todoClient.ListTags(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (h *TagHandler) UpdateTag(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	tagID := c.Param("id")
+	if tagID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "tag ID is required")
+	}
+
+	var req models.UpdateTagRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	tag, err := h.todoClient.UpdateTag(c.Request().Context(), tagID, userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	tagID := c.Param("id")
+	if tagID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "tag ID is required")
+	}
+
+	err := h.todoClient.DeleteTag(c.Request().Context(), tagID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "tag deleted successfully"})
+}
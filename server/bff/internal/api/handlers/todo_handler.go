@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
@@ -11,13 +15,21 @@ import (
 	"github.com/tadasy/mytodo202507/server/bff/internal/models"
 )
 
+// maxPageLimit and maxSearchLimit mirror the limits of the todo service
+const (
+	maxPageLimit   = 500
+	maxSearchLimit = 100
+)
+
 type TodoHandler struct {
 	todoClient *clients.TodoServiceClient
+	userClient *clients.UserServiceClient
 }
 
-func NewTodoHandler(todoClient *clients.TodoServiceClient) *TodoHandler {
+func NewTodoHandler(todoClient *clients.TodoServiceClient, userClient *clients.UserServiceClient) *TodoHandler {
 	return &TodoHandler{
 		todoClient: todoClient,
+		userClient: userClient,
 	}
 }
 
@@ -26,13 +38,22 @@ func (h *TodoHandler) CreateTodo(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
 
 	var req models.CreateTodoRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
+	// Subtasks stay where their parent is, and the default project is a
+	// personal one, so it doesn't apply in a workspace
+	prefs := middleware.GetPreferencesFromContext(c)
+	if prefs != nil && req.ProjectID == "" && req.ParentID == "" && middleware.GetWorkspaceIDFromContext(c) == "" {
+		req.ProjectID = prefs.DefaultProjectID
+	}
 
-	todo, err := h.todoClient.CreateTodo(c.Request().Context(), userID, req.Title, req.Description)
+	todo, err := h.todoClient.CreateTodo(c.Request().Context(), userID, &req)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -45,6 +66,9 @@ func (h *TodoHandler) GetTodo(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
 
 	todoID := c.Param("id")
 	if todoID == "" {
@@ -55,6 +79,7 @@ func (h *TodoHandler) GetTodo(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusNotFound, err.Error())
 	}
+	h.attachAssigneeEmails(c.Request().Context(), todo)
 
 	return c.JSON(http.StatusOK, todo)
 }
@@ -64,24 +89,131 @@ func (h *TodoHandler) ListTodos(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
 
 	// Check if we should list completed todos only
 	completedOnly, _ := strconv.ParseBool(c.QueryParam("completed"))
 
-	var todos []*models.Todo
+	opts := models.TodoListOptions{
+		Due:   c.QueryParam("due"),
+		Sort:  c.QueryParam("sort"),
+		Order: c.QueryParam("order"),
+		// tags_any=a,b matches either tag; tags_all=a,b requires both
+		AnyTagIDs: splitQueryList(c.QueryParam("tags_any")),
+		AllTagIDs: splitQueryList(c.QueryParam("tags_all")),
+		// project_id=none lists todos outside of any project
+		ProjectID: c.QueryParam("project_id"),
+		Filter:    c.QueryParam("filter"),
+	}
+	if prefs := middleware.GetPreferencesFromContext(c); prefs != nil && opts.Sort == "" {
+		opts.Sort, opts.Order = prefs.DefaultSort, prefs.DefaultSortOrder
+	}
+	// assignee=me lists what others handed to the user
+	switch c.QueryParam("assignee") {
+	case "":
+	case "me":
+		if completedOnly {
+			return echo.NewHTTPError(http.StatusBadRequest, "assignee can't be combined with completed")
+		}
+		opts.AssignedToMe = true
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "assignee must be me")
+	}
+	if topLevel := c.QueryParam("top_level"); topLevel != "" {
+		var err error
+		if opts.TopLevelOnly, err = strconv.ParseBool(topLevel); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "top_level must be a boolean")
+		}
+	}
+	switch opts.Due {
+	case "", "overdue", "today":
+	case "upcoming":
+		opts.DueWithinDays = 7
+		if days := c.QueryParam("days"); days != "" {
+			n, err := strconv.Atoi(days)
+			if err != nil || n < 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "days must be a positive integer")
+			}
+			opts.DueWithinDays = n
+		}
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "due must be one of overdue, today, upcoming")
+	}
+	switch opts.Sort {
+	case "", "priority", "due_at", "created_at", "updated_at", "title":
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "sort must be one of priority, due_at, created_at, updated_at, title")
+	}
+	switch opts.Order {
+	case "", "asc", "desc":
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "order must be asc or desc")
+	}
+	// Pages are only returned when limit or cursor is given
 	var err error
+	if opts.Page, err = pageOptions(c); err != nil {
+		return err
+	}
 
+	var todos *models.TodoPage
 	if completedOnly {
-		todos, err = h.todoClient.ListCompletedTodos(c.Request().Context(), userID)
+		todos, err = h.todoClient.ListCompletedTodos(c.Request().Context(), userID, opts.Page)
 	} else {
-		todos, err = h.todoClient.ListTodos(c.Request().Context(), userID)
+		todos, err = h.todoClient.ListTodos(c.Request().Context(), userID, opts)
 	}
 
+	var filterErr *models.FilterError
+	if errors.As(err, &filterErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, filterErr)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	h.attachAssigneeEmails(c.Request().Context(), todos.Todos...)
 
-	return c.JSON(http.StatusOK, todos)
+	return respondTodoPage(c, opts.Page, todos)
+}
+
+// SearchTodos handles GET /api/todos/search?q=&limit=
+func (h *TodoHandler) SearchTodos(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	query := c.QueryParam("q")
+	if strings.TrimSpace(query) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "q is required")
+	}
+	limit := 0
+	if value := c.QueryParam("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit))
+		}
+		limit = n
+	}
+
+	hits, err := h.todoClient.SearchTodos(c.Request().Context(), userID, query, limit)
+	var searchErr *clients.InvalidSearchError
+	if errors.As(err, &searchErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, searchErr.Message)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	todos := make([]*models.Todo, len(hits))
+	for i, hit := range hits {
+		todos[i] = hit.Todo
+	}
+	h.attachAssigneeEmails(c.Request().Context(), todos...)
+
+	return c.JSON(http.StatusOK, hits)
 }
 
 func (h *TodoHandler) UpdateTodo(c echo.Context) error {
@@ -89,6 +221,9 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
 
 	todoID := c.Param("id")
 	if todoID == "" {
@@ -100,10 +235,11 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
 
-	todo, err := h.todoClient.UpdateTodo(c.Request().Context(), todoID, userID, req.Title, req.Description)
+	todo, err := h.todoClient.UpdateTodo(c.Request().Context(), todoID, userID, &req)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	h.attachAssigneeEmails(c.Request().Context(), todo)
 
 	return c.JSON(http.StatusOK, todo)
 }
@@ -113,6 +249,9 @@ func (h *TodoHandler) MarkTodoComplete(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
 
 	todoID := c.Param("id")
 	if todoID == "" {
@@ -124,10 +263,11 @@ func (h *TodoHandler) MarkTodoComplete(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
 
-	todo, err := h.todoClient.MarkTodoComplete(c.Request().Context(), todoID, userID, req.Completed)
+	todo, err := h.todoClient.MarkTodoComplete(c.Request().Context(), todoID, userID, &req)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	h.attachAssigneeEmails(c.Request().Context(), todo)
 
 	return c.JSON(http.StatusOK, todo)
 }
@@ -137,6 +277,9 @@ func (h *TodoHandler) DeleteTodo(c echo.Context) error {
 	if userID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
 	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
 
 	todoID := c.Param("id")
 	if todoID == "" {
@@ -150,3 +293,112 @@ func (h *TodoHandler) DeleteTodo(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "todo deleted successfully"})
 }
+
+// AssignTodo handles PUT /api/todos/:id/assignee
+func (h *TodoHandler) AssignTodo(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	var req models.AssignTodoRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	todo, err := h.todoClient.AssignTodo(c.Request().Context(), c.Param("id"), userID, req.AssigneeID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.attachAssigneeEmails(c.Request().Context(), todo)
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// UnassignTodo handles DELETE /api/todos/:id/assignee
+func (h *TodoHandler) UnassignTodo(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	todo, err := h.todoClient.UnassignTodo(c.Request().Context(), c.Param("id"), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// attachAssigneeEmails fills in the assignees' email addresses, looking up
+// each assignee once however many todos and subtasks they have
+func (h *TodoHandler) attachAssigneeEmails(ctx context.Context, todos ...*models.Todo) {
+	var all []*models.Todo
+	var collect func(todos []*models.Todo)
+	collect = func(todos []*models.Todo) {
+		for _, todo := range todos {
+			if todo == nil {
+				continue
+			}
+			all = append(all, todo)
+			collect(todo.Subtasks)
+			collect([]*models.Todo{todo.NextOccurrence})
+		}
+	}
+	collect(todos)
+
+	var ids []string
+	for _, todo := range all {
+		if todo.AssigneeID != "" {
+			ids = append(ids, todo.AssigneeID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	emails := h.userClient.GetUserEmails(ctx, ids)
+	for _, todo := range all {
+		todo.AssigneeEmail = emails[todo.AssigneeID]
+	}
+}
+
+// pageOptions reads the limit and cursor query parameters. Listings are only
+// paged when one of them is given.
+func pageOptions(c echo.Context) (models.PageOptions, error) {
+	page := models.PageOptions{Cursor: c.QueryParam("cursor")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxPageLimit {
+			return page, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+		}
+		page.Limit = n
+	}
+	return page, nil
+}
+
+// respondTodoPage responds with the page object for paged requests and with
+// the plain todo array otherwise
+func respondTodoPage(c echo.Context, page models.PageOptions, todos *models.TodoPage) error {
+	if page.Paged() {
+		return c.JSON(http.StatusOK, todos)
+	}
+	return c.JSON(http.StatusOK, todos.Todos)
+}
+
+// splitQueryList splits a comma separated query parameter, dropping empty items
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// TwoFactorHandler manages the TOTP second factor of the signed-in user
+type TwoFactorHandler struct {
+	userClient *clients.UserServiceClient
+}
+
+func NewTwoFactorHandler(userClient *clients.UserServiceClient) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		userClient: userClient,
+	}
+}
+
+func (h *TwoFactorHandler) GetStatus(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	status, err := h.userClient.GetTwoFactorStatus(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// Enroll returns a new secret to add to an authenticator app. Two-factor
+// authentication is on once Confirm receives a code generated from it.
+func (h *TwoFactorHandler) Enroll(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	enrollment, err := h.userClient.EnrollTOTP(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, enrollment)
+}
+
+// Confirm turns two-factor authentication on and returns the recovery codes,
+// which can't be shown again
+func (h *TwoFactorHandler) Confirm(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "code is required")
+	}
+
+	codes, err := h.userClient.ConfirmTOTP(c.Request().Context(), userID, req.Code)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
+
+// Disable turns two-factor authentication off with a current code or a
+// recovery code
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "code is required")
+	}
+
+	if err := h.userClient.DisableTOTP(c.Request().Context(), userID, req.Code); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "two-factor authentication disabled successfully"})
+}
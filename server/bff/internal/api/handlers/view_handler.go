@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type ViewHandler struct {
+	todoClient *clients.TodoServiceClient
+}
+
+func NewViewHandler(todoClient *clients.TodoServiceClient) *ViewHandler {
+	return &ViewHandler{
+		todoClient: todoClient,
+	}
+}
+
+func (h *ViewHandler) CreateView(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	var req models.CreateSavedViewRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	view, err := h.todoClient.CreateSavedView(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, view)
+}
+
+func (h *ViewHandler) GetView(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	viewID := c.Param("id")
+	if viewID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "view ID is required")
+	}
+
+	view, err := h.todoClient.GetSavedView(c.Request().Context(), viewID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
+
+func (h *ViewHandler) ListViews(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	views, err := h.todoClient.ListSavedViews(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, views)
+}
+
+func (h *ViewHandler) UpdateView(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	viewID := c.Param("id")
+	if viewID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "view ID is required")
+	}
+
+	var req models.UpdateSavedViewRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	view, err := h.todoClient.UpdateSavedView(c.Request().Context(), viewID, userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
+
+func (h *ViewHandler) DeleteView(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosWrite); err != nil {
+		return err
+	}
+
+	viewID := c.Param("id")
+	if viewID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "view ID is required")
+	}
+
+	err := h.todoClient.DeleteSavedView(c.Request().Context(), viewID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "view deleted successfully"})
+}
+
+// ListViewTodos handles GET /api/views/:id/todos?limit=&cursor=, listing the
+// todos that currently match the view's criteria
+func (h *ViewHandler) ListViewTodos(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if err := middleware.RequireScope(c, middleware.ScopeTodosRead); err != nil {
+		return err
+	}
+
+	viewID := c.Param("id")
+	if viewID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "view ID is required")
+	}
+
+	page, err := pageOptions(c)
+	if err != nil {
+		return err
+	}
+
+	todos, err := h.todoClient.ListTodosByView(c.Request().Context(), viewID, userID, page)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return respondTodoPage(c, page, todos)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/api/middleware"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+type WorkspaceHandler struct {
+	userClient *clients.UserServiceClient
+	todoClient *clients.TodoServiceClient
+}
+
+func NewWorkspaceHandler(userClient *clients.UserServiceClient, todoClient *clients.TodoServiceClient) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		userClient: userClient,
+		todoClient: todoClient,
+	}
+}
+
+// CreateWorkspace creates a workspace with the user as its owner
+func (h *WorkspaceHandler) CreateWorkspace(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.CreateWorkspaceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	workspace, err := h.userClient.CreateWorkspace(c.Request().Context(), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, workspace)
+}
+
+func (h *WorkspaceHandler) ListWorkspaces(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	workspaces, err := h.userClient.ListWorkspaces(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workspaces)
+}
+
+func (h *WorkspaceHandler) GetWorkspace(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	workspace, err := h.userClient.GetWorkspace(c.Request().Context(), c.Param("workspace_id"), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workspace)
+}
+
+// InviteToWorkspace emails an invitation; only owners can invite
+func (h *WorkspaceHandler) InviteToWorkspace(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.InviteToWorkspaceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	invitation, err := h.userClient.InviteToWorkspace(c.Request().Context(), c.Param("workspace_id"), userID, &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations lists the invitations waiting for the user
+func (h *WorkspaceHandler) ListInvitations(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	invitations, err := h.userClient.ListWorkspaceInvitations(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, invitations)
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	workspace, err := h.userClient.AcceptWorkspaceInvitation(c.Request().Context(), c.Param("id"), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workspace)
+}
+
+// LeaveWorkspace removes the user from a workspace. When they were the last
+// member the workspace is gone, and so are its todos, tags and projects.
+func (h *WorkspaceHandler) LeaveWorkspace(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	ctx := c.Request().Context()
+	workspaceID := c.Param("workspace_id")
+	deleted, err := h.userClient.LeaveWorkspace(ctx, workspaceID, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if deleted {
+		// The workspace is already gone, so a failure to delete its data can only be logged
+		if err := h.todoClient.DeleteUserData(ctx, workspaceID); err != nil {
+			log.Printf("Failed to delete data of workspace %s: %v", workspaceID, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]bool{"workspace_deleted": deleted})
+}
+
+func (h *WorkspaceHandler) SetMemberRole(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	var req models.SetWorkspaceMemberRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	member, err := h.userClient.SetWorkspaceMemberRole(c.Request().Context(), c.Param("workspace_id"), userID, c.Param("user_id"), req.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
+
+func (h *WorkspaceHandler) RemoveMember(c echo.Context) error {
+	userID := middleware.GetUserIDFromContext(c)
+	if userID == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	if err := h.userClient.RemoveWorkspaceMember(c.Request().Context(), c.Param("workspace_id"), userID, c.Param("user_id")); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "member removed successfully"})
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireVerifiedEmail rejects requests that change data when the access
+// token's email address is unverified. Reads, and the account's own auth and
+// account endpoints, stay available so the user can still sign out, fix a
+// mistyped address or delete the account. It must run after JWTMiddleware.
+func RequireVerifiedEmail() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetEmailVerifiedFromContext(c) {
+				return next(c)
+			}
+
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+			path := c.Request().URL.Path
+			if path == "/api/me" || strings.HasPrefix(path, "/api/auth/") || strings.HasPrefix(path, "/api/me/") {
+				return next(c)
+			}
+
+			return echo.NewHTTPError(http.StatusForbidden, "email not verified")
+		}
+	}
+}
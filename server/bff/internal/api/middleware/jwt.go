@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -9,45 +10,82 @@ import (
 )
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID        string `json:"user_id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-var JWTSecret = []byte("your-secret-key") // In production, use environment variable
+// JWTMiddleware accepts access tokens signed by one of the user service's
+// keys, named by the kid header, whose session, identified by the jti, has
+// not been revoked. Personal access tokens are accepted too; their scopes are
+// checked by the handlers.
+func JWTMiddleware(keys *KeyCache, sessions *SessionCache, tokens *TokenCache) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if authHeader == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing authorization header")
+			}
 
-func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" {
-			return echo.NewHTTPError(http.StatusUnauthorized, "missing authorization header")
-		}
+			// Check if the header starts with "Bearer "
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
+			}
 
-		// Check if the header starts with "Bearer "
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
-		}
+			// Extract the token
+			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		// Extract the token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+			if strings.HasPrefix(tokenString, personalAccessTokenPrefix) {
+				identity, err := tokens.Authenticate(c.Request().Context(), tokenString)
+				if errors.Is(err, ErrInvalidPersonalAccessToken) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+				}
+				if err != nil {
+					return echo.NewHTTPError(http.StatusServiceUnavailable, "failed to check token")
+				}
 
-		// Parse and validate the token
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return JWTSecret, nil
-		})
+				c.Set("user_id", identity.UserID)
+				c.Set("email", identity.Email)
+				c.Set("email_verified", identity.EmailVerified)
+				c.Set("token_id", identity.TokenID)
+				c.Set("scopes", identity.Scopes)
+				return next(c)
+			}
 
-		if err != nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
-		}
+			// Parse and validate the token
+			token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+				kid, _ := token.Header["kid"].(string)
+				return keys.Key(c.Request().Context(), kid, token.Method.Alg())
+			}, jwt.WithValidMethods([]string{"RS256", "EdDSA"}))
+
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+			}
+
+			claims, ok := token.Claims.(*Claims)
+			if !ok || !token.Valid || claims.ID == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+			}
+
+			// Reject tokens of sessions that have been signed out
+			active, err := sessions.Active(c.Request().Context(), claims.ID, c.RealIP())
+			if err != nil {
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "failed to check session")
+			}
+			if !active {
+				return echo.NewHTTPError(http.StatusUnauthorized, "session revoked")
+			}
 
-		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
 			// Store user information in context
 			c.Set("user_id", claims.UserID)
 			c.Set("email", claims.Email)
+			c.Set("session_id", claims.ID)
+			c.Set("email_verified", claims.EmailVerified)
+			c.Set("role", claims.Role)
 			return next(c)
 		}
-
-		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
 	}
 }
 
@@ -58,3 +96,16 @@ func GetUserIDFromContext(c echo.Context) string {
 	}
 	return userID
 }
+
+func GetEmailVerifiedFromContext(c echo.Context) bool {
+	verified, _ := c.Get("email_verified").(bool)
+	return verified
+}
+
+func GetSessionIDFromContext(c echo.Context) string {
+	sessionID, ok := c.Get("session_id").(string)
+	if !ok {
+		return ""
+	}
+	return sessionID
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// minKeyRefetchInterval limits how often an unknown kid makes the cache ask
+// the user service again
+const minKeyRefetchInterval = 10 * time.Second
+
+var (
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// KeyFetcher returns the public keys that verify access tokens
+type KeyFetcher interface {
+	GetJWKS(ctx context.Context) ([]models.JWK, error)
+}
+
+// keyFetchTimeout bounds a fetch of the keys, which runs on behalf of every
+// request waiting for it rather than the one that started it
+const keyFetchTimeout = 10 * time.Second
+
+// KeyCache keeps the user service's public keys. They are fetched again once
+// maxAge has passed, or earlier when a token names a key not seen yet, which
+// is how a newly rotated key is picked up. Keys already known stay usable
+// while a fetch is under way.
+type KeyCache struct {
+	fetcher KeyFetcher
+	maxAge  time.Duration
+
+	mu        sync.Mutex
+	jwks      []models.JWK
+	keys      map[string]verificationKey
+	fetchedAt time.Time
+	// refreshing is closed once the fetch under way has finished
+	refreshing chan struct{}
+	refreshErr error
+}
+
+type verificationKey struct {
+	algorithm string
+	key       crypto.PublicKey
+}
+
+func NewKeyCache(fetcher KeyFetcher, maxAge time.Duration) *KeyCache {
+	return &KeyCache{
+		fetcher: fetcher,
+		maxAge:  maxAge,
+		keys:    make(map[string]verificationKey),
+	}
+}
+
+// Key returns the public key with the given kid, checking that it is meant
+// for the token's algorithm
+func (c *KeyCache) Key(ctx context.Context, kid, algorithm string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	var done <-chan struct{}
+	if time.Since(c.fetchedAt) > c.maxAge || (!ok && time.Since(c.fetchedAt) > minKeyRefetchInterval) {
+		done = c.startRefresh()
+	}
+	c.mu.Unlock()
+
+	if done != nil && !ok {
+		if err := c.wait(ctx, done); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		key, ok = c.keys[kid]
+		err := c.refreshErr
+		c.mu.Unlock()
+		if !ok && err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	if key.algorithm != algorithm {
+		return nil, fmt.Errorf("key %q is not a %s key", kid, algorithm)
+	}
+	return key.key, nil
+}
+
+// JWKS returns the current public keys
+func (c *KeyCache) JWKS(ctx context.Context) ([]models.JWK, error) {
+	c.mu.Lock()
+	jwks := c.jwks
+	var done <-chan struct{}
+	if time.Since(c.fetchedAt) > c.maxAge {
+		done = c.startRefresh()
+	}
+	c.mu.Unlock()
+
+	if done != nil && jwks == nil {
+		if err := c.wait(ctx, done); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		jwks = c.jwks
+		err := c.refreshErr
+		c.mu.Unlock()
+		if jwks == nil && err != nil {
+			return nil, err
+		}
+	}
+	return jwks, nil
+}
+
+// startRefresh starts fetching the keys unless a fetch is already under way,
+// returning a channel closed when it has finished. c.mu must be held.
+func (c *KeyCache) startRefresh() <-chan struct{} {
+	if c.refreshing == nil {
+		c.refreshing = make(chan struct{})
+		c.fetchedAt = time.Now()
+		go c.refresh(c.refreshing)
+	}
+	return c.refreshing
+}
+
+func (c *KeyCache) wait(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refresh fetches the keys without holding c.mu; on failure the previous
+// keys stay in use
+func (c *KeyCache) refresh(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyFetchTimeout)
+	defer cancel()
+
+	jwks, err := c.fetcher.GetJWKS(ctx)
+	var keys map[string]verificationKey
+	if err == nil {
+		keys = make(map[string]verificationKey, len(jwks))
+		for _, jwk := range jwks {
+			key, err := parseJWK(jwk)
+			if err != nil {
+				log.Printf("Ignoring signing key %s: %v", jwk.KeyID, err)
+				continue
+			}
+			keys[jwk.KeyID] = verificationKey{algorithm: jwk.Algorithm, key: key}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshErr = err
+	if err == nil {
+		c.jwks = jwks
+		c.keys = keys
+	}
+	c.refreshing = nil
+	close(done)
+}
+
+func parseJWK(jwk models.JWK) (crypto.PublicKey, error) {
+	switch {
+	case jwk.KeyType == "RSA" && jwk.Algorithm == "RS256":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case jwk.KeyType == "OKP" && jwk.Curve == "Ed25519" && jwk.Algorithm == "EdDSA":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key size %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s/%s", jwk.KeyType, jwk.Algorithm)
+}
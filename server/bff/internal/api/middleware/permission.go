@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Roles carried by access tokens
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permission names something beyond managing one's own data
+type Permission string
+
+const (
+	PermissionUsersRead   Permission = "users:read"
+	PermissionUsersManage Permission = "users:manage"
+)
+
+// rolePermissions lists what each role may do. Regular users and personal
+// access tokens, which carry no role, have no permissions.
+var rolePermissions = map[string][]Permission{
+	RoleAdmin: {PermissionUsersRead, PermissionUsersManage},
+}
+
+// GetRoleFromContext returns the role of the signed-in user, or "" for a
+// personal access token
+func GetRoleFromContext(c echo.Context) string {
+	role, _ := c.Get("role").(string)
+	return role
+}
+
+// HasPermission reports whether the role grants the permission
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission rejects requests whose role doesn't grant the permission
+// with 403. The role comes from the access token, so a promotion takes effect
+// once the token is refreshed.
+func RequirePermission(permission Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasPermission(GetRoleFromContext(c), permission) {
+				return echo.NewHTTPError(http.StatusForbidden, "permission denied")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// maxCachedPreferences bounds the cache; expired entries are dropped once it
+// is reached
+const maxCachedPreferences = 10000
+
+// PreferencesLoader fetches the preferences of a user
+type PreferencesLoader interface {
+	GetPreferences(ctx context.Context, userID string) (*models.Preferences, error)
+}
+
+// PreferencesCache remembers users' preferences for a short time, since
+// every todo request needs the time zone. Changes made through another BFF
+// instance show up here at most ttl later.
+type PreferencesCache struct {
+	loader PreferencesLoader
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]preferencesEntry
+}
+
+type preferencesEntry struct {
+	prefs     *models.Preferences
+	expiresAt time.Time
+}
+
+func NewPreferencesCache(loader PreferencesLoader, ttl time.Duration) *PreferencesCache {
+	return &PreferencesCache{
+		loader:  loader,
+		ttl:     ttl,
+		entries: make(map[string]preferencesEntry),
+	}
+}
+
+// Get returns the user's preferences, asking the user service when the
+// cached copy is missing or stale
+func (c *PreferencesCache) Get(ctx context.Context, userID string) (*models.Preferences, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.prefs, nil
+	}
+
+	prefs, err := c.loader.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c.Store(userID, prefs)
+	return prefs, nil
+}
+
+// Store replaces the cached preferences of a user after they were changed
+// through this instance
+func (c *PreferencesCache) Store(userID string, prefs *models.Preferences) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedPreferences {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+	c.entries[userID] = preferencesEntry{prefs: prefs, expiresAt: now.Add(c.ttl)}
+}
+
+// LoadPreferences makes the signed-in user's preferences available to the
+// handlers and passes their time zone on to the todo service. Requests go on
+// with the defaults of the services when the preferences can't be loaded.
+// It must run after JWTMiddleware.
+func LoadPreferences(cache *PreferencesCache) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID := GetUserIDFromContext(c)
+			if userID == "" {
+				return next(c)
+			}
+
+			req := c.Request()
+			prefs, err := cache.Get(req.Context(), userID)
+			if err != nil {
+				log.Printf("Failed to load preferences of user %s: %v", userID, err)
+				return next(c)
+			}
+
+			c.Set("preferences", prefs)
+			c.SetRequest(req.WithContext(clients.WithTimezone(req.Context(), prefs.Timezone)))
+			return next(c)
+		}
+	}
+}
+
+// GetPreferencesFromContext returns the preferences loaded by
+// LoadPreferences, or nil when they are not available
+func GetPreferencesFromContext(c echo.Context) *models.Preferences {
+	prefs, _ := c.Get("preferences").(*models.Preferences)
+	return prefs
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Scopes a personal access token can be granted. Requests signed in with a
+// session have every scope.
+const (
+	ScopeTodosRead  = "todos:read"
+	ScopeTodosWrite = "todos:write"
+)
+
+// GetScopesFromContext returns the scopes of the personal access token the
+// request was made with, or nil for a session
+func GetScopesFromContext(c echo.Context) []string {
+	scopes, _ := c.Get("scopes").([]string)
+	return scopes
+}
+
+// GetTokenIDFromContext returns the ID of the personal access token the
+// request was made with, or "" for a session
+func GetTokenIDFromContext(c echo.Context) string {
+	tokenID, _ := c.Get("token_id").(string)
+	return tokenID
+}
+
+// RequireScope fails with 403 when the request was made with a personal
+// access token lacking the scope
+func RequireScope(c echo.Context, scope string) error {
+	if GetTokenIDFromContext(c) == "" {
+		return nil
+	}
+	for _, granted := range GetScopesFromContext(c) {
+		if granted == scope {
+			return nil
+		}
+	}
+	return echo.NewHTTPError(http.StatusForbidden, "token lacks the "+scope+" scope")
+}
+
+// SessionOnly rejects personal access tokens on the routes that manage the
+// account itself, such as sessions, two-factor authentication and tokens
+func SessionOnly() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetTokenIDFromContext(c) != "" {
+				return echo.NewHTTPError(http.StatusForbidden, "personal access tokens can't be used here")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxCachedSessions bounds the cache; expired entries are dropped once it is
+// reached
+const maxCachedSessions = 10000
+
+// SessionChecker reports whether access tokens of a session are still accepted
+type SessionChecker interface {
+	CheckSession(ctx context.Context, id, ipAddress string) (bool, error)
+}
+
+// SessionCache remembers session checks for a short time so that not every
+// request reaches the user service. A session revoked through another BFF
+// instance is rejected here at most ttl later.
+type SessionCache struct {
+	checker SessionChecker
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]sessionEntry
+}
+
+type sessionEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+func NewSessionCache(checker SessionChecker, ttl time.Duration) *SessionCache {
+	return &SessionCache{
+		checker: checker,
+		ttl:     ttl,
+		entries: make(map[string]sessionEntry),
+	}
+}
+
+// Active reports whether a session is still active, asking the user service
+// when the cached answer is missing or stale
+func (c *SessionCache) Active(ctx context.Context, id, ipAddress string) (bool, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.active, nil
+	}
+
+	active, err := c.checker.CheckSession(ctx, id, ipAddress)
+	if err != nil {
+		return false, err
+	}
+	c.store(id, active, now)
+	return active, nil
+}
+
+// Revoked marks a session revoked through this instance so that its tokens
+// are rejected immediately
+func (c *SessionCache) Revoked(ids ...string) {
+	now := time.Now()
+	for _, id := range ids {
+		c.store(id, false, now)
+	}
+}
+
+func (c *SessionCache) store(id string, active bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedSessions {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+	c.entries[id] = sessionEntry{active: active, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/models"
+)
+
+// personalAccessTokenPrefix starts every personal access token issued by the
+// user service; other bearer tokens are treated as JWTs
+const personalAccessTokenPrefix = "mytodo_pat_"
+
+// maxCachedTokens bounds the cache; expired entries are dropped once it is
+// reached
+const maxCachedTokens = 10000
+
+var (
+	ErrInvalidPersonalAccessToken = errors.New("invalid personal access token")
+)
+
+// TokenAuthenticator resolves personal access tokens. It returns a nil
+// identity without an error for tokens that are not accepted.
+type TokenAuthenticator interface {
+	AuthenticatePersonalAccessToken(ctx context.Context, secret string) (*models.TokenIdentity, error)
+}
+
+// TokenCache remembers personal access token checks for a short time so that
+// scripts don't reach the user service on every request. A token revoked
+// through another BFF instance is rejected here at most ttl later.
+type TokenCache struct {
+	authenticator TokenAuthenticator
+	ttl           time.Duration
+
+	mu      sync.Mutex
+	entries map[string]tokenEntry
+}
+
+type tokenEntry struct {
+	identity  *models.TokenIdentity
+	expiresAt time.Time
+}
+
+func NewTokenCache(authenticator TokenAuthenticator, ttl time.Duration) *TokenCache {
+	return &TokenCache{
+		authenticator: authenticator,
+		ttl:           ttl,
+		entries:       make(map[string]tokenEntry),
+	}
+}
+
+// Authenticate returns the identity of a token, or
+// ErrInvalidPersonalAccessToken when it is not accepted
+func (c *TokenCache) Authenticate(ctx context.Context, secret string) (*models.TokenIdentity, error) {
+	// Only a hash of the secret is kept in memory
+	sum := sha256.Sum256([]byte(secret))
+	key := hex.EncodeToString(sum[:])
+
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		if entry.identity == nil {
+			return nil, ErrInvalidPersonalAccessToken
+		}
+		return entry.identity, nil
+	}
+
+	identity, err := c.authenticator.AuthenticatePersonalAccessToken(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, identity, now)
+	if identity == nil {
+		return nil, ErrInvalidPersonalAccessToken
+	}
+	return identity, nil
+}
+
+// Revoked forgets a token revoked through this instance so that it is
+// rejected immediately
+func (c *TokenCache) Revoked(tokenID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.identity != nil && entry.identity.TokenID == tokenID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *TokenCache) store(key string, identity *models.TokenIdentity, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedTokens {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = tokenEntry{identity: identity, expiresAt: now.Add(c.ttl)}
+}
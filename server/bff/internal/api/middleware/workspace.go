@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+)
+
+// WorkspaceViewer is the workspace role that can only read
+const WorkspaceViewer = "viewer"
+
+// WorkspaceRoleChecker looks up a user's role in a workspace
+type WorkspaceRoleChecker interface {
+	GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error)
+}
+
+// InWorkspace scopes the todo, tag and project routes under
+// /workspaces/:workspace_id to that workspace. Non-members get 404 so the
+// workspace's existence isn't revealed, and viewers can't change anything.
+// The todo service checks the membership again; this only turns the answer
+// into the right status code. It must run after JWTMiddleware.
+func InWorkspace(checker WorkspaceRoleChecker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID := GetUserIDFromContext(c)
+			if userID == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+			}
+
+			workspaceID := c.Param("workspace_id")
+			req := c.Request()
+			role, err := checker.GetWorkspaceRole(req.Context(), workspaceID, userID)
+			if err != nil {
+				log.Printf("Failed to check membership of user %s in workspace %s: %v", userID, workspaceID, err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to check workspace membership")
+			}
+			if role == "" {
+				return echo.NewHTTPError(http.StatusNotFound, "workspace not found")
+			}
+			if role == WorkspaceViewer && req.Method != http.MethodGet {
+				return echo.NewHTTPError(http.StatusForbidden, "workspace is read-only for viewers")
+			}
+
+			c.Set("workspace_id", workspaceID)
+			c.Set("workspace_role", role)
+			c.SetRequest(req.WithContext(clients.WithWorkspace(req.Context(), workspaceID)))
+			return next(c)
+		}
+	}
+}
+
+// GetWorkspaceIDFromContext returns the workspace the request is scoped to,
+// or "" for the user's personal data
+func GetWorkspaceIDFromContext(c echo.Context) string {
+	workspaceID, _ := c.Get("workspace_id").(string)
+	return workspaceID
+}
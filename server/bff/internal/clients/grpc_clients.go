@@ -2,16 +2,140 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/bff/internal/models"
 )
 
+// maxConcurrentUserLookups bounds the GetUser calls GetUserEmails makes at once
+const maxConcurrentUserLookups = 8
+
+// ErrEmailNotVerified is returned by AuthenticateUser when the email
+// verification policy refuses to sign the user in
+var ErrEmailNotVerified = errors.New("email not verified")
+
+// ErrAccountDisabled is returned by AuthenticateUser when an administrator
+// has disabled the account
+var ErrAccountDisabled = errors.New("account disabled")
+
+// ErrLastAdmin is returned when an administrator tries to disable or demote
+// the last active administrator
+var ErrLastAdmin = errors.New("the last active administrator can't be disabled or demoted")
+
+// ErrIncorrectPassword is returned when the current password a user entered
+// to confirm a change of their account is wrong
+var ErrIncorrectPassword = errors.New("current password is incorrect")
+
+// ErrLastWorkspaceOwner is returned by ScheduleAccountDeletion when the user
+// is the only owner of a workspace that others belong to
+var ErrLastWorkspaceOwner = errors.New("make another member an owner of your workspaces first")
+
+// TwoFactorRequiredError is returned by AuthenticateUser when the password
+// was right but the account needs a second factor
+type TwoFactorRequiredError struct {
+	Challenge models.TwoFactorChallenge
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return "two-factor authentication required"
+}
+
+// LoginBlockedError is returned by AuthenticateUser when the user service
+// refuses to check the password because of earlier failed attempts.
+// AccountLocked tells a lockout of the account from a rate limit.
+type LoginBlockedError struct {
+	AccountLocked bool
+	RetryAt       time.Time
+	Message       string
+}
+
+func (e *LoginBlockedError) Error() string {
+	return e.Message
+}
+
+// PasswordPolicyError is returned when the user service rejects a new
+// password; Violations hold one code and message per broken rule
+type PasswordPolicyError struct {
+	Message    string
+	Violations []*pb.PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return e.Message
+}
+
+// FieldErrors reports the violations as errors of the given request field
+func (e *PasswordPolicyError) FieldErrors(field string) []models.FieldError {
+	errs := make([]models.FieldError, len(e.Violations))
+	for i, violation := range e.Violations {
+		errs[i] = models.FieldError{
+			Field:   field,
+			Code:    violation.Code,
+			Message: violation.Message,
+		}
+	}
+	return errs
+}
+
+// InvalidSearchError is returned by SearchTodos when the todo service
+// rejects the query itself rather than failing to run it
+type InvalidSearchError struct {
+	Message string
+}
+
+func (e *InvalidSearchError) Error() string {
+	return e.Message
+}
+
+// responseError turns the error of a response into a Go error, keeping
+// password violations when there are any
+func responseError(message string, violations []*pb.PasswordViolation) error {
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Message: message, Violations: violations}
+	}
+	return errors.New(message)
+}
+
+// clientIPMetadataKey passes the address of the end user to the user
+// service, which otherwise only sees the BFF
+const clientIPMetadataKey = "x-client-ip"
+
+// timezoneMetadataKey passes the user's time zone to the todo service, which
+// uses it for "today" and the offset of the times it returns
+const timezoneMetadataKey = "x-timezone"
+
+// WithTimezone returns a context whose todo service calls work in the given
+// IANA time zone
+func WithTimezone(ctx context.Context, timezone string) context.Context {
+	if timezone == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, timezoneMetadataKey, timezone)
+}
+
+// workspaceKey is the context key of the workspace set by WithWorkspace
+type workspaceKey struct{}
+
+// WithWorkspace returns a context whose todo, tag and project calls work on
+// the shared data of a workspace instead of the user's own. The todo service
+// checks that the user belongs to it.
+func WithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspaceID)
+}
+
+func workspaceFromContext(ctx context.Context) string {
+	workspaceID, _ := ctx.Value(workspaceKey{}).(string)
+	return workspaceID
+}
+
 type UserServiceClient struct {
 	client pb.UserServiceClient
 	conn   *grpc.ClientConn
@@ -40,26 +164,72 @@ func (c *UserServiceClient) CreateUser(ctx context.Context, email, password stri
 	}
 
 	if resp.Error != "" {
-		return nil, fmt.Errorf(resp.Error)
+		return nil, responseError(resp.Error, resp.PasswordViolations)
 	}
 
 	return c.protoUserToModel(resp.User), nil
 }
 
-func (c *UserServiceClient) AuthenticateUser(ctx context.Context, email, password string) (*models.User, string, error) {
+// AuthenticateUser signs a user in, starting a session for the given device
+func (c *UserServiceClient) AuthenticateUser(ctx context.Context, email, password, device, ipAddress string) (*models.AuthResponse, error) {
+	if ipAddress != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, clientIPMetadataKey, ipAddress)
+	}
 	resp, err := c.client.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{
-		Email:    email,
-		Password: password,
+		Email:     email,
+		Password:  password,
+		Device:    device,
+		IpAddress: ipAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.RetryAt != "" {
+		retryAt, _ := time.Parse(time.RFC3339, resp.RetryAt)
+		return nil, &LoginBlockedError{
+			AccountLocked: resp.AccountLocked,
+			RetryAt:       retryAt,
+			Message:       resp.Error,
+		}
+	}
+	if resp.AccountDisabled {
+		return nil, ErrAccountDisabled
+	}
+	if resp.EmailNotVerified {
+		return nil, ErrEmailNotVerified
+	}
+	if resp.TwoFactorRequired {
+		expiresAt, _ := time.Parse(time.RFC3339, resp.TwoFactorExpiresAt)
+		return nil, &TwoFactorRequiredError{Challenge: models.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			TwoFactorToken:    resp.TwoFactorToken,
+			ExpiresAt:         expiresAt,
+		}}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.authResponse(resp.User, resp.Token, resp.ExpiresAt, resp.RefreshToken, resp.RefreshTokenExpiresAt), nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair; the presented
+// refresh token can't be used again
+func (c *UserServiceClient) RefreshToken(ctx context.Context, refreshToken, ipAddress string) (*models.AuthResponse, error) {
+	resp, err := c.client.RefreshToken(ctx, &pb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+		IpAddress:    ipAddress,
 	})
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	if resp.Error != "" {
-		return nil, "", fmt.Errorf(resp.Error)
+		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return c.protoUserToModel(resp.User), resp.Token, nil
+	return c.authResponse(resp.User, resp.Token, resp.ExpiresAt, resp.RefreshToken, resp.RefreshTokenExpiresAt), nil
 }
 
 func (c *UserServiceClient) GetUser(ctx context.Context, id string) (*models.User, error) {
@@ -77,46 +247,110 @@ func (c *UserServiceClient) GetUser(ctx context.Context, id string) (*models.Use
 	return c.protoUserToModel(resp.User), nil
 }
 
-func (c *UserServiceClient) Close() error {
-	return c.conn.Close()
+// GetUserEmails looks up the email addresses of several users, asking the
+// user service once per distinct ID with a few requests in flight. Users
+// that can't be looked up are left out of the result.
+func (c *UserServiceClient) GetUserEmails(ctx context.Context, ids []string) map[string]string {
+	emails := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentUserLookups)
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			user, err := c.GetUser(ctx, id)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			emails[id] = user.Email
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return emails
 }
 
-func (c *UserServiceClient) protoUserToModel(pbUser *pb.User) *models.User {
-	createdAt, _ := time.Parse(time.RFC3339, pbUser.CreatedAt)
-	updatedAt, _ := time.Parse(time.RFC3339, pbUser.UpdatedAt)
+// UpdateUser changes the email or password of a user's own account, checking
+// their current password first. A new email takes effect once verified.
+func (c *UserServiceClient) UpdateUser(ctx context.Context, id, email, password, currentPassword string) (*models.User, error) {
+	resp, err := c.client.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:              id,
+		Email:           email,
+		Password:        password,
+		CurrentPassword: currentPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return &models.User{
-		ID:        pbUser.Id,
-		Email:     pbUser.Email,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+	if resp.IncorrectPassword {
+		return nil, ErrIncorrectPassword
+	}
+	if resp.Error != "" {
+		return nil, responseError(resp.Error, resp.PasswordViolations)
 	}
+
+	return c.protoUserToModel(resp.User), nil
 }
 
-// TodoServiceClient represents a client for the Todo service
-type TodoServiceClient struct {
-	client pb.TodoServiceClient
-	conn   *grpc.ClientConn
+// DeleteUser deletes an account right away. The user's data in the todo
+// service has to be deleted first.
+// DeleteUser deletes an account and returns the workspaces deleted with it
+// because the user was their last member. They are returned even when the
+// deletion fails afterwards.
+func (c *UserServiceClient) DeleteUser(ctx context.Context, id string) ([]string, error) {
+	resp, err := c.client.DeleteUser(ctx, &pb.DeleteUserRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return resp.DeletedWorkspaceIds, fmt.Errorf(resp.Error)
+	}
+
+	return resp.DeletedWorkspaceIds, nil
 }
 
-func NewTodoServiceClient(address string) (*TodoServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// ScheduleAccountDeletion schedules the deletion of a user's own account
+// after the grace period of the user service
+func (c *UserServiceClient) ScheduleAccountDeletion(ctx context.Context, userID, currentPassword string) (*models.User, error) {
+	resp, err := c.client.ScheduleAccountDeletion(ctx, &pb.ScheduleAccountDeletionRequest{
+		UserId:          userID,
+		CurrentPassword: currentPassword,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to todo service: %v", err)
+		return nil, err
 	}
 
-	client := pb.NewTodoServiceClient(conn)
-	return &TodoServiceClient{
-		client: client,
-		conn:   conn,
-	}, nil
+	if resp.IncorrectPassword {
+		return nil, ErrIncorrectPassword
+	}
+	if resp.LastWorkspaceOwner {
+		return nil, ErrLastWorkspaceOwner
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoUserToModel(resp.User), nil
 }
 
-func (c *TodoServiceClient) CreateTodo(ctx context.Context, userID, title, description string) (*models.Todo, error) {
-	resp, err := c.client.CreateTodo(ctx, &pb.CreateTodoRequest{
-		UserId:      userID,
-		Title:       title,
-		Description: description,
+func (c *UserServiceClient) CancelAccountDeletion(ctx context.Context, userID string) (*models.User, error) {
+	resp, err := c.client.CancelAccountDeletion(ctx, &pb.CancelAccountDeletionRequest{
+		UserId: userID,
 	})
 	if err != nil {
 		return nil, err
@@ -126,12 +360,34 @@ func (c *TodoServiceClient) CreateTodo(ctx context.Context, userID, title, descr
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return c.protoTodoToModel(resp.Todo), nil
+	return c.protoUserToModel(resp.User), nil
 }
 
-func (c *TodoServiceClient) GetTodo(ctx context.Context, id, userID string) (*models.Todo, error) {
-	resp, err := c.client.GetTodo(ctx, &pb.GetTodoRequest{
-		Id:     id,
+// ListDueAccountDeletions lists up to limit accounts whose grace period is
+// over, the longest overdue first
+func (c *UserServiceClient) ListDueAccountDeletions(ctx context.Context, limit int) ([]*models.User, error) {
+	resp, err := c.client.ListDueAccountDeletions(ctx, &pb.ListDueAccountDeletionsRequest{
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	users := make([]*models.User, len(resp.Users))
+	for i, pbUser := range resp.Users {
+		users[i] = c.protoUserToModel(pbUser)
+	}
+	return users, nil
+}
+
+// GetPreferences returns the user's preferences, the defaults if they never
+// changed them
+func (c *UserServiceClient) GetPreferences(ctx context.Context, userID string) (*models.Preferences, error) {
+	resp, err := c.client.GetPreferences(ctx, &pb.GetPreferencesRequest{
 		UserId: userID,
 	})
 	if err != nil {
@@ -142,13 +398,19 @@ func (c *TodoServiceClient) GetTodo(ctx context.Context, id, userID string) (*mo
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return c.protoTodoToModel(resp.Todo), nil
+	return protoPreferencesToModel(resp.Preferences), nil
 }
 
-func (c *TodoServiceClient) ListTodos(ctx context.Context, userID string) ([]*models.Todo, error) {
-	resp, err := c.client.ListTodos(ctx, &pb.ListTodosRequest{
-		UserId:        userID,
-		CompletedOnly: false,
+func (c *UserServiceClient) UpdatePreferences(ctx context.Context, userID string, req *models.UpdatePreferencesRequest) (*models.Preferences, error) {
+	resp, err := c.client.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{
+		UserId:           userID,
+		Timezone:         req.Timezone,
+		Locale:           req.Locale,
+		WeekStart:        req.WeekStart,
+		DateFormat:       req.DateFormat,
+		DefaultSort:      req.DefaultSort,
+		DefaultSortOrder: req.DefaultSortOrder,
+		DefaultProjectId: req.DefaultProjectID,
 	})
 	if err != nil {
 		return nil, err
@@ -158,16 +420,40 @@ func (c *TodoServiceClient) ListTodos(ctx context.Context, userID string) ([]*mo
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	var todos []*models.Todo
-	for _, pbTodo := range resp.Todos {
-		todos = append(todos, c.protoTodoToModel(pbTodo))
+	return protoPreferencesToModel(resp.Preferences), nil
+}
+
+func protoPreferencesToModel(pbPrefs *pb.Preferences) *models.Preferences {
+	return &models.Preferences{
+		Timezone:         pbPrefs.Timezone,
+		Locale:           pbPrefs.Locale,
+		WeekStart:        pbPrefs.WeekStart,
+		DateFormat:       pbPrefs.DateFormat,
+		DefaultSort:      pbPrefs.DefaultSort,
+		DefaultSortOrder: pbPrefs.DefaultSortOrder,
+		DefaultProjectID: pbPrefs.DefaultProjectId,
+		UpdatedAt:        parseOptionalTime(pbPrefs.UpdatedAt),
+	}
+}
+
+func (c *UserServiceClient) CreateWorkspace(ctx context.Context, userID string, req *models.CreateWorkspaceRequest) (*models.Workspace, error) {
+	resp, err := c.client.CreateWorkspace(ctx, &pb.CreateWorkspaceRequest{
+		UserId: userID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return todos, nil
+	return protoWorkspaceToModel(resp.Workspace), nil
 }
 
-func (c *TodoServiceClient) ListCompletedTodos(ctx context.Context, userID string) ([]*models.Todo, error) {
-	resp, err := c.client.ListCompletedTodos(ctx, &pb.ListCompletedTodosRequest{
+func (c *UserServiceClient) ListWorkspaces(ctx context.Context, userID string) ([]*models.Workspace, error) {
+	resp, err := c.client.ListWorkspaces(ctx, &pb.ListWorkspacesRequest{
 		UserId: userID,
 	})
 	if err != nil {
@@ -178,20 +464,62 @@ func (c *TodoServiceClient) ListCompletedTodos(ctx context.Context, userID strin
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	var todos []*models.Todo
-	for _, pbTodo := range resp.Todos {
-		todos = append(todos, c.protoTodoToModel(pbTodo))
+	workspaces := make([]*models.Workspace, len(resp.Workspaces))
+	for i, pbWorkspace := range resp.Workspaces {
+		workspaces[i] = protoWorkspaceToModel(pbWorkspace)
+	}
+	return workspaces, nil
+}
+
+// GetWorkspace returns a workspace with its members to one of them
+func (c *UserServiceClient) GetWorkspace(ctx context.Context, id, userID string) (*models.Workspace, error) {
+	resp, err := c.client.GetWorkspace(ctx, &pb.GetWorkspaceRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return todos, nil
+	workspace := protoWorkspaceToModel(resp.Workspace)
+	workspace.Members = make([]*models.WorkspaceMember, len(resp.Members))
+	for i, pbMember := range resp.Members {
+		workspace.Members[i] = protoWorkspaceMemberToModel(pbMember)
+	}
+	return workspace, nil
 }
 
-func (c *TodoServiceClient) UpdateTodo(ctx context.Context, id, userID, title, description string) (*models.Todo, error) {
-	resp, err := c.client.UpdateTodo(ctx, &pb.UpdateTodoRequest{
-		Id:          id,
+// GetWorkspaceRole returns the user's role in a workspace, or "" when they
+// don't belong to it
+func (c *UserServiceClient) GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	resp, err := c.client.GetWorkspaceMembership(ctx, &pb.GetWorkspaceMembershipRequest{
+		WorkspaceId: workspaceID,
 		UserId:      userID,
-		Title:       title,
-		Description: description,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if resp.Error != "" {
+		return "", fmt.Errorf(resp.Error)
+	}
+	if resp.NotMember {
+		return "", nil
+	}
+
+	return resp.Role, nil
+}
+
+func (c *UserServiceClient) InviteToWorkspace(ctx context.Context, workspaceID, userID string, req *models.InviteToWorkspaceRequest) (*models.WorkspaceInvitation, error) {
+	resp, err := c.client.InviteToWorkspace(ctx, &pb.InviteToWorkspaceRequest{
+		WorkspaceId: workspaceID,
+		UserId:      userID,
+		Email:       req.Email,
+		Role:        req.Role,
 	})
 	if err != nil {
 		return nil, err
@@ -201,14 +529,14 @@ func (c *TodoServiceClient) UpdateTodo(ctx context.Context, id, userID, title, d
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return c.protoTodoToModel(resp.Todo), nil
+	return protoWorkspaceInvitationToModel(resp.Invitation), nil
 }
 
-func (c *TodoServiceClient) MarkTodoComplete(ctx context.Context, id, userID string, completed bool) (*models.Todo, error) {
-	resp, err := c.client.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{
-		Id:        id,
-		UserId:    userID,
-		Completed: completed,
+// ListWorkspaceInvitations lists the pending invitations to the user's email
+// address
+func (c *UserServiceClient) ListWorkspaceInvitations(ctx context.Context, userID string) ([]*models.WorkspaceInvitation, error) {
+	resp, err := c.client.ListWorkspaceInvitations(ctx, &pb.ListWorkspaceInvitationsRequest{
+		UserId: userID,
 	})
 	if err != nil {
 		return nil, err
@@ -218,14 +546,71 @@ func (c *TodoServiceClient) MarkTodoComplete(ctx context.Context, id, userID str
 		return nil, fmt.Errorf(resp.Error)
 	}
 
-	return c.protoTodoToModel(resp.Todo), nil
+	invitations := make([]*models.WorkspaceInvitation, len(resp.Invitations))
+	for i, pbInvitation := range resp.Invitations {
+		invitations[i] = protoWorkspaceInvitationToModel(pbInvitation)
+	}
+	return invitations, nil
 }
 
-func (c *TodoServiceClient) DeleteTodo(ctx context.Context, id, userID string) error {
-	resp, err := c.client.DeleteTodo(ctx, &pb.DeleteTodoRequest{
+func (c *UserServiceClient) AcceptWorkspaceInvitation(ctx context.Context, id, userID string) (*models.Workspace, error) {
+	resp, err := c.client.AcceptWorkspaceInvitation(ctx, &pb.AcceptWorkspaceInvitationRequest{
 		Id:     id,
 		UserId: userID,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoWorkspaceToModel(resp.Workspace), nil
+}
+
+// LeaveWorkspace removes the user from a workspace and reports whether that
+// deleted it, which leaves its todos to be deleted
+func (c *UserServiceClient) LeaveWorkspace(ctx context.Context, workspaceID, userID string) (bool, error) {
+	resp, err := c.client.LeaveWorkspace(ctx, &pb.LeaveWorkspaceRequest{
+		WorkspaceId: workspaceID,
+		UserId:      userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if resp.Error != "" {
+		return false, fmt.Errorf(resp.Error)
+	}
+
+	return resp.WorkspaceDeleted, nil
+}
+
+func (c *UserServiceClient) SetWorkspaceMemberRole(ctx context.Context, workspaceID, userID, memberID, role string) (*models.WorkspaceMember, error) {
+	resp, err := c.client.SetWorkspaceMemberRole(ctx, &pb.SetWorkspaceMemberRoleRequest{
+		WorkspaceId: workspaceID,
+		UserId:      userID,
+		MemberId:    memberID,
+		Role:        role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoWorkspaceMemberToModel(resp.Member), nil
+}
+
+func (c *UserServiceClient) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID, memberID string) error {
+	resp, err := c.client.RemoveWorkspaceMember(ctx, &pb.RemoveWorkspaceMemberRequest{
+		WorkspaceId: workspaceID,
+		UserId:      userID,
+		MemberId:    memberID,
+	})
 	if err != nil {
 		return err
 	}
@@ -237,28 +622,1552 @@ func (c *TodoServiceClient) DeleteTodo(ctx context.Context, id, userID string) e
 	return nil
 }
 
-func (c *TodoServiceClient) Close() error {
+func protoWorkspaceToModel(pbWorkspace *pb.Workspace) *models.Workspace {
+	createdAt, _ := time.Parse(time.RFC3339, pbWorkspace.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbWorkspace.UpdatedAt)
+	return &models.Workspace{
+		ID:        pbWorkspace.Id,
+		Name:      pbWorkspace.Name,
+		Role:      pbWorkspace.Role,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func protoWorkspaceMemberToModel(pbMember *pb.WorkspaceMember) *models.WorkspaceMember {
+	joinedAt, _ := time.Parse(time.RFC3339, pbMember.JoinedAt)
+	return &models.WorkspaceMember{
+		UserID:   pbMember.UserId,
+		Email:    pbMember.Email,
+		Role:     pbMember.Role,
+		JoinedAt: joinedAt,
+	}
+}
+
+func protoWorkspaceInvitationToModel(pbInvitation *pb.WorkspaceInvitation) *models.WorkspaceInvitation {
+	createdAt, _ := time.Parse(time.RFC3339, pbInvitation.CreatedAt)
+	expiresAt, _ := time.Parse(time.RFC3339, pbInvitation.ExpiresAt)
+	return &models.WorkspaceInvitation{
+		ID:            pbInvitation.Id,
+		WorkspaceID:   pbInvitation.WorkspaceId,
+		WorkspaceName: pbInvitation.WorkspaceName,
+		Email:         pbInvitation.Email,
+		Role:          pbInvitation.Role,
+		InvitedBy:     pbInvitation.InvitedBy,
+		CreatedAt:     createdAt,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func (c *UserServiceClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *TodoServiceClient) protoTodoToModel(pbTodo *pb.Todo) *models.Todo {
-	createdAt, _ := time.Parse(time.RFC3339, pbTodo.CreatedAt)
-	updatedAt, _ := time.Parse(time.RFC3339, pbTodo.UpdatedAt)
+func (c *UserServiceClient) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	resp, err := c.client.ListSessions(ctx, &pb.ListSessionsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	todo := &models.Todo{
-		ID:          pbTodo.Id,
-		UserID:      pbTodo.UserId,
-		Title:       pbTodo.Title,
-		Description: pbTodo.Description,
-		Completed:   pbTodo.Completed,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
 	}
 
-	if pbTodo.CompletedAt != "" {
-		completedAt, _ := time.Parse(time.RFC3339, pbTodo.CompletedAt)
-		todo.CompletedAt = &completedAt
+	sessions := make([]*models.Session, 0, len(resp.Sessions))
+	for _, pbSession := range resp.Sessions {
+		createdAt, _ := time.Parse(time.RFC3339, pbSession.CreatedAt)
+		lastSeenAt, _ := time.Parse(time.RFC3339, pbSession.LastSeenAt)
+		sessions = append(sessions, &models.Session{
+			ID:         pbSession.Id,
+			Device:     pbSession.Device,
+			IPAddress:  pbSession.IpAddress,
+			CreatedAt:  createdAt,
+			LastSeenAt: lastSeenAt,
+		})
 	}
+	return sessions, nil
+}
 
-	return todo
+func (c *UserServiceClient) RevokeSession(ctx context.Context, id, userID string) error {
+	resp, err := c.client.RevokeSession(ctx, &pb.RevokeSessionRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs a user out everywhere except exceptID and returns
+// the number of sessions revoked
+func (c *UserServiceClient) RevokeAllSessions(ctx context.Context, userID, exceptID string) (int, error) {
+	resp, err := c.client.RevokeAllSessions(ctx, &pb.RevokeAllSessionsRequest{
+		UserId:          userID,
+		ExceptSessionId: exceptID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.Error != "" {
+		return int(resp.RevokedCount), fmt.Errorf(resp.Error)
+	}
+
+	return int(resp.RevokedCount), nil
+}
+
+// CheckSession reports whether access tokens of a session are still accepted
+func (c *UserServiceClient) CheckSession(ctx context.Context, id, ipAddress string) (bool, error) {
+	resp, err := c.client.CheckSession(ctx, &pb.CheckSessionRequest{
+		Id:        id,
+		IpAddress: ipAddress,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if resp.Error != "" {
+		return false, fmt.Errorf(resp.Error)
+	}
+
+	return resp.Active, nil
+}
+
+// RequestPasswordReset mails a reset link to the address if it belongs to an
+// account
+func (c *UserServiceClient) RequestPasswordReset(ctx context.Context, email string) error {
+	resp, err := c.client.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{
+		Email: email,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *UserServiceClient) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	resp, err := c.client.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{
+		Token:       token,
+		NewPassword: newPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return responseError(resp.Error, resp.PasswordViolations)
+	}
+
+	return nil
+}
+
+// VerifyEmail confirms the address of a mailed verification token
+func (c *UserServiceClient) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	resp, err := c.client.VerifyEmail(ctx, &pb.VerifyEmailRequest{
+		Token: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoUserToModel(resp.User), nil
+}
+
+// ResendVerificationEmail mails a new verification link if the address
+// belongs to an account that still needs one
+func (c *UserServiceClient) ResendVerificationEmail(ctx context.Context, email string) error {
+	resp, err := c.client.ResendVerificationEmail(ctx, &pb.ResendVerificationEmailRequest{
+		Email: email,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+// VerifyTwoFactor completes a sign-in that required a second factor
+func (c *UserServiceClient) VerifyTwoFactor(ctx context.Context, twoFactorToken, code string) (*models.AuthResponse, error) {
+	resp, err := c.client.VerifyTwoFactor(ctx, &pb.VerifyTwoFactorRequest{
+		TwoFactorToken: twoFactorToken,
+		Code:           code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.authResponse(resp.User, resp.Token, resp.ExpiresAt, resp.RefreshToken, resp.RefreshTokenExpiresAt), nil
+}
+
+func (c *UserServiceClient) EnrollTOTP(ctx context.Context, userID string) (*models.TOTPEnrollment, error) {
+	resp, err := c.client.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return &models.TOTPEnrollment{
+		Secret:     resp.Secret,
+		OTPAuthURI: resp.OtpauthUri,
+	}, nil
+}
+
+// ConfirmTOTP turns two-factor authentication on and returns the recovery
+// codes
+func (c *UserServiceClient) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	resp, err := c.client.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{
+		UserId: userID,
+		Code:   code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return resp.RecoveryCodes, nil
+}
+
+func (c *UserServiceClient) DisableTOTP(ctx context.Context, userID, code string) error {
+	resp, err := c.client.DisableTOTP(ctx, &pb.DisableTOTPRequest{
+		UserId: userID,
+		Code:   code,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *UserServiceClient) GetTwoFactorStatus(ctx context.Context, userID string) (*models.TwoFactorStatus, error) {
+	resp, err := c.client.GetTwoFactorStatus(ctx, &pb.GetTwoFactorStatusRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return &models.TwoFactorStatus{
+		Enabled:                resp.Enabled,
+		RecoveryCodesRemaining: int(resp.RecoveryCodesRemaining),
+	}, nil
+}
+
+// GetJWKS fetches the public keys that verify access tokens
+func (c *UserServiceClient) GetJWKS(ctx context.Context) ([]models.JWK, error) {
+	resp, err := c.client.GetJWKS(ctx, &pb.GetJWKSRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	keys := make([]models.JWK, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		keys = append(keys, models.JWK{
+			KeyID:     key.Kid,
+			KeyType:   key.Kty,
+			Algorithm: key.Alg,
+			Use:       "sig",
+			N:         key.N,
+			E:         key.E,
+			Curve:     key.Crv,
+			X:         key.X,
+		})
+	}
+	return keys, nil
+}
+
+func (c *UserServiceClient) authResponse(pbUser *pb.User, token, expiresAt, refreshToken, refreshTokenExpiresAt string) *models.AuthResponse {
+	accessExpiry, _ := time.Parse(time.RFC3339, expiresAt)
+	refreshExpiry, _ := time.Parse(time.RFC3339, refreshTokenExpiresAt)
+
+	return &models.AuthResponse{
+		User:                  *c.protoUserToModel(pbUser),
+		Token:                 token,
+		ExpiresAt:             accessExpiry,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiry,
+	}
+}
+
+// CreatePersonalAccessToken issues a token; the returned Token field holds
+// the secret, which can't be retrieved again
+func (c *UserServiceClient) CreatePersonalAccessToken(ctx context.Context, userID string, req *models.CreatePersonalAccessTokenRequest) (*models.PersonalAccessToken, error) {
+	pbReq := &pb.CreatePersonalAccessTokenRequest{
+		UserId: userID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+	if req.ExpiresAt != nil {
+		pbReq.ExpiresAt = req.ExpiresAt.Format(time.RFC3339)
+	}
+
+	resp, err := c.client.CreatePersonalAccessToken(ctx, pbReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	token := c.protoPersonalAccessTokenToModel(resp.Token)
+	token.Token = resp.Secret
+	return token, nil
+}
+
+func (c *UserServiceClient) ListPersonalAccessTokens(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error) {
+	resp, err := c.client.ListPersonalAccessTokens(ctx, &pb.ListPersonalAccessTokensRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	tokens := make([]*models.PersonalAccessToken, 0, len(resp.Tokens))
+	for _, pbToken := range resp.Tokens {
+		tokens = append(tokens, c.protoPersonalAccessTokenToModel(pbToken))
+	}
+	return tokens, nil
+}
+
+func (c *UserServiceClient) RevokePersonalAccessToken(ctx context.Context, id, userID string) error {
+	resp, err := c.client.RevokePersonalAccessToken(ctx, &pb.RevokePersonalAccessTokenRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+// AuthenticatePersonalAccessToken resolves a bearer token to the account
+// and scopes it acts with. Unknown, expired and revoked tokens give a nil
+// identity.
+func (c *UserServiceClient) AuthenticatePersonalAccessToken(ctx context.Context, secret string) (*models.TokenIdentity, error) {
+	resp, err := c.client.AuthenticatePersonalAccessToken(ctx, &pb.AuthenticatePersonalAccessTokenRequest{
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, nil
+	}
+
+	return &models.TokenIdentity{
+		UserID:        resp.User.Id,
+		Email:         resp.User.Email,
+		EmailVerified: resp.User.EmailVerified,
+		TokenID:       resp.Token.Id,
+		Scopes:        resp.Token.Scopes,
+	}, nil
+}
+
+func (c *UserServiceClient) protoPersonalAccessTokenToModel(pbToken *pb.PersonalAccessToken) *models.PersonalAccessToken {
+	createdAt, _ := time.Parse(time.RFC3339, pbToken.CreatedAt)
+	return &models.PersonalAccessToken{
+		ID:         pbToken.Id,
+		Name:       pbToken.Name,
+		Hint:       pbToken.Hint,
+		Scopes:     pbToken.Scopes,
+		CreatedAt:  createdAt,
+		ExpiresAt:  parseOptionalTime(pbToken.ExpiresAt),
+		LastUsedAt: parseOptionalTime(pbToken.LastUsedAt),
+	}
+}
+
+// ListUsers lists one page of the users whose email contains query, ordered
+// by email. Todo counts are left for the caller to fill in.
+func (c *UserServiceClient) ListUsers(ctx context.Context, query string, page models.PageOptions) (*models.AdminUserPage, error) {
+	resp, err := c.client.ListUsers(ctx, &pb.ListUsersRequest{
+		Query:     query,
+		PageSize:  int32(page.Limit),
+		PageToken: page.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	users := make([]*models.AdminUser, len(resp.Users))
+	for i, pbUser := range resp.Users {
+		users[i] = &models.AdminUser{User: *c.protoUserToModel(pbUser)}
+	}
+	return &models.AdminUserPage{
+		Users:      users,
+		NextCursor: resp.NextPageToken,
+		Total:      int(resp.TotalCount),
+	}, nil
+}
+
+// SetUserDisabled disables or enables an account on behalf of the
+// administrator actorID
+func (c *UserServiceClient) SetUserDisabled(ctx context.Context, actorID, id string, disabled bool) (*models.User, error) {
+	resp, err := c.client.SetUserDisabled(ctx, &pb.SetUserDisabledRequest{
+		Id:       id,
+		Disabled: disabled,
+		ActorId:  actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.LastAdmin {
+		return nil, ErrLastAdmin
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoUserToModel(resp.User), nil
+}
+
+func (c *UserServiceClient) SetUserRole(ctx context.Context, actorID, id, role string) (*models.User, error) {
+	resp, err := c.client.SetUserRole(ctx, &pb.SetUserRoleRequest{
+		Id:      id,
+		Role:    role,
+		ActorId: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.LastAdmin {
+		return nil, ErrLastAdmin
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoUserToModel(resp.User), nil
+}
+
+// ForcePasswordReset invalidates the user's password and sessions and mails
+// them a password reset link
+func (c *UserServiceClient) ForcePasswordReset(ctx context.Context, id string) error {
+	resp, err := c.client.ForcePasswordReset(ctx, &pb.ForcePasswordResetRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *UserServiceClient) protoUserToModel(pbUser *pb.User) *models.User {
+	createdAt, _ := time.Parse(time.RFC3339, pbUser.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbUser.UpdatedAt)
+
+	return &models.User{
+		ID:                  pbUser.Id,
+		Email:               pbUser.Email,
+		EmailVerified:       pbUser.EmailVerified,
+		PendingEmail:        pbUser.PendingEmail,
+		Role:                pbUser.Role,
+		DisabledAt:          parseOptionalTime(pbUser.DisabledAt),
+		DeletionScheduledAt: parseOptionalTime(pbUser.DeletionScheduledAt),
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
+	}
+}
+
+// TodoServiceClient represents a client for the Todo service
+type TodoServiceClient struct {
+	client pb.TodoServiceClient
+	conn   *grpc.ClientConn
+}
+
+func NewTodoServiceClient(address string) (*TodoServiceClient, error) {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to todo service: %v", err)
+	}
+
+	client := pb.NewTodoServiceClient(conn)
+	return &TodoServiceClient{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+func (c *TodoServiceClient) CreateTodo(ctx context.Context, userID string, req *models.CreateTodoRequest) (*models.Todo, error) {
+	priority, err := priorityToProto(req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	recurrence, err := recurrenceToProto(req.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.CreateTodo(ctx, &pb.CreateTodoRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Title:       req.Title,
+		Description: req.Description,
+		DueAt:       formatOptionalTime(req.DueAt),
+		RemindAt:    formatOptionalTime(req.RemindAt),
+		Priority:    priority,
+		TagIds:      req.TagIDs,
+		ProjectId:   req.ProjectID,
+		ParentId:    req.ParentID,
+		Recurrence:  recurrence,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodoToModel(resp.Todo), nil
+}
+
+func (c *TodoServiceClient) GetTodo(ctx context.Context, id, userID string) (*models.Todo, error) {
+	resp, err := c.client.GetTodo(ctx, &pb.GetTodoRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodoToModel(resp.Todo), nil
+}
+
+func (c *TodoServiceClient) ListTodos(ctx context.Context, userID string, opts models.TodoListOptions) (*models.TodoPage, error) {
+	dueFilter, err := dueFilterToProto(opts.Due)
+	if err != nil {
+		return nil, err
+	}
+	sortBy, sortDirection, err := sortToProto(opts.Sort, opts.Order)
+	if err != nil {
+		return nil, err
+	}
+	projectID, noProject := opts.ProjectID, false
+	if projectID == "none" {
+		projectID, noProject = "", true
+	}
+
+	resp, err := c.client.ListTodos(ctx, &pb.ListTodosRequest{
+		UserId:        userID,
+		WorkspaceId:   workspaceFromContext(ctx),
+		CompletedOnly: false,
+		DueFilter:     dueFilter,
+		DueWithinDays: int32(opts.DueWithinDays),
+		SortBy:        sortBy,
+		SortDirection: sortDirection,
+		AnyTagIds:     opts.AnyTagIDs,
+		AllTagIds:     opts.AllTagIDs,
+		ProjectId:     projectID,
+		NoProject:     noProject,
+		TopLevelOnly:  opts.TopLevelOnly,
+		Filter:        opts.Filter,
+		AssignedToMe:  opts.AssignedToMe,
+		PageSize:      int32(opts.Page.Limit),
+		PageToken:     opts.Page.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.FilterErrorPosition > 0 {
+		return nil, &models.FilterError{Message: resp.Error, Position: int(resp.FilterErrorPosition)}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodosToPage(resp.Todos, resp.NextPageToken, resp.TotalCount), nil
+}
+
+func (c *TodoServiceClient) ListCompletedTodos(ctx context.Context, userID string, page models.PageOptions) (*models.TodoPage, error) {
+	resp, err := c.client.ListCompletedTodos(ctx, &pb.ListCompletedTodosRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		PageSize:    int32(page.Limit),
+		PageToken:   page.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodosToPage(resp.Todos, resp.NextPageToken, resp.TotalCount), nil
+}
+
+func (c *TodoServiceClient) SearchTodos(ctx context.Context, userID, query string, limit int) ([]*models.TodoSearchHit, error) {
+	resp, err := c.client.SearchTodos(ctx, &pb.SearchTodosRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Query:       query,
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.InvalidQuery {
+		return nil, &InvalidSearchError{Message: resp.Error}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	hits := make([]*models.TodoSearchHit, 0, len(resp.Hits))
+	for _, pbHit := range resp.Hits {
+		hits = append(hits, &models.TodoSearchHit{
+			Todo:           c.protoTodoToModel(pbHit.Todo),
+			TitleHighlight: pbHit.TitleHighlight,
+			Snippet:        pbHit.Snippet,
+			Rank:           pbHit.Rank,
+		})
+	}
+
+	return hits, nil
+}
+
+// CountTodosByUser counts the todos of each given user, keyed by user ID.
+// Users without todos are omitted.
+func (c *TodoServiceClient) CountTodosByUser(ctx context.Context, userIDs []string) (map[string]models.TodoCounts, error) {
+	resp, err := c.client.CountTodosByUser(ctx, &pb.CountTodosByUserRequest{
+		UserIds: userIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	counts := make(map[string]models.TodoCounts, len(resp.Counts))
+	for _, pbCount := range resp.Counts {
+		counts[pbCount.UserId] = models.TodoCounts{
+			Total:     int(pbCount.Total),
+			Completed: int(pbCount.Completed),
+		}
+	}
+	return counts, nil
+}
+
+// DeleteUserData deletes all todos, tags, projects and saved views of a user
+func (c *TodoServiceClient) DeleteUserData(ctx context.Context, userID string) error {
+	resp, err := c.client.DeleteUserData(ctx, &pb.DeleteUserDataRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) protoTodosToPage(pbTodos []*pb.Todo, nextPageToken string, total int32) *models.TodoPage {
+	page := &models.TodoPage{
+		NextCursor: nextPageToken,
+		Total:      int(total),
+	}
+	for _, pbTodo := range pbTodos {
+		page.Todos = append(page.Todos, c.protoTodoToModel(pbTodo))
+	}
+	return page
+}
+
+func (c *TodoServiceClient) UpdateTodo(ctx context.Context, id, userID string, req *models.UpdateTodoRequest) (*models.Todo, error) {
+	var priority *pb.Priority
+	if req.Priority != nil {
+		p, err := priorityToProto(*req.Priority)
+		if err != nil {
+			return nil, err
+		}
+		priority = &p
+	}
+
+	var tagIDs []string
+	if req.TagIDs != nil {
+		tagIDs = *req.TagIDs
+	}
+	recurrence, err := recurrenceToProto(req.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:              id,
+		UserId:          userID,
+		WorkspaceId:     workspaceFromContext(ctx),
+		Title:           req.Title,
+		Description:     req.Description,
+		DueAt:           formatOptionalTime(req.DueAt),
+		RemindAt:        formatOptionalTime(req.RemindAt),
+		ClearDueAt:      req.ClearDueAt,
+		ClearRemindAt:   req.ClearRemindAt,
+		Priority:        priority,
+		TagIds:          tagIDs,
+		SetTags:         req.TagIDs != nil,
+		ProjectId:       req.ProjectID,
+		ClearProject:    req.ClearProject,
+		ParentId:        req.ParentID,
+		ClearParent:     req.ClearParent,
+		Recurrence:      recurrence,
+		ClearRecurrence: req.ClearRecurrence,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodoToModel(resp.Todo), nil
+}
+
+func (c *TodoServiceClient) MarkTodoComplete(ctx context.Context, id, userID string, req *models.MarkTodoCompleteRequest) (*models.Todo, error) {
+	rule := pb.SubtaskRule_SUBTASK_RULE_REQUIRE_DONE
+	if req.CompleteSubtasks {
+		rule = pb.SubtaskRule_SUBTASK_RULE_COMPLETE_ALL
+	}
+
+	resp, err := c.client.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Completed:   req.Completed,
+		SubtaskRule: rule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	todo := c.protoTodoToModel(resp.Todo)
+	if resp.NextTodo != nil {
+		todo.NextOccurrence = c.protoTodoToModel(resp.NextTodo)
+	}
+
+	return todo, nil
+}
+
+// AssignTodo hands a todo to a user; in a workspace they must be able to
+// edit its todos
+func (c *TodoServiceClient) AssignTodo(ctx context.Context, id, userID, assigneeID string) (*models.Todo, error) {
+	resp, err := c.client.AssignTodo(ctx, &pb.AssignTodoRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		AssigneeId:  assigneeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodoToModel(resp.Todo), nil
+}
+
+func (c *TodoServiceClient) UnassignTodo(ctx context.Context, id, userID string) (*models.Todo, error) {
+	resp, err := c.client.UnassignTodo(ctx, &pb.UnassignTodoRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodoToModel(resp.Todo), nil
+}
+
+func (c *TodoServiceClient) DeleteTodo(ctx context.Context, id, userID string) error {
+	resp, err := c.client.DeleteTodo(ctx, &pb.DeleteTodoRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) AddComment(ctx context.Context, todoID, userID string, req *models.CommentRequest) (*models.Comment, error) {
+	resp, err := c.client.AddComment(ctx, &pb.AddCommentRequest{
+		TodoId:      todoID,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Body:        req.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoCommentToModel(resp.Comment), nil
+}
+
+// ListComments returns a todo's thread oldest first, deleted comments
+// included
+func (c *TodoServiceClient) ListComments(ctx context.Context, todoID, userID string) ([]*models.Comment, error) {
+	resp, err := c.client.ListComments(ctx, &pb.ListCommentsRequest{
+		TodoId:      todoID,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	comments := make([]*models.Comment, len(resp.Comments))
+	for i, pbComment := range resp.Comments {
+		comments[i] = protoCommentToModel(pbComment)
+	}
+
+	return comments, nil
+}
+
+func (c *TodoServiceClient) EditComment(ctx context.Context, id, todoID, userID string, req *models.CommentRequest) (*models.Comment, error) {
+	resp, err := c.client.EditComment(ctx, &pb.EditCommentRequest{
+		Id:          id,
+		TodoId:      todoID,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Body:        req.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoCommentToModel(resp.Comment), nil
+}
+
+// DeleteComment removes a comment from the thread; only its author or the
+// todo's owner may do so
+func (c *TodoServiceClient) DeleteComment(ctx context.Context, id, todoID, userID string) error {
+	resp, err := c.client.DeleteComment(ctx, &pb.DeleteCommentRequest{
+		Id:          id,
+		TodoId:      todoID,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) CreateTag(ctx context.Context, userID string, req *models.CreateTagRequest) (*models.Tag, error) {
+	resp, err := c.client.CreateTag(ctx, &pb.CreateTagRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Name:        req.Name,
+		Color:       req.Color,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTagToModel(resp.Tag), nil
+}
+
+func (c *TodoServiceClient) ListTags(ctx context.Context, userID string) ([]*models.Tag, error) {
+	resp, err := c.client.ListTags(ctx, &pb.ListTagsRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	tags := make([]*models.Tag, 0, len(resp.Tags))
+	for _, pbTag := range resp.Tags {
+		tags = append(tags, c.protoTagToModel(pbTag))
+	}
+
+	return tags, nil
+}
+
+func (c *TodoServiceClient) UpdateTag(ctx context.Context, id, userID string, req *models.UpdateTagRequest) (*models.Tag, error) {
+	resp, err := c.client.UpdateTag(ctx, &pb.UpdateTagRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Name:        req.Name,
+		Color:       req.Color,
+		ClearColor:  req.ClearColor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTagToModel(resp.Tag), nil
+}
+
+func (c *TodoServiceClient) DeleteTag(ctx context.Context, id, userID string) error {
+	resp, err := c.client.DeleteTag(ctx, &pb.DeleteTagRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) CreateProject(ctx context.Context, userID string, req *models.CreateProjectRequest) (*models.Project, error) {
+	resp, err := c.client.CreateProject(ctx, &pb.CreateProjectRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoProjectToModel(resp.Project), nil
+}
+
+func (c *TodoServiceClient) GetProject(ctx context.Context, id, userID string) (*models.Project, error) {
+	resp, err := c.client.GetProject(ctx, &pb.GetProjectRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoProjectToModel(resp.Project), nil
+}
+
+func (c *TodoServiceClient) ListProjects(ctx context.Context, userID string) ([]*models.Project, error) {
+	resp, err := c.client.ListProjects(ctx, &pb.ListProjectsRequest{
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	projects := make([]*models.Project, 0, len(resp.Projects))
+	for _, pbProject := range resp.Projects {
+		projects = append(projects, c.protoProjectToModel(pbProject))
+	}
+
+	return projects, nil
+}
+
+func (c *TodoServiceClient) UpdateProject(ctx context.Context, id, userID string, req *models.UpdateProjectRequest) (*models.Project, error) {
+	resp, err := c.client.UpdateProject(ctx, &pb.UpdateProjectRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoProjectToModel(resp.Project), nil
+}
+
+func (c *TodoServiceClient) DeleteProject(ctx context.Context, id, userID string) error {
+	resp, err := c.client.DeleteProject(ctx, &pb.DeleteProjectRequest{
+		Id:          id,
+		UserId:      userID,
+		WorkspaceId: workspaceFromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) CreateSavedView(ctx context.Context, userID string, req *models.CreateSavedViewRequest) (*models.SavedView, error) {
+	criteria, err := viewCriteriaToProto(req.Criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.CreateSavedView(ctx, &pb.CreateSavedViewRequest{
+		UserId:   userID,
+		Name:     req.Name,
+		Icon:     req.Icon,
+		Criteria: criteria,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoSavedViewToModel(resp.View), nil
+}
+
+func (c *TodoServiceClient) GetSavedView(ctx context.Context, id, userID string) (*models.SavedView, error) {
+	resp, err := c.client.GetSavedView(ctx, &pb.GetSavedViewRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoSavedViewToModel(resp.View), nil
+}
+
+func (c *TodoServiceClient) ListSavedViews(ctx context.Context, userID string) ([]*models.SavedView, error) {
+	resp, err := c.client.ListSavedViews(ctx, &pb.ListSavedViewsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	views := make([]*models.SavedView, 0, len(resp.Views))
+	for _, pbView := range resp.Views {
+		views = append(views, protoSavedViewToModel(pbView))
+	}
+
+	return views, nil
+}
+
+func (c *TodoServiceClient) UpdateSavedView(ctx context.Context, id, userID string, req *models.UpdateSavedViewRequest) (*models.SavedView, error) {
+	pbReq := &pb.UpdateSavedViewRequest{
+		Id:     id,
+		UserId: userID,
+		Name:   req.Name,
+		Icon:   req.Icon,
+	}
+	if req.Criteria != nil {
+		criteria, err := viewCriteriaToProto(*req.Criteria)
+		if err != nil {
+			return nil, err
+		}
+		pbReq.Criteria = criteria
+	}
+
+	resp, err := c.client.UpdateSavedView(ctx, pbReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return protoSavedViewToModel(resp.View), nil
+}
+
+func (c *TodoServiceClient) DeleteSavedView(ctx context.Context, id, userID string) error {
+	resp, err := c.client.DeleteSavedView(ctx, &pb.DeleteSavedViewRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+
+	return nil
+}
+
+func (c *TodoServiceClient) ListTodosByView(ctx context.Context, viewID, userID string, page models.PageOptions) (*models.TodoPage, error) {
+	resp, err := c.client.ListTodosByView(ctx, &pb.ListTodosByViewRequest{
+		ViewId:    viewID,
+		UserId:    userID,
+		PageSize:  int32(page.Limit),
+		PageToken: page.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf(resp.Error)
+	}
+
+	return c.protoTodosToPage(resp.Todos, resp.NextPageToken, resp.TotalCount), nil
+}
+
+func (c *TodoServiceClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *TodoServiceClient) protoTodoToModel(pbTodo *pb.Todo) *models.Todo {
+	createdAt, _ := time.Parse(time.RFC3339, pbTodo.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbTodo.UpdatedAt)
+
+	todo := &models.Todo{
+		ID:          pbTodo.Id,
+		UserID:      pbTodo.UserId,
+		Title:       pbTodo.Title,
+		Description: pbTodo.Description,
+		Completed:   pbTodo.Completed,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Priority:    priorityNames[pbTodo.Priority],
+		WorkspaceID: pbTodo.WorkspaceId,
+		CreatedBy:   pbTodo.CreatedBy,
+		AssigneeID:  pbTodo.AssigneeId,
+		Progress: models.Progress{
+			Done:  int(pbTodo.SubtasksDone),
+			Total: int(pbTodo.SubtasksTotal),
+		},
+	}
+
+	if pbTodo.CompletedAt != "" {
+		completedAt, _ := time.Parse(time.RFC3339, pbTodo.CompletedAt)
+		todo.CompletedAt = &completedAt
+	}
+	todo.DueAt = parseOptionalTime(pbTodo.DueAt)
+	todo.RemindAt = parseOptionalTime(pbTodo.RemindAt)
+
+	if pbTodo.ProjectId != "" {
+		projectID := pbTodo.ProjectId
+		todo.ProjectID = &projectID
+	}
+	if pbTodo.ParentId != "" {
+		parentID := pbTodo.ParentId
+		todo.ParentID = &parentID
+	}
+	for _, pbSubtask := range pbTodo.Subtasks {
+		todo.Subtasks = append(todo.Subtasks, c.protoTodoToModel(pbSubtask))
+	}
+	if pbTodo.Recurrence != nil {
+		todo.Recurrence = recurrenceToModel(pbTodo.Recurrence)
+	}
+
+	todo.Tags = make([]*models.Tag, 0, len(pbTodo.Tags))
+	for _, pbTag := range pbTodo.Tags {
+		todo.Tags = append(todo.Tags, c.protoTagToModel(pbTag))
+	}
+
+	return todo
+}
+
+func (c *TodoServiceClient) protoTagToModel(pbTag *pb.Tag) *models.Tag {
+	createdAt, _ := time.Parse(time.RFC3339, pbTag.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbTag.UpdatedAt)
+
+	return &models.Tag{
+		ID:        pbTag.Id,
+		Name:      pbTag.Name,
+		Color:     pbTag.Color,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func (c *TodoServiceClient) protoProjectToModel(pbProject *pb.Project) *models.Project {
+	createdAt, _ := time.Parse(time.RFC3339, pbProject.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbProject.UpdatedAt)
+
+	return &models.Project{
+		ID:             pbProject.Id,
+		Name:           pbProject.Name,
+		Description:    pbProject.Description,
+		OpenCount:      int(pbProject.OpenCount),
+		CompletedCount: int(pbProject.CompletedCount),
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+func dueFilterToProto(due string) (pb.DueFilter, error) {
+	switch due {
+	case "":
+		return pb.DueFilter_DUE_FILTER_UNSPECIFIED, nil
+	case "overdue":
+		return pb.DueFilter_DUE_FILTER_OVERDUE, nil
+	case "today":
+		return pb.DueFilter_DUE_FILTER_TODAY, nil
+	case "upcoming":
+		return pb.DueFilter_DUE_FILTER_WITHIN_DAYS, nil
+	default:
+		return pb.DueFilter_DUE_FILTER_UNSPECIFIED, fmt.Errorf("unknown due filter: %s", due)
+	}
+}
+
+var priorityNames = map[pb.Priority]string{
+	pb.Priority_PRIORITY_NONE:   "none",
+	pb.Priority_PRIORITY_LOW:    "low",
+	pb.Priority_PRIORITY_MEDIUM: "medium",
+	pb.Priority_PRIORITY_HIGH:   "high",
+	pb.Priority_PRIORITY_URGENT: "urgent",
+}
+
+func priorityToProto(name string) (pb.Priority, error) {
+	if name == "" {
+		return pb.Priority_PRIORITY_NONE, nil
+	}
+	for priority, priorityName := range priorityNames {
+		if priorityName == name {
+			return priority, nil
+		}
+	}
+	return pb.Priority_PRIORITY_NONE, fmt.Errorf("unknown priority: %s", name)
+}
+
+func sortToProto(field, order string) (pb.SortField, pb.SortDirection, error) {
+	var sortBy pb.SortField
+	switch field {
+	case "":
+		sortBy = pb.SortField_SORT_FIELD_UNSPECIFIED
+	case "priority":
+		sortBy = pb.SortField_SORT_FIELD_PRIORITY
+	case "due_at":
+		sortBy = pb.SortField_SORT_FIELD_DUE_AT
+	case "created_at":
+		sortBy = pb.SortField_SORT_FIELD_CREATED_AT
+	case "updated_at":
+		sortBy = pb.SortField_SORT_FIELD_UPDATED_AT
+	case "title":
+		sortBy = pb.SortField_SORT_FIELD_TITLE
+	default:
+		return sortBy, pb.SortDirection_SORT_DIRECTION_UNSPECIFIED, fmt.Errorf("unknown sort field: %s", field)
+	}
+
+	switch order {
+	case "":
+		return sortBy, pb.SortDirection_SORT_DIRECTION_UNSPECIFIED, nil
+	case "asc":
+		return sortBy, pb.SortDirection_SORT_DIRECTION_ASC, nil
+	case "desc":
+		return sortBy, pb.SortDirection_SORT_DIRECTION_DESC, nil
+	default:
+		return sortBy, pb.SortDirection_SORT_DIRECTION_UNSPECIFIED, fmt.Errorf("unknown sort order: %s", order)
+	}
+}
+
+var viewCompletionNames = map[pb.ViewCompletion]string{
+	pb.ViewCompletion_VIEW_COMPLETION_UNSPECIFIED: "",
+	pb.ViewCompletion_VIEW_COMPLETION_OPEN:        "open",
+	pb.ViewCompletion_VIEW_COMPLETION_COMPLETED:   "completed",
+}
+
+var sortFieldNames = map[pb.SortField]string{
+	pb.SortField_SORT_FIELD_UNSPECIFIED: "",
+	pb.SortField_SORT_FIELD_PRIORITY:    "priority",
+	pb.SortField_SORT_FIELD_DUE_AT:      "due_at",
+	pb.SortField_SORT_FIELD_CREATED_AT:  "created_at",
+	pb.SortField_SORT_FIELD_UPDATED_AT:  "updated_at",
+	pb.SortField_SORT_FIELD_TITLE:       "title",
+}
+
+var sortDirectionNames = map[pb.SortDirection]string{
+	pb.SortDirection_SORT_DIRECTION_UNSPECIFIED: "",
+	pb.SortDirection_SORT_DIRECTION_ASC:         "asc",
+	pb.SortDirection_SORT_DIRECTION_DESC:        "desc",
+}
+
+func viewCriteriaToProto(criteria models.ViewCriteria) (*pb.ViewCriteria, error) {
+	sortBy, sortDirection, err := sortToProto(criteria.Sort, criteria.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	pbCriteria := &pb.ViewCriteria{
+		CreatedFrom:   criteria.CreatedFrom,
+		CreatedBefore: criteria.CreatedBefore,
+		UpdatedFrom:   criteria.UpdatedFrom,
+		UpdatedBefore: criteria.UpdatedBefore,
+		TitleContains: criteria.TitleContains,
+		Filter:        criteria.Filter,
+		SortBy:        sortBy,
+		SortDirection: sortDirection,
+	}
+	found := false
+	for completion, name := range viewCompletionNames {
+		if name == criteria.Completion {
+			pbCriteria.Completion, found = completion, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown completion: %s", criteria.Completion)
+	}
+	return pbCriteria, nil
+}
+
+func protoSavedViewToModel(pbView *pb.SavedView) *models.SavedView {
+	createdAt, _ := time.Parse(time.RFC3339, pbView.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pbView.UpdatedAt)
+
+	view := &models.SavedView{
+		ID:        pbView.Id,
+		Name:      pbView.Name,
+		Icon:      pbView.Icon,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+	if criteria := pbView.Criteria; criteria != nil {
+		view.Criteria = models.ViewCriteria{
+			Completion:    viewCompletionNames[criteria.Completion],
+			CreatedFrom:   criteria.CreatedFrom,
+			CreatedBefore: criteria.CreatedBefore,
+			UpdatedFrom:   criteria.UpdatedFrom,
+			UpdatedBefore: criteria.UpdatedBefore,
+			TitleContains: criteria.TitleContains,
+			Filter:        criteria.Filter,
+			Sort:          sortFieldNames[criteria.SortBy],
+			Order:         sortDirectionNames[criteria.SortDirection],
+		}
+	}
+	return view
+}
+
+func protoCommentToModel(pbComment *pb.Comment) *models.Comment {
+	createdAt, _ := time.Parse(time.RFC3339, pbComment.CreatedAt)
+
+	return &models.Comment{
+		ID:        pbComment.Id,
+		TodoID:    pbComment.TodoId,
+		AuthorID:  pbComment.AuthorId,
+		Body:      pbComment.Body,
+		CreatedAt: createdAt,
+		EditedAt:  parseOptionalTime(pbComment.EditedAt),
+		Deleted:   pbComment.Deleted,
+	}
+}
+
+var frequencyNames = map[pb.RecurrenceFrequency]string{
+	pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_DAILY:            "daily",
+	pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_WEEKLY:           "weekly",
+	pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_MONTHLY:          "monthly",
+	pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_AFTER_COMPLETION: "after_completion",
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func recurrenceToProto(recurrence *models.Recurrence) (*pb.Recurrence, error) {
+	if recurrence == nil {
+		return nil, nil
+	}
+
+	pbRecurrence := &pb.Recurrence{
+		Interval: int32(recurrence.Interval),
+		MonthDay: int32(recurrence.MonthDay),
+	}
+	for frequency, name := range frequencyNames {
+		if name == recurrence.Frequency {
+			pbRecurrence.Frequency = frequency
+		}
+	}
+	if pbRecurrence.Frequency == pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_UNSPECIFIED {
+		return nil, fmt.Errorf("unknown recurrence frequency: %s", recurrence.Frequency)
+	}
+	// An omitted interval means every day, week or month
+	if pbRecurrence.Interval == 0 {
+		pbRecurrence.Interval = 1
+	}
+
+	for _, day := range recurrence.Weekdays {
+		weekday := -1
+		for i, name := range weekdayNames {
+			if name == day {
+				weekday = i
+			}
+		}
+		if weekday < 0 {
+			return nil, fmt.Errorf("unknown weekday: %s", day)
+		}
+		pbRecurrence.Weekdays = append(pbRecurrence.Weekdays, int32(weekday))
+	}
+
+	return pbRecurrence, nil
+}
+
+func recurrenceToModel(pbRecurrence *pb.Recurrence) *models.Recurrence {
+	recurrence := &models.Recurrence{
+		Frequency: frequencyNames[pbRecurrence.Frequency],
+		Interval:  int(pbRecurrence.Interval),
+		MonthDay:  int(pbRecurrence.MonthDay),
+	}
+	for _, weekday := range pbRecurrence.Weekdays {
+		if weekday >= 0 && int(weekday) < len(weekdayNames) {
+			recurrence.Weekdays = append(recurrence.Weekdays, weekdayNames[weekday])
+		}
+	}
+	return recurrence
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseOptionalTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
 }
@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+)
+
+// purgeBatchSize caps the accounts deleted per run; the rest wait for the
+// next one
+const purgeBatchSize = 100
+
+// AccountPurger deletes the accounts whose deletion grace period is over.
+// The BFF runs it because it is the only component that talks to both
+// services: the todo service's data is deleted first, so an account is
+// never gone while its todos remain. That includes the data of the
+// workspaces the user is the last member of, which go with the account.
+// Failed deletions are retried on the next run.
+type AccountPurger struct {
+	userClient *clients.UserServiceClient
+	todoClient *clients.TodoServiceClient
+	interval   time.Duration
+}
+
+func NewAccountPurger(userClient *clients.UserServiceClient, todoClient *clients.TodoServiceClient, interval time.Duration) *AccountPurger {
+	return &AccountPurger{
+		userClient: userClient,
+		todoClient: todoClient,
+		interval:   interval,
+	}
+}
+
+// Run purges due accounts every interval until ctx is done
+func (p *AccountPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.PurgeDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeDue deletes one batch of due accounts and returns how many were
+// deleted
+func (p *AccountPurger) PurgeDue(ctx context.Context) int {
+	users, err := p.userClient.ListDueAccountDeletions(ctx, purgeBatchSize)
+	if err != nil {
+		log.Printf("Failed to list accounts due for deletion: %v", err)
+		return 0
+	}
+
+	deleted := 0
+	for _, user := range users {
+		if err := p.deleteTodoData(ctx, user.ID); err != nil {
+			log.Printf("Failed to delete the todos of user %s: %v", user.ID, err)
+			continue
+		}
+		workspaceIDs, err := p.userClient.DeleteUser(ctx, user.ID)
+		// Only workspaces whose other members left since deleteTodoData still
+		// have data; they are gone now, so a failure can only be logged
+		for _, workspaceID := range workspaceIDs {
+			if err := p.todoClient.DeleteUserData(ctx, workspaceID); err != nil {
+				log.Printf("Failed to delete data of workspace %s: %v", workspaceID, err)
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to delete user %s: %v", user.ID, err)
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Printf("Deleted %d accounts after their grace period", deleted)
+	}
+	return deleted
+}
+
+// deleteTodoData deletes the user's data in the todo service, along with
+// that of the workspaces the user is the last member of
+func (p *AccountPurger) deleteTodoData(ctx context.Context, userID string) error {
+	if err := p.todoClient.DeleteUserData(ctx, userID); err != nil {
+		return err
+	}
+
+	workspaces, err := p.userClient.ListWorkspaces(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, workspace := range workspaces {
+		workspace, err := p.userClient.GetWorkspace(ctx, workspace.ID, userID)
+		if err != nil {
+			return err
+		}
+		if len(workspace.Members) > 1 {
+			continue
+		}
+		if err := p.todoClient.DeleteUserData(ctx, workspace.ID); err != nil {
+			return fmt.Errorf("workspace %s: %w", workspace.ID, err)
+		}
+	}
+	return nil
+}
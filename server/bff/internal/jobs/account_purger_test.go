@@ -0,0 +1,157 @@
+package jobs
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/bff/internal/clients"
+)
+
+// purgeUserService has one account due for deletion, which is the only
+// member of ws-solo and shares ws-shared with someone else
+type purgeUserService struct {
+	pb.UnimplementedUserServiceServer
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (s *purgeUserService) ListDueAccountDeletions(ctx context.Context, req *pb.ListDueAccountDeletionsRequest) (*pb.ListDueAccountDeletionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.deleted) > 0 {
+		return &pb.ListDueAccountDeletionsResponse{}, nil
+	}
+	return &pb.ListDueAccountDeletionsResponse{Users: []*pb.User{{Id: "user-1", Email: "user@example.com"}}}, nil
+}
+
+func (s *purgeUserService) ListWorkspaces(ctx context.Context, req *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	return &pb.ListWorkspacesResponse{Workspaces: []*pb.Workspace{{Id: "ws-shared"}, {Id: "ws-solo"}}}, nil
+}
+
+func (s *purgeUserService) GetWorkspace(ctx context.Context, req *pb.GetWorkspaceRequest) (*pb.GetWorkspaceResponse, error) {
+	members := []*pb.WorkspaceMember{{UserId: req.UserId, Role: "owner"}}
+	if req.Id == "ws-shared" {
+		members = append(members, &pb.WorkspaceMember{UserId: "user-2", Role: "owner"})
+	}
+	return &pb.GetWorkspaceResponse{Workspace: &pb.Workspace{Id: req.Id}, Members: members}, nil
+}
+
+func (s *purgeUserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted = append(s.deleted, req.Id)
+	return &pb.DeleteUserResponse{Success: true, DeletedWorkspaceIds: []string{"ws-solo"}}, nil
+}
+
+func (s *purgeUserService) deletedUsers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.deleted...)
+}
+
+// purgeTodoService records whose data was deleted and fails for the
+// workspace in failFor
+type purgeTodoService struct {
+	pb.UnimplementedTodoServiceServer
+
+	mu      sync.Mutex
+	failFor string
+	owners  []string
+}
+
+func (s *purgeTodoService) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.UserId == s.failFor {
+		return &pb.DeleteUserDataResponse{Error: "database is locked"}, nil
+	}
+	s.owners = append(s.owners, req.UserId)
+	return &pb.DeleteUserDataResponse{Success: true}, nil
+}
+
+func (s *purgeTodoService) setFailFor(owner string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failFor = owner
+}
+
+func (s *purgeTodoService) deletedOwners() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.owners...)
+}
+
+func newTestPurger(t *testing.T) (*AccountPurger, *purgeUserService, *purgeTodoService) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	users := &purgeUserService{}
+	todos := &purgeTodoService{}
+	s := grpc.NewServer()
+	pb.RegisterUserServiceServer(s, users)
+	pb.RegisterTodoServiceServer(s, todos)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	userClient, err := clients.NewUserServiceClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { userClient.Close() })
+	todoClient, err := clients.NewTodoServiceClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { todoClient.Close() })
+
+	return NewAccountPurger(userClient, todoClient, 0), users, todos
+}
+
+func TestAccountPurger_RetriesWorkspaceData(t *testing.T) {
+	// Arrange - deleting the data of the workspace only the user belongs to fails
+	purger, users, todos := newTestPurger(t)
+	todos.setFailFor("ws-solo")
+	ctx := context.Background()
+
+	// Act & Assert - the account is kept for the next run
+	if deleted := purger.PurgeDue(ctx); deleted != 0 {
+		t.Fatalf("Expected no account to be deleted, got %d", deleted)
+	}
+	if deleted := users.deletedUsers(); len(deleted) != 0 {
+		t.Fatalf("The account should be kept until its workspace data is gone, got %v", deleted)
+	}
+
+	// Act & Assert - the next run deletes it along with the workspace data
+	todos.setFailFor("")
+	if deleted := purger.PurgeDue(ctx); deleted != 1 {
+		t.Fatalf("Expected the account to be deleted, got %d", deleted)
+	}
+	if deleted := users.deletedUsers(); len(deleted) != 1 || deleted[0] != "user-1" {
+		t.Errorf("Expected user-1 to be deleted, got %v", deleted)
+	}
+	owners := todos.deletedOwners()
+	if containsOwner(owners, "ws-shared") {
+		t.Errorf("The shared workspace's data should be kept, got %v", owners)
+	}
+	if !containsOwner(owners, "user-1") || !containsOwner(owners, "ws-solo") {
+		t.Errorf("Expected the data of user-1 and ws-solo to be deleted, got %v", owners)
+	}
+}
+
+func containsOwner(owners []string, owner string) bool {
+	for _, o := range owners {
+		if o == owner {
+			return true
+		}
+	}
+	return false
+}
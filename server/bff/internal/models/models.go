@@ -3,10 +3,20 @@ package models
 import "time"
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	// PendingEmail replaces Email once the link mailed to it is opened
+	PendingEmail string `json:"pending_email,omitempty"`
+	// Role is "user" or "admin"
+	Role string `json:"role"`
+	// DisabledAt is set while an administrator has disabled the account
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	// DeletionScheduledAt is when the account and its todos are deleted,
+	// set while the user can still cancel the deletion
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
 type Todo struct {
@@ -18,6 +28,62 @@ type Todo struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	RemindAt    *time.Time `json:"remind_at,omitempty"`
+	Priority    string     `json:"priority"`
+	Tags        []*Tag     `json:"tags"`
+	ProjectID   *string    `json:"project_id,omitempty"`
+	ParentID    *string    `json:"parent_id,omitempty"`
+	// Subtasks is only filled when a single todo is fetched
+	Subtasks []*Todo  `json:"subtasks,omitempty"`
+	Progress Progress `json:"progress"`
+	// Recurrence is set on the open occurrence of a repeating todo
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// NextOccurrence is only set in the response to completing a recurring todo
+	NextOccurrence *Todo `json:"next_occurrence,omitempty"`
+	// WorkspaceID is set on todos shared in a workspace
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// CreatedBy is the ID of the user who created the todo
+	CreatedBy string `json:"created_by,omitempty"`
+	// AssigneeID is the user the todo was handed to; AssigneeEmail is filled
+	// in by the BFF
+	AssigneeID    string `json:"assignee_id,omitempty"`
+	AssigneeEmail string `json:"assignee_email,omitempty"`
+}
+
+// Recurrence is a repeat rule for a todo
+type Recurrence struct {
+	// Frequency is one of "daily", "weekly", "monthly" or "after_completion"
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval"`
+	// Weekdays are "sun" ... "sat" and are used by weekly rules
+	Weekdays []string `json:"weekdays,omitempty"`
+	// MonthDay (1-31) is used by monthly rules
+	MonthDay int `json:"month_day,omitempty"`
+}
+
+// Progress counts the completed and total direct subtasks of a todo
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+type Project struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	OpenCount      int       `json:"open_count"`
+	CompletedCount int       `json:"completed_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type LoginRequest struct {
@@ -27,26 +93,440 @@ type LoginRequest struct {
 
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 }
 
+// FieldError explains why one field of a request was rejected; Code is
+// stable for clients to map to their own messages
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the body of a 422 response listing field errors
+type ValidationError struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+// AuthResponse carries a short-lived access token and the refresh token used
+// to obtain the next one
 type AuthResponse struct {
-	User  User   `json:"user"`
-	Token string `json:"token"`
+	User                  User      `json:"user"`
+	Token                 string    `json:"token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// UpdateProfileRequest changes the signed-in user's email or password; both
+// need the current password
+type UpdateProfileRequest struct {
+	Email           string `json:"email"`
+	Password        string `json:"password"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+// DeleteAccountRequest confirms the deletion of the signed-in user's account
+type DeleteAccountRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+// Preferences are the signed-in user's display and listing settings.
+// Timezone decides where "today" starts for due filters and which offset
+// todo times are returned in; the defaults apply to list and create requests
+// that don't choose for themselves.
+type Preferences struct {
+	Timezone         string     `json:"timezone"`
+	Locale           string     `json:"locale"`
+	WeekStart        string     `json:"week_start"`
+	DateFormat       string     `json:"date_format"`
+	DefaultSort      string     `json:"default_sort,omitempty"`
+	DefaultSortOrder string     `json:"default_sort_order,omitempty"`
+	DefaultProjectID string     `json:"default_project_id,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdatePreferencesRequest changes the fields that are present; an empty
+// default_sort, default_sort_order or default_project_id removes the default
+type UpdatePreferencesRequest struct {
+	Timezone         *string `json:"timezone,omitempty"`
+	Locale           *string `json:"locale,omitempty"`
+	WeekStart        *string `json:"week_start,omitempty"`
+	DateFormat       *string `json:"date_format,omitempty"`
+	DefaultSort      *string `json:"default_sort,omitempty"`
+	DefaultSortOrder *string `json:"default_sort_order,omitempty"`
+	DefaultProjectID *string `json:"default_project_id,omitempty"`
+}
+
+// Workspace is a group of users sharing todos, tags and projects. Role is
+// the signed-in user's: "owner", "member" or "viewer".
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Members is only filled when a single workspace is fetched
+	Members []*WorkspaceMember `json:"members,omitempty"`
+}
+
+type WorkspaceMember struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// WorkspaceInvitation asks the owner of an email address to join a workspace
+type WorkspaceInvitation struct {
+	ID            string    `json:"id"`
+	WorkspaceID   string    `json:"workspace_id"`
+	WorkspaceName string    `json:"workspace_name"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	InvitedBy     string    `json:"invited_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type InviteToWorkspaceRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role"`
+}
+
+type SetWorkspaceMemberRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// JWK is a public key verifying access tokens (RFC 7517)
+type JWK struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// TwoFactorChallenge is returned by login instead of tokens when the account
+// has two-factor authentication on
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	TwoFactorToken    string    `json:"two_factor_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// LoginBlocked is the body of a 429 response to a login refused because of
+// earlier failed attempts
+type LoginBlocked struct {
+	Message       string    `json:"message"`
+	AccountLocked bool      `json:"account_locked"`
+	RetryAt       time.Time `json:"retry_at"`
+}
+
+// VerifyTwoFactorRequest completes a login; Code is a TOTP code or a
+// recovery code
+type VerifyTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TOTPEnrollment is added to an authenticator app, usually as a QR code of
+// OTPAuthURI
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// Session is a signed-in device; Current marks the session of the request
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// PersonalAccessToken lets scripts call the API within its scopes. Token is
+// only set in the response that creates it.
+type PersonalAccessToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Hint       string     `json:"hint"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Token      string     `json:"token,omitempty"`
+}
+
+// CreatePersonalAccessTokenRequest creates a token; without ExpiresAt it
+// never expires
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// TokenIdentity is the account and scopes a personal access token acts with
+type TokenIdentity struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	TokenID       string
+	Scopes        []string
+}
+
+// TodoCounts counts every todo of a user, subtasks included
+type TodoCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+// AdminUser is a user as listed for administrators
+type AdminUser struct {
+	User
+	Todos TodoCounts `json:"todos"`
+}
+
+// AdminUserPage is the response of the admin user listing
+type AdminUserPage struct {
+	Users []*AdminUser `json:"users"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
 }
 
 type CreateTodoRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
+	Title       string      `json:"title" validate:"required"`
+	Description string      `json:"description"`
+	DueAt       *time.Time  `json:"due_at,omitempty"`
+	RemindAt    *time.Time  `json:"remind_at,omitempty"`
+	Priority    string      `json:"priority,omitempty"`
+	TagIDs      []string    `json:"tag_ids,omitempty"`
+	ProjectID   string      `json:"project_id,omitempty"`
+	ParentID    string      `json:"parent_id,omitempty"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
 }
 
 type UpdateTodoRequest struct {
-	Title       string `json:"title,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	DueAt         *time.Time `json:"due_at,omitempty"`
+	RemindAt      *time.Time `json:"remind_at,omitempty"`
+	ClearDueAt    bool       `json:"clear_due_at,omitempty"`
+	ClearRemindAt bool       `json:"clear_remind_at,omitempty"`
+	Priority      *string    `json:"priority,omitempty"`
+	// TagIDs replaces the todo's tags when present; an empty list removes them all
+	TagIDs *[]string `json:"tag_ids,omitempty"`
+	// ProjectID moves the todo to another project; ClearProject removes it from its project
+	ProjectID    string `json:"project_id,omitempty"`
+	ClearProject bool   `json:"clear_project,omitempty"`
+	// ParentID makes the todo a subtask; ClearParent makes it top-level again
+	ParentID    string `json:"parent_id,omitempty"`
+	ClearParent bool   `json:"clear_parent,omitempty"`
+	// Recurrence replaces the repeat rule; ClearRecurrence stops the todo from repeating
+	Recurrence      *Recurrence `json:"recurrence,omitempty"`
+	ClearRecurrence bool        `json:"clear_recurrence,omitempty"`
+}
+
+// TodoListOptions holds the query parameters accepted by GET /api/todos
+type TodoListOptions struct {
+	// Due is one of "overdue", "today" or "upcoming"
+	Due           string
+	DueWithinDays int
+	// Sort is one of "priority", "due_at", "created_at", "updated_at" or "title"
+	Sort string
+	// Order is "asc" or "desc"; empty uses the natural order of Sort
+	Order string
+	// AnyTagIDs matches todos carrying at least one of the tags
+	AnyTagIDs []string
+	// AllTagIDs matches todos carrying every one of the tags
+	AllTagIDs []string
+	// ProjectID lists a single project; "none" lists todos outside of any project
+	ProjectID string
+	// TopLevelOnly leaves out subtasks
+	TopLevelOnly bool
+	// AssignedToMe lists the todos assigned to the user, including those of
+	// their workspaces unless the request is scoped to one
+	AssignedToMe bool
+	// Filter is an expression such as
+	// completed:false AND (title:~deploy OR created>2026-01-01)
+	Filter string
+	Page   PageOptions
+}
+
+// FilterError reports a syntax error in TodoListOptions.Filter
+type FilterError struct {
+	Message string `json:"message"`
+	// Position is the 1-based character offset of the error
+	Position int `json:"position"`
+}
+
+func (e *FilterError) Error() string {
+	return e.Message
+}
+
+// TodoSearchHit is one result of GET /api/todos/search
+type TodoSearchHit struct {
+	Todo *Todo `json:"todo"`
+	// TitleHighlight and Snippet are HTML-escaped with matches wrapped in <mark>
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet,omitempty"`
+	Rank           float64 `json:"rank"`
+}
+
+// PageOptions selects one page of a listing; the zero value lists everything
+type PageOptions struct {
+	Limit int
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+}
+
+// Paged reports whether a single page was requested
+func (p PageOptions) Paged() bool {
+	return p.Limit != 0 || p.Cursor != ""
+}
+
+// TodoPage is the response of a paged todo listing
+type TodoPage struct {
+	Todos []*Todo `json:"todos"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+// ViewCriteria is the stored query of a saved view. Dates accept 2026-01-01,
+// today or offsets such as -14d, which are resolved each time the view is
+// listed; From bounds are inclusive and Before bounds exclusive.
+type ViewCriteria struct {
+	// Completion is "open", "completed" or empty for every todo
+	Completion    string `json:"completion,omitempty"`
+	CreatedFrom   string `json:"created_from,omitempty"`
+	CreatedBefore string `json:"created_before,omitempty"`
+	UpdatedFrom   string `json:"updated_from,omitempty"`
+	UpdatedBefore string `json:"updated_before,omitempty"`
+	TitleContains string `json:"title_contains,omitempty"`
+	// Filter is an additional filter expression, e.g. priority>=high
+	Filter string `json:"filter,omitempty"`
+	// Sort and Order accept the same values as GET /api/todos
+	Sort  string `json:"sort,omitempty"`
+	Order string `json:"order,omitempty"`
+}
+
+type SavedView struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Icon      string       `json:"icon,omitempty"`
+	Criteria  ViewCriteria `json:"criteria"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type CreateSavedViewRequest struct {
+	Name     string       `json:"name" validate:"required"`
+	Icon     string       `json:"icon,omitempty"`
+	Criteria ViewCriteria `json:"criteria"`
+}
+
+type UpdateSavedViewRequest struct {
+	Name string `json:"name,omitempty"`
+	// Icon replaces the icon; an empty string removes it
+	Icon *string `json:"icon,omitempty"`
+	// Criteria replaces the stored criteria as a whole
+	Criteria *ViewCriteria `json:"criteria,omitempty"`
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Color string `json:"color,omitempty"`
+}
+
+type UpdateTagRequest struct {
+	Name       string `json:"name,omitempty"`
+	Color      string `json:"color,omitempty"`
+	ClearColor bool   `json:"clear_color,omitempty"`
+}
+
+type CreateProjectRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+type UpdateProjectRequest struct {
+	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
 }
 
 type MarkTodoCompleteRequest struct {
 	Completed bool `json:"completed"`
+	// CompleteSubtasks completes open subtasks too instead of refusing
+	CompleteSubtasks bool `json:"complete_subtasks,omitempty"`
+}
+
+type AssignTodoRequest struct {
+	AssigneeID string `json:"assignee_id" validate:"required"`
+}
+
+// Comment is a message in a todo's discussion thread. Deleted comments keep
+// their place in the thread without a body.
+type Comment struct {
+	ID       string `json:"id"`
+	TodoID   string `json:"todo_id"`
+	AuthorID string `json:"author_id"`
+	// AuthorEmail is filled in by the BFF
+	AuthorEmail string     `json:"author_email,omitempty"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EditedAt    *time.Time `json:"edited_at,omitempty"`
+	Deleted     bool       `json:"deleted"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body" validate:"required"`
 }
 
 type ErrorResponse struct {
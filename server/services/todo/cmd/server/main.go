@@ -3,6 +3,9 @@ package main
 import (
 	"log"
 	"net"
+	"os"
+	// Callers name their IANA time zone, which must not depend on the host
+	_ "time/tzdata"
 
 	"google.golang.org/grpc"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
 	grpcServer "github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/grpc"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/userservice"
 )
 
 func main() {
@@ -19,12 +23,31 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 	defer todoRepo.Close()
+	tagRepo := database.NewSQLiteTagRepository(todoRepo)
+	projectRepo := database.NewSQLiteProjectRepository(todoRepo)
+	viewRepo := database.NewSQLiteSavedViewRepository(todoRepo)
+	commentRepo := database.NewSQLiteCommentRepository(todoRepo)
 
-	// Initialize domain service
-	todoService := service.NewTodoService(todoRepo)
+	// Workspace members and their roles are kept by the user service
+	userServiceAddr := os.Getenv("USER_SERVICE_ADDR")
+	if userServiceAddr == "" {
+		userServiceAddr = "localhost:50051"
+	}
+	memberships, err := userservice.NewMembershipClient(userServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer memberships.Close()
+
+	// Initialize domain services
+	todoService := service.NewTodoServiceWithMembership(todoRepo, memberships)
+	tagService := service.NewTagService(tagRepo)
+	projectService := service.NewProjectService(projectRepo)
+	viewService := service.NewSavedViewService(viewRepo, todoService)
+	commentService := service.NewCommentService(commentRepo, todoService)
 
 	// Initialize gRPC server
-	todoGRPCServer := grpcServer.NewTodoServer(todoService)
+	todoGRPCServer := grpcServer.NewTodoServer(todoService, tagService, projectService, viewService, commentService)
 
 	// Create gRPC server
 	s := grpc.NewServer()
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const MaxCommentLength = 10000
+
+var (
+	ErrInvalidCommentBody = errors.New("comment must be 1-10000 characters")
+	ErrCommentDeleted     = errors.New("comment has been deleted")
+)
+
+// Comment is one message in the discussion thread of a todo. Deleted
+// comments are kept so the thread stays in order, but their body is no
+// longer shown.
+type Comment struct {
+	ID        string     `json:"id"`
+	TodoID    string     `json:"todo_id"`
+	AuthorID  string     `json:"author_id"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// NewComment creates a comment by authorID on a todo
+func NewComment(id, todoID, authorID, body string) (*Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comment{
+		ID:        id,
+		TodoID:    todoID,
+		AuthorID:  authorID,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Edit replaces the comment's body
+func (c *Comment) Edit(body string) error {
+	if c.Deleted() {
+		return ErrCommentDeleted
+	}
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.Body = body
+	c.EditedAt = &now
+	return nil
+}
+
+// Delete marks the comment as deleted
+func (c *Comment) Delete() {
+	if c.Deleted() {
+		return
+	}
+	now := time.Now()
+	c.DeletedAt = &now
+}
+
+// Deleted reports whether the comment has been deleted
+func (c *Comment) Deleted() bool {
+	return c.DeletedAt != nil
+}
+
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || len([]rune(body)) > MaxCommentLength {
+		return "", ErrInvalidCommentBody
+	}
+	return body, nil
+}
@@ -0,0 +1,54 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func TestNewComment(t *testing.T) {
+	// Act
+	comment, err := entity.NewComment("comment-1", "todo-1", "user-123", "  Looks good  ")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewComment should succeed: %v", err)
+	}
+	if comment.Body != "Looks good" || comment.AuthorID != "user-123" {
+		t.Errorf("Expected a trimmed comment by user-123, got %+v", comment)
+	}
+	if comment.EditedAt != nil || comment.Deleted() {
+		t.Errorf("Expected a new comment to be neither edited nor deleted")
+	}
+
+	// 本文が空、または長すぎる場合はエラー
+	if _, err := entity.NewComment("comment-2", "todo-1", "user-123", "  "); err != entity.ErrInvalidCommentBody {
+		t.Errorf("Expected ErrInvalidCommentBody for blank body, got %v", err)
+	}
+	if _, err := entity.NewComment("comment-3", "todo-1", "user-123", strings.Repeat("あ", 10001)); err != entity.ErrInvalidCommentBody {
+		t.Errorf("Expected ErrInvalidCommentBody for long body, got %v", err)
+	}
+}
+
+func TestComment_EditAndDelete(t *testing.T) {
+	// Arrange
+	comment, _ := entity.NewComment("comment-1", "todo-1", "user-123", "First draft")
+
+	// Act & Assert - 編集すると編集日時が記録される
+	if err := comment.Edit("Second draft"); err != nil {
+		t.Fatalf("Edit should succeed: %v", err)
+	}
+	if comment.Body != "Second draft" || comment.EditedAt == nil {
+		t.Errorf("Expected the edited body with EditedAt set, got %+v", comment)
+	}
+
+	// Act & Assert - 削除後は編集できない
+	comment.Delete()
+	if !comment.Deleted() {
+		t.Fatal("Expected the comment to be deleted")
+	}
+	if err := comment.Edit("Third draft"); err != entity.ErrCommentDeleted {
+		t.Errorf("Expected ErrCommentDeleted, got %v", err)
+	}
+}
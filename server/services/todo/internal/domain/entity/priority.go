@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidPriority = errors.New("invalid priority")
+)
+
+// Priority ranks todos for triage. Higher values are more urgent.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = map[Priority]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+// ParsePriority converts a priority name such as "high" into a Priority
+func ParsePriority(name string) (Priority, error) {
+	for priority, priorityName := range priorityNames {
+		if priorityName == name {
+			return priority, nil
+		}
+	}
+	return PriorityNone, ErrInvalidPriority
+}
+
+// Valid reports whether the priority is one of the defined levels
+func (p Priority) Valid() bool {
+	_, ok := priorityNames[p]
+	return ok
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
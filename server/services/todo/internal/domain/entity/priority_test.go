@@ -0,0 +1,23 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func TestPriority_Parse(t *testing.T) {
+	for _, name := range []string{"none", "low", "medium", "high", "urgent"} {
+		priority, err := entity.ParsePriority(name)
+		if err != nil {
+			t.Errorf("ParsePriority(%q) should succeed: %v", name, err)
+		}
+		if priority.String() != name {
+			t.Errorf("Expected round trip of %q, got %q", name, priority.String())
+		}
+	}
+
+	if _, err := entity.ParsePriority("critical"); err != entity.ErrInvalidPriority {
+		t.Errorf("Expected ErrInvalidPriority for unknown name, got %v", err)
+	}
+}
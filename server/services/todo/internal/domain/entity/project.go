@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const MaxProjectNameLength = 100
+
+var (
+	ErrInvalidProjectName = errors.New("project name must be 1-100 characters")
+)
+
+type Project struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProjectCounts holds the number of open and completed todos in a project
+type ProjectCounts struct {
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+}
+
+// NewProject creates a new project owned by a user
+func NewProject(id, userID, name, description string) (*Project, error) {
+	name, err := normalizeProjectName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Project{
+		ID:          id,
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Update updates the project's name and description; empty values are left unchanged
+func (p *Project) Update(name, description string) error {
+	if name != "" {
+		normalized, err := normalizeProjectName(name)
+		if err != nil {
+			return err
+		}
+		p.Name = normalized
+	}
+	if description != "" {
+		p.Description = description
+	}
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func normalizeProjectName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > MaxProjectNameLength {
+		return "", ErrInvalidProjectName
+	}
+	return name, nil
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func TestNewProject(t *testing.T) {
+	// Act
+	project, err := entity.NewProject("project-1", "user-123", "  Website  ", "Relaunch")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewProject should succeed: %v", err)
+	}
+	if project.Name != "Website" {
+		t.Errorf("Expected trimmed name 'Website', got %q", project.Name)
+	}
+	if project.CreatedAt.IsZero() || !project.CreatedAt.Equal(project.UpdatedAt) {
+		t.Errorf("Expected CreatedAt and UpdatedAt to be set to the same time")
+	}
+
+	// 名前が空、または長すぎる場合はエラー
+	if _, err := entity.NewProject("project-2", "user-123", "   ", ""); err != entity.ErrInvalidProjectName {
+		t.Errorf("Expected ErrInvalidProjectName for blank name, got %v", err)
+	}
+	if _, err := entity.NewProject("project-3", "user-123", strings.Repeat("a", 101), ""); err != entity.ErrInvalidProjectName {
+		t.Errorf("Expected ErrInvalidProjectName for long name, got %v", err)
+	}
+}
+
+func TestProject_Update(t *testing.T) {
+	// Arrange
+	project, _ := entity.NewProject("project-1", "user-123", "Website", "Relaunch")
+
+	// Act & Assert - 空の値は変更しない
+	if err := project.Update("", "New description"); err != nil {
+		t.Fatalf("Update should succeed: %v", err)
+	}
+	if project.Name != "Website" || project.Description != "New description" {
+		t.Errorf("Unexpected project after update: %+v", project)
+	}
+
+	// 不正な名前は拒否し、元の名前を保持する
+	if err := project.Update(strings.Repeat("a", 101), ""); err != entity.ErrInvalidProjectName {
+		t.Errorf("Expected ErrInvalidProjectName, got %v", err)
+	}
+	if project.Name != "Website" {
+		t.Errorf("Name should be unchanged after a failed update, got %q", project.Name)
+	}
+}
@@ -0,0 +1,235 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+)
+
+// MaxRecurrenceInterval bounds Interval so that due dates stay in a range
+// time arithmetic handles
+const MaxRecurrenceInterval = 1000
+
+// Frequency is the unit a recurrence repeats in
+type Frequency int
+
+const (
+	FrequencyDaily Frequency = iota + 1
+	FrequencyWeekly
+	FrequencyMonthly
+	// FrequencyAfterCompletion repeats a number of days after the previous
+	// occurrence was completed rather than on a fixed schedule
+	FrequencyAfterCompletion
+)
+
+var frequencyNames = map[Frequency]string{
+	FrequencyDaily:           "DAILY",
+	FrequencyWeekly:          "WEEKLY",
+	FrequencyMonthly:         "MONTHLY",
+	FrequencyAfterCompletion: "AFTER_COMPLETION",
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// Recurrence is a repeat rule for a todo, e.g. every 2 days, weekly on
+// Monday and Thursday, or monthly on the 15th
+type Recurrence struct {
+	Frequency Frequency `json:"frequency"`
+	// Interval is the number of days, weeks or months between occurrences
+	Interval int `json:"interval"`
+	// Weekdays is used with FrequencyWeekly
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	// MonthDay (1-31) is used with FrequencyMonthly; shorter months use their last day
+	MonthDay int `json:"month_day,omitempty"`
+}
+
+// Validate checks that the rule has the fields its frequency needs
+func (r *Recurrence) Validate() error {
+	if r.Interval < 1 || r.Interval > MaxRecurrenceInterval {
+		return ErrInvalidRecurrence
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyAfterCompletion:
+		if len(r.Weekdays) > 0 || r.MonthDay != 0 {
+			return ErrInvalidRecurrence
+		}
+	case FrequencyWeekly:
+		if len(r.Weekdays) == 0 || r.MonthDay != 0 {
+			return ErrInvalidRecurrence
+		}
+		for _, weekday := range r.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return ErrInvalidRecurrence
+			}
+		}
+	case FrequencyMonthly:
+		if r.MonthDay < 1 || r.MonthDay > 31 || len(r.Weekdays) > 0 {
+			return ErrInvalidRecurrence
+		}
+	default:
+		return ErrInvalidRecurrence
+	}
+	return nil
+}
+
+// NextDue returns the due date of the occurrence following one that was due
+// at due (nil if it had none) and completed at completedAt. Fixed schedules
+// skip occurrences that would already be in the past at completion time.
+func (r *Recurrence) NextDue(due *time.Time, completedAt time.Time) time.Time {
+	if r.Frequency == FrequencyAfterCompletion {
+		return completedAt.AddDate(0, 0, r.Interval)
+	}
+
+	next := completedAt
+	if due != nil {
+		next = *due
+	}
+	for {
+		next = r.next(next)
+		if next.After(completedAt) {
+			return next
+		}
+	}
+}
+
+// next returns the first scheduled time strictly after from, keeping its time of day
+func (r *Recurrence) next(from time.Time) time.Time {
+	switch r.Frequency {
+	case FrequencyWeekly:
+		// 同じ週の残りの曜日を見てから、Interval 週後の週の月曜まで飛ぶ
+		for d := from.AddDate(0, 0, 1); d.Weekday() != time.Monday; d = d.AddDate(0, 0, 1) {
+			if r.hasWeekday(d.Weekday()) {
+				return d
+			}
+		}
+		offset := (int(from.Weekday()) + 6) % 7
+		for d := from.AddDate(0, 0, 7*r.Interval-offset); ; d = d.AddDate(0, 0, 1) {
+			if r.hasWeekday(d.Weekday()) {
+				return d
+			}
+		}
+	case FrequencyMonthly:
+		for months := 0; ; months += r.Interval {
+			candidate := monthDay(from, months, r.MonthDay)
+			if candidate.After(from) {
+				return candidate
+			}
+		}
+	default:
+		return from.AddDate(0, 0, r.Interval)
+	}
+}
+
+func (r *Recurrence) hasWeekday(weekday time.Weekday) bool {
+	for _, w := range r.Weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// String encodes the rule in an RRULE-like form such as
+// "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH"
+func (r *Recurrence) String() string {
+	parts := []string{
+		"FREQ=" + frequencyNames[r.Frequency],
+		"INTERVAL=" + strconv.Itoa(r.Interval),
+	}
+	if len(r.Weekdays) > 0 {
+		weekdays := append([]time.Weekday(nil), r.Weekdays...)
+		sort.Slice(weekdays, func(i, j int) bool { return weekdays[i] < weekdays[j] })
+		days := make([]string, 0, len(weekdays))
+		for _, weekday := range weekdays {
+			days = append(days, weekdayNames[weekday])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRecurrence parses a rule produced by Recurrence.String
+func ParseRecurrence(value string) (*Recurrence, error) {
+	r := &Recurrence{}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		switch key {
+		case "FREQ":
+			for frequency, name := range frequencyNames {
+				if name == val {
+					r.Frequency = frequency
+				}
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := parseWeekday(day)
+				if !ok {
+					return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, day)
+				}
+				r.Weekdays = append(r.Weekdays, weekday)
+			}
+		case "BYMONTHDAY":
+			monthDay, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+			}
+			r.MonthDay = monthDay
+		default:
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidRecurrence, key)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for weekday, weekdayName := range weekdayNames {
+		if weekdayName == name {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// monthDay returns day of the month months after t's month, clamped to the
+// month's last day, at t's time of day
+func monthDay(t time.Time, months, day int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,216 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestRecurrence_NextDue(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        entity.Recurrence
+		due         *time.Time
+		completedAt time.Time
+		expected    time.Time
+	}{
+		{
+			name:        "毎日",
+			rule:        entity.Recurrence{Frequency: entity.FrequencyDaily, Interval: 1},
+			due:         ptrTime(date(2025, 3, 10, 9)),
+			completedAt: date(2025, 3, 10, 8),
+			expected:    date(2025, 3, 11, 9),
+		},
+		{
+			name:        "期限切れの場合は完了時刻より後の回まで進める",
+			rule:        entity.Recurrence{Frequency: entity.FrequencyDaily, Interval: 2},
+			due:         ptrTime(date(2025, 3, 1, 9)),
+			completedAt: date(2025, 3, 6, 12),
+			expected:    date(2025, 3, 7, 9),
+		},
+		{
+			name: "毎週月曜と木曜",
+			rule: entity.Recurrence{
+				Frequency: entity.FrequencyWeekly,
+				Interval:  1,
+				Weekdays:  []time.Weekday{time.Monday, time.Thursday},
+			},
+			due:         ptrTime(date(2025, 3, 10, 9)), // 月曜
+			completedAt: date(2025, 3, 10, 10),
+			expected:    date(2025, 3, 13, 9),
+		},
+		{
+			name: "隔週月曜",
+			rule: entity.Recurrence{
+				Frequency: entity.FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Monday},
+			},
+			due:         ptrTime(date(2025, 3, 10, 9)),
+			completedAt: date(2025, 3, 10, 10),
+			expected:    date(2025, 3, 24, 9),
+		},
+		{
+			name: "隔週の週の途中から、同じ週の残りの曜日",
+			rule: entity.Recurrence{
+				Frequency: entity.FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Monday, time.Friday, time.Sunday},
+			},
+			due:         ptrTime(date(2025, 3, 12, 9)), // 水曜
+			completedAt: date(2025, 3, 12, 10),
+			expected:    date(2025, 3, 14, 9),
+		},
+		{
+			name: "日曜の次は Interval 週後",
+			rule: entity.Recurrence{
+				Frequency: entity.FrequencyWeekly,
+				Interval:  3,
+				Weekdays:  []time.Weekday{time.Sunday},
+			},
+			due:         ptrTime(date(2025, 3, 16, 9)), // 日曜
+			completedAt: date(2025, 3, 16, 10),
+			expected:    date(2025, 4, 6, 9),
+		},
+		{
+			name:        "毎月31日は短い月では月末になる",
+			rule:        entity.Recurrence{Frequency: entity.FrequencyMonthly, Interval: 1, MonthDay: 31},
+			due:         ptrTime(date(2025, 1, 31, 9)),
+			completedAt: date(2025, 1, 31, 10),
+			expected:    date(2025, 2, 28, 9),
+		},
+		{
+			name:        "完了から3日後",
+			rule:        entity.Recurrence{Frequency: entity.FrequencyAfterCompletion, Interval: 3},
+			due:         ptrTime(date(2025, 3, 1, 9)),
+			completedAt: date(2025, 3, 10, 18),
+			expected:    date(2025, 3, 13, 18),
+		},
+		{
+			name:        "期限なしは完了時刻を基準にする",
+			rule:        entity.Recurrence{Frequency: entity.FrequencyDaily, Interval: 1},
+			completedAt: date(2025, 3, 10, 18),
+			expected:    date(2025, 3, 11, 18),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.NextDue(tt.due, tt.completedAt)
+			if !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRecurrence_NextDue_MaxInterval(t *testing.T) {
+	// Arrange - 上限の間隔で、水曜に完了した毎週月曜の繰り返し
+	rule := entity.Recurrence{Frequency: entity.FrequencyWeekly, Interval: entity.MaxRecurrenceInterval, Weekdays: []time.Weekday{time.Monday}}
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("The maximum interval should be valid: %v", err)
+	}
+	due := date(2025, 3, 12, 9)
+
+	// Act - 1日ずつ進めずに計算する
+	done := make(chan time.Time, 1)
+	go func() { done <- rule.NextDue(&due, due) }()
+
+	// Assert
+	select {
+	case got := <-done:
+		expected := date(2025, 3, 10, 9).AddDate(0, 0, 7*entity.MaxRecurrenceInterval)
+		if !got.Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("NextDue should finish quickly")
+	}
+}
+
+func TestRecurrence_StringRoundTrip(t *testing.T) {
+	rules := []entity.Recurrence{
+		{Frequency: entity.FrequencyDaily, Interval: 2},
+		{Frequency: entity.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+		{Frequency: entity.FrequencyMonthly, Interval: 1, MonthDay: 15},
+		{Frequency: entity.FrequencyAfterCompletion, Interval: 7},
+	}
+
+	for _, rule := range rules {
+		parsed, err := entity.ParseRecurrence(rule.String())
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q) should succeed: %v", rule.String(), err)
+		}
+		if parsed.String() != rule.String() {
+			t.Errorf("Expected %q, got %q", rule.String(), parsed.String())
+		}
+	}
+
+	if rule := (&entity.Recurrence{Frequency: entity.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Thursday, time.Monday}}); rule.String() != "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH" {
+		t.Errorf("Unexpected encoding: %q", rule.String())
+	}
+}
+
+func TestRecurrence_Validate(t *testing.T) {
+	invalid := []entity.Recurrence{
+		{Frequency: entity.FrequencyDaily, Interval: 0},
+		{Frequency: entity.FrequencyDaily, Interval: entity.MaxRecurrenceInterval + 1},
+		{Frequency: entity.FrequencyWeekly, Interval: 20000, Weekdays: []time.Weekday{time.Monday}},
+		{Frequency: entity.FrequencyWeekly, Interval: 1},
+		{Frequency: entity.FrequencyMonthly, Interval: 1, MonthDay: 32},
+		{Frequency: entity.Frequency(99), Interval: 1},
+	}
+
+	for _, rule := range invalid {
+		if err := rule.Validate(); err != entity.ErrInvalidRecurrence {
+			t.Errorf("Expected ErrInvalidRecurrence for %+v, got %v", rule, err)
+		}
+	}
+	if _, err := entity.ParseRecurrence("FREQ=HOURLY;INTERVAL=1"); err == nil {
+		t.Error("Expected error for unknown frequency")
+	}
+}
+
+func TestTodo_SpawnNext(t *testing.T) {
+	// Arrange
+	due := date(2025, 3, 10, 9)
+	remind := date(2025, 3, 10, 8)
+	todo := entity.NewTodo("todo-1", "user-123", "Patch servers", "")
+	todo.SetDueAt(&due)
+	todo.SetRemindAt(&remind)
+	todo.SetPriority(entity.PriorityHigh)
+	todo.SetRecurrence(&entity.Recurrence{Frequency: entity.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday}})
+
+	// Act
+	next := todo.SpawnNext("todo-2", date(2025, 3, 10, 10))
+
+	// Assert
+	if next == nil {
+		t.Fatal("Expected next occurrence")
+	}
+	if !next.DueAt.Equal(date(2025, 3, 17, 9)) {
+		t.Errorf("Expected next due on 2025-03-17 09:00, got %v", next.DueAt)
+	}
+	if !next.RemindAt.Equal(date(2025, 3, 17, 8)) {
+		t.Errorf("Expected reminder to keep its offset, got %v", next.RemindAt)
+	}
+	if next.Priority != entity.PriorityHigh || next.Completed {
+		t.Errorf("Unexpected next occurrence: %+v", next)
+	}
+	// ルールは次回分に引き継がれ、完了済みの方からは外れる
+	if next.Recurrence == nil || todo.Recurrence != nil {
+		t.Errorf("Expected the rule to move to the next occurrence")
+	}
+	if todo.SpawnNext("todo-3", date(2025, 3, 10, 11)) != nil {
+		t.Errorf("Expected no second occurrence")
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	MaxSavedViewNameLength = 100
+	MaxSavedViewIconLength = 32
+)
+
+var (
+	ErrInvalidSavedViewName = errors.New("view name must be 1-100 characters")
+	ErrInvalidSavedViewIcon = errors.New("view icon must be at most 32 characters")
+	ErrInvalidViewCriteria  = errors.New("invalid view criteria")
+)
+
+// ViewCompletion selects todos by completion state
+type ViewCompletion string
+
+const (
+	ViewCompletionAny       ViewCompletion = ""
+	ViewCompletionOpen      ViewCompletion = "open"
+	ViewCompletionCompleted ViewCompletion = "completed"
+)
+
+// ViewCriteria is the stored query of a saved view. Dates use the syntax of
+// the filter language, so relative values such as -14d are resolved each
+// time the view is listed. From bounds are inclusive, Before bounds exclusive.
+type ViewCriteria struct {
+	Completion    ViewCompletion `json:"completion,omitempty"`
+	CreatedFrom   string         `json:"created_from,omitempty"`
+	CreatedBefore string         `json:"created_before,omitempty"`
+	UpdatedFrom   string         `json:"updated_from,omitempty"`
+	UpdatedBefore string         `json:"updated_before,omitempty"`
+	// TitleContains matches titles containing the text, ignoring case
+	TitleContains string `json:"title_contains,omitempty"`
+	// Filter is an additional filter expression, e.g. priority>=high
+	Filter string `json:"filter,omitempty"`
+	// Sort is a todo sort field such as "priority"; Order is "asc", "desc"
+	// or empty for the field's natural order
+	Sort  string `json:"sort,omitempty"`
+	Order string `json:"order,omitempty"`
+}
+
+// SavedView is a named, reusable todo listing
+type SavedView struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	// Icon is an optional emoji or icon name shown next to the view
+	Icon      string       `json:"icon"`
+	Criteria  ViewCriteria `json:"criteria"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// NewSavedView creates a new view owned by a user
+func NewSavedView(id, userID, name, icon string, criteria ViewCriteria) (*SavedView, error) {
+	name, err := normalizeSavedViewName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSavedViewIcon(icon); err != nil {
+		return nil, err
+	}
+	if err := criteria.validateCompletion(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &SavedView{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		Icon:      icon,
+		Criteria:  criteria,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Rename changes the view's name; an empty name is left unchanged
+func (v *SavedView) Rename(name string) error {
+	if name == "" {
+		return nil
+	}
+	normalized, err := normalizeSavedViewName(name)
+	if err != nil {
+		return err
+	}
+	v.Name = normalized
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetIcon changes the view's icon; an empty icon removes it
+func (v *SavedView) SetIcon(icon string) error {
+	if err := validateSavedViewIcon(icon); err != nil {
+		return err
+	}
+	v.Icon = icon
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCriteria replaces the view's stored criteria
+func (v *SavedView) SetCriteria(criteria ViewCriteria) error {
+	if err := criteria.validateCompletion(); err != nil {
+		return err
+	}
+	v.Criteria = criteria
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+func (c ViewCriteria) validateCompletion() error {
+	switch c.Completion {
+	case ViewCompletionAny, ViewCompletionOpen, ViewCompletionCompleted:
+		return nil
+	}
+	return ErrInvalidViewCriteria
+}
+
+func normalizeSavedViewName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > MaxSavedViewNameLength {
+		return "", ErrInvalidSavedViewName
+	}
+	return name, nil
+}
+
+func validateSavedViewIcon(icon string) error {
+	if len([]rune(icon)) > MaxSavedViewIconLength {
+		return ErrInvalidSavedViewIcon
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func TestNewSavedView(t *testing.T) {
+	// Arrange
+	criteria := entity.ViewCriteria{Completion: entity.ViewCompletionOpen, UpdatedBefore: "-14d"}
+
+	// Act
+	view, err := entity.NewSavedView("view-1", "user-123", "  Stale  ", "🕸", criteria)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewSavedView should succeed: %v", err)
+	}
+	if view.Name != "Stale" {
+		t.Errorf("Expected trimmed name 'Stale', got %q", view.Name)
+	}
+	if view.Criteria != criteria {
+		t.Errorf("Expected criteria %+v, got %+v", criteria, view.Criteria)
+	}
+
+	// 名前・アイコン・完了状態が不正な場合はエラー
+	if _, err := entity.NewSavedView("view-2", "user-123", " ", "", criteria); err != entity.ErrInvalidSavedViewName {
+		t.Errorf("Expected ErrInvalidSavedViewName, got %v", err)
+	}
+	if _, err := entity.NewSavedView("view-3", "user-123", "Stale", strings.Repeat("x", 33), criteria); err != entity.ErrInvalidSavedViewIcon {
+		t.Errorf("Expected ErrInvalidSavedViewIcon, got %v", err)
+	}
+	if _, err := entity.NewSavedView("view-4", "user-123", "Stale", "", entity.ViewCriteria{Completion: "done"}); err != entity.ErrInvalidViewCriteria {
+		t.Errorf("Expected ErrInvalidViewCriteria, got %v", err)
+	}
+}
+
+func TestSavedView_Update(t *testing.T) {
+	// Arrange
+	view, _ := entity.NewSavedView("view-1", "user-123", "Stale", "🕸", entity.ViewCriteria{})
+
+	// Act & Assert - 空の名前は変更しない
+	if err := view.Rename(""); err != nil || view.Name != "Stale" {
+		t.Errorf("Empty name should leave the view unchanged, got %q (%v)", view.Name, err)
+	}
+	if err := view.SetIcon(""); err != nil || view.Icon != "" {
+		t.Errorf("Empty icon should remove the icon, got %q (%v)", view.Icon, err)
+	}
+
+	// 不正な条件は拒否し、元の条件を保持する
+	if err := view.SetCriteria(entity.ViewCriteria{Completion: "done"}); err != entity.ErrInvalidViewCriteria {
+		t.Errorf("Expected ErrInvalidViewCriteria, got %v", err)
+	}
+	if view.Criteria != (entity.ViewCriteria{}) {
+		t.Errorf("Criteria should be unchanged after a failed update, got %+v", view.Criteria)
+	}
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const MaxTagNameLength = 50
+
+var (
+	ErrInvalidTagName  = errors.New("tag name must be 1-50 characters")
+	ErrInvalidTagColor = errors.New("tag color must be a hex color like #1e90ff")
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Tag struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTag creates a new tag owned by a user. Color may be empty.
+func NewTag(id, userID, name, color string) (*Tag, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTagColor(color); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Tag{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		Color:     strings.ToLower(color),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Rename changes the tag's name
+func (t *Tag) Rename(name string) error {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Recolor changes the tag's color; an empty color removes it
+func (t *Tag) Recolor(color string) error {
+	if err := validateTagColor(color); err != nil {
+		return err
+	}
+	t.Color = strings.ToLower(color)
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > MaxTagNameLength {
+		return "", ErrInvalidTagName
+	}
+	return name, nil
+}
+
+func validateTagColor(color string) error {
+	if color != "" && !tagColorPattern.MatchString(color) {
+		return ErrInvalidTagColor
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func TestTag_NewTag(t *testing.T) {
+	// Act
+	tag, err := entity.NewTag("tag-1", "user-123", "  backend ", "#1E90FF")
+
+	// Assert - 名前は前後の空白を除去、色は小文字に正規化
+	if err != nil {
+		t.Fatalf("NewTag should succeed: %v", err)
+	}
+	if tag.Name != "backend" {
+		t.Errorf("Expected name 'backend', got %q", tag.Name)
+	}
+	if tag.Color != "#1e90ff" {
+		t.Errorf("Expected color '#1e90ff', got %q", tag.Color)
+	}
+	if tag.CreatedAt.IsZero() || tag.UpdatedAt.IsZero() {
+		t.Errorf("Expected timestamps to be set")
+	}
+
+	// 色なしも許可
+	if _, err := entity.NewTag("tag-2", "user-123", "frontend", ""); err != nil {
+		t.Errorf("NewTag without color should succeed: %v", err)
+	}
+}
+
+func TestTag_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagName  string
+		color    string
+		expected error
+	}{
+		{"empty name", "   ", "", entity.ErrInvalidTagName},
+		{"too long name", strings.Repeat("a", entity.MaxTagNameLength+1), "", entity.ErrInvalidTagName},
+		{"named color", "backend", "red", entity.ErrInvalidTagColor},
+		{"short hex", "backend", "#fff", entity.ErrInvalidTagColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewTag("tag-1", "user-123", tt.tagName, tt.color)
+			if err != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestTag_RenameAndRecolor(t *testing.T) {
+	// Arrange
+	tag, _ := entity.NewTag("tag-1", "user-123", "backend", "#000000")
+
+	// Act & Assert - 名前変更
+	if err := tag.Rename("infra"); err != nil {
+		t.Errorf("Rename should succeed: %v", err)
+	}
+	if tag.Name != "infra" {
+		t.Errorf("Expected name 'infra', got %q", tag.Name)
+	}
+	if err := tag.Rename(""); err != entity.ErrInvalidTagName {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+	if tag.Name != "infra" {
+		t.Errorf("Name should be unchanged after invalid rename")
+	}
+
+	// Act & Assert - 色変更
+	if err := tag.Recolor("#ABCDEF"); err != nil {
+		t.Errorf("Recolor should succeed: %v", err)
+	}
+	if tag.Color != "#abcdef" {
+		t.Errorf("Expected color '#abcdef', got %q", tag.Color)
+	}
+	if err := tag.Recolor("blue"); err != entity.ErrInvalidTagColor {
+		t.Errorf("Expected ErrInvalidTagColor, got %v", err)
+	}
+	if err := tag.Recolor(""); err != nil || tag.Color != "" {
+		t.Errorf("Recolor with empty string should remove the color")
+	}
+}
@@ -5,14 +5,48 @@ import (
 )
 
 type Todo struct {
-	ID          string     `json:"id"`
-	UserID      string     `json:"user_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Completed   bool       `json:"completed"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ID          string      `json:"id"`
+	UserID      string      `json:"user_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Completed   bool        `json:"completed"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+	DueAt       *time.Time  `json:"due_at,omitempty"`
+	RemindAt    *time.Time  `json:"remind_at,omitempty"`
+	Priority    Priority    `json:"priority"`
+	Tags        []*Tag      `json:"tags,omitempty"`
+	ProjectID   *string     `json:"project_id,omitempty"`
+	ParentID    *string     `json:"parent_id,omitempty"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+	// WorkspaceID is set on todos shared in a workspace; their UserID is
+	// the workspace ID too, so that they are stored like a user's todos
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// CreatedBy is the user who created the todo
+	CreatedBy string `json:"created_by,omitempty"`
+	// AssigneeID is the user the todo was handed to, if any
+	AssigneeID string `json:"assignee_id,omitempty"`
+	// Subtasks is only loaded when the whole subtree is requested
+	Subtasks []*Todo         `json:"subtasks,omitempty"`
+	Progress SubtaskProgress `json:"progress"`
+}
+
+// SubtaskProgress counts the completed and total direct subtasks of a todo
+type SubtaskProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// Open returns the number of subtasks that are not completed yet
+func (p SubtaskProgress) Open() int {
+	return p.Total - p.Done
+}
+
+// TodoCounts counts the completed and total todos of a user
+type TodoCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
 }
 
 // NewTodo creates a new todo item
@@ -21,6 +55,7 @@ func NewTodo(id, userID, title, description string) *Todo {
 	return &Todo{
 		ID:          id,
 		UserID:      userID,
+		CreatedBy:   userID,
 		Title:       title,
 		Description: description,
 		Completed:   false,
@@ -52,3 +87,124 @@ func (t *Todo) MarkComplete(completed bool) {
 		t.CompletedAt = nil
 	}
 }
+
+// SetDueAt sets or clears (nil) the todo's due date
+func (t *Todo) SetDueAt(dueAt *time.Time) {
+	t.DueAt = dueAt
+	t.UpdatedAt = time.Now()
+}
+
+// SetRemindAt sets or clears (nil) the todo's reminder time
+func (t *Todo) SetRemindAt(remindAt *time.Time) {
+	t.RemindAt = remindAt
+	t.UpdatedAt = time.Now()
+}
+
+// SetPriority changes the todo's priority
+func (t *Todo) SetPriority(priority Priority) error {
+	if !priority.Valid() {
+		return ErrInvalidPriority
+	}
+	t.Priority = priority
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetTags replaces the todo's tags
+func (t *Todo) SetTags(tags []*Tag) {
+	t.Tags = tags
+	t.UpdatedAt = time.Now()
+}
+
+// SetProjectID moves the todo into a project, or out of any project (nil)
+func (t *Todo) SetProjectID(projectID *string) {
+	t.ProjectID = projectID
+	t.UpdatedAt = time.Now()
+}
+
+// SetParentID makes the todo a subtask of another todo, or top-level (nil)
+func (t *Todo) SetParentID(parentID *string) {
+	t.ParentID = parentID
+	t.UpdatedAt = time.Now()
+}
+
+// SetAssignee hands the todo to a user, or takes it back ("")
+func (t *Todo) SetAssignee(assigneeID string) {
+	t.AssigneeID = assigneeID
+	t.UpdatedAt = time.Now()
+}
+
+// SetSubtasks attaches loaded subtasks and recomputes the progress from them
+func (t *Todo) SetSubtasks(subtasks []*Todo) {
+	t.Subtasks = subtasks
+	t.Progress = SubtaskProgress{Total: len(subtasks)}
+	for _, subtask := range subtasks {
+		if subtask.Completed {
+			t.Progress.Done++
+		}
+	}
+}
+
+// SetRecurrence sets or clears (nil) the todo's repeat rule
+func (t *Todo) SetRecurrence(recurrence *Recurrence) error {
+	if recurrence != nil {
+		if err := recurrence.Validate(); err != nil {
+			return err
+		}
+	}
+	t.Recurrence = recurrence
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SpawnNext creates the next occurrence of a recurring todo that was
+// completed at completedAt. The repeat rule moves to the new occurrence so
+// that completing this todo again does not spawn a second one. It returns nil
+// for todos without a rule.
+func (t *Todo) SpawnNext(id string, completedAt time.Time) *Todo {
+	if t.Recurrence == nil {
+		return nil
+	}
+
+	next := NewTodo(id, t.UserID, t.Title, t.Description)
+	next.Priority = t.Priority
+	next.Tags = t.Tags
+	next.ProjectID = t.ProjectID
+	next.ParentID = t.ParentID
+	next.Recurrence = t.Recurrence
+	next.WorkspaceID = t.WorkspaceID
+	next.CreatedBy = t.CreatedBy
+	next.AssigneeID = t.AssigneeID
+
+	dueAt := t.Recurrence.NextDue(t.DueAt, completedAt)
+	next.DueAt = &dueAt
+	if t.RemindAt != nil && t.DueAt != nil {
+		// リマインダーは期限との間隔を保つ
+		remindAt := dueAt.Add(t.RemindAt.Sub(*t.DueAt))
+		next.RemindAt = &remindAt
+	}
+
+	t.Recurrence = nil
+	t.UpdatedAt = time.Now()
+	return next
+}
+
+// InProject reports whether the todo belongs to the project with the given ID
+func (t *Todo) InProject(projectID string) bool {
+	return t.ProjectID != nil && *t.ProjectID == projectID
+}
+
+// HasTag reports whether the todo carries the tag with the given ID
+func (t *Todo) HasTag(tagID string) bool {
+	for _, tag := range t.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOverdue reports whether the todo is still open after its due date
+func (t *Todo) IsOverdue(now time.Time) bool {
+	return !t.Completed && t.DueAt != nil && t.DueAt.Before(now)
+}
@@ -137,3 +137,91 @@ func TestTodo_BusinessRules(t *testing.T) {
 		t.Errorf("Update should not change completion status")
 	}
 }
+
+func TestTodo_DueDates(t *testing.T) {
+	// Arrange
+	todo := entity.NewTodo("test-id", "user-123", "Test Todo", "Test Description")
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	// Act & Assert - 期限なしは期限切れにならない
+	if todo.IsOverdue(now) {
+		t.Errorf("Todo without due date should not be overdue")
+	}
+
+	// Act & Assert - 過去の期限は期限切れ
+	todo.SetDueAt(&yesterday)
+	if !todo.IsOverdue(now) {
+		t.Errorf("Todo due yesterday should be overdue")
+	}
+
+	// Act & Assert - 完了済みは期限切れにならない
+	todo.MarkComplete(true)
+	if todo.IsOverdue(now) {
+		t.Errorf("Completed todo should not be overdue")
+	}
+
+	// Act & Assert - 未来の期限とリマインダー
+	todo.MarkComplete(false)
+	todo.SetDueAt(&tomorrow)
+	todo.SetRemindAt(&now)
+	if todo.IsOverdue(now) {
+		t.Errorf("Todo due tomorrow should not be overdue")
+	}
+	if todo.RemindAt == nil || !todo.RemindAt.Equal(now) {
+		t.Errorf("Expected RemindAt to be set")
+	}
+
+	// Act & Assert - nilでクリア
+	todo.SetDueAt(nil)
+	todo.SetRemindAt(nil)
+	if todo.DueAt != nil || todo.RemindAt != nil {
+		t.Errorf("Expected DueAt and RemindAt to be cleared")
+	}
+}
+
+func TestTodo_SetPriority(t *testing.T) {
+	// Arrange
+	todo := entity.NewTodo("test-id", "user-123", "Test Todo", "Test Description")
+
+	// Assert - 初期値は none
+	if todo.Priority != entity.PriorityNone {
+		t.Errorf("Expected default priority none, got %s", todo.Priority)
+	}
+
+	// Act & Assert - 有効な優先度
+	if err := todo.SetPriority(entity.PriorityUrgent); err != nil {
+		t.Errorf("SetPriority should succeed: %v", err)
+	}
+	if todo.Priority != entity.PriorityUrgent {
+		t.Errorf("Expected priority urgent, got %s", todo.Priority)
+	}
+
+	// Act & Assert - 範囲外の優先度は拒否され、値は変わらない
+	if err := todo.SetPriority(entity.Priority(42)); err != entity.ErrInvalidPriority {
+		t.Errorf("Expected ErrInvalidPriority, got %v", err)
+	}
+	if todo.Priority != entity.PriorityUrgent {
+		t.Errorf("Priority should be unchanged after invalid update")
+	}
+}
+
+func TestTodo_SetSubtasks(t *testing.T) {
+	// Arrange
+	parent := entity.NewTodo("parent", "user-123", "Epic", "")
+	open := entity.NewTodo("open", "user-123", "Open", "")
+	done := entity.NewTodo("done", "user-123", "Done", "")
+	done.MarkComplete(true)
+
+	// Act
+	parent.SetSubtasks([]*entity.Todo{open, done})
+
+	// Assert
+	if parent.Progress.Done != 1 || parent.Progress.Total != 2 {
+		t.Errorf("Expected progress 1/2, got %+v", parent.Progress)
+	}
+	if parent.Progress.Open() != 1 {
+		t.Errorf("Expected 1 open subtask, got %d", parent.Progress.Open())
+	}
+}
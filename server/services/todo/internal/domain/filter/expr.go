@@ -0,0 +1,200 @@
+// Package filter implements the todo filter expression language, e.g.
+//
+//	completed:false AND (title:~deploy OR created>2026-01-01)
+//
+// Expressions are parsed into a tree of conditions that repositories either
+// compile to storage queries or evaluate in memory with Expr.Matches.
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+// Field is an attribute of a todo that can be filtered on
+type Field string
+
+const (
+	FieldCompleted   Field = "completed"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldPriority    Field = "priority"
+	FieldCreated     Field = "created"
+	FieldUpdated     Field = "updated"
+	FieldDue         Field = "due"
+	FieldTag         Field = "tag"
+	FieldProject     Field = "project"
+)
+
+// Op is a comparison operator
+type Op string
+
+const (
+	OpEqual        Op = ":"
+	OpContains     Op = ":~"
+	OpNotEqual     Op = "!="
+	OpLess         Op = "<"
+	OpLessEqual    Op = "<="
+	OpGreater      Op = ">"
+	OpGreaterEqual Op = ">="
+)
+
+// Expr is a node of a parsed filter
+type Expr interface {
+	// Matches evaluates the filter against a todo
+	Matches(todo *entity.Todo) bool
+}
+
+// And matches todos matching both sides
+type And struct {
+	Left, Right Expr
+}
+
+func (e *And) Matches(todo *entity.Todo) bool {
+	return e.Left.Matches(todo) && e.Right.Matches(todo)
+}
+
+// Or matches todos matching either side
+type Or struct {
+	Left, Right Expr
+}
+
+func (e *Or) Matches(todo *entity.Todo) bool {
+	return e.Left.Matches(todo) || e.Right.Matches(todo)
+}
+
+// Not matches todos that do not match Expr
+type Not struct {
+	Expr Expr
+}
+
+func (e *Not) Matches(todo *entity.Todo) bool {
+	return !e.Expr.Matches(todo)
+}
+
+// BoolCondition compares the completed flag
+type BoolCondition struct {
+	Field Field
+	Value bool
+}
+
+func (c *BoolCondition) Matches(todo *entity.Todo) bool {
+	return todo.Completed == c.Value
+}
+
+// TextCondition compares the title or description, ignoring case. Op is
+// OpEqual or OpContains.
+type TextCondition struct {
+	Field Field
+	Op    Op
+	Value string
+}
+
+func (c *TextCondition) Matches(todo *entity.Todo) bool {
+	text := todo.Title
+	if c.Field == FieldDescription {
+		text = todo.Description
+	}
+	if c.Op == OpContains {
+		return ContainsFold(text, c.Value)
+	}
+	return strings.EqualFold(text, c.Value)
+}
+
+// ContainsFold reports whether value is within text, ignoring case in any
+// script. Repositories that evaluate text conditions themselves use it so
+// that they agree with Matches.
+func ContainsFold(text, value string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+}
+
+// PriorityCondition compares the priority level
+type PriorityCondition struct {
+	Op    Op
+	Value entity.Priority
+}
+
+func (c *PriorityCondition) Matches(todo *entity.Todo) bool {
+	switch c.Op {
+	case OpNotEqual:
+		return todo.Priority != c.Value
+	case OpLess:
+		return todo.Priority < c.Value
+	case OpLessEqual:
+		return todo.Priority <= c.Value
+	case OpGreater:
+		return todo.Priority > c.Value
+	case OpGreaterEqual:
+		return todo.Priority >= c.Value
+	default:
+		return todo.Priority == c.Value
+	}
+}
+
+// TimeCondition compares a timestamp. The parser resolves dates to instants,
+// so Op is always OpLess or OpGreaterEqual. Todos without the timestamp never match.
+type TimeCondition struct {
+	Field Field
+	Op    Op
+	Value time.Time
+}
+
+func (c *TimeCondition) Matches(todo *entity.Todo) bool {
+	value := timeField(todo, c.Field)
+	if value == nil {
+		return false
+	}
+	if c.Op == OpLess {
+		return value.Before(c.Value)
+	}
+	return !value.Before(c.Value)
+}
+
+// NullCondition matches todos that have no value for a timestamp, e.g. due:none
+type NullCondition struct {
+	Field Field
+}
+
+func (c *NullCondition) Matches(todo *entity.Todo) bool {
+	return timeField(todo, c.Field) == nil
+}
+
+// TagCondition matches todos carrying a tag with the given name, ignoring case
+type TagCondition struct {
+	Name string
+}
+
+func (c *TagCondition) Matches(todo *entity.Todo) bool {
+	for _, tag := range todo.Tags {
+		if strings.EqualFold(tag.Name, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectCondition matches todos in a project; an empty ProjectID matches
+// todos outside of every project
+type ProjectCondition struct {
+	ProjectID string
+}
+
+func (c *ProjectCondition) Matches(todo *entity.Todo) bool {
+	if c.ProjectID == "" {
+		return todo.ProjectID == nil
+	}
+	return todo.InProject(c.ProjectID)
+}
+
+func timeField(todo *entity.Todo, field Field) *time.Time {
+	switch field {
+	case FieldCreated:
+		return &todo.CreatedAt
+	case FieldUpdated:
+		return &todo.UpdatedAt
+	default:
+		return todo.DueAt
+	}
+}
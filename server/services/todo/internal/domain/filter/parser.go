@@ -0,0 +1,441 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+const (
+	// MaxLength bounds the size of a filter expression
+	MaxLength = 1000
+	maxDepth  = 20
+)
+
+// Error is a syntax or validation error in a filter expression
+type Error struct {
+	// Position is the 1-based character offset the error was found at
+	Position int
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Position, e.Message)
+}
+
+var fieldTypes = map[Field][]Op{
+	FieldCompleted:   {OpEqual, OpNotEqual},
+	FieldTitle:       {OpEqual, OpContains, OpNotEqual},
+	FieldDescription: {OpEqual, OpContains, OpNotEqual},
+	FieldPriority:    {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual},
+	FieldCreated:     {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual},
+	FieldUpdated:     {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual},
+	FieldDue:         {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual},
+	FieldTag:         {OpEqual, OpNotEqual},
+	FieldProject:     {OpEqual, OpNotEqual},
+}
+
+// operators are listed longest first so that ">=" wins over ">"
+var operators = []Op{OpContains, OpNotEqual, OpGreaterEqual, OpLessEqual, OpEqual, OpGreater, OpLess}
+
+// Parse parses a filter expression. Conditions are joined with AND, OR and
+// NOT (AND binds tighter than OR, and adjacent conditions are ANDed) and
+// grouped with parentheses. Dates may be written as 2006-01-02, as RFC3339
+// timestamps, or relative to now as today, yesterday, tomorrow, -7d or +2w;
+// days start at midnight in now's location.
+func Parse(input string, now time.Time) (Expr, error) {
+	p := &parser{input: input, now: now}
+	if utf8.RuneCountInString(input) > MaxLength {
+		return nil, p.errorAt(0, fmt.Sprintf("filter is longer than %d characters", MaxLength))
+	}
+
+	p.skipSpace()
+	if p.done() {
+		return nil, p.errorAt(p.pos, "filter is empty")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorAt(p.pos, fmt.Sprintf("unexpected %q", p.input[p.pos:p.pos+1]))
+	}
+	return expr, nil
+}
+
+// Condition builds a single comparison such as created>=-14d from its parts,
+// with the same value syntax as Parse
+func Condition(field Field, op Op, value string, now time.Time) (Expr, error) {
+	ops, ok := fieldTypes[field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	if !containsOp(ops, op) {
+		return nil, fmt.Errorf("operator %s cannot be used with %s", op, field)
+	}
+
+	p := &parser{now: now}
+	expr, message := p.condition(field, op, value)
+	if message != "" {
+		return nil, fmt.Errorf("%s: %s", field, message)
+	}
+	return expr, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+	depth int
+	now   time.Time
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.done() || p.peek() == ')' || p.peekKeyword("OR") {
+			return left, nil
+		}
+		// AND は省略できる
+		p.keyword("AND")
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.keyword("NOT") {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		return &Not{Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	if p.done() {
+		return nil, p.errorAt(p.pos, "expected a condition")
+	}
+	if p.peek() != '(' {
+		return p.parseCondition()
+	}
+
+	open := p.pos
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	p.pos++
+	p.skipSpace()
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.done() || p.peek() != ')' {
+		return nil, p.errorAt(open, "unclosed parenthesis")
+	}
+	p.pos++
+	p.depth--
+	p.skipSpace()
+	return expr, nil
+}
+
+func (p *parser) parseCondition() (Expr, error) {
+	start := p.pos
+	name := p.readWhile(func(r rune) bool { return r == '_' || unicode.IsLetter(r) })
+	if name == "" {
+		return nil, p.errorAt(start, "expected a field name or '('")
+	}
+	field := Field(strings.ToLower(name))
+	ops, ok := fieldTypes[field]
+	if !ok {
+		return nil, p.errorAt(start, fmt.Sprintf("unknown field %q", name))
+	}
+
+	opPos := p.pos
+	op, ok := p.readOperator()
+	if !ok {
+		return nil, p.errorAt(opPos, fmt.Sprintf("expected an operator after %q", name))
+	}
+	if !containsOp(ops, op) {
+		return nil, p.errorAt(opPos, fmt.Sprintf("operator %s cannot be used with %s", op, field))
+	}
+
+	valuePos := p.pos
+	value, err := p.readValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+
+	expr, message := p.condition(field, op, value)
+	if message != "" {
+		return nil, p.errorAt(valuePos, message)
+	}
+	return expr, nil
+}
+
+// condition builds the node for a comparison, or returns a message explaining
+// why the value is invalid
+func (p *parser) condition(field Field, op Op, value string) (Expr, string) {
+	switch field {
+	case FieldCompleted:
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Sprintf("expected true or false, got %q", value)
+		}
+		return &BoolCondition{Field: field, Value: completed == (op == OpEqual)}, ""
+
+	case FieldTitle, FieldDescription:
+		if op == OpNotEqual {
+			return &Not{Expr: &TextCondition{Field: field, Op: OpEqual, Value: value}}, ""
+		}
+		return &TextCondition{Field: field, Op: op, Value: value}, ""
+
+	case FieldPriority:
+		priority, err := entity.ParsePriority(strings.ToLower(value))
+		if err != nil {
+			return nil, fmt.Sprintf("unknown priority %q", value)
+		}
+		return &PriorityCondition{Op: op, Value: priority}, ""
+
+	case FieldTag:
+		return negate(&TagCondition{Name: value}, op), ""
+
+	case FieldProject:
+		if strings.EqualFold(value, "none") {
+			value = ""
+		}
+		return negate(&ProjectCondition{ProjectID: value}, op), ""
+	}
+
+	// 日時のフィールド
+	if strings.EqualFold(value, "none") {
+		if op != OpEqual && op != OpNotEqual {
+			return nil, fmt.Sprintf("none can only be compared with %s or %s", OpEqual, OpNotEqual)
+		}
+		return negate(&NullCondition{Field: field}, op), ""
+	}
+	start, end, ok := p.timeRange(value)
+	if !ok {
+		return nil, fmt.Sprintf("invalid date %q", value)
+	}
+	switch op {
+	case OpLess:
+		return &TimeCondition{Field: field, Op: OpLess, Value: start}, ""
+	case OpLessEqual:
+		return &TimeCondition{Field: field, Op: OpLess, Value: end}, ""
+	case OpGreater:
+		return &TimeCondition{Field: field, Op: OpGreaterEqual, Value: end}, ""
+	case OpGreaterEqual:
+		return &TimeCondition{Field: field, Op: OpGreaterEqual, Value: start}, ""
+	default:
+		within := &And{
+			Left:  &TimeCondition{Field: field, Op: OpGreaterEqual, Value: start},
+			Right: &TimeCondition{Field: field, Op: OpLess, Value: end},
+		}
+		return negate(within, op), ""
+	}
+}
+
+// timeRange resolves a date value to the instants [start, end) it covers:
+// a whole day for dates and a single second for timestamps
+func (p *parser) timeRange(value string) (time.Time, time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.Truncate(time.Second)
+		return t, t.Add(time.Second), true
+	}
+
+	loc := p.now.Location()
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, loc)
+	var day time.Time
+	switch strings.ToLower(value) {
+	case "today":
+		day = today
+	case "yesterday":
+		day = today.AddDate(0, 0, -1)
+	case "tomorrow":
+		day = today.AddDate(0, 0, 1)
+	default:
+		if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+			day = t
+			break
+		}
+		days, ok := relativeDays(value)
+		if !ok {
+			return time.Time{}, time.Time{}, false
+		}
+		day = today.AddDate(0, 0, days)
+	}
+	return day, day.AddDate(0, 0, 1), true
+}
+
+// relativeDays parses offsets such as -7d, +3d or -2w into a number of days
+func relativeDays(value string) (int, bool) {
+	if len(value) < 3 || (value[0] != '-' && value[0] != '+') {
+		return 0, false
+	}
+	unit := 1
+	switch value[len(value)-1] {
+	case 'd':
+	case 'w':
+		unit = 7
+	default:
+		return 0, false
+	}
+	n, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || n < 0 || n > 3650 {
+		return 0, false
+	}
+	if value[0] == '-' {
+		n = -n
+	}
+	return n * unit, true
+}
+
+func negate(expr Expr, op Op) Expr {
+	if op == OpNotEqual {
+		return &Not{Expr: expr}
+	}
+	return expr
+}
+
+func containsOp(ops []Op, op Op) bool {
+	for _, candidate := range ops {
+		if candidate == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) readOperator() (Op, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(p.input[p.pos:], string(op)) {
+			p.pos += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// readValue reads a bare word up to the next space or parenthesis, or a
+// double-quoted string in which \" and \\ are escapes
+func (p *parser) readValue() (string, error) {
+	start := p.pos
+	if p.done() || p.peek() != '"' {
+		value := p.readWhile(func(r rune) bool { return !unicode.IsSpace(r) && r != '(' && r != ')' })
+		if value == "" {
+			return "", p.errorAt(start, "expected a value")
+		}
+		return value, nil
+	}
+
+	var b strings.Builder
+	p.pos++
+	for !p.done() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		p.pos += size
+		switch {
+		case r == '"':
+			return b.String(), nil
+		case r == '\\' && !p.done():
+			escaped, size := utf8.DecodeRuneInString(p.input[p.pos:])
+			p.pos += size
+			b.WriteRune(escaped)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", p.errorAt(start, "unterminated string")
+}
+
+// keyword consumes a case-insensitive keyword followed by a space, a
+// parenthesis or the end of the input
+func (p *parser) keyword(word string) bool {
+	if !p.peekKeyword(word) {
+		return false
+	}
+	p.pos += len(word)
+	p.skipSpace()
+	return true
+}
+
+func (p *parser) peekKeyword(word string) bool {
+	rest := p.input[p.pos:]
+	if len(rest) < len(word) || !strings.EqualFold(rest[:len(word)], word) {
+		return false
+	}
+	if len(rest) == len(word) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(rest[len(word):])
+	return unicode.IsSpace(next) || next == '('
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return p.errorAt(p.pos, fmt.Sprintf("filter is nested more than %d levels deep", maxDepth))
+	}
+	return nil
+}
+
+func (p *parser) readWhile(accept func(r rune) bool) string {
+	start := p.pos
+	for !p.done() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !accept(r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) skipSpace() {
+	p.readWhile(unicode.IsSpace)
+}
+
+func (p *parser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+// errorAt reports an error at a byte offset of the input
+func (p *parser) errorAt(offset int, message string) *Error {
+	return &Error{Position: utf8.RuneCountInString(p.input[:offset]) + 1, Message: message}
+}
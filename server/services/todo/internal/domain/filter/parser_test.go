@@ -0,0 +1,148 @@
+package filter_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
+)
+
+var now = time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+func todoAt(title string, created time.Time) *entity.Todo {
+	todo := entity.NewTodo(title, "user-123", title, "")
+	todo.CreatedAt = created
+	todo.UpdatedAt = created
+	return todo
+}
+
+func TestParse_Matches(t *testing.T) {
+	deploy := todoAt("Deploy API", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC))
+	deploy.SetPriority(entity.PriorityHigh)
+	deploy.SetTags([]*entity.Tag{{ID: "tag-1", Name: "Backend"}})
+	done := todoAt("Write docs", time.Date(2025, 12, 24, 9, 0, 0, 0, time.UTC))
+	done.MarkComplete(true)
+	due := now.Add(2 * time.Hour)
+	dueToday := todoAt("Call vendor", time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC))
+	dueToday.SetDueAt(&due)
+	projectID := "project-1"
+	dueToday.SetProjectID(&projectID)
+	todos := []*entity.Todo{deploy, done, dueToday}
+
+	tests := []struct {
+		filter   string
+		expected []string
+	}{
+		{`completed:false AND (title:~deploy OR created>2026-01-01)`, []string{"Deploy API"}},
+		{`completed:false (title:~DEPLOY or created>2026-01-01)`, []string{"Deploy API"}},
+		{`completed:true OR priority>=high`, []string{"Deploy API", "Write docs"}},
+		{`NOT completed:true`, []string{"Deploy API", "Call vendor"}},
+		{`completed!=true`, []string{"Deploy API", "Call vendor"}},
+		{`title:"write docs"`, []string{"Write docs"}},
+		{`title!="write docs" priority<medium`, []string{"Call vendor"}},
+		{`created:2026-02-01`, []string{"Deploy API"}},
+		{`created<=2025-12-24`, []string{"Write docs"}},
+		{`created>2025-12-24 created<2026-02-01`, []string{"Call vendor"}},
+		{`created>=2026-02-01T09:00:00Z`, []string{"Deploy API"}},
+		{`created<-60d`, []string{"Write docs", "Call vendor"}},
+		{`due:today`, []string{"Call vendor"}},
+		{`due:none`, []string{"Deploy API", "Write docs"}},
+		{`due!=none`, []string{"Call vendor"}},
+		{`NOT due<tomorrow`, []string{"Deploy API", "Write docs"}},
+		{`tag:backend`, []string{"Deploy API"}},
+		{`project:project-1`, []string{"Call vendor"}},
+		{`project:none AND tag!=backend`, []string{"Write docs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			// Act
+			expr, err := filter.Parse(tt.filter, now)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Parse should succeed: %v", err)
+			}
+			var matched []string
+			for _, todo := range todos {
+				if expr.Matches(todo) {
+					matched = append(matched, todo.Title)
+				}
+			}
+			if strings.Join(matched, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, matched)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		filter   string
+		position int
+		message  string
+	}{
+		{``, 1, "empty"},
+		{`completed:maybe`, 11, "true or false"},
+		{`status:open`, 1, `unknown field "status"`},
+		{`title deploy`, 6, "expected an operator"},
+		{`completed:false AND`, 20, "expected a condition"},
+		{`completed:false AND (title:~x`, 21, "unclosed parenthesis"},
+		{`title:~x)`, 9, "unexpected"},
+		{`title:"deploy`, 7, "unterminated string"},
+		{`completed>true`, 10, "cannot be used"},
+		{`created>soon`, 9, "invalid date"},
+		{`priority:critical`, 10, "unknown priority"},
+		{`due>none`, 5, "none can only"},
+		{`title:`, 7, "expected a value"},
+		{`título:x`, 1, "unknown field"},
+		{`title:"é" AND nope:1`, 15, `unknown field "nope"`},
+		{strings.Repeat("(", 25) + "completed:true" + strings.Repeat(")", 25), 21, "nested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			// Act
+			_, err := filter.Parse(tt.filter, now)
+
+			// Assert
+			var filterErr *filter.Error
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("Expected *filter.Error, got %v", err)
+			}
+			if filterErr.Position != tt.position {
+				t.Errorf("Expected position %d, got %d (%s)", tt.position, filterErr.Position, filterErr.Message)
+			}
+			if !strings.Contains(filterErr.Error(), tt.message) {
+				t.Errorf("Expected message containing %q, got %q", tt.message, filterErr.Error())
+			}
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	stale := todoAt("Stale", now.AddDate(0, 0, -20))
+	fresh := todoAt("Fresh", now.AddDate(0, 0, -1))
+
+	// Act
+	expr, err := filter.Condition(filter.FieldUpdated, filter.OpLess, "-14d", now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Condition should succeed: %v", err)
+	}
+	if !expr.Matches(stale) || expr.Matches(fresh) {
+		t.Errorf("Expected only the stale todo to match")
+	}
+
+	// 値や演算子が不正な場合はエラー
+	if _, err := filter.Condition(filter.FieldCreated, filter.OpGreaterEqual, "soon", now); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+	if _, err := filter.Condition(filter.FieldCompleted, filter.OpLess, "true", now); err == nil {
+		t.Error("Expected error for unsupported operator")
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+// CommentRepository stores the comments on todos. Callers check access to
+// the todo first; comments are only looked up within it.
+type CommentRepository interface {
+	Create(comment *entity.Comment) error
+	GetByID(id, todoID string) (*entity.Comment, error)
+	// ListByTodoID returns the todo's comments, including deleted ones,
+	// oldest first
+	ListByTodoID(todoID string) ([]*entity.Comment, error)
+	// Update saves an edit or a soft delete
+	Update(comment *entity.Comment) error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
+
+// Cursor marks the position after the last todo of a page: the values of
+// its sort keys followed by its ID as the final tie-breaker
+type Cursor struct {
+	// Order identifies the sort the cursor was created for (see TodoQuery.OrderKey)
+	Order  string        `json:"o"`
+	Values []interface{} `json:"v,omitempty"`
+	ID     string        `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// PageRequest selects one page of a listing
+type PageRequest struct {
+	Size int
+	// After is the cursor returned with the previous page; nil starts at the beginning
+	After *Cursor
+}
+
+// TodoPage is one page of todos returned by TodoRepository.ListPage
+type TodoPage struct {
+	Todos []*entity.Todo
+	// Next is nil on the last page
+	Next *Cursor
+	// Total is the number of todos matching the query across all pages
+	Total int
+}
+
+// PaginateInMemory cuts a page out of an already filtered and ordered list.
+// Repositories that cannot page in storage can use it; their cursors only
+// carry the ID of the last todo.
+func PaginateInMemory(todos []*entity.Todo, query TodoQuery, page PageRequest) (*TodoPage, error) {
+	start := 0
+	if page.After != nil {
+		start = -1
+		for i, todo := range todos {
+			if todo.ID == page.After.ID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	result := &TodoPage{Total: len(todos)}
+	end := len(todos)
+	if page.Size > 0 && start+page.Size < end {
+		end = start + page.Size
+		result.Next = &Cursor{Order: query.OrderKey(), ID: todos[end-1].ID}
+	}
+	result.Todos = todos[start:end]
+	return result, nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+)
+
+type ProjectRepository interface {
+	Create(project *entity.Project) error
+	GetByID(id, userID string) (*entity.Project, error)
+	ListByUserID(userID string) ([]*entity.Project, error)
+	Update(project *entity.Project) error
+	// Delete removes the project; its todos are kept without a project
+	Delete(id, userID string) error
+	// CountTodos returns the open/completed todo counts of the user's
+	// projects keyed by project ID. Projects without todos are omitted.
+	CountTodos(userID string) (map[string]entity.ProjectCounts, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+var (
+	ErrSavedViewNotFound = errors.New("view not found")
+)
+
+type SavedViewRepository interface {
+	Create(view *entity.SavedView) error
+	GetByID(id, userID string) (*entity.SavedView, error)
+	ListByUserID(userID string) ([]*entity.SavedView, error)
+	Update(view *entity.SavedView) error
+	Delete(id, userID string) error
+}
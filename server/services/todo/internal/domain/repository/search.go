@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+// SearchTerm is one word or quoted phrase of a full-text search
+type SearchTerm struct {
+	Text string
+	// Phrase terms match their words next to each other and in order
+	Phrase bool
+	// Prefix terms also match words that start with Text
+	Prefix bool
+}
+
+// SearchQuery describes a full-text search over a user's todo titles and
+// descriptions. A todo matches when it contains every term.
+type SearchQuery struct {
+	UserID string
+	Terms  []SearchTerm
+	Limit  int
+}
+
+// Matches reports whether a todo contains every term, ignoring case.
+// Repositories without a full-text index can use it to search in memory.
+func (q SearchQuery) Matches(todo *entity.Todo) bool {
+	if todo.UserID != q.UserID {
+		return false
+	}
+	text := strings.ToLower(todo.Title + " " + todo.Description)
+	for _, term := range q.Terms {
+		if !strings.Contains(text, strings.ToLower(term.Text)) {
+			return false
+		}
+	}
+	return true
+}
+
+// TodoSearchHit is a todo found by TodoRepository.Search
+type TodoSearchHit struct {
+	Todo *entity.Todo
+	// TitleHighlight is the HTML-escaped title with matches wrapped in <mark>
+	TitleHighlight string
+	// Snippet is an HTML-escaped excerpt of the description around the
+	// matches, wrapped in <mark> like TitleHighlight
+	Snippet string
+	// Rank orders the hits by relevance; lower is better
+	Rank float64
+}
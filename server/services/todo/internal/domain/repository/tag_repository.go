@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+)
+
+type TagRepository interface {
+	Create(tag *entity.Tag) error
+	GetByID(id, userID string) (*entity.Tag, error)
+	GetByName(userID, name string) (*entity.Tag, error)
+	ListByUserID(userID string) ([]*entity.Tag, error)
+	// ListByIDs returns the user's tags among ids; unknown ids are skipped
+	ListByIDs(userID string, ids []string) ([]*entity.Tag, error)
+	Update(tag *entity.Tag) error
+	// Delete removes the tag and detaches it from every todo
+	Delete(id, userID string) error
+}
@@ -1,14 +1,228 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
+)
+
+// SortField names the attribute todos are ordered by
+type SortField string
+
+const (
+	SortByDefault   SortField = ""
+	SortByPriority  SortField = "priority"
+	SortByDueAt     SortField = "due_at"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByTitle     SortField = "title"
+	// SortByCompletedAt is used for the completed todos listing
+	SortByCompletedAt SortField = "completed_at"
 )
 
+// SortDirection selects ascending or descending order. SortDirectionDefault
+// uses the natural direction of the field (see DefaultDirection).
+type SortDirection int
+
+const (
+	SortDirectionDefault SortDirection = iota
+	SortAscending
+	SortDescending
+)
+
+// TodoSort is the ordering applied by TodoRepository.List
+type TodoSort struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// Valid reports whether the sort refers to a known field and direction
+func (s TodoSort) Valid() bool {
+	switch s.Field {
+	case SortByDefault, SortByPriority, SortByDueAt, SortByCreatedAt, SortByUpdatedAt, SortByTitle, SortByCompletedAt:
+	default:
+		return false
+	}
+	return s.Direction >= SortDirectionDefault && s.Direction <= SortDescending
+}
+
+// Descending resolves the direction, falling back to the field's natural
+// order: most urgent, soonest due, newest first and alphabetical titles.
+func (s TodoSort) Descending() bool {
+	switch s.Direction {
+	case SortAscending:
+		return false
+	case SortDescending:
+		return true
+	}
+	switch s.Field {
+	case SortByDueAt, SortByTitle:
+		return false
+	default:
+		return true
+	}
+}
+
+// TodoQuery describes the criteria used by TodoRepository.List
+type TodoQuery struct {
+	UserID string
+	// UserIDs, when set, takes the place of UserID to list the todos of
+	// several owners at once, e.g. a user and their workspaces
+	UserIDs []string
+	// AssigneeID restricts the result to todos handed to that user
+	AssigneeID     string
+	CompletedOnly  bool
+	IncompleteOnly bool
+	// DueFrom and DueBefore restrict the result to todos whose due date is
+	// in [DueFrom, DueBefore). Todos without a due date never match.
+	DueFrom   *time.Time
+	DueBefore *time.Time
+	// AnyTagIDs matches todos carrying at least one of the tags,
+	// AllTagIDs matches todos carrying every one of them
+	AnyTagIDs []string
+	AllTagIDs []string
+	// ProjectID restricts the result to one project; NoProject to todos
+	// outside of every project
+	ProjectID string
+	NoProject bool
+	// ParentIDs restricts the result to subtasks of the given todos;
+	// TopLevelOnly leaves out subtasks altogether
+	ParentIDs    []string
+	TopLevelOnly bool
+	// Filter is a parsed filter expression applied on top of the other criteria
+	Filter filter.Expr
+	Sort   TodoSort
+}
+
+// OrderKey identifies the order the query's result is listed in, so that a
+// page cursor cannot be reused with a different sort
+func (q TodoQuery) OrderKey() string {
+	sort := q.Sort
+	if sort.Field == SortByDefault && q.HasDueRange() {
+		sort.Field = SortByDueAt
+	}
+	return fmt.Sprintf("%s:%t", sort.Field, sort.Descending())
+}
+
+// HasDueRange reports whether the query filters by due date
+func (q TodoQuery) HasDueRange() bool {
+	return q.DueFrom != nil || q.DueBefore != nil
+}
+
+// Matches reports whether a todo satisfies the query. Repositories that
+// cannot push the query down to storage can use it to filter in memory.
+func (q TodoQuery) Matches(todo *entity.Todo) bool {
+	if len(q.UserIDs) > 0 {
+		found := false
+		for _, userID := range q.UserIDs {
+			if todo.UserID == userID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	} else if todo.UserID != q.UserID {
+		return false
+	}
+	if q.AssigneeID != "" && todo.AssigneeID != q.AssigneeID {
+		return false
+	}
+	if q.CompletedOnly && !todo.Completed {
+		return false
+	}
+	if q.IncompleteOnly && todo.Completed {
+		return false
+	}
+	if q.HasDueRange() {
+		if todo.DueAt == nil {
+			return false
+		}
+		if q.DueFrom != nil && todo.DueAt.Before(*q.DueFrom) {
+			return false
+		}
+		if q.DueBefore != nil && !todo.DueAt.Before(*q.DueBefore) {
+			return false
+		}
+	}
+	if len(q.AnyTagIDs) > 0 {
+		found := false
+		for _, tagID := range q.AnyTagIDs {
+			if todo.HasTag(tagID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, tagID := range q.AllTagIDs {
+		if !todo.HasTag(tagID) {
+			return false
+		}
+	}
+	if q.ProjectID != "" && !todo.InProject(q.ProjectID) {
+		return false
+	}
+	if q.NoProject && todo.ProjectID != nil {
+		return false
+	}
+	if q.TopLevelOnly && todo.ParentID != nil {
+		return false
+	}
+	if len(q.ParentIDs) > 0 {
+		if todo.ParentID == nil {
+			return false
+		}
+		found := false
+		for _, parentID := range q.ParentIDs {
+			if *todo.ParentID == parentID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.Filter != nil && !q.Filter.Matches(todo) {
+		return false
+	}
+	return true
+}
+
 type TodoRepository interface {
 	Create(todo *entity.Todo) error
 	GetByID(id, userID string) (*entity.Todo, error)
 	ListByUserID(userID string) ([]*entity.Todo, error)
 	ListCompletedByUserID(userID string) ([]*entity.Todo, error)
+	List(query TodoQuery) ([]*entity.Todo, error)
+	// ListPage returns one page of the todos matching the query along with
+	// the total number of matches
+	ListPage(query TodoQuery, page PageRequest) (*TodoPage, error)
+	// Search finds todos by the words in their title and description, most
+	// relevant first
+	Search(query SearchQuery) ([]*TodoSearchHit, error)
+	// CountSubtasks returns the progress of the given todos' direct subtasks
+	// keyed by parent ID. Todos without subtasks are omitted.
+	CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error)
+	// CountByUserIDs counts all todos of the given users keyed by user ID.
+	// Users without todos are omitted.
+	CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error)
 	Update(todo *entity.Todo) error
+	// UpdateMany saves several todos atomically
+	UpdateMany(todos []*entity.Todo) error
+	// CreateWithUpdates creates a todo and saves updates to others atomically
+	CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error
+	// Delete removes the todo together with all of its subtasks and their
+	// comments
 	Delete(id, userID string) error
+	// DeleteAllByUserID removes every todo of the user along with their
+	// comments, tags, projects and saved views. On the todos of others the
+	// user is unassigned and their comments are deleted, body included.
+	DeleteAllByUserID(userID string) error
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+var (
+	ErrNotCommentAuthor    = errors.New("only the author can edit a comment")
+	ErrCommentDeleteDenied = errors.New("only the author or the todo's owner can delete a comment")
+)
+
+// CommentService manages the discussion threads of todos. Every method
+// takes the owner key the todo is stored under (see TodoService.Owner) and
+// the user acting on the thread.
+type CommentService struct {
+	commentRepo repository.CommentRepository
+	todoService *TodoService
+}
+
+func NewCommentService(commentRepo repository.CommentRepository, todoService *TodoService) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		todoService: todoService,
+	}
+}
+
+func (s *CommentService) AddComment(todoID, owner, userID, body string) (*entity.Comment, error) {
+	if _, err := s.todoService.GetTodo(todoID, owner); err != nil {
+		return nil, err
+	}
+
+	comment, err := entity.NewComment(uuid.New().String(), todoID, userID, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ListComments returns the todo's thread oldest first, deleted comments
+// included
+func (s *CommentService) ListComments(todoID, owner string) ([]*entity.Comment, error) {
+	if _, err := s.todoService.GetTodo(todoID, owner); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.ListByTodoID(todoID)
+}
+
+func (s *CommentService) EditComment(id, todoID, owner, userID, body string) (*entity.Comment, error) {
+	if _, err := s.todoService.GetTodo(todoID, owner); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(id, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != userID {
+		return nil, ErrNotCommentAuthor
+	}
+	if err := comment.Edit(body); err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.Update(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment soft-deletes a comment. Besides its author, the owner of
+// the todo (whoever created it) may remove comments from its thread.
+func (s *CommentService) DeleteComment(id, todoID, owner, userID string) error {
+	todo, err := s.todoService.GetTodo(todoID, owner)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.commentRepo.GetByID(id, todoID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID && todoOwner(todo) != userID {
+		return ErrCommentDeleteDenied
+	}
+	if comment.Deleted() {
+		return nil
+	}
+
+	comment.Delete()
+	return s.commentRepo.Update(comment)
+}
+
+// todoOwner returns the user who owns a todo. Todos created before their
+// creator was recorded are personal ones, owned by their user.
+func todoOwner(todo *entity.Todo) string {
+	if todo.CreatedBy != "" {
+		return todo.CreatedBy
+	}
+	return todo.UserID
+}
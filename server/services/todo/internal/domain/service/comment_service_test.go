@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+// SimpleMockCommentRepository - テスト用の簡単なコメントリポジトリ
+type SimpleMockCommentRepository struct {
+	comments []*entity.Comment
+}
+
+func NewSimpleMockCommentRepository() *SimpleMockCommentRepository {
+	return &SimpleMockCommentRepository{}
+}
+
+func (r *SimpleMockCommentRepository) Create(comment *entity.Comment) error {
+	r.comments = append(r.comments, comment)
+	return nil
+}
+
+func (r *SimpleMockCommentRepository) GetByID(id, todoID string) (*entity.Comment, error) {
+	for _, comment := range r.comments {
+		if comment.ID == id && comment.TodoID == todoID {
+			return comment, nil
+		}
+	}
+	return nil, repository.ErrCommentNotFound
+}
+
+func (r *SimpleMockCommentRepository) ListByTodoID(todoID string) ([]*entity.Comment, error) {
+	var comments []*entity.Comment
+	for _, comment := range r.comments {
+		if comment.TodoID == todoID {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (r *SimpleMockCommentRepository) Update(comment *entity.Comment) error {
+	_, err := r.GetByID(comment.ID, comment.TodoID)
+	return err
+}
+
+var _ repository.CommentRepository = (*SimpleMockCommentRepository)(nil)
+
+func TestCommentService_Thread(t *testing.T) {
+	// Arrange - alice が作ったワークスペースのTodo
+	todoService := service.NewTodoService(NewSimpleMockRepository())
+	commentService := service.NewCommentService(NewSimpleMockCommentRepository(), todoService)
+	todo, _ := todoService.CreateTodo("ws-1", "Release", "", service.InWorkspace("ws-1", "alice"))
+
+	// Act & Assert - メンバーがコメントを追加する
+	first, err := commentService.AddComment(todo.ID, "ws-1", "bob", "Can we ship on Friday?")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := commentService.AddComment(todo.ID, "ws-1", "alice", "Yes"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := commentService.AddComment(todo.ID, "ws-1", "bob", "   "); err != entity.ErrInvalidCommentBody {
+		t.Errorf("Expected ErrInvalidCommentBody, got %v", err)
+	}
+
+	// Act & Assert - 別の所有者からはTodoが見えない
+	if _, err := commentService.ListComments(todo.ID, "mallory"); err == nil {
+		t.Error("Expected the todo not to be found for another owner")
+	}
+
+	// Act & Assert - 編集できるのは作成者だけ
+	if _, err := commentService.EditComment(first.ID, todo.ID, "ws-1", "alice", "Ship it"); err != service.ErrNotCommentAuthor {
+		t.Errorf("Expected ErrNotCommentAuthor, got %v", err)
+	}
+	edited, err := commentService.EditComment(first.ID, todo.ID, "ws-1", "bob", "Can we ship on Monday?")
+	if err != nil || edited.EditedAt == nil {
+		t.Errorf("Expected bob to edit his comment, got %+v (%v)", edited, err)
+	}
+
+	// Act & Assert - 削除できるのは作成者とTodoのオーナー
+	if err := commentService.DeleteComment(first.ID, todo.ID, "ws-1", "carol"); err != service.ErrCommentDeleteDenied {
+		t.Errorf("Expected ErrCommentDeleteDenied, got %v", err)
+	}
+	if err := commentService.DeleteComment(first.ID, todo.ID, "ws-1", "alice"); err != nil {
+		t.Errorf("Expected the todo's owner to delete the comment, got %v", err)
+	}
+
+	// Assert - 削除したコメントも順番どおりに残る
+	comments, err := commentService.ListComments(todo.ID, "ws-1")
+	if err != nil {
+		t.Fatalf("ListComments failed: %v", err)
+	}
+	if len(comments) != 2 || !comments[0].Deleted() || comments[1].Body != "Yes" {
+		t.Errorf("Expected the deleted comment followed by alice's reply, got %+v", comments)
+	}
+}
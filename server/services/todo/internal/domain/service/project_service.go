@@ -0,0 +1,108 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+// ProjectSummary is a project together with the counts of its todos
+type ProjectSummary struct {
+	Project *entity.Project
+	Counts  entity.ProjectCounts
+}
+
+type ProjectService struct {
+	projectRepo repository.ProjectRepository
+}
+
+func NewProjectService(projectRepo repository.ProjectRepository) *ProjectService {
+	return &ProjectService{
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *ProjectService) CreateProject(userID, name, description string) (*entity.Project, error) {
+	project, err := entity.NewProject(uuid.New().String(), userID, name, description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *ProjectService) GetProject(id, userID string) (*ProjectSummary, error) {
+	project, err := s.projectRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.summarize(project)
+}
+
+// ListProjects returns the user's projects with their open/completed todo counts
+func (s *ProjectService) ListProjects(userID string) ([]*ProjectSummary, error) {
+	projects, err := s.projectRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.projectRepo.CountTodos(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*ProjectSummary, 0, len(projects))
+	for _, project := range projects {
+		summaries = append(summaries, &ProjectSummary{
+			Project: project,
+			Counts:  counts[project.ID],
+		})
+	}
+
+	return summaries, nil
+}
+
+func (s *ProjectService) UpdateProject(id, userID, name, description string) (*ProjectSummary, error) {
+	project, err := s.projectRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.Update(name, description); err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return s.summarize(project)
+}
+
+// DeleteProject removes a project; its todos stay but no longer belong to a project
+func (s *ProjectService) DeleteProject(id, userID string) error {
+	return s.projectRepo.Delete(id, userID)
+}
+
+// EnsureProject fails with ErrProjectNotFound unless the project belongs to the user
+func (s *ProjectService) EnsureProject(id, userID string) error {
+	_, err := s.projectRepo.GetByID(id, userID)
+	return err
+}
+
+func (s *ProjectService) summarize(project *entity.Project) (*ProjectSummary, error) {
+	counts, err := s.projectRepo.CountTodos(project.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProjectSummary{
+		Project: project,
+		Counts:  counts[project.ID],
+	}, nil
+}
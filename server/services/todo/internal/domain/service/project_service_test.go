@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+// SimpleMockProjectRepository - テスト用の簡単なプロジェクトリポジトリ
+type SimpleMockProjectRepository struct {
+	projects map[string]*entity.Project
+	todoRepo *SimpleMockRepository
+}
+
+func NewSimpleMockProjectRepository(todoRepo *SimpleMockRepository) *SimpleMockProjectRepository {
+	return &SimpleMockProjectRepository{
+		projects: make(map[string]*entity.Project),
+		todoRepo: todoRepo,
+	}
+}
+
+func (r *SimpleMockProjectRepository) Create(project *entity.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) GetByID(id, userID string) (*entity.Project, error) {
+	project, exists := r.projects[id]
+	if !exists || project.UserID != userID {
+		return nil, repository.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (r *SimpleMockProjectRepository) ListByUserID(userID string) ([]*entity.Project, error) {
+	var projects []*entity.Project
+	for _, project := range r.projects {
+		if project.UserID == userID {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (r *SimpleMockProjectRepository) Update(project *entity.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) Delete(id, userID string) error {
+	if _, err := r.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(r.projects, id)
+	for _, todo := range r.todoRepo.todos {
+		if todo.InProject(id) {
+			todo.ProjectID = nil
+		}
+	}
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) CountTodos(userID string) (map[string]entity.ProjectCounts, error) {
+	counts := make(map[string]entity.ProjectCounts)
+	for _, todo := range r.todoRepo.todos {
+		if todo.UserID != userID || todo.ProjectID == nil {
+			continue
+		}
+		c := counts[*todo.ProjectID]
+		if todo.Completed {
+			c.Completed++
+		} else {
+			c.Open++
+		}
+		counts[*todo.ProjectID] = c
+	}
+	return counts, nil
+}
+
+// Interface compliance check
+var _ repository.ProjectRepository = (*SimpleMockProjectRepository)(nil)
+
+func TestProjectService_CRUD(t *testing.T) {
+	// Arrange
+	projectService := service.NewProjectService(NewSimpleMockProjectRepository(NewSimpleMockRepository()))
+
+	// Act & Assert - 作成
+	project, err := projectService.CreateProject("user-123", "Website", "Relaunch")
+	if err != nil {
+		t.Fatalf("CreateProject should succeed: %v", err)
+	}
+	if project.ID == "" {
+		t.Errorf("Expected project ID to be generated")
+	}
+	if _, err := projectService.CreateProject("user-123", "", ""); err != entity.ErrInvalidProjectName {
+		t.Errorf("Expected ErrInvalidProjectName, got %v", err)
+	}
+
+	// Act & Assert - 更新
+	updated, err := projectService.UpdateProject(project.ID, "user-123", "Marketing site", "")
+	if err != nil {
+		t.Fatalf("UpdateProject should succeed: %v", err)
+	}
+	if updated.Project.Name != "Marketing site" || updated.Project.Description != "Relaunch" {
+		t.Errorf("Unexpected project after update: %+v", updated.Project)
+	}
+
+	// 他ユーザーからは参照できない
+	if err := projectService.EnsureProject(project.ID, "user-456"); err != repository.ErrProjectNotFound {
+		t.Errorf("Expected ErrProjectNotFound, got %v", err)
+	}
+
+	// Act & Assert - 削除
+	if err := projectService.DeleteProject(project.ID, "user-123"); err != nil {
+		t.Errorf("DeleteProject should succeed: %v", err)
+	}
+	if _, err := projectService.GetProject(project.ID, "user-123"); err != repository.ErrProjectNotFound {
+		t.Errorf("Expected ErrProjectNotFound after delete, got %v", err)
+	}
+}
+
+func TestProjectService_ListProjectsWithCounts(t *testing.T) {
+	// Arrange
+	todoRepo := NewSimpleMockRepository()
+	projectService := service.NewProjectService(NewSimpleMockProjectRepository(todoRepo))
+	todoService := service.NewTodoService(todoRepo)
+
+	work, _ := projectService.CreateProject("user-123", "Work", "")
+	projectService.CreateProject("user-123", "Home", "")
+
+	open, _ := todoService.CreateTodo("user-123", "Open", "", service.WithProject(&work.ID))
+	done, _ := todoService.CreateTodo("user-123", "Done", "", service.WithProject(&work.ID))
+	todoService.MarkTodoComplete(done.ID, "user-123", true)
+	todoService.CreateTodo("user-123", "Inbox", "")
+
+	// Act
+	summaries, err := projectService.ListProjects("user-123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListProjects should succeed: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(summaries))
+	}
+	for _, summary := range summaries {
+		switch summary.Project.ID {
+		case work.ID:
+			if summary.Counts.Open != 1 || summary.Counts.Completed != 1 {
+				t.Errorf("Expected 1 open and 1 completed in Work, got %+v", summary.Counts)
+			}
+		default:
+			if summary.Counts.Open != 0 || summary.Counts.Completed != 0 {
+				t.Errorf("Expected empty counts for Home, got %+v", summary.Counts)
+			}
+		}
+	}
+
+	// Assert - プロジェクトで絞り込み
+	todos, _ := todoService.ListTodosWithOptions("user-123", service.ListOptions{ProjectID: work.ID})
+	if len(todos) != 2 {
+		t.Errorf("Expected 2 todos in Work, got %d", len(todos))
+	}
+	inbox, _ := todoService.ListTodosWithOptions("user-123", service.ListOptions{NoProject: true})
+	if len(inbox) != 1 || inbox[0].ID == open.ID {
+		t.Errorf("Expected only the inbox todo, got %v", inbox)
+	}
+}
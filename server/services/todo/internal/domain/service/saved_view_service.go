@@ -0,0 +1,193 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+// SavedViewUpdate holds the changes applied by UpdateSavedView; nil fields
+// are left unchanged
+type SavedViewUpdate struct {
+	Name     string
+	Icon     *string
+	Criteria *entity.ViewCriteria
+}
+
+type SavedViewService struct {
+	viewRepo    repository.SavedViewRepository
+	todoService *TodoService
+}
+
+func NewSavedViewService(viewRepo repository.SavedViewRepository, todoService *TodoService) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:    viewRepo,
+		todoService: todoService,
+	}
+}
+
+// In returns a copy of the service whose date conditions use the given
+// time zone
+func (s *SavedViewService) In(loc *time.Location) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:    s.viewRepo,
+		todoService: s.todoService.In(loc),
+	}
+}
+
+func (s *SavedViewService) CreateSavedView(userID, name, icon string, criteria entity.ViewCriteria) (*entity.SavedView, error) {
+	view, err := entity.NewSavedView(uuid.New().String(), userID, name, icon, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.viewQuery(view); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *SavedViewService) GetSavedView(id, userID string) (*entity.SavedView, error) {
+	return s.viewRepo.GetByID(id, userID)
+}
+
+func (s *SavedViewService) ListSavedViews(userID string) ([]*entity.SavedView, error) {
+	return s.viewRepo.ListByUserID(userID)
+}
+
+func (s *SavedViewService) UpdateSavedView(id, userID string, update SavedViewUpdate) (*entity.SavedView, error) {
+	view, err := s.viewRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := view.Rename(update.Name); err != nil {
+		return nil, err
+	}
+	if update.Icon != nil {
+		if err := view.SetIcon(*update.Icon); err != nil {
+			return nil, err
+		}
+	}
+	if update.Criteria != nil {
+		if err := view.SetCriteria(*update.Criteria); err != nil {
+			return nil, err
+		}
+		if _, err := s.viewQuery(view); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.viewRepo.Update(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *SavedViewService) DeleteSavedView(id, userID string) error {
+	return s.viewRepo.Delete(id, userID)
+}
+
+// ListTodosByView lists the todos matching a view's criteria. Relative dates
+// in the criteria are resolved against the current time.
+func (s *SavedViewService) ListTodosByView(id, userID string) ([]*entity.Todo, error) {
+	query, err := s.loadViewQuery(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.todoService.todoRepo.List(query)
+}
+
+// ListTodosByViewPage lists one page of the todos matching a view's criteria
+func (s *SavedViewService) ListTodosByViewPage(id, userID string, pageSize int, pageToken string) (*Page, error) {
+	query, err := s.loadViewQuery(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.todoService.listPage(query, pageSize, pageToken)
+}
+
+func (s *SavedViewService) loadViewQuery(id, userID string) (repository.TodoQuery, error) {
+	view, err := s.viewRepo.GetByID(id, userID)
+	if err != nil {
+		return repository.TodoQuery{}, err
+	}
+
+	return s.viewQuery(view)
+}
+
+// viewQuery translates a view's criteria into a repository query. Errors
+// wrap entity.ErrInvalidViewCriteria.
+func (s *SavedViewService) viewQuery(view *entity.SavedView) (repository.TodoQuery, error) {
+	criteria := view.Criteria
+	query := repository.TodoQuery{
+		UserID:         view.UserID,
+		CompletedOnly:  criteria.Completion == entity.ViewCompletionCompleted,
+		IncompleteOnly: criteria.Completion == entity.ViewCompletionOpen,
+		Sort:           repository.TodoSort{Field: repository.SortField(criteria.Sort)},
+	}
+
+	switch criteria.Order {
+	case "":
+	case "asc":
+		query.Sort.Direction = repository.SortAscending
+	case "desc":
+		query.Sort.Direction = repository.SortDescending
+	default:
+		return query, fmt.Errorf("%w: order must be asc or desc", entity.ErrInvalidViewCriteria)
+	}
+	if !query.Sort.Valid() {
+		return query, fmt.Errorf("%w: unknown sort %q", entity.ErrInvalidViewCriteria, criteria.Sort)
+	}
+
+	now := s.todoService.now()
+	conditions := []struct {
+		field filter.Field
+		op    filter.Op
+		value string
+	}{
+		{filter.FieldCreated, filter.OpGreaterEqual, criteria.CreatedFrom},
+		{filter.FieldCreated, filter.OpLess, criteria.CreatedBefore},
+		{filter.FieldUpdated, filter.OpGreaterEqual, criteria.UpdatedFrom},
+		{filter.FieldUpdated, filter.OpLess, criteria.UpdatedBefore},
+		{filter.FieldTitle, filter.OpContains, criteria.TitleContains},
+	}
+	var exprs []filter.Expr
+	for _, c := range conditions {
+		if c.value == "" {
+			continue
+		}
+		expr, err := filter.Condition(c.field, c.op, c.value, now)
+		if err != nil {
+			return query, fmt.Errorf("%w: %v", entity.ErrInvalidViewCriteria, err)
+		}
+		exprs = append(exprs, expr)
+	}
+	if criteria.Filter != "" {
+		expr, err := filter.Parse(criteria.Filter, now)
+		if err != nil {
+			return query, fmt.Errorf("%w: %v", entity.ErrInvalidViewCriteria, err)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	for _, expr := range exprs {
+		if query.Filter == nil {
+			query.Filter = expr
+		} else {
+			query.Filter = &filter.And{Left: query.Filter, Right: expr}
+		}
+	}
+	return query, nil
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+// SimpleMockSavedViewRepository - テスト用の簡単なビューリポジトリ
+type SimpleMockSavedViewRepository struct {
+	views map[string]*entity.SavedView
+}
+
+func NewSimpleMockSavedViewRepository() *SimpleMockSavedViewRepository {
+	return &SimpleMockSavedViewRepository{
+		views: make(map[string]*entity.SavedView),
+	}
+}
+
+func (r *SimpleMockSavedViewRepository) Create(view *entity.SavedView) error {
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *SimpleMockSavedViewRepository) GetByID(id, userID string) (*entity.SavedView, error) {
+	view, exists := r.views[id]
+	if !exists || view.UserID != userID {
+		return nil, repository.ErrSavedViewNotFound
+	}
+	return view, nil
+}
+
+func (r *SimpleMockSavedViewRepository) ListByUserID(userID string) ([]*entity.SavedView, error) {
+	var views []*entity.SavedView
+	for _, view := range r.views {
+		if view.UserID == userID {
+			views = append(views, view)
+		}
+	}
+	return views, nil
+}
+
+func (r *SimpleMockSavedViewRepository) Update(view *entity.SavedView) error {
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *SimpleMockSavedViewRepository) Delete(id, userID string) error {
+	if _, err := r.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(r.views, id)
+	return nil
+}
+
+func TestSavedViewService_CRUD(t *testing.T) {
+	// Arrange
+	viewService := service.NewSavedViewService(NewSimpleMockSavedViewRepository(), service.NewTodoService(NewSimpleMockRepository()))
+
+	// Act & Assert - 作成
+	view, err := viewService.CreateSavedView("user-123", "Stale", "🕸", entity.ViewCriteria{UpdatedBefore: "-14d"})
+	if err != nil {
+		t.Fatalf("CreateSavedView should succeed: %v", err)
+	}
+	if view.ID == "" {
+		t.Errorf("Expected view ID to be generated")
+	}
+
+	// 解釈できない条件は保存しない
+	invalid := []entity.ViewCriteria{
+		{CreatedFrom: "soon"},
+		{Sort: "color"},
+		{Sort: "title", Order: "up"},
+		{Filter: "status:open"},
+	}
+	for _, criteria := range invalid {
+		if _, err := viewService.CreateSavedView("user-123", "Broken", "", criteria); !errors.Is(err, entity.ErrInvalidViewCriteria) {
+			t.Errorf("Expected ErrInvalidViewCriteria for %+v, got %v", criteria, err)
+		}
+	}
+
+	// Act & Assert - 更新
+	icon := ""
+	criteria := entity.ViewCriteria{Completion: entity.ViewCompletionOpen}
+	updated, err := viewService.UpdateSavedView(view.ID, "user-123", service.SavedViewUpdate{Icon: &icon, Criteria: &criteria})
+	if err != nil {
+		t.Fatalf("UpdateSavedView should succeed: %v", err)
+	}
+	if updated.Name != "Stale" || updated.Icon != "" || updated.Criteria != criteria {
+		t.Errorf("Unexpected view after update: %+v", updated)
+	}
+
+	// 他ユーザーからは参照できない
+	if _, err := viewService.GetSavedView(view.ID, "user-456"); err != repository.ErrSavedViewNotFound {
+		t.Errorf("Expected ErrSavedViewNotFound, got %v", err)
+	}
+
+	// Act & Assert - 削除
+	if err := viewService.DeleteSavedView(view.ID, "user-123"); err != nil {
+		t.Errorf("DeleteSavedView should succeed: %v", err)
+	}
+	if _, err := viewService.ListTodosByView(view.ID, "user-123"); err != repository.ErrSavedViewNotFound {
+		t.Errorf("Expected ErrSavedViewNotFound after delete, got %v", err)
+	}
+}
+
+func TestSavedViewService_ListTodosByView(t *testing.T) {
+	// Arrange
+	todoRepo := NewSimpleMockRepository()
+	todoService := service.NewTodoService(todoRepo)
+	viewService := service.NewSavedViewService(NewSimpleMockSavedViewRepository(), todoService)
+
+	stale, _ := todoService.CreateTodo("user-123", "Renew certificate", "")
+	stale.UpdatedAt = time.Now().AddDate(0, 0, -20)
+	fresh, _ := todoService.CreateTodo("user-123", "Renew domain", "")
+	done, _ := todoService.CreateTodo("user-123", "Renew lease", "")
+	done.MarkComplete(true)
+	done.UpdatedAt = time.Now().AddDate(0, 0, -30)
+	todoService.CreateTodo("user-456", "Renew passport", "")
+
+	open, _ := viewService.CreateSavedView("user-123", "Renewals", "", entity.ViewCriteria{
+		Completion:    entity.ViewCompletionOpen,
+		TitleContains: "renew",
+		Sort:          "title",
+	})
+	staleView, _ := viewService.CreateSavedView("user-123", "Stale", "", entity.ViewCriteria{UpdatedBefore: "-14d", Sort: "updated_at", Order: "asc"})
+
+	// Act
+	openTodos, err := viewService.ListTodosByView(open.ID, "user-123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListTodosByView should succeed: %v", err)
+	}
+	if len(openTodos) != 2 {
+		t.Fatalf("Expected 2 open todos, got %d", len(openTodos))
+	}
+	ids := []string{openTodos[0].ID, openTodos[1].ID}
+	expected := []string{stale.ID, fresh.ID}
+	sort.Strings(ids)
+	sort.Strings(expected)
+	if ids[0] != expected[0] || ids[1] != expected[1] {
+		t.Errorf("Expected the open renewals, got %v", ids)
+	}
+
+	// 相対日付は一覧のたびに現在時刻から解決される
+	page, err := viewService.ListTodosByViewPage(staleView.ID, "user-123", 10, "")
+	if err != nil {
+		t.Fatalf("ListTodosByViewPage should succeed: %v", err)
+	}
+	if page.Total != 2 || len(page.Todos) != 2 {
+		t.Errorf("Expected 2 stale todos, got %d of %d", len(page.Todos), page.Total)
+	}
+	if _, err := viewService.ListTodosByView(open.ID, "user-456"); err != repository.ErrSavedViewNotFound {
+		t.Errorf("Expected ErrSavedViewNotFound for another user, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+var (
+	ErrTagNameTaken = errors.New("tag name already exists")
+)
+
+type TagService struct {
+	tagRepo repository.TagRepository
+}
+
+func NewTagService(tagRepo repository.TagRepository) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+	}
+}
+
+func (s *TagService) CreateTag(userID, name, color string) (*entity.Tag, error) {
+	tag, err := entity.NewTag(uuid.New().String(), userID, name, color)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(userID, tag.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) ListTags(userID string) ([]*entity.Tag, error) {
+	return s.tagRepo.ListByUserID(userID)
+}
+
+func (s *TagService) RenameTag(id, userID, name string) (*entity.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 重複チェックが終わるまで保存済みのタグを変更しないようコピーに適用する
+	renamed := *tag
+	if err := renamed.Rename(name); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(userID, renamed.Name, renamed.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Update(&renamed); err != nil {
+		return nil, err
+	}
+
+	return &renamed, nil
+}
+
+func (s *TagService) RecolorTag(id, userID, color string) (*entity.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tag.Recolor(color); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) DeleteTag(id, userID string) error {
+	return s.tagRepo.Delete(id, userID)
+}
+
+// ResolveTags loads the user's tags for the given IDs. It fails if any ID does
+// not name one of the user's tags, so todos can never carry foreign tags.
+func (s *TagService) ResolveTags(userID string, ids []string) ([]*entity.Tag, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	tags, err := s.tagRepo.ListByIDs(userID, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(unique) {
+		return nil, repository.ErrTagNotFound
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) ensureNameAvailable(userID, name, exceptID string) error {
+	existing, err := s.tagRepo.GetByName(userID, name)
+	if errors.Is(err, repository.ErrTagNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != exceptID {
+		return ErrTagNameTaken
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+// SimpleMockTagRepository - テスト用の簡単なタグリポジトリ
+type SimpleMockTagRepository struct {
+	tags map[string]*entity.Tag
+}
+
+func NewSimpleMockTagRepository() *SimpleMockTagRepository {
+	return &SimpleMockTagRepository{
+		tags: make(map[string]*entity.Tag),
+	}
+}
+
+func (m *SimpleMockTagRepository) Create(tag *entity.Tag) error {
+	m.tags[tag.ID] = tag
+	return nil
+}
+
+func (m *SimpleMockTagRepository) GetByID(id, userID string) (*entity.Tag, error) {
+	tag, exists := m.tags[id]
+	if !exists || tag.UserID != userID {
+		return nil, repository.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (m *SimpleMockTagRepository) GetByName(userID, name string) (*entity.Tag, error) {
+	for _, tag := range m.tags {
+		if tag.UserID == userID && strings.EqualFold(tag.Name, name) {
+			return tag, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+func (m *SimpleMockTagRepository) ListByUserID(userID string) ([]*entity.Tag, error) {
+	var result []*entity.Tag
+	for _, tag := range m.tags {
+		if tag.UserID == userID {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+func (m *SimpleMockTagRepository) ListByIDs(userID string, ids []string) ([]*entity.Tag, error) {
+	var result []*entity.Tag
+	for _, id := range ids {
+		if tag, exists := m.tags[id]; exists && tag.UserID == userID {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+func (m *SimpleMockTagRepository) Update(tag *entity.Tag) error {
+	m.tags[tag.ID] = tag
+	return nil
+}
+
+func (m *SimpleMockTagRepository) Delete(id, userID string) error {
+	if _, err := m.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(m.tags, id)
+	return nil
+}
+
+// Interface compliance check
+var _ repository.TagRepository = (*SimpleMockTagRepository)(nil)
+
+func TestTagService_CreateTag(t *testing.T) {
+	// Arrange
+	tagService := service.NewTagService(NewSimpleMockTagRepository())
+
+	// Act
+	tag, err := tagService.CreateTag("user-123", "backend", "#336699")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreateTag should succeed: %v", err)
+	}
+	if tag.ID == "" {
+		t.Errorf("Expected tag ID to be generated")
+	}
+	if tag.Name != "backend" || tag.Color != "#336699" {
+		t.Errorf("Unexpected tag: %+v", tag)
+	}
+
+	// 同じユーザー内で大文字小文字違いの重複は不可
+	if _, err := tagService.CreateTag("user-123", "Backend", ""); err != service.ErrTagNameTaken {
+		t.Errorf("Expected ErrTagNameTaken, got %v", err)
+	}
+
+	// 別ユーザーなら同名でも作成できる
+	if _, err := tagService.CreateTag("user-456", "backend", ""); err != nil {
+		t.Errorf("Other users should be able to reuse the name: %v", err)
+	}
+}
+
+func TestTagService_RenameAndRecolor(t *testing.T) {
+	// Arrange
+	tagService := service.NewTagService(NewSimpleMockTagRepository())
+	backend, _ := tagService.CreateTag("user-123", "backend", "")
+	tagService.CreateTag("user-123", "frontend", "")
+
+	// Act & Assert - 名前変更
+	renamed, err := tagService.RenameTag(backend.ID, "user-123", "infra")
+	if err != nil {
+		t.Fatalf("RenameTag should succeed: %v", err)
+	}
+	if renamed.Name != "infra" {
+		t.Errorf("Expected name 'infra', got %q", renamed.Name)
+	}
+
+	// 大文字小文字だけの変更は自分自身との重複とみなさない
+	if _, err := tagService.RenameTag(backend.ID, "user-123", "Infra"); err != nil {
+		t.Errorf("Changing only the case should succeed: %v", err)
+	}
+
+	// 既存の名前には変更できない
+	if _, err := tagService.RenameTag(backend.ID, "user-123", "frontend"); err != service.ErrTagNameTaken {
+		t.Errorf("Expected ErrTagNameTaken, got %v", err)
+	}
+
+	// Act & Assert - 色変更
+	recolored, err := tagService.RecolorTag(backend.ID, "user-123", "#ff0000")
+	if err != nil {
+		t.Fatalf("RecolorTag should succeed: %v", err)
+	}
+	if recolored.Color != "#ff0000" {
+		t.Errorf("Expected color '#ff0000', got %q", recolored.Color)
+	}
+
+	// 他ユーザーのタグは変更できない
+	if _, err := tagService.RecolorTag(backend.ID, "user-456", "#00ff00"); err != repository.ErrTagNotFound {
+		t.Errorf("Expected ErrTagNotFound, got %v", err)
+	}
+}
+
+func TestTagService_ResolveTags(t *testing.T) {
+	// Arrange
+	tagService := service.NewTagService(NewSimpleMockTagRepository())
+	mine, _ := tagService.CreateTag("user-123", "backend", "")
+	theirs, _ := tagService.CreateTag("user-456", "backend", "")
+
+	// Act & Assert - 重複IDはまとめられる
+	tags, err := tagService.ResolveTags("user-123", []string{mine.ID, mine.ID})
+	if err != nil {
+		t.Fatalf("ResolveTags should succeed: %v", err)
+	}
+	if len(tags) != 1 || tags[0].ID != mine.ID {
+		t.Errorf("Expected only the user's tag, got %v", tags)
+	}
+
+	// Act & Assert - 他ユーザーのタグは解決できない
+	if _, err := tagService.ResolveTags("user-123", []string{mine.ID, theirs.ID}); err != repository.ErrTagNotFound {
+		t.Errorf("Expected ErrTagNotFound, got %v", err)
+	}
+}
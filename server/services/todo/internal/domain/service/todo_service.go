@@ -1,25 +1,255 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/google/uuid"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 )
 
+var (
+	ErrInvalidDueWindow = errors.New("due window must be at least 1 day")
+	ErrInvalidSort      = errors.New("invalid sort order")
+	ErrParentNotFound   = errors.New("parent todo not found")
+	ErrInvalidParent    = errors.New("a todo cannot be a subtask of itself or of its own subtasks")
+	ErrOpenSubtasks     = errors.New("todo has open subtasks")
+	ErrInvalidPageSize  = errors.New("page size must be between 0 and 500")
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrEmptySearch      = errors.New("search query must contain at least one word")
+	ErrInvalidSearch    = errors.New("invalid search query")
+	ErrUserIDRequired   = errors.New("user ID is required")
+
+	ErrWorkspaceAccessDenied  = errors.New("workspace not found")
+	ErrWorkspaceReadOnly      = errors.New("viewers can't change the workspace's todos")
+	ErrWorkspacesNotAvailable = errors.New("workspaces are not available")
+	ErrAssigneeRequired       = errors.New("assignee ID is required")
+	ErrInvalidAssignee        = errors.New("todos can only be assigned to yourself or to workspace members who can edit them")
+)
+
+// Workspace roles as reported by a MembershipChecker
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleViewer = "viewer"
+)
+
+// MembershipChecker tells the role of a user in a workspace. The user
+// service keeps the workspaces; an empty role means the user isn't a member.
+type MembershipChecker interface {
+	WorkspaceRole(workspaceID, userID string) (string, error)
+	// WorkspaceIDs lists the workspaces the user is a member of
+	WorkspaceIDs(userID string) ([]string, error)
+}
+
+const (
+	// DefaultPageSize is used when a page token is passed without a page size
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	maxSearchTerms     = 16
+)
+
+// Page is one page of a todo listing
+type Page struct {
+	Todos []*entity.Todo
+	// NextPageToken is empty on the last page
+	NextPageToken string
+	// Total is the number of todos across all pages
+	Total int
+}
+
+// SubtaskRule decides what MarkTodoComplete does with open subtasks
+type SubtaskRule int
+
+const (
+	// SubtaskRuleRequireDone refuses to complete a todo with open subtasks
+	SubtaskRuleRequireDone SubtaskRule = iota
+	// SubtaskRuleCompleteAll completes all open subtasks along with the todo
+	SubtaskRuleCompleteAll
+)
+
+// DueFilter selects todos by due date relative to the current time
+type DueFilter int
+
+const (
+	DueAny DueFilter = iota
+	DueOverdue
+	DueToday
+	DueWithin
+)
+
+// ListOptions narrows and orders the result of ListTodosWithOptions
+type ListOptions struct {
+	CompletedOnly bool
+	Due           DueFilter
+	// DueWithinDays is the window size used with DueWithin
+	DueWithinDays int
+	AnyTagIDs     []string
+	AllTagIDs     []string
+	// ProjectID lists a single project; NoProject lists todos outside of any project
+	ProjectID    string
+	NoProject    bool
+	TopLevelOnly bool
+	// AssigneeID lists the todos handed to that user. When it is the user
+	// the todos are listed for, the todos of their workspaces are included.
+	AssigneeID string
+	// Filter is an expression in the filter language (see filter.Parse).
+	// Syntax errors are returned as *filter.Error with the position.
+	Filter string
+	Sort   repository.TodoSort
+}
+
+// TodoOption sets an optional attribute on a todo during create or update.
+// Options that are not passed leave the attribute unchanged.
+type TodoOption func(todo *entity.Todo) error
+
+// WithDueAt sets the due date; nil clears it
+func WithDueAt(dueAt *time.Time) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.SetDueAt(dueAt)
+		return nil
+	}
+}
+
+// WithPriority sets the priority
+func WithPriority(priority entity.Priority) TodoOption {
+	return func(todo *entity.Todo) error {
+		return todo.SetPriority(priority)
+	}
+}
+
+// WithTags replaces the todo's tags. Callers should resolve the tags with
+// TagService.ResolveTags so that they belong to the todo's owner.
+func WithTags(tags []*entity.Tag) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.SetTags(tags)
+		return nil
+	}
+}
+
+// WithProject moves the todo into a project; nil removes it from its project.
+// Callers should check with ProjectService.EnsureProject that the project
+// belongs to the todo's owner.
+func WithProject(projectID *string) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.SetProjectID(projectID)
+		return nil
+	}
+}
+
+// WithParent makes the todo a subtask of another todo of the same user; nil
+// makes it top-level. The parent is validated when the todo is saved.
+func WithParent(parentID *string) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.SetParentID(parentID)
+		return nil
+	}
+}
+
+// WithRecurrence sets the repeat rule; nil stops the todo from repeating
+func WithRecurrence(recurrence *entity.Recurrence) TodoOption {
+	return func(todo *entity.Todo) error {
+		return todo.SetRecurrence(recurrence)
+	}
+}
+
+// InWorkspace marks a new todo as shared in the workspace that owns it and
+// records the member who created it
+func InWorkspace(workspaceID, createdBy string) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.WorkspaceID = workspaceID
+		todo.CreatedBy = createdBy
+		return nil
+	}
+}
+
+// WithRemindAt sets the reminder time; nil clears it
+func WithRemindAt(remindAt *time.Time) TodoOption {
+	return func(todo *entity.Todo) error {
+		todo.SetRemindAt(remindAt)
+		return nil
+	}
+}
+
 type TodoService struct {
-	todoRepo repository.TodoRepository
+	todoRepo    repository.TodoRepository
+	memberships MembershipChecker
+	now         func() time.Time
 }
 
 func NewTodoService(todoRepo repository.TodoRepository) *TodoService {
 	return &TodoService{
 		todoRepo: todoRepo,
+		now:      time.Now,
+	}
+}
+
+// NewTodoServiceWithMembership returns a service that also serves the todos
+// of workspaces, checking the members' roles with memberships
+func NewTodoServiceWithMembership(todoRepo repository.TodoRepository, memberships MembershipChecker) *TodoService {
+	service := NewTodoService(todoRepo)
+	service.memberships = memberships
+	return service
+}
+
+// Owner returns the owner key under which the todos, tags and projects a
+// user works on are stored: the user's own ID, or the workspace ID once the
+// user is found to be a member. Viewers may only read.
+func (s *TodoService) Owner(userID, workspaceID string, write bool) (string, error) {
+	if workspaceID == "" {
+		return userID, nil
+	}
+	if s.memberships == nil {
+		return "", ErrWorkspacesNotAvailable
+	}
+
+	role, err := s.memberships.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	switch role {
+	case WorkspaceRoleOwner, WorkspaceRoleMember:
+		return workspaceID, nil
+	case WorkspaceRoleViewer:
+		if write {
+			return "", ErrWorkspaceReadOnly
+		}
+		return workspaceID, nil
 	}
+	return "", ErrWorkspaceAccessDenied
 }
 
-func (s *TodoService) CreateTodo(userID, title, description string) (*entity.Todo, error) {
+// In returns a copy of the service working in the given time zone, so that
+// "today", filter dates and recurrences follow the user's calendar
+func (s *TodoService) In(loc *time.Location) *TodoService {
+	return &TodoService{
+		todoRepo:    s.todoRepo,
+		memberships: s.memberships,
+		now: func() time.Time {
+			return s.now().In(loc)
+		},
+	}
+}
+
+func (s *TodoService) CreateTodo(userID, title, description string, opts ...TodoOption) (*entity.Todo, error) {
 	todoID := uuid.New().String()
 	todo := entity.NewTodo(todoID, userID, title, description)
 
+	if err := applyOptions(todo, opts); err != nil {
+		return nil, err
+	}
+	if err := s.validateParent(todo); err != nil {
+		return nil, err
+	}
+
 	if err := s.todoRepo.Create(todo); err != nil {
 		return nil, err
 	}
@@ -31,6 +261,71 @@ func (s *TodoService) GetTodo(id, userID string) (*entity.Todo, error) {
 	return s.todoRepo.GetByID(id, userID)
 }
 
+// GetTodoTree returns a todo with its whole subtree of subtasks loaded
+func (s *TodoService) GetTodoTree(id, userID string) (*entity.Todo, error) {
+	todo, err := s.todoRepo.GetByID(id, userID)
+	if err != nil || todo == nil {
+		return todo, err
+	}
+
+	// 階層ごとに1回のクエリで子を読み込む
+	level := []*entity.Todo{todo}
+	seen := map[string]bool{todo.ID: true}
+	for len(level) > 0 {
+		byID := make(map[string]*entity.Todo, len(level))
+		parentIDs := make([]string, 0, len(level))
+		for _, parent := range level {
+			byID[parent.ID] = parent
+			parentIDs = append(parentIDs, parent.ID)
+		}
+
+		children, err := s.todoRepo.List(repository.TodoQuery{UserID: userID, ParentIDs: parentIDs})
+		if err != nil {
+			return nil, err
+		}
+
+		subtasks := make(map[string][]*entity.Todo, len(level))
+		var next []*entity.Todo
+		for _, child := range children {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			subtasks[*child.ParentID] = append(subtasks[*child.ParentID], child)
+			next = append(next, child)
+		}
+		for _, parent := range level {
+			parent.SetSubtasks(subtasks[parent.ID])
+		}
+		level = next
+	}
+
+	return todo, nil
+}
+
+// AttachProgress fills in the subtask progress of the given todos. Todos
+// assigned to the user can belong to several owners, so subtasks are counted
+// per owner.
+func (s *TodoService) AttachProgress(todos []*entity.Todo) error {
+	idsByOwner := make(map[string][]string)
+	for _, todo := range todos {
+		idsByOwner[todo.UserID] = append(idsByOwner[todo.UserID], todo.ID)
+	}
+
+	for owner, ids := range idsByOwner {
+		progress, err := s.todoRepo.CountSubtasks(owner, ids)
+		if err != nil {
+			return err
+		}
+		for _, todo := range todos {
+			if todo.UserID == owner {
+				todo.Progress = progress[todo.ID]
+			}
+		}
+	}
+	return nil
+}
+
 func (s *TodoService) ListTodos(userID string) ([]*entity.Todo, error) {
 	return s.todoRepo.ListByUserID(userID)
 }
@@ -39,14 +334,228 @@ func (s *TodoService) ListCompletedTodos(userID string) ([]*entity.Todo, error)
 	return s.todoRepo.ListCompletedByUserID(userID)
 }
 
-func (s *TodoService) UpdateTodo(id, userID, title, description string) (*entity.Todo, error) {
+// ListTodosWithOptions lists a user's todos filtered by completion, due date,
+// tags and project, ordered by the requested sort
+func (s *TodoService) ListTodosWithOptions(userID string, opts ListOptions) ([]*entity.Todo, error) {
+	query, err := s.listQuery(userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.todoRepo.List(query)
+}
+
+// listQuery translates list options into a repository query
+func (s *TodoService) listQuery(userID string, opts ListOptions) (repository.TodoQuery, error) {
+	query := repository.TodoQuery{
+		UserID:        userID,
+		CompletedOnly: opts.CompletedOnly,
+		AnyTagIDs:     opts.AnyTagIDs,
+		AllTagIDs:     opts.AllTagIDs,
+		ProjectID:     opts.ProjectID,
+		NoProject:     opts.NoProject,
+		TopLevelOnly:  opts.TopLevelOnly,
+		AssigneeID:    opts.AssigneeID,
+		Sort:          opts.Sort,
+	}
+	if !opts.Sort.Valid() {
+		return query, ErrInvalidSort
+	}
+	if opts.AssigneeID != "" && opts.AssigneeID == userID {
+		// 他の人が作った Todo も、今も見られるワークスペースのものに限って含める
+		owners, err := s.visibleOwners(userID)
+		if err != nil {
+			return query, err
+		}
+		query.UserIDs = owners
+	}
+	if err := s.applyDueFilter(&query, opts.Due, opts.DueWithinDays); err != nil {
+		return query, err
+	}
+	if opts.Filter != "" {
+		expr, err := filter.Parse(opts.Filter, s.now())
+		if err != nil {
+			return query, err
+		}
+		query.Filter = expr
+	}
+	return query, nil
+}
+
+// visibleOwners returns the owner keys whose todos the user can see: their
+// own and those of their workspaces
+func (s *TodoService) visibleOwners(userID string) ([]string, error) {
+	owners := []string{userID}
+	if s.memberships == nil {
+		return owners, nil
+	}
+
+	workspaceIDs, err := s.memberships.WorkspaceIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(owners, workspaceIDs...), nil
+}
+
+// ListTodosPage lists one page of the todos selected by opts. pageToken is the
+// NextPageToken of the previous page, or empty for the first page.
+func (s *TodoService) ListTodosPage(userID string, opts ListOptions, pageSize int, pageToken string) (*Page, error) {
+	query, err := s.listQuery(userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listPage(query, pageSize, pageToken)
+}
+
+// ListCompletedTodosPage lists one page of completed todos, most recently completed first
+func (s *TodoService) ListCompletedTodosPage(userID string, pageSize int, pageToken string) (*Page, error) {
+	query := repository.TodoQuery{
+		UserID:        userID,
+		CompletedOnly: true,
+		Sort:          repository.TodoSort{Field: repository.SortByCompletedAt},
+	}
+	return s.listPage(query, pageSize, pageToken)
+}
+
+func (s *TodoService) listPage(query repository.TodoQuery, pageSize int, pageToken string) (*Page, error) {
+	if pageSize < 0 || pageSize > MaxPageSize {
+		return nil, ErrInvalidPageSize
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	request := repository.PageRequest{Size: pageSize}
+	if pageToken != "" {
+		cursor, err := repository.DecodeCursor(pageToken)
+		if err != nil || cursor.Order != query.OrderKey() {
+			return nil, ErrInvalidPageToken
+		}
+		request.After = cursor
+	}
+
+	result, err := s.todoRepo.ListPage(query, request)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, ErrInvalidPageToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Todos: result.Todos, Total: result.Total}
+	if result.Next != nil {
+		page.NextPageToken = result.Next.Encode()
+	}
+	return page, nil
+}
+
+// SearchTodos finds a user's todos by the words in their title and
+// description. Words ending in * match as prefixes and double-quoted text
+// matches as a phrase, e.g. `deplo* "on-call rotation"`.
+func (s *TodoService) SearchTodos(userID, query string, limit int) ([]*repository.TodoSearchHit, error) {
+	if limit < 0 || limit > MaxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 0 and %d", ErrInvalidSearch, MaxSearchLimit)
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+
+	terms, err := parseSearchTerms(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.todoRepo.Search(repository.SearchQuery{UserID: userID, Terms: terms, Limit: limit})
+}
+
+// CountTodosByUser counts the todos of each given user, subtasks included.
+// Users without todos are omitted.
+func (s *TodoService) CountTodosByUser(userIDs []string) (map[string]entity.TodoCounts, error) {
+	return s.todoRepo.CountByUserIDs(userIDs)
+}
+
+// DeleteUserData removes everything the user has stored in the todo
+// service, unassigns them from workspace todos and deletes their comments
+// there. It is called when their account is deleted.
+func (s *TodoService) DeleteUserData(userID string) error {
+	if userID == "" {
+		return ErrUserIDRequired
+	}
+	return s.todoRepo.DeleteAllByUserID(userID)
+}
+
+// parseSearchTerms splits a search query into words and quoted phrases
+func parseSearchTerms(query string) ([]repository.SearchTerm, error) {
+	var terms []repository.SearchTerm
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		var term repository.SearchTerm
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidSearch)
+			}
+			term = repository.SearchTerm{Text: strings.TrimSpace(rest[1 : end+1]), Phrase: true}
+			rest = rest[end+2:]
+		} else {
+			word := rest
+			if end := strings.IndexFunc(rest, unicode.IsSpace); end >= 0 {
+				word = rest[:end]
+			}
+			rest = rest[len(word):]
+			term = repository.SearchTerm{Text: strings.TrimRight(word, "*"), Prefix: strings.HasSuffix(word, "*")}
+		}
+		rest = strings.TrimSpace(rest)
+
+		if term.Text == "" {
+			continue
+		}
+		terms = append(terms, term)
+		if len(terms) > maxSearchTerms {
+			return nil, fmt.Errorf("%w: at most %d words", ErrInvalidSearch, maxSearchTerms)
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil, ErrEmptySearch
+	}
+	return terms, nil
+}
+
+// ListOverdueTodos returns open todos whose due date has already passed
+func (s *TodoService) ListOverdueTodos(userID string) ([]*entity.Todo, error) {
+	return s.ListTodosWithOptions(userID, ListOptions{Due: DueOverdue})
+}
+
+// ListTodosDueToday returns open todos due between today's midnight and tomorrow's
+func (s *TodoService) ListTodosDueToday(userID string) ([]*entity.Todo, error) {
+	return s.ListTodosWithOptions(userID, ListOptions{Due: DueToday})
+}
+
+// ListTodosDueWithin returns open todos due from now until the given number of days ahead
+func (s *TodoService) ListTodosDueWithin(userID string, days int) ([]*entity.Todo, error) {
+	return s.ListTodosWithOptions(userID, ListOptions{Due: DueWithin, DueWithinDays: days})
+}
+
+func (s *TodoService) UpdateTodo(id, userID, title, description string, opts ...TodoOption) (*entity.Todo, error) {
 	todo, err := s.todoRepo.GetByID(id, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	previousParent := todo.ParentID
 	todo.Update(title, description)
 
+	if err := applyOptions(todo, opts); err != nil {
+		return nil, err
+	}
+	if !sameID(previousParent, todo.ParentID) {
+		if err := s.validateParent(todo); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.todoRepo.Update(todo); err != nil {
 		return nil, err
 	}
@@ -54,21 +563,242 @@ func (s *TodoService) UpdateTodo(id, userID, title, description string) (*entity
 	return todo, nil
 }
 
+// CompletionResult is the outcome of MarkTodoCompleteWithRule
+type CompletionResult struct {
+	Todo *entity.Todo
+	// Next is the new occurrence spawned by completing a recurring todo
+	Next *entity.Todo
+}
+
+// MarkTodoComplete completes or reopens a todo. A todo with open subtasks
+// cannot be completed; use MarkTodoCompleteWithRule to complete them as well.
 func (s *TodoService) MarkTodoComplete(id, userID string, completed bool) (*entity.Todo, error) {
+	result, err := s.MarkTodoCompleteWithRule(id, userID, completed, SubtaskRuleRequireDone)
+	if err != nil {
+		return nil, err
+	}
+	return result.Todo, nil
+}
+
+// MarkTodoCompleteWithRule completes or reopens a todo, handling open subtasks
+// according to rule. Reopening a todo never touches its subtasks. Completing a
+// recurring todo creates its next occurrence in the same transaction.
+func (s *TodoService) MarkTodoCompleteWithRule(id, userID string, completed bool, rule SubtaskRule) (*CompletionResult, error) {
 	todo, err := s.todoRepo.GetByID(id, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	todo.MarkComplete(completed)
+	if !completed {
+		todo.MarkComplete(false)
+		if err := s.todoRepo.Update(todo); err != nil {
+			return nil, err
+		}
+		return &CompletionResult{Todo: todo}, nil
+	}
+
+	progress, err := s.todoRepo.CountSubtasks(userID, []string{todo.ID})
+	if err != nil {
+		return nil, err
+	}
+	todo.Progress = progress[todo.ID]
+
+	changed := []*entity.Todo{todo}
+	if todo.Progress.Open() > 0 {
+		switch rule {
+		case SubtaskRuleRequireDone:
+			return nil, ErrOpenSubtasks
+		case SubtaskRuleCompleteAll:
+		default:
+			return nil, errors.New("unknown subtask rule")
+		}
+
+		if todo, err = s.GetTodoTree(id, userID); err != nil {
+			return nil, err
+		}
+		changed = completeSubtree(todo)
+	}
+
+	wasOpen := !todo.Completed
+	todo.MarkComplete(true)
+	todo.SetSubtasks(todo.Subtasks)
+
+	var next *entity.Todo
+	if wasOpen {
+		// 曜日や月末の判定は利用者のタイムゾーンで行う
+		loc := s.now().Location()
+		if todo.DueAt != nil {
+			dueAt := todo.DueAt.In(loc)
+			todo.DueAt = &dueAt
+		}
+		next = todo.SpawnNext(uuid.New().String(), todo.CompletedAt.In(loc))
+	}
+
+	// 親と子孫の完了、次回分の作成を1つのトランザクションで保存する
+	switch {
+	case next != nil:
+		err = s.todoRepo.CreateWithUpdates(next, changed)
+	case len(changed) == 1:
+		err = s.todoRepo.Update(todo)
+	default:
+		err = s.todoRepo.UpdateMany(changed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompletionResult{Todo: todo, Next: next}, nil
+}
+
+// completeSubtree marks every open subtask below root complete and returns root
+// together with the subtasks that changed
+func completeSubtree(root *entity.Todo) []*entity.Todo {
+	changed := []*entity.Todo{root}
+	var walk func(t *entity.Todo)
+	walk = func(t *entity.Todo) {
+		for _, subtask := range t.Subtasks {
+			if !subtask.Completed {
+				subtask.MarkComplete(true)
+				changed = append(changed, subtask)
+			}
+			walk(subtask)
+			subtask.SetSubtasks(subtask.Subtasks)
+		}
+	}
+	walk(root)
+	return changed
+}
+
+// AssignTodo hands a todo to a user. A personal todo can only be assigned to
+// its owner; a workspace todo to an owner or member of the workspace.
+func (s *TodoService) AssignTodo(id, userID, assigneeID string) (*entity.Todo, error) {
+	if assigneeID == "" {
+		return nil, ErrAssigneeRequired
+	}
+
+	todo, err := s.todoRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAssignee(todo, assigneeID); err != nil {
+		return nil, err
+	}
 
+	todo.SetAssignee(assigneeID)
 	if err := s.todoRepo.Update(todo); err != nil {
 		return nil, err
 	}
+	return todo, nil
+}
+
+// UnassignTodo takes a todo back from its assignee
+func (s *TodoService) UnassignTodo(id, userID string) (*entity.Todo, error) {
+	todo, err := s.todoRepo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
 
+	todo.SetAssignee("")
+	if err := s.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
 	return todo, nil
 }
 
+// checkAssignee makes sure the assignee can see and work on the todo
+func (s *TodoService) checkAssignee(todo *entity.Todo, assigneeID string) error {
+	if todo.WorkspaceID == "" {
+		if assigneeID != todo.UserID {
+			return ErrInvalidAssignee
+		}
+		return nil
+	}
+	if s.memberships == nil {
+		return ErrWorkspacesNotAvailable
+	}
+
+	role, err := s.memberships.WorkspaceRole(todo.WorkspaceID, assigneeID)
+	if err != nil {
+		return err
+	}
+	if role != WorkspaceRoleOwner && role != WorkspaceRoleMember {
+		return ErrInvalidAssignee
+	}
+	return nil
+}
+
 func (s *TodoService) DeleteTodo(id, userID string) error {
 	return s.todoRepo.Delete(id, userID)
 }
+
+// applyDueFilter translates a relative due filter into an absolute range on the query
+func (s *TodoService) applyDueFilter(query *repository.TodoQuery, filter DueFilter, days int) error {
+	now := s.now()
+
+	switch filter {
+	case DueAny:
+		return nil
+	case DueOverdue:
+		query.DueBefore = &now
+	case DueToday:
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		end := start.AddDate(0, 0, 1)
+		query.DueFrom = &start
+		query.DueBefore = &end
+	case DueWithin:
+		if days < 1 {
+			return ErrInvalidDueWindow
+		}
+		end := now.AddDate(0, 0, days)
+		query.DueFrom = &now
+		query.DueBefore = &end
+	default:
+		return errors.New("unknown due filter")
+	}
+
+	// 期限でのフィルタは未完了のTodoのみを対象とする
+	query.IncompleteOnly = true
+	return nil
+}
+
+// validateParent checks that the todo's parent belongs to the same user and
+// that the parent chain does not loop back to the todo
+func (s *TodoService) validateParent(todo *entity.Todo) error {
+	if todo.ParentID == nil {
+		return nil
+	}
+
+	seen := map[string]bool{todo.ID: true}
+	parentID := *todo.ParentID
+	for {
+		if seen[parentID] {
+			return ErrInvalidParent
+		}
+		seen[parentID] = true
+
+		parent, err := s.todoRepo.GetByID(parentID, todo.UserID)
+		if err != nil || parent == nil {
+			return ErrParentNotFound
+		}
+		if parent.ParentID == nil {
+			return nil
+		}
+		parentID = *parent.ParentID
+	}
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func applyOptions(todo *entity.Todo, opts []TodoOption) error {
+	for _, opt := range opts {
+		if err := opt(todo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
@@ -24,6 +25,7 @@ type DetailedMockTodoRepository struct {
 	updateError error
 	deleteError error
 	callLog     []string // 呼び出しログ（実装テストの特徴）
+	lastSearch  repository.SearchQuery
 }
 
 func NewDetailedMockTodoRepository() *DetailedMockTodoRepository {
@@ -78,6 +80,43 @@ func (m *DetailedMockTodoRepository) ListCompletedByUserID(userID string) ([]*en
 	return completed, nil
 }
 
+func (m *DetailedMockTodoRepository) List(query repository.TodoQuery) ([]*entity.Todo, error) {
+	m.callLog = append(m.callLog, "List")
+	if m.listError != nil {
+		return nil, m.listError
+	}
+	var result []*entity.Todo
+	for _, todo := range m.userTodos[query.UserID] {
+		if query.Matches(todo) {
+			result = append(result, todo)
+		}
+	}
+	return result, nil
+}
+
+func (m *DetailedMockTodoRepository) ListPage(query repository.TodoQuery, page repository.PageRequest) (*repository.TodoPage, error) {
+	m.callLog = append(m.callLog, "ListPage")
+	if m.listError != nil {
+		return nil, m.listError
+	}
+	var result []*entity.Todo
+	for _, todo := range m.userTodos[query.UserID] {
+		if query.Matches(todo) {
+			result = append(result, todo)
+		}
+	}
+	return repository.PaginateInMemory(result, query, page)
+}
+
+func (m *DetailedMockTodoRepository) Search(query repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	m.callLog = append(m.callLog, "Search")
+	m.lastSearch = query
+	if m.listError != nil {
+		return nil, m.listError
+	}
+	return nil, nil
+}
+
 func (m *DetailedMockTodoRepository) Update(todo *entity.Todo) error {
 	m.callLog = append(m.callLog, "Update")
 	if m.updateError != nil {
@@ -87,6 +126,81 @@ func (m *DetailedMockTodoRepository) Update(todo *entity.Todo) error {
 	return nil
 }
 
+func (m *DetailedMockTodoRepository) CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error) {
+	m.callLog = append(m.callLog, "CountSubtasks")
+	progress := make(map[string]entity.SubtaskProgress)
+	for _, parentID := range parentIDs {
+		for _, todo := range m.todos {
+			if todo.UserID != userID || todo.ParentID == nil || *todo.ParentID != parentID {
+				continue
+			}
+			p := progress[parentID]
+			p.Total++
+			if todo.Completed {
+				p.Done++
+			}
+			progress[parentID] = p
+		}
+	}
+	return progress, nil
+}
+
+func (m *DetailedMockTodoRepository) CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error) {
+	m.callLog = append(m.callLog, "CountByUserIDs")
+	counts := make(map[string]entity.TodoCounts)
+	for _, userID := range userIDs {
+		for _, todo := range m.todos {
+			if todo.UserID != userID {
+				continue
+			}
+			c := counts[userID]
+			c.Total++
+			if todo.Completed {
+				c.Completed++
+			}
+			counts[userID] = c
+		}
+	}
+	return counts, nil
+}
+
+func (m *DetailedMockTodoRepository) DeleteAllByUserID(userID string) error {
+	m.callLog = append(m.callLog, "DeleteAllByUserID")
+	for id, todo := range m.todos {
+		if todo.UserID == userID {
+			delete(m.todos, id)
+		}
+	}
+	return nil
+}
+
+func (m *DetailedMockTodoRepository) UpdateMany(todos []*entity.Todo) error {
+	m.callLog = append(m.callLog, "UpdateMany")
+	if m.updateError != nil {
+		return m.updateError
+	}
+	for _, todo := range todos {
+		m.todos[todo.ID] = todo
+	}
+	return nil
+}
+
+func (m *DetailedMockTodoRepository) CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error {
+	m.callLog = append(m.callLog, "CreateWithUpdates")
+	if m.createError != nil {
+		return m.createError
+	}
+	if m.updateError != nil {
+		return m.updateError
+	}
+	m.todos[created.ID] = created
+	m.userTodos[created.UserID] = append(m.userTodos[created.UserID], created)
+	for _, todo := range updated {
+		m.todos[todo.ID] = todo
+	}
+	return nil
+}
+
 func (m *DetailedMockTodoRepository) Delete(id, userID string) error {
 	m.callLog = append(m.callLog, "Delete")
 	if m.deleteError != nil {
@@ -200,10 +314,10 @@ func TestTodoService_Implementation_MarkTodoComplete_CallSequence(t *testing.T)
 	if err != nil {
 		t.Errorf("MarkTodoComplete should succeed: %v", err)
 	}
-	// 実装詳細：GetByID -> Updateの順で呼ばれることを確認
-	expectedCalls := []string{"GetByID", "Update"}
-	if len(mockRepo.callLog) != 2 {
-		t.Errorf("Expected 2 calls, got %d", len(mockRepo.callLog))
+	// 実装詳細：GetByID -> CountSubtasks -> Updateの順で呼ばれることを確認
+	expectedCalls := []string{"GetByID", "CountSubtasks", "Update"}
+	if len(mockRepo.callLog) != 3 {
+		t.Errorf("Expected 3 calls, got %d", len(mockRepo.callLog))
 	}
 	for i, expectedCall := range expectedCalls {
 		if mockRepo.callLog[i] != expectedCall {
@@ -290,3 +404,307 @@ func TestTodoService_Implementation_MockInternalState(t *testing.T) {
 		t.Errorf("User todo should match created todo ID")
 	}
 }
+
+func TestTodoService_Implementation_DueQueries(t *testing.T) {
+	// Arrange - 時刻を固定して期限の境界を検証する
+	mockRepo := NewDetailedMockTodoRepository()
+	todoService := NewTodoService(mockRepo)
+	fixedNow := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	todoService.now = func() time.Time { return fixedNow }
+
+	userID := "user-123"
+	yesterday := fixedNow.Add(-24 * time.Hour)
+	laterToday := fixedNow.Add(5 * time.Hour)
+	inThreeDays := fixedNow.AddDate(0, 0, 3)
+	nextMonth := fixedNow.AddDate(0, 1, 0)
+
+	todoService.CreateTodo(userID, "Overdue", "", WithDueAt(&yesterday))
+	todoService.CreateTodo(userID, "Today", "", WithDueAt(&laterToday))
+	todoService.CreateTodo(userID, "Soon", "", WithDueAt(&inThreeDays))
+	todoService.CreateTodo(userID, "Later", "", WithDueAt(&nextMonth))
+	todoService.CreateTodo(userID, "No due date", "")
+	done, _ := todoService.CreateTodo(userID, "Done overdue", "", WithDueAt(&yesterday))
+	todoService.MarkTodoComplete(done.ID, userID, true)
+
+	// Act & Assert - 期限切れ
+	overdue, err := todoService.ListOverdueTodos(userID)
+	if err != nil {
+		t.Fatalf("ListOverdueTodos should succeed: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].Title != "Overdue" {
+		t.Errorf("Expected only 'Overdue', got %v", titles(overdue))
+	}
+
+	// Act & Assert - 今日が期限
+	today, err := todoService.ListTodosDueToday(userID)
+	if err != nil {
+		t.Fatalf("ListTodosDueToday should succeed: %v", err)
+	}
+	if len(today) != 1 || today[0].Title != "Today" {
+		t.Errorf("Expected only 'Today', got %v", titles(today))
+	}
+
+	// Act & Assert - N日以内
+	week, err := todoService.ListTodosDueWithin(userID, 7)
+	if err != nil {
+		t.Fatalf("ListTodosDueWithin should succeed: %v", err)
+	}
+	if len(week) != 2 {
+		t.Errorf("Expected 'Today' and 'Soon', got %v", titles(week))
+	}
+
+	// 実装詳細：Listが使われることを確認
+	if mockRepo.callLog[len(mockRepo.callLog)-1] != "List" {
+		t.Errorf("Expected List to be called last, got %v", mockRepo.callLog)
+	}
+
+	// Act & Assert - 不正な日数
+	if _, err := todoService.ListTodosDueWithin(userID, 0); err != ErrInvalidDueWindow {
+		t.Errorf("Expected ErrInvalidDueWindow, got %v", err)
+	}
+}
+
+func TestTodoService_Implementation_DueTodayInTimezone(t *testing.T) {
+	// Arrange - UTCでは3/10の夜、東京では3/11の朝
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Time zone data is not available: %v", err)
+	}
+	mockRepo := NewDetailedMockTodoRepository()
+	todoService := NewTodoService(mockRepo)
+	fixedNow := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC)
+	todoService.now = func() time.Time { return fixedNow }
+
+	userID := "user-123"
+	utcToday := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tokyoToday := time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC)
+	todoService.CreateTodo(userID, "Today in UTC", "", WithDueAt(&utcToday))
+	todoService.CreateTodo(userID, "Today in Tokyo", "", WithDueAt(&tokyoToday))
+
+	// Act & Assert - サーバーのタイムゾーン（UTC）の今日
+	today, err := todoService.ListTodosDueToday(userID)
+	if err != nil {
+		t.Fatalf("ListTodosDueToday should succeed: %v", err)
+	}
+	if len(today) != 1 || today[0].Title != "Today in UTC" {
+		t.Errorf("Expected only 'Today in UTC', got %v", titles(today))
+	}
+
+	// Act & Assert - 東京の今日
+	today, err = todoService.In(tokyo).ListTodosDueToday(userID)
+	if err != nil {
+		t.Fatalf("ListTodosDueToday should succeed: %v", err)
+	}
+	if len(today) != 1 || today[0].Title != "Today in Tokyo" {
+		t.Errorf("Expected only 'Today in Tokyo', got %v", titles(today))
+	}
+}
+
+func TestTodoService_Implementation_ListTodosWithOptions_Sort(t *testing.T) {
+	// Arrange
+	mockRepo := NewDetailedMockTodoRepository()
+	todoService := NewTodoService(mockRepo)
+	todoService.CreateTodo("user-123", "High", "", WithPriority(entity.PriorityHigh))
+
+	// Act - 有効なソート指定
+	sort := repository.TodoSort{Field: repository.SortByPriority, Direction: repository.SortDescending}
+	todos, err := todoService.ListTodosWithOptions("user-123", ListOptions{Sort: sort})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListTodosWithOptions should succeed: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Priority != entity.PriorityHigh {
+		t.Errorf("Expected the high priority todo, got %v", titles(todos))
+	}
+
+	// Act & Assert - 不正なソートはリポジトリを呼ばずにエラー
+	mockRepo.callLog = nil
+	_, err = todoService.ListTodosWithOptions("user-123", ListOptions{Sort: repository.TodoSort{Field: "owner"}})
+	if err != ErrInvalidSort {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+	if len(mockRepo.callLog) != 0 {
+		t.Errorf("Repository should not be called for invalid sort, got %v", mockRepo.callLog)
+	}
+
+	// Act & Assert - 不正な優先度での作成は保存されない
+	_, err = todoService.CreateTodo("user-123", "Bad", "", WithPriority(entity.Priority(9)))
+	if err != entity.ErrInvalidPriority {
+		t.Errorf("Expected ErrInvalidPriority, got %v", err)
+	}
+	if len(mockRepo.callLog) != 0 {
+		t.Errorf("Create should not be called for invalid priority, got %v", mockRepo.callLog)
+	}
+}
+
+func titles(todos []*entity.Todo) []string {
+	var result []string
+	for _, todo := range todos {
+		result = append(result, todo.Title)
+	}
+	return result
+}
+
+func TestTodoService_Implementation_Subtasks(t *testing.T) {
+	// Arrange
+	mockRepo := NewDetailedMockTodoRepository()
+	todoService := NewTodoService(mockRepo)
+
+	parent, _ := todoService.CreateTodo("user-123", "Epic", "")
+	child, _ := todoService.CreateTodo("user-123", "Story", "", WithParent(&parent.ID))
+	grandchild, _ := todoService.CreateTodo("user-123", "Task", "", WithParent(&child.ID))
+
+	// Act & Assert - 存在しない親は指定できない
+	missing := "missing"
+	if _, err := todoService.CreateTodo("user-123", "Orphan", "", WithParent(&missing)); err != ErrParentNotFound {
+		t.Errorf("Expected ErrParentNotFound, got %v", err)
+	}
+
+	// Act & Assert - 自分の子孫を親にはできない
+	mockRepo.callLog = nil
+	if _, err := todoService.UpdateTodo(parent.ID, "user-123", "", "", WithParent(&grandchild.ID)); err != ErrInvalidParent {
+		t.Errorf("Expected ErrInvalidParent, got %v", err)
+	}
+	for _, call := range mockRepo.callLog {
+		if call == "Update" {
+			t.Errorf("Update should not be called for an invalid parent, got %v", mockRepo.callLog)
+		}
+	}
+
+	// Act & Assert - 子が未完了なら完了を拒否する
+	mockRepo.callLog = nil
+	if _, err := todoService.MarkTodoComplete(parent.ID, "user-123", true); err != ErrOpenSubtasks {
+		t.Errorf("Expected ErrOpenSubtasks, got %v", err)
+	}
+	if mockRepo.callLog[len(mockRepo.callLog)-1] != "CountSubtasks" {
+		t.Errorf("Expected to stop after CountSubtasks, got %v", mockRepo.callLog)
+	}
+
+	// Act & Assert - 子孫をまとめて完了にする場合は UpdateMany を1回だけ呼ぶ
+	mockRepo.callLog = nil
+	result, err := todoService.MarkTodoCompleteWithRule(parent.ID, "user-123", true, SubtaskRuleCompleteAll)
+	if err != nil {
+		t.Fatalf("MarkTodoCompleteWithRule should succeed: %v", err)
+	}
+	tree := result.Todo
+	updates := 0
+	for _, call := range mockRepo.callLog {
+		if call == "Update" || call == "UpdateMany" {
+			updates++
+		}
+	}
+	if updates != 1 || mockRepo.callLog[len(mockRepo.callLog)-1] != "UpdateMany" {
+		t.Errorf("Expected a single UpdateMany, got %v", mockRepo.callLog)
+	}
+	if !grandchild.Completed || !tree.Completed {
+		t.Errorf("Expected the whole subtree to be completed")
+	}
+	if tree.Progress.Done != 1 || tree.Progress.Total != 1 {
+		t.Errorf("Expected progress 1/1, got %+v", tree.Progress)
+	}
+}
+
+func TestTodoService_Implementation_RecurringCompletion(t *testing.T) {
+	// Arrange
+	mockRepo := NewDetailedMockTodoRepository()
+	todoService := NewTodoService(mockRepo)
+
+	due := time.Now().Add(time.Hour)
+	todo, err := todoService.CreateTodo("user-123", "Rotate on-call", "",
+		WithDueAt(&due),
+		WithRecurrence(&entity.Recurrence{Frequency: entity.FrequencyDaily, Interval: 7}))
+	if err != nil {
+		t.Fatalf("CreateTodo should succeed: %v", err)
+	}
+	mockRepo.callLog = nil
+
+	// Act
+	result, err := todoService.MarkTodoCompleteWithRule(todo.ID, "user-123", true, SubtaskRuleRequireDone)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("MarkTodoCompleteWithRule should succeed: %v", err)
+	}
+	// 実装詳細：完了と次回分の作成は CreateWithUpdates 1回で保存される
+	expectedCalls := []string{"GetByID", "CountSubtasks", "CreateWithUpdates"}
+	if len(mockRepo.callLog) != len(expectedCalls) {
+		t.Fatalf("Expected calls %v, got %v", expectedCalls, mockRepo.callLog)
+	}
+	for i, expectedCall := range expectedCalls {
+		if mockRepo.callLog[i] != expectedCall {
+			t.Errorf("Expected call %d to be %s, got %s", i, expectedCall, mockRepo.callLog[i])
+		}
+	}
+	if result.Next == nil || !result.Next.DueAt.Equal(due.AddDate(0, 0, 7)) {
+		t.Errorf("Expected next occurrence a week later, got %v", result.Next)
+	}
+
+	// 再度完了にしても次回分は増えない
+	todoService.MarkTodoComplete(todo.ID, "user-123", false)
+	mockRepo.callLog = nil
+	again, _ := todoService.MarkTodoCompleteWithRule(todo.ID, "user-123", true, SubtaskRuleRequireDone)
+	if again.Next != nil {
+		t.Errorf("Expected no new occurrence when completing again")
+	}
+
+	// 保存に失敗した場合はエラーを返す
+	mockRepo.createError = errors.New("disk full")
+	next := result.Next
+	if _, err := todoService.MarkTodoCompleteWithRule(next.ID, "user-123", true, SubtaskRuleRequireDone); err == nil {
+		t.Error("Expected error when the next occurrence cannot be saved")
+	}
+}
+
+func TestTodoService_Implementation_SearchTodos_ParsesQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []repository.SearchTerm
+		err      error
+	}{
+		{"words", "  deploy   api ", []repository.SearchTerm{{Text: "deploy"}, {Text: "api"}}, nil},
+		{"prefix", "deplo*", []repository.SearchTerm{{Text: "deplo", Prefix: true}}, nil},
+		{"phrase", `"on-call rotation" weekly`, []repository.SearchTerm{{Text: "on-call rotation", Phrase: true}, {Text: "weekly"}}, nil},
+		{"lone asterisk is ignored", "* patch", []repository.SearchTerm{{Text: "patch"}}, nil},
+		{"empty", "   ", nil, ErrEmptySearch},
+		{"empty phrase", `""`, nil, ErrEmptySearch},
+		{"unterminated quote", `"on-call`, nil, ErrInvalidSearch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewDetailedMockTodoRepository()
+			todoService := NewTodoService(mockRepo)
+
+			// Act
+			_, err := todoService.SearchTodos("user-123", tt.query, 0)
+
+			// Assert
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Expected %v, got %v", tt.err, err)
+				}
+				if len(mockRepo.callLog) != 0 {
+					t.Errorf("Repository should not be called for an invalid query, got %v", mockRepo.callLog)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SearchTodos should succeed: %v", err)
+			}
+			if mockRepo.lastSearch.Limit != DefaultSearchLimit {
+				t.Errorf("Expected default limit %d, got %d", DefaultSearchLimit, mockRepo.lastSearch.Limit)
+			}
+			if len(mockRepo.lastSearch.Terms) != len(tt.expected) {
+				t.Fatalf("Expected terms %v, got %v", tt.expected, mockRepo.lastSearch.Terms)
+			}
+			for i, term := range tt.expected {
+				if mockRepo.lastSearch.Terms[i] != term {
+					t.Errorf("Term %d: expected %v, got %v", i, term, mockRepo.lastSearch.Terms[i])
+				}
+			}
+		})
+	}
+}
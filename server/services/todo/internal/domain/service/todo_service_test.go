@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"errors"
+	"sort"
 	"testing"
 
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
@@ -49,6 +50,22 @@ func (m *SimpleMockRepository) ListByUserID(userID string) ([]*entity.Todo, erro
 	return result, nil
 }
 
+func (m *SimpleMockRepository) ListPage(query repository.TodoQuery, page repository.PageRequest) (*repository.TodoPage, error) {
+	todos, _ := m.List(query)
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+	return repository.PaginateInMemory(todos, query, page)
+}
+
+func (m *SimpleMockRepository) Search(query repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	var hits []*repository.TodoSearchHit
+	for _, todo := range m.todos {
+		if query.Matches(todo) {
+			hits = append(hits, &repository.TodoSearchHit{Todo: todo, TitleHighlight: todo.Title})
+		}
+	}
+	return hits, nil
+}
+
 func (m *SimpleMockRepository) ListCompletedByUserID(userID string) ([]*entity.Todo, error) {
 	var result []*entity.Todo
 	for _, todo := range m.todos {
@@ -59,11 +76,78 @@ func (m *SimpleMockRepository) ListCompletedByUserID(userID string) ([]*entity.T
 	return result, nil
 }
 
+func (m *SimpleMockRepository) List(query repository.TodoQuery) ([]*entity.Todo, error) {
+	var result []*entity.Todo
+	for _, todo := range m.todos {
+		if query.Matches(todo) {
+			result = append(result, todo)
+		}
+	}
+	return result, nil
+}
+
 func (m *SimpleMockRepository) Update(todo *entity.Todo) error {
 	m.todos[todo.ID] = todo
 	return nil
 }
 
+func (m *SimpleMockRepository) CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error) {
+	progress := make(map[string]entity.SubtaskProgress)
+	for _, parentID := range parentIDs {
+		for _, todo := range m.todos {
+			if todo.UserID != userID || todo.ParentID == nil || *todo.ParentID != parentID {
+				continue
+			}
+			p := progress[parentID]
+			p.Total++
+			if todo.Completed {
+				p.Done++
+			}
+			progress[parentID] = p
+		}
+	}
+	return progress, nil
+}
+
+func (m *SimpleMockRepository) CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error) {
+	counts := make(map[string]entity.TodoCounts)
+	for _, userID := range userIDs {
+		for _, todo := range m.todos {
+			if todo.UserID != userID {
+				continue
+			}
+			c := counts[userID]
+			c.Total++
+			if todo.Completed {
+				c.Completed++
+			}
+			counts[userID] = c
+		}
+	}
+	return counts, nil
+}
+
+func (m *SimpleMockRepository) DeleteAllByUserID(userID string) error {
+	for id, todo := range m.todos {
+		if todo.UserID == userID {
+			delete(m.todos, id)
+		}
+	}
+	return nil
+}
+
+func (m *SimpleMockRepository) UpdateMany(todos []*entity.Todo) error {
+	for _, todo := range todos {
+		m.todos[todo.ID] = todo
+	}
+	return nil
+}
+
+func (m *SimpleMockRepository) CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error {
+	m.todos[created.ID] = created
+	return m.UpdateMany(updated)
+}
+
 func (m *SimpleMockRepository) Delete(id, userID string) error {
 	todo, exists := m.todos[id]
 	if !exists || todo.UserID != userID {
@@ -275,3 +359,172 @@ func TestTodoService_UserIsolation(t *testing.T) {
 		t.Errorf("User2 should not update User1's todo")
 	}
 }
+
+func TestTodoService_ListTodosPage(t *testing.T) {
+	// Arrange
+	repo := NewSimpleMockRepository()
+	todoService := service.NewTodoService(repo)
+
+	userID := "user-123"
+	for i := 0; i < 3; i++ {
+		todoService.CreateTodo(userID, "Todo", "")
+	}
+
+	// Act - 1ページ目
+	first, err := todoService.ListTodosPage(userID, service.ListOptions{}, 2, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListTodosPage should succeed: %v", err)
+	}
+	if len(first.Todos) != 2 || first.Total != 3 || first.NextPageToken == "" {
+		t.Fatalf("Expected 2 of 3 todos with a next page, got %d of %d", len(first.Todos), first.Total)
+	}
+
+	// Act - 2ページ目
+	second, err := todoService.ListTodosPage(userID, service.ListOptions{}, 2, first.NextPageToken)
+	if err != nil {
+		t.Fatalf("ListTodosPage should succeed: %v", err)
+	}
+	if len(second.Todos) != 1 || second.NextPageToken != "" {
+		t.Errorf("Expected last page with 1 todo, got %d", len(second.Todos))
+	}
+
+	// 別の並び順のトークンは使えない
+	byTitle := service.ListOptions{Sort: repository.TodoSort{Field: repository.SortByTitle}}
+	if _, err := todoService.ListTodosPage(userID, byTitle, 2, first.NextPageToken); !errors.Is(err, service.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+	}
+	if _, err := todoService.ListTodosPage(userID, service.ListOptions{}, 2, "garbage"); !errors.Is(err, service.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for garbage token, got %v", err)
+	}
+	if _, err := todoService.ListTodosPage(userID, service.ListOptions{}, service.MaxPageSize+1, ""); !errors.Is(err, service.ErrInvalidPageSize) {
+		t.Errorf("Expected ErrInvalidPageSize, got %v", err)
+	}
+}
+
+// fakeMemberships はワークスペースごとのメンバーの役割を返す
+type fakeMemberships map[string]map[string]string
+
+func (f fakeMemberships) WorkspaceRole(workspaceID, userID string) (string, error) {
+	return f[workspaceID][userID], nil
+}
+
+func (f fakeMemberships) WorkspaceIDs(userID string) ([]string, error) {
+	var ids []string
+	for workspaceID, members := range f {
+		if members[userID] != "" {
+			ids = append(ids, workspaceID)
+		}
+	}
+	return ids, nil
+}
+
+func TestTodoService_Owner(t *testing.T) {
+	// Arrange
+	memberships := fakeMemberships{"ws-1": {"owner": "owner", "member": "member", "viewer": "viewer"}}
+	todoService := service.NewTodoServiceWithMembership(NewSimpleMockRepository(), memberships)
+
+	// Act & Assert - ワークスペースを指定しなければ本人のTodo
+	if owner, err := todoService.Owner("user-1", "", true); err != nil || owner != "user-1" {
+		t.Errorf("Expected the user's own todos, got %q (%v)", owner, err)
+	}
+
+	// Act & Assert - オーナーとメンバーは読み書きできる
+	for _, userID := range []string{"owner", "member"} {
+		if owner, err := todoService.Owner(userID, "ws-1", true); err != nil || owner != "ws-1" {
+			t.Errorf("Expected %s to write to the workspace, got %q (%v)", userID, owner, err)
+		}
+	}
+
+	// Act & Assert - 閲覧者は読むだけ
+	if owner, err := todoService.Owner("viewer", "ws-1", false); err != nil || owner != "ws-1" {
+		t.Errorf("Expected the viewer to read the workspace, got %q (%v)", owner, err)
+	}
+	if _, err := todoService.Owner("viewer", "ws-1", true); err != service.ErrWorkspaceReadOnly {
+		t.Errorf("Expected ErrWorkspaceReadOnly, got %v", err)
+	}
+
+	// Act & Assert - メンバーでなければ拒否する
+	if _, err := todoService.Owner("outsider", "ws-1", false); err != service.ErrWorkspaceAccessDenied {
+		t.Errorf("Expected ErrWorkspaceAccessDenied, got %v", err)
+	}
+
+	// Act & Assert - メンバーを確認できなければワークスペースは使えない
+	if _, err := service.NewTodoService(NewSimpleMockRepository()).Owner("owner", "ws-1", false); err != service.ErrWorkspacesNotAvailable {
+		t.Errorf("Expected ErrWorkspacesNotAvailable, got %v", err)
+	}
+}
+
+func TestTodoService_AssignTodo(t *testing.T) {
+	// Arrange
+	memberships := fakeMemberships{"ws-1": {"alice": "owner", "bob": "member", "carol": "viewer"}}
+	todoService := service.NewTodoServiceWithMembership(NewSimpleMockRepository(), memberships)
+	personal, _ := todoService.CreateTodo("alice", "Personal", "")
+	shared, _ := todoService.CreateTodo("ws-1", "Shared", "", service.InWorkspace("ws-1", "alice"))
+
+	// Act & Assert - 個人のTodoは本人にしか割り当てられない
+	if _, err := todoService.AssignTodo(personal.ID, "alice", "bob"); err != service.ErrInvalidAssignee {
+		t.Errorf("Expected ErrInvalidAssignee, got %v", err)
+	}
+	if todo, err := todoService.AssignTodo(personal.ID, "alice", "alice"); err != nil || todo.AssigneeID != "alice" {
+		t.Errorf("Expected the todo to be assigned to alice, got %+v (%v)", todo, err)
+	}
+
+	// Act & Assert - ワークスペースのTodoは編集できるメンバーに割り当てられる
+	if todo, err := todoService.AssignTodo(shared.ID, "ws-1", "bob"); err != nil || todo.AssigneeID != "bob" {
+		t.Errorf("Expected the todo to be assigned to bob, got %+v (%v)", todo, err)
+	}
+	for _, assigneeID := range []string{"carol", "outsider"} {
+		if _, err := todoService.AssignTodo(shared.ID, "ws-1", assigneeID); err != service.ErrInvalidAssignee {
+			t.Errorf("Expected ErrInvalidAssignee for %s, got %v", assigneeID, err)
+		}
+	}
+	if _, err := todoService.AssignTodo(shared.ID, "ws-1", ""); err != service.ErrAssigneeRequired {
+		t.Errorf("Expected ErrAssigneeRequired, got %v", err)
+	}
+
+	// Act & Assert - 割り当てを外す
+	if todo, err := todoService.UnassignTodo(shared.ID, "ws-1"); err != nil || todo.AssigneeID != "" {
+		t.Errorf("Expected the todo to be unassigned, got %+v (%v)", todo, err)
+	}
+}
+
+func TestTodoService_ListTodosAssignedToMe(t *testing.T) {
+	// Arrange
+	memberships := fakeMemberships{"ws-1": {"alice": "owner", "bob": "member"}}
+	repo := NewSimpleMockRepository()
+	todoService := service.NewTodoServiceWithMembership(repo, memberships)
+	own, _ := todoService.CreateTodo("bob", "Own", "")
+	todoService.AssignTodo(own.ID, "bob", "bob")
+	delegated, _ := todoService.CreateTodo("ws-1", "Delegated", "", service.InWorkspace("ws-1", "alice"))
+	todoService.AssignTodo(delegated.ID, "ws-1", "bob")
+	todoService.CreateTodo("ws-1", "Unassigned", "", service.InWorkspace("ws-1", "alice"))
+	// 抜けたワークスペースで割り当てられたままのTodo
+	left := entity.NewTodo("left", "ws-2", "Left behind", "")
+	left.WorkspaceID = "ws-2"
+	left.AssigneeID = "bob"
+	repo.Create(left)
+
+	// Act
+	todos, err := todoService.ListTodosWithOptions("bob", service.ListOptions{AssigneeID: "bob"})
+
+	// Assert - 自分のTodoと、見られるワークスペースで割り当てられたTodoだけ
+	if err != nil {
+		t.Fatalf("ListTodosWithOptions failed: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("Expected 2 assigned todos, got %d", len(todos))
+	}
+	for _, todo := range todos {
+		if todo.ID != own.ID && todo.ID != delegated.ID {
+			t.Errorf("Unexpected todo %s in the assigned list", todo.Title)
+		}
+	}
+
+	// Act & Assert - ワークスペースの中ではそのワークスペースの分だけ
+	todos, err = todoService.ListTodosWithOptions("ws-1", service.ListOptions{AssigneeID: "bob"})
+	if err != nil || len(todos) != 1 || todos[0].ID != delegated.ID {
+		t.Errorf("Expected only the delegated todo in ws-1, got %d todos (%v)", len(todos), err)
+	}
+}
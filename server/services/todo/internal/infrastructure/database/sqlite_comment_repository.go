@@ -0,0 +1,92 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+const commentColumns = `id, todo_id, author_id, body, created_at, edited_at, deleted_at`
+
+// SQLiteCommentRepository stores comments in the same database as their
+// todos. The table is created by NewSQLiteTodoRepository.
+type SQLiteCommentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteCommentRepository(todoRepo *SQLiteTodoRepository) *SQLiteCommentRepository {
+	return &SQLiteCommentRepository{db: todoRepo.db}
+}
+
+func (r *SQLiteCommentRepository) Create(comment *entity.Comment) error {
+	query := `
+	INSERT INTO todo_comments (` + commentColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(query, comment.ID, comment.TodoID, comment.AuthorID, comment.Body,
+		comment.CreatedAt.Format(time.RFC3339), formatNullableTime(comment.EditedAt),
+		formatNullableTime(comment.DeletedAt))
+	return err
+}
+
+func (r *SQLiteCommentRepository) GetByID(id, todoID string) (*entity.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM todo_comments WHERE id = ? AND todo_id = ?`
+
+	comment, err := scanComment(r.db.QueryRow(query, id, todoID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrCommentNotFound
+	}
+	return comment, err
+}
+
+func (r *SQLiteCommentRepository) ListByTodoID(todoID string) ([]*entity.Comment, error) {
+	// 同じ秒に投稿されたコメントは追加した順に並べる
+	query := `SELECT ` + commentColumns + ` FROM todo_comments WHERE todo_id = ? ORDER BY created_at, rowid`
+
+	rows, err := r.db.Query(query, todoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*entity.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *SQLiteCommentRepository) Update(comment *entity.Comment) error {
+	query := `UPDATE todo_comments SET body = ?, edited_at = ?, deleted_at = ? WHERE id = ? AND todo_id = ?`
+
+	result, err := r.db.Exec(query, comment.Body, formatNullableTime(comment.EditedAt),
+		formatNullableTime(comment.DeletedAt), comment.ID, comment.TodoID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, repository.ErrCommentNotFound)
+}
+
+func scanComment(scanner rowScanner) (*entity.Comment, error) {
+	var comment entity.Comment
+	var createdAt string
+	var editedAt, deletedAt sql.NullString
+
+	err := scanner.Scan(&comment.ID, &comment.TodoID, &comment.AuthorID, &comment.Body,
+		&createdAt, &editedAt, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	comment.EditedAt = parseNullableTime(editedAt)
+	comment.DeletedAt = parseNullableTime(deletedAt)
+	return &comment, nil
+}
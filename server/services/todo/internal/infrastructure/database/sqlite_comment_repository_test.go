@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
+)
+
+func TestCommentRepository_Thread(t *testing.T) {
+	// Arrange
+	dbPath := "test_comments_thread.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+
+	var repo repository.CommentRepository = database.NewSQLiteCommentRepository(todoRepo)
+
+	parent := entity.NewTodo("todo-1", "user-123", "Release", "")
+	todoRepo.Create(parent)
+	subtask := entity.NewTodo("todo-2", "user-123", "Changelog", "")
+	subtask.SetParentID(&parent.ID)
+	todoRepo.Create(subtask)
+
+	// Act & Assert - 同じ秒に追加したコメントも追加した順に並ぶ
+	for _, id := range []string{"c-3", "c-1", "c-2"} {
+		comment, _ := entity.NewComment(id, "todo-1", "user-123", "Comment "+id)
+		if err := repo.Create(comment); err != nil {
+			t.Fatalf("Create should succeed: %v", err)
+		}
+	}
+	comments, err := repo.ListByTodoID("todo-1")
+	if err != nil {
+		t.Fatalf("ListByTodoID should succeed: %v", err)
+	}
+	if len(comments) != 3 || comments[0].ID != "c-3" || comments[2].ID != "c-2" {
+		t.Errorf("Expected the comments in the order they were added, got %v", comments)
+	}
+
+	// Act & Assert - 編集と論理削除が保存される
+	comment, _ := repo.GetByID("c-1", "todo-1")
+	comment.Edit("Edited")
+	comment.Delete()
+	if err := repo.Update(comment); err != nil {
+		t.Fatalf("Update should succeed: %v", err)
+	}
+	retrieved, err := repo.GetByID("c-1", "todo-1")
+	if err != nil {
+		t.Fatalf("GetByID should succeed: %v", err)
+	}
+	if retrieved.Body != "Edited" || retrieved.EditedAt == nil || !retrieved.Deleted() {
+		t.Errorf("Expected an edited and deleted comment, got %+v", retrieved)
+	}
+
+	// Act & Assert - 別のTodoのコメントとしては見つからない
+	if _, err := repo.GetByID("c-1", "todo-2"); err != repository.ErrCommentNotFound {
+		t.Errorf("Expected ErrCommentNotFound, got %v", err)
+	}
+
+	// Act & Assert - Todoを削除するとサブタスクのものも含めてコメントが消える
+	onSubtask, _ := entity.NewComment("c-4", "todo-2", "user-123", "Draft ready")
+	repo.Create(onSubtask)
+	if err := todoRepo.Delete("todo-1", "user-123"); err != nil {
+		t.Fatalf("Delete should succeed: %v", err)
+	}
+	for _, todoID := range []string{"todo-1", "todo-2"} {
+		if comments, _ := repo.ListByTodoID(todoID); len(comments) != 0 {
+			t.Errorf("Expected the comments on %s to be deleted, got %d", todoID, len(comments))
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+const projectColumns = `id, user_id, name, description, created_at, updated_at`
+
+// SQLiteProjectRepository stores projects in the same database as their
+// todos. The table is created by NewSQLiteTodoRepository.
+type SQLiteProjectRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteProjectRepository(todoRepo *SQLiteTodoRepository) *SQLiteProjectRepository {
+	return &SQLiteProjectRepository{db: todoRepo.db}
+}
+
+func (r *SQLiteProjectRepository) Create(project *entity.Project) error {
+	query := `
+	INSERT INTO projects (` + projectColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(query, project.ID, project.UserID, project.Name, project.Description,
+		project.CreatedAt.Format(time.RFC3339), project.UpdatedAt.Format(time.RFC3339))
+	return err
+}
+
+func (r *SQLiteProjectRepository) GetByID(id, userID string) (*entity.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ? AND user_id = ?`
+
+	project, err := scanProject(r.db.QueryRow(query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrProjectNotFound
+	}
+	return project, err
+}
+
+func (r *SQLiteProjectRepository) ListByUserID(userID string) ([]*entity.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE user_id = ? ORDER BY name COLLATE NOCASE, id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*entity.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *SQLiteProjectRepository) Update(project *entity.Project) error {
+	query := `UPDATE projects SET name = ?, description = ?, updated_at = ? WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(query, project.Name, project.Description,
+		project.UpdatedAt.Format(time.RFC3339), project.ID, project.UserID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, repository.ErrProjectNotFound)
+}
+
+func (r *SQLiteProjectRepository) Delete(id, userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM projects WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result, repository.ErrProjectNotFound); err != nil {
+		return err
+	}
+
+	// Todoは削除せずプロジェクトから外す
+	if _, err := tx.Exec(`UPDATE todos SET project_id = NULL WHERE project_id = ? AND user_id = ?`, id, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLiteProjectRepository) CountTodos(userID string) (map[string]entity.ProjectCounts, error) {
+	query := `
+	SELECT project_id,
+		SUM(CASE WHEN completed THEN 0 ELSE 1 END),
+		SUM(CASE WHEN completed THEN 1 ELSE 0 END)
+	FROM todos
+	WHERE user_id = ? AND project_id IS NOT NULL
+	GROUP BY project_id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]entity.ProjectCounts)
+	for rows.Next() {
+		var projectID string
+		var c entity.ProjectCounts
+		if err := rows.Scan(&projectID, &c.Open, &c.Completed); err != nil {
+			return nil, err
+		}
+		counts[projectID] = c
+	}
+
+	return counts, rows.Err()
+}
+
+func scanProject(scanner rowScanner) (*entity.Project, error) {
+	var project entity.Project
+	var createdAt, updatedAt string
+
+	err := scanner.Scan(&project.ID, &project.UserID, &project.Name, &project.Description, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	project.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	project.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &project, nil
+}
@@ -0,0 +1,124 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
+)
+
+func TestProjectRepository_CRUD(t *testing.T) {
+	// Arrange
+	dbPath := "test_projects_crud.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+
+	var repo repository.ProjectRepository = database.NewSQLiteProjectRepository(todoRepo)
+
+	project, _ := entity.NewProject("project-1", "user-123", "Website", "Relaunch")
+
+	// Act & Assert - Create / GetByID
+	if err := repo.Create(project); err != nil {
+		t.Fatalf("Create should succeed: %v", err)
+	}
+	retrieved, err := repo.GetByID(project.ID, "user-123")
+	if err != nil {
+		t.Fatalf("GetByID should succeed: %v", err)
+	}
+	if retrieved.Name != "Website" || retrieved.Description != "Relaunch" {
+		t.Errorf("Unexpected project: %+v", retrieved)
+	}
+	if _, err := repo.GetByID(project.ID, "user-456"); err != repository.ErrProjectNotFound {
+		t.Errorf("Expected ErrProjectNotFound for other user, got %v", err)
+	}
+
+	// Act & Assert - Update
+	project.Update("Marketing", "")
+	if err := repo.Update(project); err != nil {
+		t.Errorf("Update should succeed: %v", err)
+	}
+	projects, _ := repo.ListByUserID("user-123")
+	if len(projects) != 1 || projects[0].Name != "Marketing" {
+		t.Errorf("Expected renamed project in list, got %v", projects)
+	}
+
+	// Act & Assert - Delete
+	if err := repo.Delete(project.ID, "user-123"); err != nil {
+		t.Errorf("Delete should succeed: %v", err)
+	}
+	if err := repo.Delete(project.ID, "user-123"); err != repository.ErrProjectNotFound {
+		t.Errorf("Expected ErrProjectNotFound on second delete, got %v", err)
+	}
+}
+
+func TestProjectRepository_TodosAndCounts(t *testing.T) {
+	// Arrange
+	dbPath := "test_projects_todos.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+	projectRepo := database.NewSQLiteProjectRepository(todoRepo)
+
+	userID := "user-123"
+	project, _ := entity.NewProject("project-1", userID, "Website", "")
+	projectRepo.Create(project)
+
+	open := entity.NewTodo("todo-open", userID, "Open", "")
+	open.SetProjectID(&project.ID)
+	done := entity.NewTodo("todo-done", userID, "Done", "")
+	done.SetProjectID(&project.ID)
+	done.MarkComplete(true)
+	inbox := entity.NewTodo("todo-inbox", userID, "Inbox", "")
+	todoRepo.Create(open)
+	todoRepo.Create(done)
+	todoRepo.Create(inbox)
+
+	// Act & Assert - project_id が読み込まれる
+	retrieved, _ := todoRepo.GetByID("todo-open", userID)
+	if !retrieved.InProject(project.ID) {
+		t.Errorf("Expected todo to be in project, got %v", retrieved.ProjectID)
+	}
+
+	// Act & Assert - プロジェクトでの絞り込み
+	inProject, err := todoRepo.List(repository.TodoQuery{UserID: userID, ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(inProject) != 2 {
+		t.Errorf("Expected 2 todos in project, got %d", len(inProject))
+	}
+	noProject, _ := todoRepo.List(repository.TodoQuery{UserID: userID, NoProject: true})
+	if len(noProject) != 1 || noProject[0].ID != "todo-inbox" {
+		t.Errorf("Expected only todo-inbox without project, got %v", noProject)
+	}
+
+	// Act & Assert - 件数の集計
+	counts, err := projectRepo.CountTodos(userID)
+	if err != nil {
+		t.Fatalf("CountTodos should succeed: %v", err)
+	}
+	if c := counts[project.ID]; c.Open != 1 || c.Completed != 1 {
+		t.Errorf("Expected 1 open and 1 completed, got %+v", c)
+	}
+
+	// Act & Assert - プロジェクト削除でTodoはプロジェクトから外れる
+	projectRepo.Delete(project.ID, userID)
+	retrieved, err = todoRepo.GetByID("todo-done", userID)
+	if err != nil {
+		t.Fatalf("Todo should survive project deletion: %v", err)
+	}
+	if retrieved.ProjectID != nil {
+		t.Errorf("Expected project to be cleared, got %v", *retrieved.ProjectID)
+	}
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+const savedViewColumns = `id, user_id, name, icon, criteria, created_at, updated_at`
+
+// SQLiteSavedViewRepository stores saved views in the same database as the
+// todos they list. Criteria are kept as JSON. The table is created by
+// NewSQLiteTodoRepository.
+type SQLiteSavedViewRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteSavedViewRepository(todoRepo *SQLiteTodoRepository) *SQLiteSavedViewRepository {
+	return &SQLiteSavedViewRepository{db: todoRepo.db}
+}
+
+func (r *SQLiteSavedViewRepository) Create(view *entity.SavedView) error {
+	criteria, err := json.Marshal(view.Criteria)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO saved_views (` + savedViewColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err = r.db.Exec(query, view.ID, view.UserID, view.Name, view.Icon, string(criteria),
+		view.CreatedAt.Format(time.RFC3339), view.UpdatedAt.Format(time.RFC3339))
+	return err
+}
+
+func (r *SQLiteSavedViewRepository) GetByID(id, userID string) (*entity.SavedView, error) {
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE id = ? AND user_id = ?`
+
+	view, err := scanSavedView(r.db.QueryRow(query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSavedViewNotFound
+	}
+	return view, err
+}
+
+func (r *SQLiteSavedViewRepository) ListByUserID(userID string) ([]*entity.SavedView, error) {
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE user_id = ? ORDER BY name COLLATE NOCASE, id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*entity.SavedView
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+func (r *SQLiteSavedViewRepository) Update(view *entity.SavedView) error {
+	criteria, err := json.Marshal(view.Criteria)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE saved_views SET name = ?, icon = ?, criteria = ?, updated_at = ? WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(query, view.Name, view.Icon, string(criteria),
+		view.UpdatedAt.Format(time.RFC3339), view.ID, view.UserID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, repository.ErrSavedViewNotFound)
+}
+
+func (r *SQLiteSavedViewRepository) Delete(id, userID string) error {
+	result, err := r.db.Exec(`DELETE FROM saved_views WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, repository.ErrSavedViewNotFound)
+}
+
+func scanSavedView(scanner rowScanner) (*entity.SavedView, error) {
+	var view entity.SavedView
+	var criteria, createdAt, updatedAt string
+
+	err := scanner.Scan(&view.ID, &view.UserID, &view.Name, &view.Icon, &criteria, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(criteria), &view.Criteria); err != nil {
+		return nil, err
+	}
+	view.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	view.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &view, nil
+}
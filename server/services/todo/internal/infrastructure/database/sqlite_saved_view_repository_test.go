@@ -0,0 +1,69 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
+)
+
+func TestSavedViewRepository_CRUD(t *testing.T) {
+	// Arrange
+	dbPath := "test_saved_views_crud.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+
+	var repo repository.SavedViewRepository = database.NewSQLiteSavedViewRepository(todoRepo)
+
+	criteria := entity.ViewCriteria{
+		Completion:    entity.ViewCompletionOpen,
+		UpdatedBefore: "-14d",
+		TitleContains: "deploy",
+		Filter:        "priority>=high",
+		Sort:          "updated_at",
+		Order:         "asc",
+	}
+	view, _ := entity.NewSavedView("view-1", "user-123", "Stale", "🕸", criteria)
+
+	// Act & Assert - Create / GetByID
+	if err := repo.Create(view); err != nil {
+		t.Fatalf("Create should succeed: %v", err)
+	}
+	retrieved, err := repo.GetByID(view.ID, "user-123")
+	if err != nil {
+		t.Fatalf("GetByID should succeed: %v", err)
+	}
+	// 条件はそのまま復元される
+	if retrieved.Name != "Stale" || retrieved.Icon != "🕸" || retrieved.Criteria != criteria {
+		t.Errorf("Unexpected view: %+v", retrieved)
+	}
+	if _, err := repo.GetByID(view.ID, "user-456"); err != repository.ErrSavedViewNotFound {
+		t.Errorf("Expected ErrSavedViewNotFound for other user, got %v", err)
+	}
+
+	// Act & Assert - Update
+	view.Rename("My open P1s")
+	view.SetCriteria(entity.ViewCriteria{Completion: entity.ViewCompletionOpen, Filter: "priority>=high"})
+	if err := repo.Update(view); err != nil {
+		t.Errorf("Update should succeed: %v", err)
+	}
+	views, _ := repo.ListByUserID("user-123")
+	if len(views) != 1 || views[0].Name != "My open P1s" || views[0].Criteria.UpdatedBefore != "" {
+		t.Errorf("Expected updated view in list, got %v", views)
+	}
+
+	// Act & Assert - Delete
+	if err := repo.Delete(view.ID, "user-123"); err != nil {
+		t.Errorf("Delete should succeed: %v", err)
+	}
+	if err := repo.Delete(view.ID, "user-123"); err != repository.ErrSavedViewNotFound {
+		t.Errorf("Expected ErrSavedViewNotFound on second delete, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+const tagColumns = `id, user_id, name, color, created_at, updated_at`
+
+const tagColumnsWithAlias = `t.id, t.user_id, t.name, t.color, t.created_at, t.updated_at`
+
+// SQLiteTagRepository stores tags in the same database as the todos they
+// label. The tables are created by NewSQLiteTodoRepository.
+type SQLiteTagRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteTagRepository(todoRepo *SQLiteTodoRepository) *SQLiteTagRepository {
+	return &SQLiteTagRepository{db: todoRepo.db}
+}
+
+func (r *SQLiteTagRepository) Create(tag *entity.Tag) error {
+	query := `
+	INSERT INTO tags (` + tagColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(query, tag.ID, tag.UserID, tag.Name, tag.Color,
+		tag.CreatedAt.Format(time.RFC3339), tag.UpdatedAt.Format(time.RFC3339))
+	return err
+}
+
+func (r *SQLiteTagRepository) GetByID(id, userID string) (*entity.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE id = ? AND user_id = ?`
+	return r.getOne(query, id, userID)
+}
+
+func (r *SQLiteTagRepository) GetByName(userID, name string) (*entity.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = ? AND name = ? COLLATE NOCASE`
+	return r.getOne(query, userID, name)
+}
+
+func (r *SQLiteTagRepository) ListByUserID(userID string) ([]*entity.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = ? ORDER BY name COLLATE NOCASE`
+	return r.queryTags(query, userID)
+}
+
+func (r *SQLiteTagRepository) ListByIDs(userID string, ids []string) ([]*entity.Tag, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{userID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = ? AND id IN (` + placeholders(len(ids)) + `)
+	ORDER BY name COLLATE NOCASE`
+	return r.queryTags(query, args...)
+}
+
+func (r *SQLiteTagRepository) Update(tag *entity.Tag) error {
+	query := `UPDATE tags SET name = ?, color = ?, updated_at = ? WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(query, tag.Name, tag.Color, tag.UpdatedAt.Format(time.RFC3339), tag.ID, tag.UserID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, repository.ErrTagNotFound)
+}
+
+func (r *SQLiteTagRepository) Delete(id, userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM tags WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result, repository.ErrTagNotFound); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM todo_tags WHERE tag_id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLiteTagRepository) getOne(query string, args ...interface{}) (*entity.Tag, error) {
+	tag, err := scanTagColumns(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrTagNotFound
+	}
+	return tag, err
+}
+
+func (r *SQLiteTagRepository) queryTags(query string, args ...interface{}) ([]*entity.Tag, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*entity.Tag
+	for rows.Next() {
+		tag, err := scanTagColumns(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// scanTagColumns scans tagColumns, optionally preceded by extra leading columns
+func scanTagColumns(scanner rowScanner, leading ...interface{}) (*entity.Tag, error) {
+	var tag entity.Tag
+	var createdAt, updatedAt string
+
+	dest := append(leading, &tag.ID, &tag.UserID, &tag.Name, &tag.Color, &createdAt, &updatedAt)
+	if err := scanner.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	tag.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	tag.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &tag, nil
+}
+
+func requireAffected(result sql.Result, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
+)
+
+func TestTagRepository_CRUD(t *testing.T) {
+	// Arrange
+	dbPath := "test_tags_crud.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+
+	var repo repository.TagRepository = database.NewSQLiteTagRepository(todoRepo)
+
+	tag, _ := entity.NewTag("tag-1", "user-123", "Backend", "#123456")
+
+	// Act & Assert - Create / GetByID
+	if err := repo.Create(tag); err != nil {
+		t.Fatalf("Create should succeed: %v", err)
+	}
+	retrieved, err := repo.GetByID(tag.ID, "user-123")
+	if err != nil {
+		t.Fatalf("GetByID should succeed: %v", err)
+	}
+	if retrieved.Name != "Backend" || retrieved.Color != "#123456" {
+		t.Errorf("Unexpected tag: %+v", retrieved)
+	}
+
+	// GetByName は大文字小文字を区別しない
+	if _, err := repo.GetByName("user-123", "backend"); err != nil {
+		t.Errorf("GetByName should be case-insensitive: %v", err)
+	}
+
+	// 他ユーザーからは見えない
+	if _, err := repo.GetByID(tag.ID, "user-456"); err != repository.ErrTagNotFound {
+		t.Errorf("Expected ErrTagNotFound for other user, got %v", err)
+	}
+
+	// Act & Assert - Update
+	tag.Rename("Infra")
+	if err := repo.Update(tag); err != nil {
+		t.Errorf("Update should succeed: %v", err)
+	}
+	tags, _ := repo.ListByUserID("user-123")
+	if len(tags) != 1 || tags[0].Name != "Infra" {
+		t.Errorf("Expected renamed tag in list, got %v", tags)
+	}
+
+	// Act & Assert - Delete
+	if err := repo.Delete(tag.ID, "user-123"); err != nil {
+		t.Errorf("Delete should succeed: %v", err)
+	}
+	if err := repo.Delete(tag.ID, "user-123"); err != repository.ErrTagNotFound {
+		t.Errorf("Expected ErrTagNotFound on second delete, got %v", err)
+	}
+}
+
+func TestTagRepository_TodoTagging(t *testing.T) {
+	// Arrange
+	dbPath := "test_tags_todos.db"
+	defer os.Remove(dbPath)
+
+	todoRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer todoRepo.Close()
+	tagRepo := database.NewSQLiteTagRepository(todoRepo)
+
+	userID := "user-123"
+	backend, _ := entity.NewTag("tag-backend", userID, "backend", "")
+	urgent, _ := entity.NewTag("tag-urgent", userID, "urgent", "")
+	tagRepo.Create(backend)
+	tagRepo.Create(urgent)
+
+	both := entity.NewTodo("todo-both", userID, "Both", "")
+	both.SetTags([]*entity.Tag{backend, urgent})
+	onlyBackend := entity.NewTodo("todo-backend", userID, "Backend only", "")
+	onlyBackend.SetTags([]*entity.Tag{backend})
+	untagged := entity.NewTodo("todo-none", userID, "Untagged", "")
+	todoRepo.Create(both)
+	todoRepo.Create(onlyBackend)
+	todoRepo.Create(untagged)
+
+	// Act & Assert - Todoにタグが読み込まれる
+	retrieved, _ := todoRepo.GetByID("todo-both", userID)
+	if len(retrieved.Tags) != 2 || retrieved.Tags[0].Name != "backend" {
+		t.Errorf("Expected 2 tags sorted by name, got %v", retrieved.Tags)
+	}
+
+	// Act & Assert - any-of フィルタ
+	anyOf, err := todoRepo.List(repository.TodoQuery{UserID: userID, AnyTagIDs: []string{"tag-backend", "tag-urgent"}})
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(anyOf) != 2 {
+		t.Errorf("Expected 2 todos with any tag, got %d", len(anyOf))
+	}
+
+	// Act & Assert - all-of フィルタ
+	allOf, _ := todoRepo.List(repository.TodoQuery{UserID: userID, AllTagIDs: []string{"tag-backend", "tag-urgent"}})
+	if len(allOf) != 1 || allOf[0].ID != "todo-both" {
+		t.Errorf("Expected only todo-both with all tags, got %v", allOf)
+	}
+
+	// Act & Assert - タグを外して更新
+	retrieved.SetTags([]*entity.Tag{urgent})
+	todoRepo.Update(retrieved)
+	allOf, _ = todoRepo.List(repository.TodoQuery{UserID: userID, AllTagIDs: []string{"tag-backend", "tag-urgent"}})
+	if len(allOf) != 0 {
+		t.Errorf("Expected no todo with both tags after update, got %d", len(allOf))
+	}
+
+	// Act & Assert - タグ削除でTodoから外れる
+	tagRepo.Delete("tag-urgent", userID)
+	retrieved, _ = todoRepo.GetByID("todo-both", userID)
+	if len(retrieved.Tags) != 0 {
+		t.Errorf("Expected tags to be detached after delete, got %v", retrieved.Tags)
+	}
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
+)
+
+// sqliteDriver is SQLite with the functions filters compile to. SQLite's
+// own lower() and NOCASE only fold ASCII, while Expr.Matches folds any
+// script, so text conditions call back into Go.
+const sqliteDriver = "sqlite3_todo"
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("contains_fold", filter.ContainsFold, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("equal_fold", strings.EqualFold, true)
+		},
+	})
+}
+
+var filterColumns = map[filter.Field]string{
+	filter.FieldTitle:       "title",
+	filter.FieldDescription: "IFNULL(description, '')",
+	filter.FieldCreated:     "created_at",
+	filter.FieldUpdated:     "updated_at",
+	filter.FieldDue:         "due_at",
+}
+
+// compileFilter translates a filter expression into a parameterized SQL
+// condition on the todos table. Every condition evaluates to true or false,
+// never NULL, so that NOT behaves like Expr.Matches.
+func compileFilter(expr filter.Expr) (string, []interface{}, error) {
+	switch e := expr.(type) {
+	case *filter.And:
+		return compileBinary(e.Left, e.Right, "AND")
+	case *filter.Or:
+		return compileBinary(e.Left, e.Right, "OR")
+	case *filter.Not:
+		condition, args, err := compileFilter(e.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, args, nil
+
+	case *filter.BoolCondition:
+		return "(completed = ?)", []interface{}{e.Value}, nil
+
+	case *filter.TextCondition:
+		column := filterColumns[e.Field]
+		if e.Op == filter.OpContains {
+			return "(contains_fold(" + column + ", ?))", []interface{}{e.Value}, nil
+		}
+		return "(equal_fold(" + column + ", ?))", []interface{}{e.Value}, nil
+
+	case *filter.PriorityCondition:
+		operator := map[filter.Op]string{
+			filter.OpEqual: "=", filter.OpNotEqual: "!=",
+			filter.OpLess: "<", filter.OpLessEqual: "<=",
+			filter.OpGreater: ">", filter.OpGreaterEqual: ">=",
+		}[e.Op]
+		if operator == "" {
+			return "", nil, fmt.Errorf("unsupported priority operator %s", e.Op)
+		}
+		return "(priority " + operator + " ?)", []interface{}{int(e.Value)}, nil
+
+	case *filter.TimeCondition:
+		// 保存時のタイムゾーンが混在しても正しく比較できるよう julianday で比較する
+		column := filterColumns[e.Field]
+		operator := ">="
+		if e.Op == filter.OpLess {
+			operator = "<"
+		}
+		return "(" + column + " IS NOT NULL AND julianday(" + column + ") " + operator + " julianday(?))",
+			[]interface{}{e.Value.UTC().Format(time.RFC3339)}, nil
+
+	case *filter.NullCondition:
+		return "(" + filterColumns[e.Field] + " IS NULL)", nil, nil
+
+	case *filter.TagCondition:
+		return `EXISTS (SELECT 1 FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+			WHERE todo_tags.todo_id = todos.id AND tags.name = ?)`, []interface{}{e.Name}, nil
+
+	case *filter.ProjectCondition:
+		if e.ProjectID == "" {
+			return "(project_id IS NULL)", nil, nil
+		}
+		return "(IFNULL(project_id, '') = ?)", []interface{}{e.ProjectID}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported filter node %T", expr)
+}
+
+func compileBinary(left, right filter.Expr, operator string) (string, []interface{}, error) {
+	leftSQL, leftArgs, err := compileFilter(left)
+	if err != nil {
+		return "", nil, err
+	}
+	rightSQL, rightArgs, err := compileFilter(right)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + leftSQL + " " + operator + " " + rightSQL + ")", append(leftArgs, rightArgs...), nil
+}
@@ -2,18 +2,27 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 )
 
+const todoColumns = `id, user_id, title, description, completed, created_at, updated_at, completed_at,
+	due_at, remind_at, priority, project_id, parent_id, recurrence, workspace_id, created_by,
+	assignee_id`
+
 type SQLiteTodoRepository struct {
 	db *sql.DB
+	// fullText is set when SQLite supports FTS5 and todos_fts is available
+	fullText bool
 }
 
 func NewSQLiteTodoRepository(dbPath string) (*SQLiteTodoRepository, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := sql.Open(sqliteDriver, dbPath)
 	if err != nil {
 		return nil, err
 	}
@@ -36,144 +45,826 @@ func (r *SQLiteTodoRepository) createTable() error {
 		completed BOOLEAN NOT NULL DEFAULT FALSE,
 		created_at DATETIME NOT NULL,
 		updated_at DATETIME NOT NULL,
-		completed_at DATETIME
+		completed_at DATETIME,
+		due_at DATETIME,
+		remind_at DATETIME,
+		priority INTEGER NOT NULL DEFAULT 0,
+		project_id TEXT,
+		parent_id TEXT,
+		recurrence TEXT,
+		workspace_id TEXT,
+		created_by TEXT,
+		assignee_id TEXT
+	)`
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+
+	// 既存のデータベースに後から追加されたカラムを補う
+	if err := r.addColumnIfMissing("todos", "due_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "remind_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "priority", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "project_id", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "parent_id", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "recurrence", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "workspace_id", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "created_by", "TEXT"); err != nil {
+		return err
+	}
+	if err := r.addColumnIfMissing("todos", "assignee_id", "TEXT"); err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_user_due ON todos (user_id, due_at)`); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_user_project ON todos (user_id, project_id)`); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_parent ON todos (parent_id)`); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_assignee ON todos (assignee_id)`); err != nil {
+		return err
+	}
+
+	if err := r.createTagTables(); err != nil {
+		return err
+	}
+	if err := r.createProjectTable(); err != nil {
+		return err
+	}
+	if err := r.createSavedViewTable(); err != nil {
+		return err
+	}
+	if err := r.createCommentTable(); err != nil {
+		return err
+	}
+	return r.createSearchIndex()
+}
+
+func (r *SQLiteTodoRepository) createTagTables() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS tags (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL COLLATE NOCASE,
+		color TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		UNIQUE (user_id, name)
+	)`, `
+	CREATE TABLE IF NOT EXISTS todo_tags (
+		todo_id TEXT NOT NULL,
+		tag_id TEXT NOT NULL,
+		PRIMARY KEY (todo_id, tag_id)
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_tags_tag ON todo_tags (tag_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteTodoRepository) createProjectTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS projects (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
 	)`
-	_, err := r.db.Exec(query)
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_projects_user ON projects (user_id)`)
 	return err
 }
 
-func (r *SQLiteTodoRepository) Create(todo *entity.Todo) error {
+func (r *SQLiteTodoRepository) createSavedViewTable() error {
 	query := `
-	INSERT INTO todos (id, user_id, title, description, completed, created_at, updated_at, completed_at)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	CREATE TABLE IF NOT EXISTS saved_views (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		icon TEXT NOT NULL DEFAULT '',
+		criteria TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	)`
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
 
-	var completedAt interface{}
-	if todo.CompletedAt != nil {
-		completedAt = todo.CompletedAt.Format(time.RFC3339)
+	_, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_saved_views_user ON saved_views (user_id)`)
+	return err
+}
+
+func (r *SQLiteTodoRepository) createCommentTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS todo_comments (
+		id TEXT PRIMARY KEY,
+		todo_id TEXT NOT NULL,
+		author_id TEXT NOT NULL,
+		body TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		edited_at DATETIME,
+		deleted_at DATETIME
+	)`
+	if _, err := r.db.Exec(query); err != nil {
+		return err
 	}
 
-	_, err := r.db.Exec(query, todo.ID, todo.UserID, todo.Title, todo.Description,
-		todo.Completed, todo.CreatedAt.Format(time.RFC3339),
-		todo.UpdatedAt.Format(time.RFC3339), completedAt)
+	_, err := r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_todo_comments_todo ON todo_comments (todo_id, created_at)`)
+	return err
+}
+
+// addColumnIfMissing adds a column to a table created by an older version of the schema
+func (r *SQLiteTodoRepository) addColumnIfMissing(table, column, definition string) error {
+	rows, err := r.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, dataType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
+func (r *SQLiteTodoRepository) Create(todo *entity.Todo) error {
+	return r.CreateWithUpdates(todo, nil)
+}
+
+func (r *SQLiteTodoRepository) CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTodo(tx, created); err != nil {
+		return err
+	}
+	for _, todo := range updated {
+		if err := updateTodo(tx, todo); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertTodo(tx *sql.Tx, todo *entity.Todo) error {
+	query := `
+	INSERT INTO todos (` + todoColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := tx.Exec(query, todo.ID, todo.UserID, todo.Title, todo.Description,
+		todo.Completed, todo.CreatedAt.Format(time.RFC3339),
+		todo.UpdatedAt.Format(time.RFC3339), formatNullableTime(todo.CompletedAt),
+		formatNullableUTC(todo.DueAt), formatNullableUTC(todo.RemindAt), int(todo.Priority),
+		nullableString(todo.ProjectID), nullableString(todo.ParentID), formatRecurrence(todo.Recurrence),
+		emptyToNull(todo.WorkspaceID), emptyToNull(todo.CreatedBy), emptyToNull(todo.AssigneeID))
+	if err != nil {
+		return err
+	}
+
+	return replaceTodoTags(tx, todo)
+}
+
 func (r *SQLiteTodoRepository) GetByID(id, userID string) (*entity.Todo, error) {
 	query := `
-	SELECT id, user_id, title, description, completed, created_at, updated_at, completed_at
+	SELECT ` + todoColumns + `
 	FROM todos WHERE id = ? AND user_id = ?`
 
 	row := r.db.QueryRow(query, id, userID)
-	return r.scanTodo(row)
+	todo, err := r.scanTodo(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTags([]*entity.Todo{todo}); err != nil {
+		return nil, err
+	}
+	return todo, nil
 }
 
 func (r *SQLiteTodoRepository) ListByUserID(userID string) ([]*entity.Todo, error) {
 	query := `
-	SELECT id, user_id, title, description, completed, created_at, updated_at, completed_at
+	SELECT ` + todoColumns + `
 	FROM todos WHERE user_id = ? ORDER BY created_at DESC`
 
-	rows, err := r.db.Query(query, userID)
+	return r.queryTodos(query, userID)
+}
+
+func (r *SQLiteTodoRepository) ListCompletedByUserID(userID string) ([]*entity.Todo, error) {
+	query := `
+	SELECT ` + todoColumns + `
+	FROM todos WHERE user_id = ? AND completed = TRUE ORDER BY completed_at DESC`
+
+	return r.queryTodos(query, userID)
+}
+
+func (r *SQLiteTodoRepository) List(q repository.TodoQuery) ([]*entity.Todo, error) {
+	where, args, err := listConditions(q)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT ` + todoColumns + `
+	FROM todos WHERE ` + where + ` ORDER BY ` + orderClause(q)
+
+	return r.queryTodos(query, args...)
+}
+
+func (r *SQLiteTodoRepository) ListPage(q repository.TodoQuery, page repository.PageRequest) (*repository.TodoPage, error) {
+	where, args, err := listConditions(q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &repository.TodoPage{}
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM todos WHERE `+where, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	keys := orderKeys(q)
+	if page.After != nil {
+		if page.After.Order != q.OrderKey() || len(page.After.Values) != len(keys) {
+			return nil, repository.ErrInvalidCursor
+		}
+		condition, cursorArgs := keysetCondition(keys, page.After)
+		where += " AND " + condition
+		args = append(args, cursorArgs...)
+	}
+
+	query := `
+	SELECT ` + todoColumns + `
+	FROM todos WHERE ` + where + ` ORDER BY ` + orderClause(q)
+	if page.Size > 0 {
+		// 1件多く読んで次のページがあるかを判定する
+		query += ` LIMIT ?`
+		args = append(args, page.Size+1)
+	}
+
+	todos, err := r.queryTodos(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Size > 0 && len(todos) > page.Size {
+		todos = todos[:page.Size]
+		last := todos[len(todos)-1]
+		result.Next = &repository.Cursor{Order: q.OrderKey(), ID: last.ID}
+		for _, key := range keys {
+			result.Next.Values = append(result.Next.Values, key.value(last))
+		}
+	}
+	result.Todos = todos
+	return result, nil
+}
+
+// listConditions builds the WHERE clause and its arguments for a query
+func listConditions(q repository.TodoQuery) (string, []interface{}, error) {
+	conditions := []string{"user_id = ?"}
+	args := []interface{}{q.UserID}
+	if len(q.UserIDs) > 0 {
+		conditions[0] = "user_id IN (" + placeholders(len(q.UserIDs)) + ")"
+		args = args[:0]
+		for _, userID := range q.UserIDs {
+			args = append(args, userID)
+		}
+	}
+	if q.AssigneeID != "" {
+		conditions = append(conditions, "assignee_id = ?")
+		args = append(args, q.AssigneeID)
+	}
+
+	if q.CompletedOnly {
+		conditions = append(conditions, "completed = TRUE")
+	}
+	if q.IncompleteOnly {
+		conditions = append(conditions, "completed = FALSE")
+	}
+	// due_at は UTC で保存しているため、文字列比較で範囲検索できる
+	if q.DueFrom != nil {
+		conditions = append(conditions, "due_at >= ?")
+		args = append(args, q.DueFrom.UTC().Format(time.RFC3339))
+	}
+	if q.DueBefore != nil {
+		conditions = append(conditions, "due_at < ?")
+		args = append(args, q.DueBefore.UTC().Format(time.RFC3339))
+	}
+	if len(q.AnyTagIDs) > 0 {
+		conditions = append(conditions,
+			"id IN (SELECT todo_id FROM todo_tags WHERE tag_id IN ("+placeholders(len(q.AnyTagIDs))+"))")
+		for _, tagID := range q.AnyTagIDs {
+			args = append(args, tagID)
+		}
+	}
+	if len(q.AllTagIDs) > 0 {
+		conditions = append(conditions,
+			"id IN (SELECT todo_id FROM todo_tags WHERE tag_id IN ("+placeholders(len(q.AllTagIDs))+
+				") GROUP BY todo_id HAVING COUNT(DISTINCT tag_id) = ?)")
+		for _, tagID := range q.AllTagIDs {
+			args = append(args, tagID)
+		}
+		args = append(args, countDistinct(q.AllTagIDs))
+	}
+	if q.ProjectID != "" {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, q.ProjectID)
+	}
+	if q.NoProject {
+		conditions = append(conditions, "project_id IS NULL")
+	}
+	if q.TopLevelOnly {
+		conditions = append(conditions, "parent_id IS NULL")
+	}
+	if len(q.ParentIDs) > 0 {
+		conditions = append(conditions, "parent_id IN ("+placeholders(len(q.ParentIDs))+")")
+		for _, parentID := range q.ParentIDs {
+			args = append(args, parentID)
+		}
+	}
+	if q.Filter != nil {
+		condition, filterArgs, err := compileFilter(q.Filter)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+		args = append(args, filterArgs...)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+func (r *SQLiteTodoRepository) CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error) {
+	progress := make(map[string]entity.SubtaskProgress)
+	if len(parentIDs) == 0 {
+		return progress, nil
+	}
+
+	args := []interface{}{userID}
+	for _, parentID := range parentIDs {
+		args = append(args, parentID)
+	}
+
+	query := `
+	SELECT parent_id, SUM(CASE WHEN completed THEN 1 ELSE 0 END), COUNT(*)
+	FROM todos
+	WHERE user_id = ? AND parent_id IN (` + placeholders(len(parentIDs)) + `)
+	GROUP BY parent_id`
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var todos []*entity.Todo
 	for rows.Next() {
-		todo, err := r.scanTodoFromRows(rows)
-		if err != nil {
+		var parentID string
+		var p entity.SubtaskProgress
+		if err := rows.Scan(&parentID, &p.Done, &p.Total); err != nil {
 			return nil, err
 		}
-		todos = append(todos, todo)
+		progress[parentID] = p
 	}
 
-	return todos, nil
+	return progress, rows.Err()
 }
 
-func (r *SQLiteTodoRepository) ListCompletedByUserID(userID string) ([]*entity.Todo, error) {
+func (r *SQLiteTodoRepository) CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error) {
+	counts := make(map[string]entity.TodoCounts)
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	args := make([]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		args = append(args, userID)
+	}
+
 	query := `
-	SELECT id, user_id, title, description, completed, created_at, updated_at, completed_at
-	FROM todos WHERE user_id = ? AND completed = TRUE ORDER BY completed_at DESC`
+	SELECT user_id, COUNT(*), SUM(CASE WHEN completed THEN 1 ELSE 0 END)
+	FROM todos
+	WHERE user_id IN (` + placeholders(len(userIDs)) + `)
+	GROUP BY user_id`
 
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var todos []*entity.Todo
 	for rows.Next() {
-		todo, err := r.scanTodoFromRows(rows)
-		if err != nil {
+		var userID string
+		var c entity.TodoCounts
+		if err := rows.Scan(&userID, &c.Total, &c.Completed); err != nil {
 			return nil, err
 		}
-		todos = append(todos, todo)
+		counts[userID] = c
 	}
 
-	return todos, nil
+	return counts, rows.Err()
 }
 
 func (r *SQLiteTodoRepository) Update(todo *entity.Todo) error {
+	return r.UpdateMany([]*entity.Todo{todo})
+}
+
+func (r *SQLiteTodoRepository) UpdateMany(todos []*entity.Todo) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, todo := range todos {
+		if err := updateTodo(tx, todo); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func updateTodo(tx *sql.Tx, todo *entity.Todo) error {
 	query := `
-	UPDATE todos SET title = ?, description = ?, completed = ?, updated_at = ?, completed_at = ?
+	UPDATE todos SET title = ?, description = ?, completed = ?, updated_at = ?, completed_at = ?,
+		due_at = ?, remind_at = ?, priority = ?, project_id = ?, parent_id = ?, recurrence = ?,
+		assignee_id = ?
 	WHERE id = ? AND user_id = ?`
 
-	var completedAt interface{}
-	if todo.CompletedAt != nil {
-		completedAt = todo.CompletedAt.Format(time.RFC3339)
+	_, err := tx.Exec(query, todo.Title, todo.Description, todo.Completed,
+		todo.UpdatedAt.Format(time.RFC3339), formatNullableTime(todo.CompletedAt),
+		formatNullableUTC(todo.DueAt), formatNullableUTC(todo.RemindAt), int(todo.Priority),
+		nullableString(todo.ProjectID), nullableString(todo.ParentID), formatRecurrence(todo.Recurrence),
+		emptyToNull(todo.AssigneeID), todo.ID, todo.UserID)
+	if err != nil {
+		return err
 	}
 
-	_, err := r.db.Exec(query, todo.Title, todo.Description, todo.Completed,
-		todo.UpdatedAt.Format(time.RFC3339), completedAt, todo.ID, todo.UserID)
-	return err
+	return replaceTodoTags(tx, todo)
 }
 
 func (r *SQLiteTodoRepository) Delete(id, userID string) error {
-	query := `DELETE FROM todos WHERE id = ? AND user_id = ?`
-	_, err := r.db.Exec(query, id, userID)
-	return err
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 子孫のTodoもまとめて削除する
+	rows, err := tx.Query(`
+	WITH RECURSIVE subtree(id) AS (
+		SELECT id FROM todos WHERE id = ? AND user_id = ?
+		UNION
+		SELECT t.id FROM todos t JOIN subtree s ON t.parent_id = s.id WHERE t.user_id = ?
+	)
+	SELECT id FROM subtree`, id, userID, userID)
+	if err != nil {
+		return err
+	}
+	var ids []interface{}
+	for rows.Next() {
+		var todoID string
+		if err := rows.Scan(&todoID); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, todoID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return tx.Commit()
+	}
+
+	in := placeholders(len(ids))
+	if _, err := tx.Exec(`DELETE FROM todo_tags WHERE todo_id IN (`+in+`)`, ids...); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM todo_comments WHERE todo_id IN (`+in+`)`, ids...); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM todos WHERE id IN (`+in+`)`, ids...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *SQLiteTodoRepository) scanTodo(row *sql.Row) (*entity.Todo, error) {
-	var todo entity.Todo
-	var createdAt, updatedAt string
-	var completedAt sql.NullString
+func (r *SQLiteTodoRepository) DeleteAllByUserID(userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 全文検索のインデックスはtodosのトリガーで削除される
+	queries := []string{
+		`DELETE FROM todo_tags WHERE todo_id IN (SELECT id FROM todos WHERE user_id = ?)`,
+		`DELETE FROM todo_comments WHERE todo_id IN (SELECT id FROM todos WHERE user_id = ?)`,
+		`DELETE FROM todos WHERE user_id = ?`,
+		`DELETE FROM tags WHERE user_id = ?`,
+		`DELETE FROM projects WHERE user_id = ?`,
+		`DELETE FROM saved_views WHERE user_id = ?`,
+		// ワークスペースのTodoの担当からも外す
+		`UPDATE todos SET assignee_id = NULL WHERE assignee_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, userID); err != nil {
+			return err
+		}
+	}
 
-	err := row.Scan(&todo.ID, &todo.UserID, &todo.Title, &todo.Description,
-		&todo.Completed, &createdAt, &updatedAt, &completedAt)
+	// ほかのTodoへのコメントはスレッドに削除済みとして残し、本文は消す
+	_, err = tx.Exec(`UPDATE todo_comments SET body = '', deleted_at = COALESCE(deleted_at, ?) WHERE author_id = ?`,
+		time.Now().Format(time.RFC3339), userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// orderKey is one column of a todo ordering. value returns the key of a todo
+// exactly as it is stored, so that it can be compared against in a cursor.
+type orderKey struct {
+	expr  string
+	desc  bool
+	value func(todo *entity.Todo) interface{}
+}
+
+var (
+	createdAtKey = func(todo *entity.Todo) interface{} { return todo.CreatedAt.Format(time.RFC3339) }
+	updatedAtKey = func(todo *entity.Todo) interface{} { return todo.UpdatedAt.Format(time.RFC3339) }
+)
+
+// orderKeys lists the sort keys of a query, not including the id tie-breaker.
+// Expressions never evaluate to NULL so that they can be compared in cursors.
+func orderKeys(q repository.TodoQuery) []orderKey {
+	field := q.Sort.Field
+	if field == repository.SortByDefault {
+		if q.HasDueRange() {
+			field = repository.SortByDueAt
+		} else {
+			field = repository.SortByCreatedAt
+		}
+	}
+	desc := repository.TodoSort{Field: field, Direction: q.Sort.Direction}.Descending()
+
+	switch field {
+	case repository.SortByPriority:
+		return []orderKey{
+			{"priority", desc, func(todo *entity.Todo) interface{} { return int(todo.Priority) }},
+			{"created_at", true, createdAtKey},
+		}
+	case repository.SortByDueAt:
+		// 期限なしのTodoは並び順に関わらず末尾に置く
+		return []orderKey{
+			{"(due_at IS NULL)", false, func(todo *entity.Todo) interface{} {
+				if todo.DueAt == nil {
+					return 1
+				}
+				return 0
+			}},
+			{"IFNULL(due_at, '')", desc, func(todo *entity.Todo) interface{} {
+				if todo.DueAt == nil {
+					return ""
+				}
+				return todo.DueAt.UTC().Format(time.RFC3339)
+			}},
+		}
+	case repository.SortByUpdatedAt:
+		return []orderKey{{"updated_at", desc, updatedAtKey}}
+	case repository.SortByTitle:
+		return []orderKey{{"title COLLATE NOCASE", desc, func(todo *entity.Todo) interface{} { return todo.Title }}}
+	case repository.SortByCompletedAt:
+		return []orderKey{{"IFNULL(completed_at, '')", desc, func(todo *entity.Todo) interface{} {
+			if todo.CompletedAt == nil {
+				return ""
+			}
+			return todo.CompletedAt.Format(time.RFC3339)
+		}}}
+	default:
+		return []orderKey{{"created_at", desc, createdAtKey}}
+	}
+}
+
+// orderClause builds the ORDER BY clause for a query. The id tie-breaker keeps
+// the order stable between calls.
+func orderClause(q repository.TodoQuery) string {
+	var terms []string
+	for _, key := range orderKeys(q) {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		terms = append(terms, key.expr+" "+direction)
+	}
+	return strings.Join(append(terms, "id ASC"), ", ")
+}
+
+// keysetCondition selects the rows that come after the cursor in the order
+// given by keys, i.e. (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... OR (all equal AND id > cursor id)
+func keysetCondition(keys []orderKey, cursor *repository.Cursor) (string, []interface{}) {
+	var alternatives []string
+	var args []interface{}
+	for i := 0; i <= len(keys); i++ {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, keys[j].expr+" = ?")
+			args = append(args, cursor.Values[j])
+		}
+		if i < len(keys) {
+			operator := " > ?"
+			if keys[i].desc {
+				operator = " < ?"
+			}
+			terms = append(terms, keys[i].expr+operator)
+			args = append(args, cursor.Values[i])
+		} else {
+			terms = append(terms, "id > ?")
+			args = append(args, cursor.ID)
+		}
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+func (r *SQLiteTodoRepository) queryTodos(query string, args ...interface{}) ([]*entity.Todo, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	todo.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	todo.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	var todos []*entity.Todo
+	for rows.Next() {
+		todo, err := r.scanTodoFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	if completedAt.Valid {
-		parsedTime, _ := time.Parse(time.RFC3339, completedAt.String)
-		todo.CompletedAt = &parsedTime
+	if err := r.attachTags(todos); err != nil {
+		return nil, err
 	}
+	return todos, nil
+}
 
-	return &todo, nil
+// attachTags loads the tags of the given todos with a single query
+func (r *SQLiteTodoRepository) attachTags(todos []*entity.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*entity.Todo, len(todos))
+	args := make([]interface{}, 0, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+		args = append(args, todo.ID)
+	}
+
+	query := `
+	SELECT tt.todo_id, ` + tagColumnsWithAlias + `
+	FROM todo_tags tt JOIN tags t ON t.id = tt.tag_id
+	WHERE tt.todo_id IN (` + placeholders(len(todos)) + `)
+	ORDER BY t.name COLLATE NOCASE`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var todoID string
+		tag, err := scanTagColumns(rows, &todoID)
+		if err != nil {
+			return err
+		}
+		if todo, ok := byID[todoID]; ok {
+			todo.Tags = append(todo.Tags, tag)
+		}
+	}
+	return rows.Err()
+}
+
+// replaceTodoTags makes todo_tags match todo.Tags
+func replaceTodoTags(tx *sql.Tx, todo *entity.Todo) error {
+	if _, err := tx.Exec(`DELETE FROM todo_tags WHERE todo_id = ?`, todo.ID); err != nil {
+		return err
+	}
+	for _, tag := range todo.Tags {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO todo_tags (todo_id, tag_id) VALUES (?, ?)`, todo.ID, tag.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func countDistinct(values []string) int {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		seen[v] = true
+	}
+	return len(seen)
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *SQLiteTodoRepository) scanTodo(row *sql.Row) (*entity.Todo, error) {
+	return r.scanTodoColumns(row)
 }
 
 func (r *SQLiteTodoRepository) scanTodoFromRows(rows *sql.Rows) (*entity.Todo, error) {
+	return r.scanTodoColumns(rows)
+}
+
+func (r *SQLiteTodoRepository) scanTodoColumns(scanner rowScanner) (*entity.Todo, error) {
 	var todo entity.Todo
 	var createdAt, updatedAt string
-	var completedAt sql.NullString
+	var completedAt, dueAt, remindAt, projectID, parentID, recurrence, workspaceID, createdBy, assigneeID sql.NullString
+	var priority int
 
-	err := rows.Scan(&todo.ID, &todo.UserID, &todo.Title, &todo.Description,
-		&todo.Completed, &createdAt, &updatedAt, &completedAt)
+	err := scanner.Scan(&todo.ID, &todo.UserID, &todo.Title, &todo.Description,
+		&todo.Completed, &createdAt, &updatedAt, &completedAt, &dueAt, &remindAt, &priority, &projectID, &parentID, &recurrence,
+		&workspaceID, &createdBy, &assigneeID)
 	if err != nil {
 		return nil, err
 	}
 
 	todo.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	todo.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-	if completedAt.Valid {
-		parsedTime, _ := time.Parse(time.RFC3339, completedAt.String)
-		todo.CompletedAt = &parsedTime
+	todo.CompletedAt = parseNullableTime(completedAt)
+	todo.DueAt = parseNullableTime(dueAt)
+	todo.RemindAt = parseNullableTime(remindAt)
+	todo.Priority = entity.Priority(priority)
+	if projectID.Valid {
+		todo.ProjectID = &projectID.String
+	}
+	if parentID.Valid {
+		todo.ParentID = &parentID.String
 	}
+	if recurrence.Valid {
+		todo.Recurrence, _ = entity.ParseRecurrence(recurrence.String)
+	}
+	todo.WorkspaceID = workspaceID.String
+	todo.CreatedBy = createdBy.String
+	todo.AssigneeID = assigneeID.String
 
 	return &todo, nil
 }
@@ -181,3 +872,48 @@ func (r *SQLiteTodoRepository) scanTodoFromRows(rows *sql.Rows) (*entity.Todo, e
 func (r *SQLiteTodoRepository) Close() error {
 	return r.db.Close()
 }
+
+func formatNullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatNullableUTC stores times that are used in range queries in UTC so
+// that they compare correctly as strings
+func formatNullableUTC(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func nullableString(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func emptyToNull(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func formatRecurrence(recurrence *entity.Recurrence) interface{} {
+	if recurrence == nil {
+		return nil
+	}
+	return recurrence.String()
+}
+
+func parseNullableTime(value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	parsedTime, _ := time.Parse(time.RFC3339, value.String)
+	return &parsedTime
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 )
 
 // ========================================
@@ -144,7 +145,7 @@ func TestSQLiteTodoRepository_Internal_ScanTodoImplementation(t *testing.T) {
 	// Act & Assert - 内部実装: scanTodo メソッドの動作確認
 	// 完了済みTodoのスキャン
 	row := repo.db.QueryRow(
-		"SELECT id, user_id, title, description, completed, created_at, updated_at, completed_at FROM todos WHERE id = ?",
+		"SELECT "+todoColumns+" FROM todos WHERE id = ?",
 		completedTodo.ID,
 	)
 	scannedCompleted, err := repo.scanTodo(row)
@@ -160,7 +161,7 @@ func TestSQLiteTodoRepository_Internal_ScanTodoImplementation(t *testing.T) {
 
 	// 未完了Todoのスキャン
 	row = repo.db.QueryRow(
-		"SELECT id, user_id, title, description, completed, created_at, updated_at, completed_at FROM todos WHERE id = ?",
+		"SELECT "+todoColumns+" FROM todos WHERE id = ?",
 		incompleteTodo.ID,
 	)
 	scannedIncomplete, err := repo.scanTodo(row)
@@ -295,3 +296,94 @@ func TestSQLiteTodoRepository_Internal_ErrorHandling(t *testing.T) {
 		t.Errorf("Duplicate key insertion should return error")
 	}
 }
+
+func TestSQLiteTodoRepository_Internal_MigratesLegacySchema(t *testing.T) {
+	// Arrange - 期限カラム追加前の古いスキーマを用意
+	dbPath := "test_internal_migrate.db"
+	defer os.Remove(dbPath)
+
+	legacy, err := NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	legacy.db.Exec("DROP TABLE todos")
+	_, err = legacy.db.Exec(`
+	CREATE TABLE todos (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		title TEXT NOT NULL,
+		description TEXT,
+		completed BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		completed_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	legacy.Close()
+
+	// Act - 再度開くとカラムが追加される
+	repo, err := NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen repository: %v", err)
+	}
+	defer repo.Close()
+
+	// Assert
+	todo := entity.NewTodo("todo-1", "user-123", "Test Todo", "Description")
+	if err := repo.Create(todo); err != nil {
+		t.Errorf("Create should succeed after migration: %v", err)
+	}
+	for _, column := range []string{"due_at", "remind_at", "priority", "project_id", "parent_id", "recurrence"} {
+		var count int
+		repo.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('todos') WHERE name = ?", column).Scan(&count)
+		if count != 1 {
+			t.Errorf("Expected column %s to be added", column)
+		}
+	}
+}
+
+func TestSQLiteTodoRepository_Internal_BackfillsSearchIndex(t *testing.T) {
+	// Arrange - 全文検索の索引がない古いデータベースを用意
+	dbPath := "test_internal_fts.db"
+	defer os.Remove(dbPath)
+
+	legacy, err := NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if !legacy.fullText {
+		legacy.Close()
+		t.Skip("SQLite was built without FTS5 (build with -tags sqlite_fts5)")
+	}
+	legacy.Create(entity.NewTodo("todo-1", "user-123", "Patch servers", ""))
+	for _, query := range []string{
+		"DROP TRIGGER todos_fts_insert", "DROP TRIGGER todos_fts_update", "DROP TRIGGER todos_fts_delete", "DROP TABLE todos_fts",
+	} {
+		if _, err := legacy.db.Exec(query); err != nil {
+			t.Fatalf("Failed to drop search index: %v", err)
+		}
+	}
+	legacy.Close()
+
+	// Act - 再度開くと既存のTodoが索引に取り込まれる
+	repo, err := NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen repository: %v", err)
+	}
+	defer repo.Close()
+
+	// Assert
+	hits, err := repo.Search(repository.SearchQuery{
+		UserID: "user-123",
+		Terms:  []repository.SearchTerm{{Text: "patch"}},
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatalf("Search should succeed: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Todo.ID != "todo-1" {
+		t.Errorf("Expected existing todo to be found after backfill, got %d hits", len(hits))
+	}
+}
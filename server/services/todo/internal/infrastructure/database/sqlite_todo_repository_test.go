@@ -1,10 +1,15 @@
 package database_test
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/infrastructure/database"
 )
@@ -277,6 +282,110 @@ func TestTodoRepository_UserIsolation(t *testing.T) {
 	}
 }
 
+func TestTodoRepository_CountByUserIDs(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_counts.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	done := entity.NewTodo("todo-1", "user-1", "Done", "")
+	done.MarkComplete(true)
+	repo.Create(done)
+	repo.Create(entity.NewTodo("todo-2", "user-1", "Open", ""))
+	repo.Create(entity.NewTodo("todo-3", "user-2", "Other user", ""))
+
+	// Act
+	counts, err := repo.CountByUserIDs([]string{"user-1", "user-3"})
+
+	// Assert - 指定したユーザーだけを数え、Todoのないユーザーは含めない
+	if err != nil {
+		t.Fatalf("CountByUserIDs should succeed: %v", err)
+	}
+	if c := counts["user-1"]; c.Total != 2 || c.Completed != 1 {
+		t.Errorf("Expected user-1 to have 1 of 2 todos completed, got %+v", c)
+	}
+	if len(counts) != 1 {
+		t.Errorf("Expected only user-1 to be counted, got %v", counts)
+	}
+}
+
+func TestTodoRepository_DeleteAllByUserID(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_delete_user.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	tagRepo := database.NewSQLiteTagRepository(repo)
+	projectRepo := database.NewSQLiteProjectRepository(repo)
+	viewRepo := database.NewSQLiteSavedViewRepository(repo)
+	for _, userID := range []string{"user-1", "user-2"} {
+		tag, _ := entity.NewTag("tag-"+userID, userID, "Backend", "")
+		tagRepo.Create(tag)
+		project, _ := entity.NewProject("project-"+userID, userID, "Website", "")
+		projectRepo.Create(project)
+		view, _ := entity.NewSavedView("view-"+userID, userID, "Open", "", entity.ViewCriteria{})
+		viewRepo.Create(view)
+		todo := entity.NewTodo("todo-"+userID, userID, "Deploy", "")
+		todo.SetTags([]*entity.Tag{tag})
+		repo.Create(todo)
+	}
+	assigned := entity.NewTodo("todo-ws", "ws-1", "Review", "")
+	assigned.AssigneeID = "user-1"
+	repo.Create(assigned)
+	commentRepo := database.NewSQLiteCommentRepository(repo)
+	for _, authorID := range []string{"user-1", "user-2"} {
+		comment, _ := entity.NewComment("comment-"+authorID, "todo-ws", authorID, "Looks good")
+		commentRepo.Create(comment)
+	}
+
+	// Act
+	err = repo.DeleteAllByUserID("user-1")
+
+	// Assert - user-1のデータだけが消え、user-2のデータは残る
+	if err != nil {
+		t.Fatalf("DeleteAllByUserID should succeed: %v", err)
+	}
+	if todos, _ := repo.ListByUserID("user-1"); len(todos) != 0 {
+		t.Errorf("Expected user-1's todos to be deleted, got %d", len(todos))
+	}
+	if tags, _ := tagRepo.ListByUserID("user-1"); len(tags) != 0 {
+		t.Errorf("Expected user-1's tags to be deleted, got %d", len(tags))
+	}
+	if projects, _ := projectRepo.ListByUserID("user-1"); len(projects) != 0 {
+		t.Errorf("Expected user-1's projects to be deleted, got %d", len(projects))
+	}
+	if views, _ := viewRepo.ListByUserID("user-1"); len(views) != 0 {
+		t.Errorf("Expected user-1's views to be deleted, got %d", len(views))
+	}
+	todos, _ := repo.ListByUserID("user-2")
+	if len(todos) != 1 || len(todos[0].Tags) != 1 {
+		t.Errorf("Expected user-2's tagged todo to remain, got %v", todos)
+	}
+	if got, _ := repo.GetByID("todo-ws", "ws-1"); got == nil || got.AssigneeID != "" {
+		t.Errorf("Expected the workspace todo to remain unassigned, got %+v", got)
+	}
+	comments, _ := commentRepo.ListByTodoID("todo-ws")
+	if len(comments) != 2 {
+		t.Fatalf("Expected both comments to stay in the thread, got %d", len(comments))
+	}
+	for _, comment := range comments {
+		deleted := comment.AuthorID == "user-1"
+		if comment.Deleted() != deleted || (comment.Body == "") != deleted {
+			t.Errorf("Expected only user-1's comment to be deleted with its body, got %+v", comment)
+		}
+	}
+}
+
 func TestTodoRepository_CompletionFlow(t *testing.T) {
 	// Arrange
 	dbPath := "test_todos_completion.db"
@@ -324,3 +433,702 @@ func TestTodoRepository_CompletionFlow(t *testing.T) {
 		t.Errorf("CompletedAt should be set")
 	}
 }
+
+func TestTodoRepository_ListByDueRange(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_due.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.TodoRepository
+	sqliteRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	userID := "user-123"
+	// 異なるタイムゾーンの時刻でも正しく比較できることを確認
+	tokyo := time.FixedZone("JST", 9*60*60)
+	base := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	early := base.Add(2 * time.Hour).In(tokyo)
+	late := base.Add(20 * time.Hour)
+	outside := base.AddDate(0, 0, 2)
+
+	todo1 := entity.NewTodo("todo-1", userID, "Early", "")
+	todo1.SetDueAt(&early)
+	todo2 := entity.NewTodo("todo-2", userID, "Late", "")
+	todo2.SetDueAt(&late)
+	todo2.SetRemindAt(&early)
+	todo3 := entity.NewTodo("todo-3", userID, "Outside", "")
+	todo3.SetDueAt(&outside)
+	todo4 := entity.NewTodo("todo-4", userID, "No due date", "")
+	repo.Create(todo1)
+	repo.Create(todo2)
+	repo.Create(todo3)
+	repo.Create(todo4)
+
+	from := base
+	before := base.AddDate(0, 0, 1)
+
+	// Act
+	todos, err := repo.List(repository.TodoQuery{
+		UserID:    userID,
+		DueFrom:   &from,
+		DueBefore: &before,
+	})
+
+	// Assert - 期限の早い順に並ぶ
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(todos))
+	}
+	if todos[0].ID != "todo-1" || todos[1].ID != "todo-2" {
+		t.Errorf("Expected todos ordered by due date, got %s, %s", todos[0].ID, todos[1].ID)
+	}
+	if todos[0].DueAt == nil || !todos[0].DueAt.Equal(early) {
+		t.Errorf("Expected DueAt %v, got %v", early, todos[0].DueAt)
+	}
+	if todos[1].RemindAt == nil || !todos[1].RemindAt.Equal(early) {
+		t.Errorf("Expected RemindAt %v, got %v", early, todos[1].RemindAt)
+	}
+}
+
+func TestTodoRepository_ListSorted(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_sort.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.TodoRepository
+	sqliteRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	userID := "user-123"
+	dueSoon := time.Now().Add(time.Hour)
+	dueLater := time.Now().Add(48 * time.Hour)
+
+	low := entity.NewTodo("todo-low", userID, "banana", "")
+	low.SetPriority(entity.PriorityLow)
+	low.SetDueAt(&dueLater)
+	urgent := entity.NewTodo("todo-urgent", userID, "Apple", "")
+	urgent.SetPriority(entity.PriorityUrgent)
+	urgent.SetDueAt(&dueSoon)
+	none := entity.NewTodo("todo-none", userID, "cherry", "")
+	repo.Create(low)
+	repo.Create(urgent)
+	repo.Create(none)
+
+	tests := []struct {
+		name     string
+		sort     repository.TodoSort
+		expected []string
+	}{
+		{"priority default (desc)", repository.TodoSort{Field: repository.SortByPriority}, []string{"todo-urgent", "todo-low", "todo-none"}},
+		{"priority asc", repository.TodoSort{Field: repository.SortByPriority, Direction: repository.SortAscending}, []string{"todo-none", "todo-low", "todo-urgent"}},
+		{"due default (asc, nulls last)", repository.TodoSort{Field: repository.SortByDueAt}, []string{"todo-urgent", "todo-low", "todo-none"}},
+		{"due desc (nulls last)", repository.TodoSort{Field: repository.SortByDueAt, Direction: repository.SortDescending}, []string{"todo-low", "todo-urgent", "todo-none"}},
+		{"title case-insensitive", repository.TodoSort{Field: repository.SortByTitle}, []string{"todo-urgent", "todo-low", "todo-none"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			todos, err := repo.List(repository.TodoQuery{UserID: userID, Sort: tt.sort})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("List should succeed: %v", err)
+			}
+			if len(todos) != len(tt.expected) {
+				t.Fatalf("Expected %d todos, got %d", len(tt.expected), len(todos))
+			}
+			for i, id := range tt.expected {
+				if todos[i].ID != id {
+					t.Errorf("Position %d: expected %s, got %s", i, id, todos[i].ID)
+				}
+			}
+		})
+	}
+
+	// 優先度の永続化を確認
+	retrieved, _ := repo.GetByID("todo-urgent", userID)
+	if retrieved.Priority != entity.PriorityUrgent {
+		t.Errorf("Expected priority urgent, got %s", retrieved.Priority)
+	}
+}
+
+func TestTodoRepository_Subtasks(t *testing.T) {
+	// Arrange
+	dbPath := "test_subtasks.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	userID := "user-123"
+	parent := entity.NewTodo("parent", userID, "Epic", "")
+	child := entity.NewTodo("child", userID, "Story", "")
+	child.SetParentID(&parent.ID)
+	done := entity.NewTodo("done", userID, "Done story", "")
+	done.SetParentID(&parent.ID)
+	done.MarkComplete(true)
+	grandchild := entity.NewTodo("grandchild", userID, "Task", "")
+	grandchild.SetParentID(&child.ID)
+	for _, todo := range []*entity.Todo{parent, child, done, grandchild} {
+		if err := repo.Create(todo); err != nil {
+			t.Fatalf("Create should succeed: %v", err)
+		}
+	}
+
+	// Act & Assert - parent_id が読み込まれる
+	retrieved, _ := repo.GetByID("grandchild", userID)
+	if retrieved.ParentID == nil || *retrieved.ParentID != "child" {
+		t.Errorf("Expected parent 'child', got %v", retrieved.ParentID)
+	}
+
+	// Act & Assert - 子の一覧とトップレベルのみの一覧
+	children, _ := repo.List(repository.TodoQuery{UserID: userID, ParentIDs: []string{"parent"}})
+	if len(children) != 2 {
+		t.Errorf("Expected 2 direct subtasks, got %d", len(children))
+	}
+	topLevel, _ := repo.List(repository.TodoQuery{UserID: userID, TopLevelOnly: true})
+	if len(topLevel) != 1 || topLevel[0].ID != "parent" {
+		t.Errorf("Expected only the parent at top level, got %v", topLevel)
+	}
+
+	// Act & Assert - 進捗の集計
+	progress, err := repo.CountSubtasks(userID, []string{"parent", "child", "grandchild"})
+	if err != nil {
+		t.Fatalf("CountSubtasks should succeed: %v", err)
+	}
+	if p := progress["parent"]; p.Done != 1 || p.Total != 2 {
+		t.Errorf("Expected parent progress 1/2, got %+v", p)
+	}
+	if p := progress["child"]; p.Done != 0 || p.Total != 1 {
+		t.Errorf("Expected child progress 0/1, got %+v", p)
+	}
+	if _, ok := progress["grandchild"]; ok {
+		t.Errorf("Todos without subtasks should be omitted")
+	}
+
+	// Act & Assert - UpdateMany でまとめて更新
+	child.MarkComplete(true)
+	grandchild.MarkComplete(true)
+	if err := repo.UpdateMany([]*entity.Todo{child, grandchild}); err != nil {
+		t.Fatalf("UpdateMany should succeed: %v", err)
+	}
+	progress, _ = repo.CountSubtasks(userID, []string{"parent"})
+	if p := progress["parent"]; p.Done != 2 {
+		t.Errorf("Expected both subtasks done, got %+v", p)
+	}
+
+	// Act & Assert - 親を削除すると子孫も削除される
+	if err := repo.Delete("parent", userID); err != nil {
+		t.Fatalf("Delete should succeed: %v", err)
+	}
+	remaining, _ := repo.ListByUserID(userID)
+	if len(remaining) != 0 {
+		t.Errorf("Expected subtree to be deleted, got %d todos", len(remaining))
+	}
+}
+
+func TestTodoRepository_RecurrenceAndCreateWithUpdates(t *testing.T) {
+	// Arrange
+	dbPath := "test_recurrence.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	userID := "user-123"
+	rule := &entity.Recurrence{Frequency: entity.FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday}}
+	todo := entity.NewTodo("todo-1", userID, "Patch servers", "")
+	todo.SetRecurrence(rule)
+	if err := repo.Create(todo); err != nil {
+		t.Fatalf("Create should succeed: %v", err)
+	}
+
+	// Act & Assert - ルールが往復できる
+	retrieved, _ := repo.GetByID("todo-1", userID)
+	if retrieved.Recurrence == nil || retrieved.Recurrence.String() != rule.String() {
+		t.Fatalf("Expected recurrence %q, got %v", rule.String(), retrieved.Recurrence)
+	}
+
+	// Act & Assert - 完了と次回分の作成
+	retrieved.MarkComplete(true)
+	next := retrieved.SpawnNext("todo-2", *retrieved.CompletedAt)
+	if err := repo.CreateWithUpdates(next, []*entity.Todo{retrieved}); err != nil {
+		t.Fatalf("CreateWithUpdates should succeed: %v", err)
+	}
+	completed, _ := repo.GetByID("todo-1", userID)
+	if !completed.Completed || completed.Recurrence != nil {
+		t.Errorf("Expected completed todo without rule, got %+v", completed)
+	}
+	spawned, err := repo.GetByID("todo-2", userID)
+	if err != nil {
+		t.Fatalf("Next occurrence should be stored: %v", err)
+	}
+	if spawned.Recurrence == nil || spawned.DueAt == nil || spawned.DueAt.Weekday() != time.Monday {
+		t.Errorf("Unexpected next occurrence: %+v", spawned)
+	}
+
+	// Act & Assert - 失敗した場合はどちらも保存されない
+	duplicate := entity.NewTodo("todo-2", userID, "Duplicate", "")
+	completed.Title = "Should not be saved"
+	if err := repo.CreateWithUpdates(duplicate, []*entity.Todo{completed}); err == nil {
+		t.Fatal("Expected error for duplicate ID")
+	}
+	unchanged, _ := repo.GetByID("todo-1", userID)
+	if unchanged.Title != "Patch servers" {
+		t.Errorf("Update should be rolled back, got title %q", unchanged.Title)
+	}
+}
+
+func TestTodoRepository_ListPage(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_page.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.TodoRepository
+	sqliteRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	// 作成日時・優先度・期限が重複するデータで、id による並びの安定性も確認する
+	userID := "user-123"
+	due := time.Now().Add(24 * time.Hour)
+	priorities := []entity.Priority{entity.PriorityHigh, entity.PriorityLow, entity.PriorityHigh, entity.PriorityNone, entity.PriorityHigh, entity.PriorityLow, entity.PriorityUrgent}
+	for i, priority := range priorities {
+		todo := entity.NewTodo(fmt.Sprintf("todo-%d", i), userID, fmt.Sprintf("Todo %d", i%3), "")
+		todo.SetPriority(priority)
+		if i%2 == 0 {
+			todo.SetDueAt(&due)
+		}
+		if i < 4 {
+			todo.MarkComplete(true)
+		}
+		repo.Create(todo)
+	}
+	repo.Create(entity.NewTodo("other-todo", "other-user", "Other", ""))
+
+	queries := map[string]repository.TodoQuery{
+		"default":         {UserID: userID},
+		"priority":        {UserID: userID, Sort: repository.TodoSort{Field: repository.SortByPriority}},
+		"priority asc":    {UserID: userID, Sort: repository.TodoSort{Field: repository.SortByPriority, Direction: repository.SortAscending}},
+		"due (nulls)":     {UserID: userID, Sort: repository.TodoSort{Field: repository.SortByDueAt, Direction: repository.SortDescending}},
+		"title":           {UserID: userID, Sort: repository.TodoSort{Field: repository.SortByTitle}},
+		"completed only":  {UserID: userID, CompletedOnly: true, Sort: repository.TodoSort{Field: repository.SortByCompletedAt}},
+		"filtered by due": {UserID: userID, DueFrom: &time.Time{}},
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			expected, err := repo.List(query)
+			if err != nil {
+				t.Fatalf("List should succeed: %v", err)
+			}
+
+			// Act - 2件ずつページをたどる（カーソルはトークン経由で往復させる）
+			var ids []string
+			page := repository.PageRequest{Size: 2}
+			for pages := 0; pages < 10; pages++ {
+				result, err := repo.ListPage(query, page)
+				if err != nil {
+					t.Fatalf("ListPage should succeed: %v", err)
+				}
+				if result.Total != len(expected) {
+					t.Errorf("Expected total %d, got %d", len(expected), result.Total)
+				}
+				for _, todo := range result.Todos {
+					ids = append(ids, todo.ID)
+				}
+				if result.Next == nil {
+					break
+				}
+				cursor, err := repository.DecodeCursor(result.Next.Encode())
+				if err != nil {
+					t.Fatalf("Cursor should round-trip: %v", err)
+				}
+				page.After = cursor
+			}
+
+			// Assert - ページを連結すると一括取得と同じ並びになる
+			if len(ids) != len(expected) {
+				t.Fatalf("Expected %d todos across pages, got %d: %v", len(expected), len(ids), ids)
+			}
+			for i, todo := range expected {
+				if ids[i] != todo.ID {
+					t.Errorf("Position %d: expected %s, got %s", i, todo.ID, ids[i])
+				}
+			}
+		})
+	}
+
+	t.Run("cursor from another sort is rejected", func(t *testing.T) {
+		result, _ := repo.ListPage(queries["priority"], repository.PageRequest{Size: 2})
+		_, err := repo.ListPage(queries["title"], repository.PageRequest{Size: 2, After: result.Next})
+		if !errors.Is(err, repository.ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+}
+
+func TestTodoRepository_Search(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_search.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.TodoRepository
+	sqliteRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	userID := "user-123"
+	repo.Create(entity.NewTodo("todo-title", userID, "Deploy API", "after review"))
+	repo.Create(entity.NewTodo("todo-desc", userID, "Friday chores", "deploy the <admin> site, then the on-call rotation"))
+	repo.Create(entity.NewTodo("todo-other", userID, "Groceries", "milk"))
+	repo.Create(entity.NewTodo("todo-foreign", "other-user", "Deploy everything", ""))
+
+	search := func(terms ...repository.SearchTerm) []*repository.TodoSearchHit {
+		t.Helper()
+		hits, err := repo.Search(repository.SearchQuery{UserID: userID, Terms: terms, Limit: 10})
+		if err != nil {
+			t.Fatalf("Search should succeed: %v", err)
+		}
+		return hits
+	}
+	ids := func(hits []*repository.TodoSearchHit) []string {
+		var result []string
+		for _, hit := range hits {
+			result = append(result, hit.Todo.ID)
+		}
+		return result
+	}
+
+	// Act & Assert - 前方一致。タイトルの一致が説明文の一致より上位になる
+	hits := search(repository.SearchTerm{Text: "deplo", Prefix: true})
+	if got := ids(hits); len(got) != 2 || got[0] != "todo-title" || got[1] != "todo-desc" {
+		t.Fatalf("Expected [todo-title todo-desc], got %v", got)
+	}
+	if !strings.Contains(hits[0].TitleHighlight, "<mark>Deploy</mark>") {
+		t.Errorf("Expected highlighted title, got %q", hits[0].TitleHighlight)
+	}
+	if hits[0].Todo.Title != "Deploy API" {
+		t.Errorf("Expected full todo in hit, got %q", hits[0].Todo.Title)
+	}
+	// スニペットはHTMLエスケープされる
+	if !strings.Contains(hits[1].Snippet, "<mark>deploy</mark>") || !strings.Contains(hits[1].Snippet, "&lt;admin&gt;") {
+		t.Errorf("Expected escaped snippet with highlight, got %q", hits[1].Snippet)
+	}
+
+	// フレーズ検索は語順を区別する
+	if got := ids(search(repository.SearchTerm{Text: "on-call rotation", Phrase: true})); len(got) != 1 || got[0] != "todo-desc" {
+		t.Errorf("Expected phrase to match todo-desc, got %v", got)
+	}
+	if got := search(repository.SearchTerm{Text: "rotation on-call", Phrase: true}); len(got) != 0 {
+		t.Errorf("Expected reversed phrase not to match, got %v", ids(got))
+	}
+
+	// すべての語を含むTodoだけが返る
+	if got := ids(search(repository.SearchTerm{Text: "deploy"}, repository.SearchTerm{Text: "review"})); len(got) != 1 || got[0] != "todo-title" {
+		t.Errorf("Expected only todo-title to contain both words, got %v", got)
+	}
+
+	// 更新・削除が索引に反映される
+	todo, _ := repo.GetByID("todo-other", userID)
+	todo.Update("Buy groceries", "oat milk and coffee")
+	repo.Update(todo)
+	if got := ids(search(repository.SearchTerm{Text: "coffee"})); len(got) != 1 || got[0] != "todo-other" {
+		t.Errorf("Expected updated description to be searchable, got %v", got)
+	}
+	repo.Delete("todo-title", userID)
+	if got := ids(search(repository.SearchTerm{Text: "review"})); len(got) != 0 {
+		t.Errorf("Expected deleted todo to disappear from search, got %v", got)
+	}
+}
+
+func TestTodoRepository_ListWithFilter(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_filter.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.TodoRepository
+	sqliteRepo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	userID := "user-123"
+	backend, _ := entity.NewTag("tag-1", userID, "Backend", "")
+	database.NewSQLiteTagRepository(sqliteRepo).Create(backend)
+
+	// 作成日時はタイムゾーンの異なる形式で保存されても比較できること
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Now()
+	due := now.Add(time.Hour)
+	projectID := "project-1"
+
+	deploy := entity.NewTodo("todo-deploy", userID, "Deploy API", "roll out v2")
+	deploy.CreatedAt = time.Date(2026, 1, 1, 8, 0, 0, 0, tokyo) // 2025-12-31T23:00:00Z
+	deploy.SetPriority(entity.PriorityHigh)
+	deploy.SetTags([]*entity.Tag{backend})
+	docs := entity.NewTodo("todo-docs", userID, "Write docs", "")
+	docs.CreatedAt = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	docs.MarkComplete(true)
+	vendor := entity.NewTodo("todo-vendor", userID, "Call vendor", "about the DEPLOY window")
+	vendor.SetDueAt(&due)
+	vendor.SetProjectID(&projectID)
+	for _, todo := range []*entity.Todo{deploy, docs, vendor} {
+		if err := repo.Create(todo); err != nil {
+			t.Fatalf("Failed to create todo: %v", err)
+		}
+	}
+	repo.Create(entity.NewTodo("todo-foreign", "other-user", "Deploy elsewhere", ""))
+
+	// 期待値は title 順
+	filters := []struct {
+		input    string
+		expected []string
+	}{
+		{`completed:false AND (title:~deploy OR created>2026-01-01)`, []string{"todo-vendor", "todo-deploy"}},
+		{`description:~deploy`, []string{"todo-vendor"}},
+		{`created<2026-01-01`, []string{"todo-deploy"}},
+		{`created:2026-01-02`, []string{"todo-docs"}},
+		{`priority>=medium OR completed:true`, []string{"todo-deploy", "todo-docs"}},
+		{`NOT due<tomorrow`, []string{"todo-deploy", "todo-docs"}},
+		{`due!=none project:project-1`, []string{"todo-vendor"}},
+		{`tag:BACKEND`, []string{"todo-deploy"}},
+		{`tag!=backend AND project:none`, []string{"todo-docs"}},
+		{`title:"write DOCS"`, []string{"todo-docs"}},
+		{`NOT (title:~deploy OR description:~deploy)`, []string{"todo-docs"}},
+	}
+
+	for _, tt := range filters {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := filter.Parse(tt.input, now)
+			if err != nil {
+				t.Fatalf("Parse should succeed: %v", err)
+			}
+			query := repository.TodoQuery{UserID: userID, Filter: expr, Sort: repository.TodoSort{Field: repository.SortByTitle}}
+
+			// Act
+			todos, err := repo.List(query)
+
+			// Assert - SQL での絞り込みがメモリ上の評価と一致する
+			if err != nil {
+				t.Fatalf("List should succeed: %v", err)
+			}
+			var got, expected []string
+			for _, todo := range todos {
+				got = append(got, todo.ID)
+			}
+			for _, todo := range []*entity.Todo{vendor, deploy, docs} {
+				if query.Matches(todo) {
+					expected = append(expected, todo.ID)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			if strings.Join(expected, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("In-memory evaluation disagrees: expected %v, got %v", tt.expected, expected)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_ListWithFilter_NonASCII(t *testing.T) {
+	// Arrange
+	dbPath := "test_todos_filter_unicode.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	userID := "user-123"
+	apples := entity.NewTodo("todo-apples", userID, "Äpfel kaufen", "")
+	street := entity.NewTodo("todo-street", userID, "Umzug", "neue Adresse: ÉCOLE")
+	for _, todo := range []*entity.Todo{apples, street} {
+		if err := repo.Create(todo); err != nil {
+			t.Fatalf("Failed to create todo: %v", err)
+		}
+	}
+
+	// ASCII 以外の大文字・小文字も区別しない
+	filters := []struct {
+		input    string
+		expected []string
+	}{
+		{`title:~äPFEL`, []string{"todo-apples"}},
+		{`title:"äpfel KAUFEN"`, []string{"todo-apples"}},
+		{`description:~école`, []string{"todo-street"}},
+		{`NOT title:~äpfel`, []string{"todo-street"}},
+	}
+
+	for _, tt := range filters {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := filter.Parse(tt.input, time.Now())
+			if err != nil {
+				t.Fatalf("Parse should succeed: %v", err)
+			}
+			query := repository.TodoQuery{UserID: userID, Filter: expr, Sort: repository.TodoSort{Field: repository.SortByTitle}}
+
+			// Act
+			todos, err := repo.List(query)
+
+			// Assert - SQL での絞り込みがメモリ上の評価と一致する
+			if err != nil {
+				t.Fatalf("List should succeed: %v", err)
+			}
+			var got []string
+			for _, todo := range todos {
+				got = append(got, todo.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			for _, todo := range []*entity.Todo{apples, street} {
+				inList := strings.Contains(strings.Join(got, ","), todo.ID)
+				if query.Matches(todo) != inList {
+					t.Errorf("In-memory evaluation of %s disagrees with SQL", todo.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoRepository_WorkspaceTodo(t *testing.T) {
+	// Arrange
+	dbPath := "test_workspace_todos.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	// ワークスペースのTodoはワークスペースIDを所有者として保存する
+	todo := entity.NewTodo("shared-id", "ws-1", "Shared", "")
+	todo.WorkspaceID = "ws-1"
+	todo.CreatedBy = "user-123"
+
+	// Act
+	if err := repo.Create(todo); err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+	personal := entity.NewTodo("personal-id", "user-123", "Personal", "")
+	if err := repo.Create(personal); err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+
+	// Assert - ワークスペースと作成者が保存される
+	got, err := repo.GetByID("shared-id", "ws-1")
+	if err != nil {
+		t.Fatalf("Failed to get todo: %v", err)
+	}
+	if got.WorkspaceID != "ws-1" || got.CreatedBy != "user-123" {
+		t.Errorf("Expected workspace ws-1 created by user-123, got %q by %q", got.WorkspaceID, got.CreatedBy)
+	}
+
+	// Assert - 個人のTodoにはワークスペースがない
+	got, err = repo.GetByID("personal-id", "user-123")
+	if err != nil {
+		t.Fatalf("Failed to get todo: %v", err)
+	}
+	if got.WorkspaceID != "" || got.CreatedBy != "user-123" {
+		t.Errorf("Expected a personal todo created by user-123, got %q by %q", got.WorkspaceID, got.CreatedBy)
+	}
+
+	// Assert - 作成したユーザーの一覧にはワークスペースのTodoは含まれない
+	todos, _ := repo.ListByUserID("user-123")
+	if len(todos) != 1 || todos[0].ID != "personal-id" {
+		t.Errorf("Expected only the personal todo, got %d todos", len(todos))
+	}
+}
+
+func TestTodoRepository_AssignedTodos(t *testing.T) {
+	// Arrange
+	dbPath := "test_assigned_todos.db"
+	defer os.Remove(dbPath)
+
+	repo, err := database.NewSQLiteTodoRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer repo.Close()
+
+	shared := entity.NewTodo("shared-id", "ws-1", "Shared", "")
+	shared.WorkspaceID = "ws-1"
+	other := entity.NewTodo("other-id", "ws-2", "Other workspace", "")
+	other.WorkspaceID = "ws-2"
+	other.AssigneeID = "user-123"
+	unassigned := entity.NewTodo("unassigned-id", "ws-1", "Unassigned", "")
+	unassigned.WorkspaceID = "ws-1"
+	for _, todo := range []*entity.Todo{shared, other, unassigned} {
+		if err := repo.Create(todo); err != nil {
+			t.Fatalf("Failed to create todo: %v", err)
+		}
+	}
+
+	// Act - 作成後に割り当てる
+	shared.SetAssignee("user-123")
+	if err := repo.Update(shared); err != nil {
+		t.Fatalf("Failed to update todo: %v", err)
+	}
+
+	// Assert - 割り当てが保存される
+	got, err := repo.GetByID("shared-id", "ws-1")
+	if err != nil {
+		t.Fatalf("Failed to get todo: %v", err)
+	}
+	if got.AssigneeID != "user-123" {
+		t.Errorf("Expected assignee user-123, got %q", got.AssigneeID)
+	}
+
+	// Assert - 指定した所有者の中から、割り当てられたTodoだけを返す
+	todos, err := repo.List(repository.TodoQuery{UserIDs: []string{"user-123", "ws-1"}, AssigneeID: "user-123"})
+	if err != nil {
+		t.Fatalf("Failed to list todos: %v", err)
+	}
+	if len(todos) != 1 || todos[0].ID != "shared-id" {
+		t.Errorf("Expected only the shared todo, got %d todos", len(todos))
+	}
+
+	// Assert - 割り当てを外すと一覧から消える
+	shared.SetAssignee("")
+	if err := repo.Update(shared); err != nil {
+		t.Fatalf("Failed to update todo: %v", err)
+	}
+	page, err := repo.ListPage(repository.TodoQuery{UserIDs: []string{"ws-1", "ws-2"}, AssigneeID: "user-123"}, repository.PageRequest{Size: 10})
+	if err != nil {
+		t.Fatalf("Failed to list todos: %v", err)
+	}
+	if page.Total != 1 || page.Todos[0].ID != "other-id" {
+		t.Errorf("Expected only the todo of ws-2, got %d todos", page.Total)
+	}
+}
@@ -0,0 +1,257 @@
+package database
+
+import (
+	"database/sql"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+)
+
+// Markers placed around matches by SQLite before the text is HTML-escaped
+const (
+	markStart = "\x01"
+	markEnd   = "\x02"
+)
+
+// snippetRadius is the number of characters kept on each side of the first
+// match when the full-text index is not available
+const snippetRadius = 60
+
+// createSearchIndex sets up the todos_fts full-text index, kept in sync with
+// todos by triggers. SQLite builds without FTS5 (the sqlite_fts5 build tag)
+// fall back to LIKE matching.
+func (r *SQLiteTodoRepository) createSearchIndex() error {
+	var exists int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'todos_fts'`).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+	CREATE VIRTUAL TABLE IF NOT EXISTS todos_fts USING fts5(
+		todo_id UNINDEXED,
+		user_id UNINDEXED,
+		title,
+		description,
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such module: fts5") {
+			return nil
+		}
+		return err
+	}
+	r.fullText = true
+
+	queries := []string{`
+	CREATE TRIGGER IF NOT EXISTS todos_fts_insert AFTER INSERT ON todos BEGIN
+		INSERT INTO todos_fts (todo_id, user_id, title, description)
+		VALUES (new.id, new.user_id, new.title, new.description);
+	END`, `
+	CREATE TRIGGER IF NOT EXISTS todos_fts_update AFTER UPDATE OF title, description ON todos BEGIN
+		DELETE FROM todos_fts WHERE todo_id = old.id;
+		INSERT INTO todos_fts (todo_id, user_id, title, description)
+		VALUES (new.id, new.user_id, new.title, new.description);
+	END`, `
+	CREATE TRIGGER IF NOT EXISTS todos_fts_delete AFTER DELETE ON todos BEGIN
+		DELETE FROM todos_fts WHERE todo_id = old.id;
+	END`,
+	}
+	// 索引を新しく作った場合は既存のTodoを取り込む
+	if exists == 0 {
+		queries = append(queries, `
+		INSERT INTO todos_fts (todo_id, user_id, title, description)
+		SELECT id, user_id, title, description FROM todos`)
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteTodoRepository) Search(q repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	if len(q.Terms) == 0 {
+		return nil, nil
+	}
+	if !r.fullText {
+		return r.searchLike(q)
+	}
+
+	// タイトルの一致を説明文より重く評価する
+	query := `
+	SELECT todo_id,
+		highlight(todos_fts, 2, char(1), char(2)),
+		snippet(todos_fts, 3, char(1), char(2), '…', 16),
+		bm25(todos_fts, 0.0, 0.0, 10.0, 1.0) AS score
+	FROM todos_fts
+	WHERE todos_fts MATCH ? AND user_id = ?
+	ORDER BY score
+	LIMIT ?`
+
+	rows, err := r.db.Query(query, matchExpression(q.Terms), q.UserID, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*repository.TodoSearchHit
+	var ids []interface{}
+	for rows.Next() {
+		var hit repository.TodoSearchHit
+		var todoID string
+		var snippet sql.NullString
+		if err := rows.Scan(&todoID, &hit.TitleHighlight, &snippet, &hit.Rank); err != nil {
+			return nil, err
+		}
+		hit.TitleHighlight = markup(hit.TitleHighlight)
+		hit.Snippet = markup(snippet.String)
+		hit.Todo = &entity.Todo{ID: todoID}
+		hits = append(hits, &hit)
+		ids = append(ids, todoID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+
+	todos, err := r.queryTodos(`
+	SELECT `+todoColumns+`
+	FROM todos WHERE user_id = ? AND id IN (`+placeholders(len(ids))+`)`,
+		append([]interface{}{q.UserID}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Todo, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+
+	result := hits[:0]
+	for _, hit := range hits {
+		if todo, ok := byID[hit.Todo.ID]; ok {
+			hit.Todo = todo
+			result = append(result, hit)
+		}
+	}
+	return result, nil
+}
+
+// searchLike is used when SQLite was built without FTS5. Hits are ordered by
+// whether the title matches and then by last update.
+func (r *SQLiteTodoRepository) searchLike(q repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	conditions := []string{"user_id = ?"}
+	args := []interface{}{q.UserID}
+	titleMatches := make([]string, 0, len(q.Terms))
+	var titleArgs []interface{}
+	for _, term := range q.Terms {
+		pattern := "%" + escapeLike(term.Text) + "%"
+		conditions = append(conditions, `(title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+		titleMatches = append(titleMatches, `title LIKE ? ESCAPE '\'`)
+		titleArgs = append(titleArgs, pattern)
+	}
+
+	query := `
+	SELECT ` + todoColumns + `
+	FROM todos WHERE ` + strings.Join(conditions, " AND ") + `
+	ORDER BY (` + strings.Join(titleMatches, " AND ") + `) DESC, updated_at DESC, id ASC
+	LIMIT ?`
+	args = append(append(args, titleArgs...), q.Limit)
+
+	todos, err := r.queryTodos(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := termPattern(q.Terms)
+	hits := make([]*repository.TodoSearchHit, 0, len(todos))
+	for i, todo := range todos {
+		hits = append(hits, &repository.TodoSearchHit{
+			Todo:           todo,
+			TitleHighlight: highlightMatches(todo.Title, pattern),
+			Snippet:        highlightMatches(excerpt(todo.Description, pattern), pattern),
+			Rank:           float64(i),
+		})
+	}
+	return hits, nil
+}
+
+// matchExpression builds an FTS5 query from search terms. Every term is
+// quoted so that user input can never be read as FTS5 syntax.
+func matchExpression(terms []repository.SearchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		part := `"` + strings.ReplaceAll(term.Text, `"`, `""`) + `"`
+		if term.Prefix {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// markup HTML-escapes text highlighted by SQLite and turns the markers into <mark> tags
+func markup(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, markStart, "<mark>")
+	return strings.ReplaceAll(text, markEnd, "</mark>")
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// termPattern matches any of the terms, ignoring case. Prefix terms match
+// up to the end of the word.
+func termPattern(terms []repository.SearchTerm) *regexp.Regexp {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		expr := regexp.QuoteMeta(term.Text)
+		if term.Prefix {
+			expr += `[\pL\pN_]*`
+		}
+		quoted = append(quoted, expr)
+	}
+	return regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+}
+
+// highlightMatches HTML-escapes text and wraps the matches of pattern in <mark>
+func highlightMatches(text string, pattern *regexp.Regexp) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:match[0]]))
+		b.WriteString("<mark>" + html.EscapeString(text[match[0]:match[1]]) + "</mark>")
+		last = match[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// excerpt cuts text down to the surroundings of the first match of pattern
+func excerpt(text string, pattern *regexp.Regexp) string {
+	match := pattern.FindStringIndex(text)
+	if match == nil {
+		return ""
+	}
+
+	runes := []rune(text)
+	start := len([]rune(text[:match[0]])) - snippetRadius
+	end := len([]rune(text[:match[1]])) + snippetRadius
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+	return prefix + string(runes[start:end]) + suffix
+}
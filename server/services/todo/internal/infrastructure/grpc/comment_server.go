@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func (s *TodoServer) AddComment(ctx context.Context, req *pb.AddCommentRequest) (*pb.AddCommentResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.AddCommentResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	comment, err := s.commentService.AddComment(req.TodoId, owner, req.UserId, req.Body)
+	if err != nil {
+		return &pb.AddCommentResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.AddCommentResponse{
+		Comment: commentToProto(comment, locationFromContext(ctx)),
+	}, nil
+}
+
+func (s *TodoServer) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.ListCommentsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	comments, err := s.commentService.ListComments(req.TodoId, owner)
+	if err != nil {
+		return &pb.ListCommentsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	loc := locationFromContext(ctx)
+	var pbComments []*pb.Comment
+	for _, comment := range comments {
+		pbComments = append(pbComments, commentToProto(comment, loc))
+	}
+
+	return &pb.ListCommentsResponse{
+		Comments: pbComments,
+	}, nil
+}
+
+func (s *TodoServer) EditComment(ctx context.Context, req *pb.EditCommentRequest) (*pb.EditCommentResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.EditCommentResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	comment, err := s.commentService.EditComment(req.Id, req.TodoId, owner, req.UserId, req.Body)
+	if err != nil {
+		return &pb.EditCommentResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.EditCommentResponse{
+		Comment: commentToProto(comment, locationFromContext(ctx)),
+	}, nil
+}
+
+func (s *TodoServer) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*pb.DeleteCommentResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.DeleteCommentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	err = s.commentService.DeleteComment(req.Id, req.TodoId, owner, req.UserId)
+	if err != nil {
+		return &pb.DeleteCommentResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteCommentResponse{
+		Success: true,
+	}, nil
+}
+
+// commentToProto converts a comment, leaving out the body of deleted ones
+func commentToProto(comment *entity.Comment, loc *time.Location) *pb.Comment {
+	pbComment := &pb.Comment{
+		Id:        comment.ID,
+		TodoId:    comment.TodoID,
+		AuthorId:  comment.AuthorID,
+		CreatedAt: comment.CreatedAt.In(loc).Format(time.RFC3339),
+		Deleted:   comment.Deleted(),
+	}
+	if !comment.Deleted() {
+		pbComment.Body = comment.Body
+	}
+	if comment.EditedAt != nil {
+		pbComment.EditedAt = comment.EditedAt.In(loc).Format(time.RFC3339)
+	}
+	return pbComment
+}
@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+func (s *TodoServer) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.CreateProjectResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.CreateProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	project, err := s.projectService.CreateProject(owner, req.Name, req.Description)
+	if err != nil {
+		return &pb.CreateProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateProjectResponse{
+		Project: projectToProto(project, entity.ProjectCounts{}),
+	}, nil
+}
+
+func (s *TodoServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.GetProjectResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.GetProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	summary, err := s.projectService.GetProject(req.Id, owner)
+	if err != nil {
+		return &pb.GetProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetProjectResponse{
+		Project: projectSummaryToProto(summary),
+	}, nil
+}
+
+func (s *TodoServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.ListProjectsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	summaries, err := s.projectService.ListProjects(owner)
+	if err != nil {
+		return &pb.ListProjectsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var projects []*pb.Project
+	for _, summary := range summaries {
+		projects = append(projects, projectSummaryToProto(summary))
+	}
+
+	return &pb.ListProjectsResponse{
+		Projects: projects,
+	}, nil
+}
+
+func (s *TodoServer) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.UpdateProjectResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.UpdateProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	summary, err := s.projectService.UpdateProject(req.Id, owner, req.Name, req.Description)
+	if err != nil {
+		return &pb.UpdateProjectResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdateProjectResponse{
+		Project: projectSummaryToProto(summary),
+	}, nil
+}
+
+func (s *TodoServer) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*pb.DeleteProjectResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.DeleteProjectResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	err = s.projectService.DeleteProject(req.Id, owner)
+	if err != nil {
+		return &pb.DeleteProjectResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteProjectResponse{
+		Success: true,
+	}, nil
+}
+
+func projectSummaryToProto(summary *service.ProjectSummary) *pb.Project {
+	return projectToProto(summary.Project, summary.Counts)
+}
+
+func projectToProto(project *entity.Project, counts entity.ProjectCounts) *pb.Project {
+	return &pb.Project{
+		Id:             project.ID,
+		UserId:         project.UserID,
+		Name:           project.Name,
+		Description:    project.Description,
+		CreatedAt:      project.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      project.UpdatedAt.Format(time.RFC3339),
+		OpenCount:      int32(counts.Open),
+		CompletedCount: int32(counts.Completed),
+	}
+}
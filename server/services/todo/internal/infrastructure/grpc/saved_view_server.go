@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
+)
+
+var viewSortFields = map[pb.SortField]repository.SortField{
+	pb.SortField_SORT_FIELD_UNSPECIFIED: repository.SortByDefault,
+	pb.SortField_SORT_FIELD_PRIORITY:    repository.SortByPriority,
+	pb.SortField_SORT_FIELD_DUE_AT:      repository.SortByDueAt,
+	pb.SortField_SORT_FIELD_CREATED_AT:  repository.SortByCreatedAt,
+	pb.SortField_SORT_FIELD_UPDATED_AT:  repository.SortByUpdatedAt,
+	pb.SortField_SORT_FIELD_TITLE:       repository.SortByTitle,
+}
+
+var viewSortOrders = map[pb.SortDirection]string{
+	pb.SortDirection_SORT_DIRECTION_UNSPECIFIED: "",
+	pb.SortDirection_SORT_DIRECTION_ASC:         "asc",
+	pb.SortDirection_SORT_DIRECTION_DESC:        "desc",
+}
+
+var viewCompletions = map[pb.ViewCompletion]entity.ViewCompletion{
+	pb.ViewCompletion_VIEW_COMPLETION_UNSPECIFIED: entity.ViewCompletionAny,
+	pb.ViewCompletion_VIEW_COMPLETION_OPEN:        entity.ViewCompletionOpen,
+	pb.ViewCompletion_VIEW_COMPLETION_COMPLETED:   entity.ViewCompletionCompleted,
+}
+
+func (s *TodoServer) CreateSavedView(ctx context.Context, req *pb.CreateSavedViewRequest) (*pb.CreateSavedViewResponse, error) {
+	criteria, err := viewCriteriaFromProto(req.Criteria)
+	if err != nil {
+		return &pb.CreateSavedViewResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	view, err := s.viewService.CreateSavedView(req.UserId, req.Name, req.Icon, criteria)
+	if err != nil {
+		return &pb.CreateSavedViewResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateSavedViewResponse{
+		View: savedViewToProto(view),
+	}, nil
+}
+
+func (s *TodoServer) GetSavedView(ctx context.Context, req *pb.GetSavedViewRequest) (*pb.GetSavedViewResponse, error) {
+	view, err := s.viewService.GetSavedView(req.Id, req.UserId)
+	if err != nil {
+		return &pb.GetSavedViewResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetSavedViewResponse{
+		View: savedViewToProto(view),
+	}, nil
+}
+
+func (s *TodoServer) ListSavedViews(ctx context.Context, req *pb.ListSavedViewsRequest) (*pb.ListSavedViewsResponse, error) {
+	views, err := s.viewService.ListSavedViews(req.UserId)
+	if err != nil {
+		return &pb.ListSavedViewsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbViews []*pb.SavedView
+	for _, view := range views {
+		pbViews = append(pbViews, savedViewToProto(view))
+	}
+
+	return &pb.ListSavedViewsResponse{
+		Views: pbViews,
+	}, nil
+}
+
+func (s *TodoServer) UpdateSavedView(ctx context.Context, req *pb.UpdateSavedViewRequest) (*pb.UpdateSavedViewResponse, error) {
+	update := service.SavedViewUpdate{
+		Name: req.Name,
+		Icon: req.Icon,
+	}
+	if req.Criteria != nil {
+		criteria, err := viewCriteriaFromProto(req.Criteria)
+		if err != nil {
+			return &pb.UpdateSavedViewResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		update.Criteria = &criteria
+	}
+
+	view, err := s.viewService.UpdateSavedView(req.Id, req.UserId, update)
+	if err != nil {
+		return &pb.UpdateSavedViewResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdateSavedViewResponse{
+		View: savedViewToProto(view),
+	}, nil
+}
+
+func (s *TodoServer) DeleteSavedView(ctx context.Context, req *pb.DeleteSavedViewRequest) (*pb.DeleteSavedViewResponse, error) {
+	err := s.viewService.DeleteSavedView(req.Id, req.UserId)
+	if err != nil {
+		return &pb.DeleteSavedViewResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteSavedViewResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *TodoServer) ListTodosByView(ctx context.Context, req *pb.ListTodosByViewRequest) (*pb.ListTodosByViewResponse, error) {
+	loc := locationFromContext(ctx)
+	viewService := s.viewService.In(loc)
+	page := &service.Page{}
+	var err error
+	if isPaged(req.PageSize, req.PageToken) {
+		page, err = viewService.ListTodosByViewPage(req.ViewId, req.UserId, int(req.PageSize), req.PageToken)
+	} else {
+		page.Todos, err = viewService.ListTodosByView(req.ViewId, req.UserId)
+		page.Total = len(page.Todos)
+	}
+	if err == nil {
+		err = s.todoService.AttachProgress(page.Todos)
+	}
+	if err != nil {
+		return &pb.ListTodosByViewResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var todos []*pb.Todo
+	for _, todo := range page.Todos {
+		todos = append(todos, s.todoToProto(todo, loc))
+	}
+
+	return &pb.ListTodosByViewResponse{
+		Todos:         todos,
+		NextPageToken: page.NextPageToken,
+		TotalCount:    int32(page.Total),
+	}, nil
+}
+
+func viewCriteriaFromProto(criteria *pb.ViewCriteria) (entity.ViewCriteria, error) {
+	if criteria == nil {
+		return entity.ViewCriteria{}, nil
+	}
+
+	completion, ok := viewCompletions[criteria.Completion]
+	if !ok {
+		return entity.ViewCriteria{}, fmt.Errorf("unknown view completion: %v", criteria.Completion)
+	}
+	sortField, ok := viewSortFields[criteria.SortBy]
+	if !ok {
+		return entity.ViewCriteria{}, fmt.Errorf("unknown sort field: %v", criteria.SortBy)
+	}
+	order, ok := viewSortOrders[criteria.SortDirection]
+	if !ok {
+		return entity.ViewCriteria{}, fmt.Errorf("unknown sort direction: %v", criteria.SortDirection)
+	}
+
+	return entity.ViewCriteria{
+		Completion:    completion,
+		CreatedFrom:   criteria.CreatedFrom,
+		CreatedBefore: criteria.CreatedBefore,
+		UpdatedFrom:   criteria.UpdatedFrom,
+		UpdatedBefore: criteria.UpdatedBefore,
+		TitleContains: criteria.TitleContains,
+		Filter:        criteria.Filter,
+		Sort:          string(sortField),
+		Order:         order,
+	}, nil
+}
+
+func viewCriteriaToProto(criteria entity.ViewCriteria) *pb.ViewCriteria {
+	pbCriteria := &pb.ViewCriteria{
+		CreatedFrom:   criteria.CreatedFrom,
+		CreatedBefore: criteria.CreatedBefore,
+		UpdatedFrom:   criteria.UpdatedFrom,
+		UpdatedBefore: criteria.UpdatedBefore,
+		TitleContains: criteria.TitleContains,
+		Filter:        criteria.Filter,
+	}
+	for completion, value := range viewCompletions {
+		if value == criteria.Completion {
+			pbCriteria.Completion = completion
+		}
+	}
+	for field, value := range viewSortFields {
+		if string(value) == criteria.Sort {
+			pbCriteria.SortBy = field
+		}
+	}
+	for direction, value := range viewSortOrders {
+		if value == criteria.Order {
+			pbCriteria.SortDirection = direction
+		}
+	}
+	return pbCriteria
+}
+
+func savedViewToProto(view *entity.SavedView) *pb.SavedView {
+	return &pb.SavedView{
+		Id:        view.ID,
+		UserId:    view.UserID,
+		Name:      view.Name,
+		Icon:      view.Icon,
+		Criteria:  viewCriteriaToProto(view.Criteria),
+		CreatedAt: view.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: view.UpdatedAt.Format(time.RFC3339),
+	}
+}
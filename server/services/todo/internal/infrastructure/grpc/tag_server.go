@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+)
+
+func (s *TodoServer) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.CreateTagResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.CreateTagResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	tag, err := s.tagService.CreateTag(owner, req.Name, req.Color)
+	if err != nil {
+		return &pb.CreateTagResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateTagResponse{
+		Tag: tagToProto(tag),
+	}, nil
+}
+
+func (s *TodoServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.ListTagsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	tagEntities, err := s.tagService.ListTags(owner)
+	if err != nil {
+		return &pb.ListTagsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var tags []*pb.Tag
+	for _, tag := range tagEntities {
+		tags = append(tags, tagToProto(tag))
+	}
+
+	return &pb.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (s *TodoServer) UpdateTag(ctx context.Context, req *pb.UpdateTagRequest) (*pb.UpdateTagResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.UpdateTagResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var tag *entity.Tag
+
+	if req.Name != "" {
+		tag, err = s.tagService.RenameTag(req.Id, owner, req.Name)
+		if err != nil {
+			return &pb.UpdateTagResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+
+	if req.Color != "" || req.ClearColor {
+		tag, err = s.tagService.RecolorTag(req.Id, owner, req.Color)
+		if err != nil {
+			return &pb.UpdateTagResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+
+	if tag == nil {
+		return &pb.UpdateTagResponse{
+			Error: "nothing to update",
+		}, nil
+	}
+
+	return &pb.UpdateTagResponse{
+		Tag: tagToProto(tag),
+	}, nil
+}
+
+func (s *TodoServer) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*pb.DeleteTagResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.DeleteTagResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	err = s.tagService.DeleteTag(req.Id, owner)
+	if err != nil {
+		return &pb.DeleteTagResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteTagResponse{
+		Success: true,
+	}, nil
+}
+
+func tagToProto(tag *entity.Tag) *pb.Tag {
+	return &pb.Tag{
+		Id:        tag.ID,
+		UserId:    tag.UserID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		CreatedAt: tag.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: tag.UpdatedAt.Format(time.RFC3339),
+	}
+}
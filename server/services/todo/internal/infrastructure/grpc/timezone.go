@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// timezoneMetadataKey carries the caller's IANA time zone; the BFF sets it
+// from the user's preferences
+const timezoneMetadataKey = "x-timezone"
+
+// locations caches loaded time zones, which are read from disk otherwise
+var locations sync.Map
+
+// locationFromContext returns the caller's time zone, falling back to the
+// server's when the call names none or an unknown one
+func locationFromContext(ctx context.Context) *time.Location {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return time.Local
+	}
+	values := md.Get(timezoneMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return time.Local
+	}
+
+	if loc, ok := locations.Load(values[0]); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(values[0])
+	if err != nil {
+		return time.Local
+	}
+	locations.Store(values[0], loc)
+	return loc
+}
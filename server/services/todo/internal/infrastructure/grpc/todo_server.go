@@ -2,26 +2,89 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/filter"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
 )
 
 type TodoServer struct {
 	pb.UnimplementedTodoServiceServer
-	todoService *service.TodoService
+	todoService    *service.TodoService
+	tagService     *service.TagService
+	projectService *service.ProjectService
+	viewService    *service.SavedViewService
+	commentService *service.CommentService
 }
 
-func NewTodoServer(todoService *service.TodoService) *TodoServer {
+func NewTodoServer(todoService *service.TodoService, tagService *service.TagService, projectService *service.ProjectService, viewService *service.SavedViewService, commentService *service.CommentService) *TodoServer {
 	return &TodoServer{
-		todoService: todoService,
+		todoService:    todoService,
+		tagService:     tagService,
+		projectService: projectService,
+		viewService:    viewService,
+		commentService: commentService,
 	}
 }
 
 func (s *TodoServer) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest) (*pb.CreateTodoResponse, error) {
-	todo, err := s.todoService.CreateTodo(req.UserId, req.Title, req.Description)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.CreateTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	opts, err := scheduleOptions(req.DueAt, req.RemindAt, false, false)
+	if err != nil {
+		return &pb.CreateTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	if req.Priority != pb.Priority_PRIORITY_NONE {
+		opts = append(opts, service.WithPriority(entity.Priority(req.Priority)))
+	}
+	if len(req.TagIds) > 0 {
+		tags, err := s.tagService.ResolveTags(owner, req.TagIds)
+		if err != nil {
+			return &pb.CreateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		opts = append(opts, service.WithTags(tags))
+	}
+	if req.ProjectId != "" {
+		if err := s.projectService.EnsureProject(req.ProjectId, owner); err != nil {
+			return &pb.CreateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		projectID := req.ProjectId
+		opts = append(opts, service.WithProject(&projectID))
+	}
+	if req.ParentId != "" {
+		parentID := req.ParentId
+		opts = append(opts, service.WithParent(&parentID))
+	}
+	if req.Recurrence != nil {
+		recurrence, err := recurrenceFromProto(req.Recurrence)
+		if err != nil {
+			return &pb.CreateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		opts = append(opts, service.WithRecurrence(recurrence))
+	}
+	if req.WorkspaceId != "" {
+		opts = append(opts, service.InWorkspace(req.WorkspaceId, req.UserId))
+	}
+
+	todo, err := s.todoService.CreateTodo(owner, req.Title, req.Description, opts...)
 	if err != nil {
 		return &pb.CreateTodoResponse{
 			Error: err.Error(),
@@ -29,12 +92,19 @@ func (s *TodoServer) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest)
 	}
 
 	return &pb.CreateTodoResponse{
-		Todo: s.todoToProto(todo),
+		Todo: s.todoToProto(todo, locationFromContext(ctx)),
 	}, nil
 }
 
 func (s *TodoServer) GetTodo(ctx context.Context, req *pb.GetTodoRequest) (*pb.GetTodoResponse, error) {
-	todo, err := s.todoService.GetTodo(req.Id, req.UserId)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.GetTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	todo, err := s.todoService.GetTodoTree(req.Id, owner)
 	if err != nil {
 		return &pb.GetTodoResponse{
 			Error: err.Error(),
@@ -43,7 +113,7 @@ func (s *TodoServer) GetTodo(ctx context.Context, req *pb.GetTodoRequest) (*pb.G
 
 	var pbTodo *pb.Todo
 	if todo != nil {
-		pbTodo = s.todoToProto(todo)
+		pbTodo = s.todoToProto(todo, locationFromContext(ctx))
 	}
 
 	return &pb.GetTodoResponse{
@@ -52,39 +122,140 @@ func (s *TodoServer) GetTodo(ctx context.Context, req *pb.GetTodoRequest) (*pb.G
 }
 
 func (s *TodoServer) ListTodos(ctx context.Context, req *pb.ListTodosRequest) (*pb.ListTodosResponse, error) {
-	var todos []*pb.Todo
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.ListTodosResponse{
+			Error: err.Error(),
+		}, nil
+	}
 
-	if req.CompletedOnly {
-		todoEntities, err := s.todoService.ListCompletedTodos(req.UserId)
-		if err != nil {
-			return &pb.ListTodosResponse{
-				Error: err.Error(),
-			}, nil
-		}
+	if isPaged(req.PageSize, req.PageToken) {
+		return s.listTodosPage(ctx, req, owner)
+	}
 
-		for _, todo := range todoEntities {
-			todos = append(todos, s.todoToProto(todo))
-		}
-	} else {
-		todoEntities, err := s.todoService.ListTodos(req.UserId)
-		if err != nil {
+	// 「今日」やフィルターの日付は利用者のタイムゾーンで解釈する
+	loc := locationFromContext(ctx)
+	todoService := s.todoService.In(loc)
+
+	var todoEntities []*entity.Todo
+
+	if hasListOptions(req) {
+		opts, optsErr := listOptionsFromProto(req)
+		if optsErr != nil {
 			return &pb.ListTodosResponse{
-				Error: err.Error(),
+				Error: optsErr.Error(),
 			}, nil
 		}
+		todoEntities, err = todoService.ListTodosWithOptions(owner, opts)
+	} else if req.CompletedOnly {
+		todoEntities, err = todoService.ListCompletedTodos(owner)
+	} else {
+		todoEntities, err = todoService.ListTodos(owner)
+	}
+	if err == nil {
+		err = todoService.AttachProgress(todoEntities)
+	}
+	if err != nil {
+		return listTodosError(err), nil
+	}
 
-		for _, todo := range todoEntities {
-			todos = append(todos, s.todoToProto(todo))
-		}
+	var todos []*pb.Todo
+	for _, todo := range todoEntities {
+		todos = append(todos, s.todoToProto(todo, loc))
+	}
+
+	return &pb.ListTodosResponse{
+		Todos:      todos,
+		TotalCount: int32(len(todos)),
+	}, nil
+}
+
+func (s *TodoServer) listTodosPage(ctx context.Context, req *pb.ListTodosRequest, owner string) (*pb.ListTodosResponse, error) {
+	opts, err := listOptionsFromProto(req)
+	if err != nil {
+		return &pb.ListTodosResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	loc := locationFromContext(ctx)
+	todoService := s.todoService.In(loc)
+	page, err := todoService.ListTodosPage(owner, opts, int(req.PageSize), req.PageToken)
+	if err == nil {
+		err = todoService.AttachProgress(page.Todos)
+	}
+	if err != nil {
+		return listTodosError(err), nil
+	}
+
+	var todos []*pb.Todo
+	for _, todo := range page.Todos {
+		todos = append(todos, s.todoToProto(todo, loc))
 	}
 
 	return &pb.ListTodosResponse{
-		Todos: todos,
+		Todos:         todos,
+		NextPageToken: page.NextPageToken,
+		TotalCount:    int32(page.Total),
 	}, nil
 }
 
 func (s *TodoServer) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest) (*pb.UpdateTodoResponse, error) {
-	todo, err := s.todoService.UpdateTodo(req.Id, req.UserId, req.Title, req.Description)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.UpdateTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	opts, err := scheduleOptions(req.DueAt, req.RemindAt, req.ClearDueAt, req.ClearRemindAt)
+	if err != nil {
+		return &pb.UpdateTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	if req.Priority != nil {
+		opts = append(opts, service.WithPriority(entity.Priority(*req.Priority)))
+	}
+	if req.SetTags {
+		tags, err := s.tagService.ResolveTags(owner, req.TagIds)
+		if err != nil {
+			return &pb.UpdateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		opts = append(opts, service.WithTags(tags))
+	}
+	if req.ClearProject {
+		opts = append(opts, service.WithProject(nil))
+	} else if req.ProjectId != "" {
+		if err := s.projectService.EnsureProject(req.ProjectId, owner); err != nil {
+			return &pb.UpdateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		projectID := req.ProjectId
+		opts = append(opts, service.WithProject(&projectID))
+	}
+	if req.ClearParent {
+		opts = append(opts, service.WithParent(nil))
+	} else if req.ParentId != "" {
+		parentID := req.ParentId
+		opts = append(opts, service.WithParent(&parentID))
+	}
+	if req.ClearRecurrence {
+		opts = append(opts, service.WithRecurrence(nil))
+	} else if req.Recurrence != nil {
+		recurrence, err := recurrenceFromProto(req.Recurrence)
+		if err != nil {
+			return &pb.UpdateTodoResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		opts = append(opts, service.WithRecurrence(recurrence))
+	}
+
+	todo, err := s.todoService.UpdateTodo(req.Id, owner, req.Title, req.Description, opts...)
 	if err != nil {
 		return &pb.UpdateTodoResponse{
 			Error: err.Error(),
@@ -92,12 +263,20 @@ func (s *TodoServer) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest)
 	}
 
 	return &pb.UpdateTodoResponse{
-		Todo: s.todoToProto(todo),
+		Todo: s.todoToProto(todo, locationFromContext(ctx)),
 	}, nil
 }
 
 func (s *TodoServer) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest) (*pb.DeleteTodoResponse, error) {
-	err := s.todoService.DeleteTodo(req.Id, req.UserId)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.DeleteTodoResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	err = s.todoService.DeleteTodo(req.Id, owner)
 	if err != nil {
 		return &pb.DeleteTodoResponse{
 			Success: false,
@@ -111,50 +290,406 @@ func (s *TodoServer) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest)
 }
 
 func (s *TodoServer) MarkTodoComplete(ctx context.Context, req *pb.MarkTodoCompleteRequest) (*pb.MarkTodoCompleteResponse, error) {
-	todo, err := s.todoService.MarkTodoComplete(req.Id, req.UserId, req.Completed)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
 	if err != nil {
 		return &pb.MarkTodoCompleteResponse{
 			Error: err.Error(),
 		}, nil
 	}
 
-	return &pb.MarkTodoCompleteResponse{
-		Todo: s.todoToProto(todo),
+	rule := service.SubtaskRuleRequireDone
+	if req.SubtaskRule == pb.SubtaskRule_SUBTASK_RULE_COMPLETE_ALL {
+		rule = service.SubtaskRuleCompleteAll
+	}
+
+	loc := locationFromContext(ctx)
+	result, err := s.todoService.In(loc).MarkTodoCompleteWithRule(req.Id, owner, req.Completed, rule)
+	if err != nil {
+		return &pb.MarkTodoCompleteResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	resp := &pb.MarkTodoCompleteResponse{
+		Todo: s.todoToProto(result.Todo, loc),
+	}
+	if result.Next != nil {
+		resp.NextTodo = s.todoToProto(result.Next, loc)
+	}
+	return resp, nil
+}
+
+func (s *TodoServer) AssignTodo(ctx context.Context, req *pb.AssignTodoRequest) (*pb.AssignTodoResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.AssignTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	todo, err := s.todoService.AssignTodo(req.Id, owner, req.AssigneeId)
+	if err != nil {
+		return &pb.AssignTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.AssignTodoResponse{
+		Todo: s.todoToProto(todo, locationFromContext(ctx)),
+	}, nil
+}
+
+func (s *TodoServer) UnassignTodo(ctx context.Context, req *pb.UnassignTodoRequest) (*pb.UnassignTodoResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, true)
+	if err != nil {
+		return &pb.UnassignTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	todo, err := s.todoService.UnassignTodo(req.Id, owner)
+	if err != nil {
+		return &pb.UnassignTodoResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.UnassignTodoResponse{
+		Todo: s.todoToProto(todo, locationFromContext(ctx)),
 	}, nil
 }
 
 func (s *TodoServer) ListCompletedTodos(ctx context.Context, req *pb.ListCompletedTodosRequest) (*pb.ListCompletedTodosResponse, error) {
-	todoEntities, err := s.todoService.ListCompletedTodos(req.UserId)
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.ListCompletedTodosResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var todoEntities []*entity.Todo
+	var nextPageToken string
+	var total int
+
+	if isPaged(req.PageSize, req.PageToken) {
+		var page *service.Page
+		page, err = s.todoService.ListCompletedTodosPage(owner, int(req.PageSize), req.PageToken)
+		if err == nil {
+			todoEntities, nextPageToken, total = page.Todos, page.NextPageToken, page.Total
+		}
+	} else {
+		todoEntities, err = s.todoService.ListCompletedTodos(owner)
+		total = len(todoEntities)
+	}
+	if err == nil {
+		err = s.todoService.AttachProgress(todoEntities)
+	}
 	if err != nil {
 		return &pb.ListCompletedTodosResponse{
 			Error: err.Error(),
 		}, nil
 	}
 
+	loc := locationFromContext(ctx)
 	var todos []*pb.Todo
 	for _, todo := range todoEntities {
-		todos = append(todos, s.todoToProto(todo))
+		todos = append(todos, s.todoToProto(todo, loc))
 	}
 
 	return &pb.ListCompletedTodosResponse{
-		Todos: todos,
+		Todos:         todos,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(total),
+	}, nil
+}
+
+func (s *TodoServer) SearchTodos(ctx context.Context, req *pb.SearchTodosRequest) (*pb.SearchTodosResponse, error) {
+	owner, err := s.todoService.Owner(req.UserId, req.WorkspaceId, false)
+	if err != nil {
+		return &pb.SearchTodosResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	hits, err := s.todoService.SearchTodos(owner, req.Query, int(req.Limit))
+	if err != nil {
+		return &pb.SearchTodosResponse{
+			Error:        err.Error(),
+			InvalidQuery: errors.Is(err, service.ErrInvalidSearch) || errors.Is(err, service.ErrEmptySearch),
+		}, nil
+	}
+
+	loc := locationFromContext(ctx)
+	var pbHits []*pb.SearchHit
+	for _, hit := range hits {
+		pbHits = append(pbHits, &pb.SearchHit{
+			Todo:           s.todoToProto(hit.Todo, loc),
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+			Rank:           hit.Rank,
+		})
+	}
+
+	return &pb.SearchTodosResponse{
+		Hits: pbHits,
 	}, nil
 }
 
-func (s *TodoServer) todoToProto(todo *entity.Todo) *pb.Todo {
+func (s *TodoServer) CountTodosByUser(ctx context.Context, req *pb.CountTodosByUserRequest) (*pb.CountTodosByUserResponse, error) {
+	counts, err := s.todoService.CountTodosByUser(req.UserIds)
+	if err != nil {
+		return &pb.CountTodosByUserResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbCounts []*pb.UserTodoCount
+	for _, userID := range req.UserIds {
+		if c, ok := counts[userID]; ok {
+			pbCounts = append(pbCounts, &pb.UserTodoCount{
+				UserId:    userID,
+				Total:     int32(c.Total),
+				Completed: int32(c.Completed),
+			})
+		}
+	}
+
+	return &pb.CountTodosByUserResponse{
+		Counts: pbCounts,
+	}, nil
+}
+
+func (s *TodoServer) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	if err := s.todoService.DeleteUserData(req.UserId); err != nil {
+		return &pb.DeleteUserDataResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteUserDataResponse{
+		Success: true,
+	}, nil
+}
+
+// todoToProto converts a todo, formatting its times in the caller's time zone
+func (s *TodoServer) todoToProto(todo *entity.Todo, loc *time.Location) *pb.Todo {
 	pbTodo := &pb.Todo{
 		Id:          todo.ID,
 		UserId:      todo.UserID,
 		Title:       todo.Title,
 		Description: todo.Description,
 		Completed:   todo.Completed,
-		CreatedAt:   todo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   todo.UpdatedAt.Format(time.RFC3339),
+		CreatedAt:   todo.CreatedAt.In(loc).Format(time.RFC3339),
+		UpdatedAt:   todo.UpdatedAt.In(loc).Format(time.RFC3339),
+		Priority:    pb.Priority(todo.Priority),
+		WorkspaceId: todo.WorkspaceID,
+		CreatedBy:   todo.CreatedBy,
+		AssigneeId:  todo.AssigneeID,
+
+		SubtasksDone:  int32(todo.Progress.Done),
+		SubtasksTotal: int32(todo.Progress.Total),
+	}
+
+	for _, tag := range todo.Tags {
+		pbTodo.Tags = append(pbTodo.Tags, tagToProto(tag))
 	}
 
 	if todo.CompletedAt != nil {
-		pbTodo.CompletedAt = todo.CompletedAt.Format(time.RFC3339)
+		pbTodo.CompletedAt = todo.CompletedAt.In(loc).Format(time.RFC3339)
+	}
+	if todo.DueAt != nil {
+		pbTodo.DueAt = todo.DueAt.In(loc).Format(time.RFC3339)
+	}
+	if todo.RemindAt != nil {
+		pbTodo.RemindAt = todo.RemindAt.In(loc).Format(time.RFC3339)
+	}
+	if todo.ProjectID != nil {
+		pbTodo.ProjectId = *todo.ProjectID
+	}
+	if todo.ParentID != nil {
+		pbTodo.ParentId = *todo.ParentID
+	}
+	for _, subtask := range todo.Subtasks {
+		pbTodo.Subtasks = append(pbTodo.Subtasks, s.todoToProto(subtask, loc))
+	}
+	if todo.Recurrence != nil {
+		pbTodo.Recurrence = recurrenceToProto(todo.Recurrence)
 	}
 
 	return pbTodo
 }
+
+// listTodosError builds a ListTodos error response, pointing at the
+// offending position for filter syntax errors
+func listTodosError(err error) *pb.ListTodosResponse {
+	resp := &pb.ListTodosResponse{
+		Error: err.Error(),
+	}
+	var filterErr *filter.Error
+	if errors.As(err, &filterErr) {
+		resp.FilterErrorPosition = int32(filterErr.Position)
+	}
+	return resp
+}
+
+// isPaged reports whether a list request asks for a single page rather than
+// the whole list
+func isPaged(pageSize int32, pageToken string) bool {
+	return pageSize != 0 || pageToken != ""
+}
+
+// hasListOptions reports whether a ListTodos request uses anything beyond the
+// plain completed_only listing
+func hasListOptions(req *pb.ListTodosRequest) bool {
+	return req.DueFilter != pb.DueFilter_DUE_FILTER_UNSPECIFIED ||
+		req.SortBy != pb.SortField_SORT_FIELD_UNSPECIFIED ||
+		len(req.AnyTagIds) > 0 ||
+		len(req.AllTagIds) > 0 ||
+		req.ProjectId != "" ||
+		req.NoProject ||
+		req.TopLevelOnly ||
+		req.Filter != "" ||
+		req.AssignedToMe
+}
+
+// listOptionsFromProto converts the filter and sort fields of a ListTodos request
+func listOptionsFromProto(req *pb.ListTodosRequest) (service.ListOptions, error) {
+	opts := service.ListOptions{
+		CompletedOnly: req.CompletedOnly,
+		DueWithinDays: int(req.DueWithinDays),
+		AnyTagIDs:     req.AnyTagIds,
+		AllTagIDs:     req.AllTagIds,
+		ProjectID:     req.ProjectId,
+		NoProject:     req.NoProject,
+		TopLevelOnly:  req.TopLevelOnly,
+		Filter:        req.Filter,
+	}
+	if req.AssignedToMe {
+		opts.AssigneeID = req.UserId
+	}
+
+	switch req.DueFilter {
+	case pb.DueFilter_DUE_FILTER_UNSPECIFIED:
+		opts.Due = service.DueAny
+	case pb.DueFilter_DUE_FILTER_OVERDUE:
+		opts.Due = service.DueOverdue
+	case pb.DueFilter_DUE_FILTER_TODAY:
+		opts.Due = service.DueToday
+	case pb.DueFilter_DUE_FILTER_WITHIN_DAYS:
+		opts.Due = service.DueWithin
+	default:
+		return opts, fmt.Errorf("unknown due filter: %v", req.DueFilter)
+	}
+
+	switch req.SortBy {
+	case pb.SortField_SORT_FIELD_UNSPECIFIED:
+		opts.Sort.Field = repository.SortByDefault
+	case pb.SortField_SORT_FIELD_PRIORITY:
+		opts.Sort.Field = repository.SortByPriority
+	case pb.SortField_SORT_FIELD_DUE_AT:
+		opts.Sort.Field = repository.SortByDueAt
+	case pb.SortField_SORT_FIELD_CREATED_AT:
+		opts.Sort.Field = repository.SortByCreatedAt
+	case pb.SortField_SORT_FIELD_UPDATED_AT:
+		opts.Sort.Field = repository.SortByUpdatedAt
+	case pb.SortField_SORT_FIELD_TITLE:
+		opts.Sort.Field = repository.SortByTitle
+	default:
+		return opts, fmt.Errorf("unknown sort field: %v", req.SortBy)
+	}
+
+	switch req.SortDirection {
+	case pb.SortDirection_SORT_DIRECTION_UNSPECIFIED:
+		opts.Sort.Direction = repository.SortDirectionDefault
+	case pb.SortDirection_SORT_DIRECTION_ASC:
+		opts.Sort.Direction = repository.SortAscending
+	case pb.SortDirection_SORT_DIRECTION_DESC:
+		opts.Sort.Direction = repository.SortDescending
+	default:
+		return opts, fmt.Errorf("unknown sort direction: %v", req.SortDirection)
+	}
+
+	return opts, nil
+}
+
+// scheduleOptions converts the RFC3339 due/remind fields of a request into
+// service options. Empty strings leave the value unchanged unless a clear flag is set.
+func scheduleOptions(dueAt, remindAt string, clearDueAt, clearRemindAt bool) ([]service.TodoOption, error) {
+	var opts []service.TodoOption
+
+	if clearDueAt {
+		opts = append(opts, service.WithDueAt(nil))
+	} else if dueAt != "" {
+		t, err := time.Parse(time.RFC3339, dueAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_at: %v", err)
+		}
+		opts = append(opts, service.WithDueAt(&t))
+	}
+
+	if clearRemindAt {
+		opts = append(opts, service.WithRemindAt(nil))
+	} else if remindAt != "" {
+		t, err := time.Parse(time.RFC3339, remindAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remind_at: %v", err)
+		}
+		opts = append(opts, service.WithRemindAt(&t))
+	}
+
+	return opts, nil
+}
+
+func recurrenceFromProto(pbRecurrence *pb.Recurrence) (*entity.Recurrence, error) {
+	recurrence := &entity.Recurrence{
+		Interval: int(pbRecurrence.Interval),
+		MonthDay: int(pbRecurrence.MonthDay),
+	}
+
+	switch pbRecurrence.Frequency {
+	case pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_DAILY:
+		recurrence.Frequency = entity.FrequencyDaily
+	case pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_WEEKLY:
+		recurrence.Frequency = entity.FrequencyWeekly
+	case pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_MONTHLY:
+		recurrence.Frequency = entity.FrequencyMonthly
+	case pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_AFTER_COMPLETION:
+		recurrence.Frequency = entity.FrequencyAfterCompletion
+	default:
+		return nil, fmt.Errorf("unknown recurrence frequency: %v", pbRecurrence.Frequency)
+	}
+
+	for _, weekday := range pbRecurrence.Weekdays {
+		recurrence.Weekdays = append(recurrence.Weekdays, time.Weekday(weekday))
+	}
+
+	if err := recurrence.Validate(); err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+func recurrenceToProto(recurrence *entity.Recurrence) *pb.Recurrence {
+	pbRecurrence := &pb.Recurrence{
+		Interval: int32(recurrence.Interval),
+		MonthDay: int32(recurrence.MonthDay),
+	}
+
+	switch recurrence.Frequency {
+	case entity.FrequencyDaily:
+		pbRecurrence.Frequency = pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_DAILY
+	case entity.FrequencyWeekly:
+		pbRecurrence.Frequency = pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_WEEKLY
+	case entity.FrequencyMonthly:
+		pbRecurrence.Frequency = pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_MONTHLY
+	case entity.FrequencyAfterCompletion:
+		pbRecurrence.Frequency = pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_AFTER_COMPLETION
+	}
+
+	for _, weekday := range recurrence.Weekdays {
+		pbRecurrence.Weekdays = append(pbRecurrence.Weekdays, int32(weekday))
+	}
+
+	return pbRecurrence
+}
@@ -3,11 +3,15 @@ package grpc
 import (
 	"context"
 	"errors"
+	"sort"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc/metadata"
+
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/repository"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/service"
 )
 
@@ -32,6 +36,16 @@ type DetailedMockRepository struct {
 	ListCompletedByUserIDReturn []*entity.Todo
 	ListCompletedByUserIDError  error
 
+	ListCalled bool
+	ListInput  repository.TodoQuery
+	ListError  error
+
+	ListPageCalled bool
+	ListPageInput  repository.PageRequest
+
+	SearchCalled bool
+	SearchInput  repository.SearchQuery
+
 	UpdateCalled bool
 	UpdateInput  *entity.Todo
 	UpdateError  error
@@ -114,6 +128,49 @@ func (r *DetailedMockRepository) ListCompletedByUserID(userID string) ([]*entity
 	return todos, nil
 }
 
+func (r *DetailedMockRepository) List(query repository.TodoQuery) ([]*entity.Todo, error) {
+	r.ListCalled = true
+	r.ListInput = query
+	if r.ListError != nil {
+		return nil, r.ListError
+	}
+
+	var todos []*entity.Todo
+	for _, todo := range r.todos {
+		if query.Matches(todo) {
+			todos = append(todos, todo)
+		}
+	}
+	return todos, nil
+}
+
+func (r *DetailedMockRepository) ListPage(query repository.TodoQuery, page repository.PageRequest) (*repository.TodoPage, error) {
+	r.ListPageCalled = true
+	r.ListInput = query
+	r.ListPageInput = page
+	if r.ListError != nil {
+		return nil, r.ListError
+	}
+
+	var todos []*entity.Todo
+	for _, todo := range r.todos {
+		if query.Matches(todo) {
+			todos = append(todos, todo)
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+	return repository.PaginateInMemory(todos, query, page)
+}
+
+func (r *DetailedMockRepository) Search(query repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	r.SearchCalled = true
+	r.SearchInput = query
+	if r.ListError != nil {
+		return nil, r.ListError
+	}
+	return nil, nil
+}
+
 func (r *DetailedMockRepository) Update(todo *entity.Todo) error {
 	r.UpdateCalled = true
 	r.UpdateInput = todo
@@ -124,6 +181,70 @@ func (r *DetailedMockRepository) Update(todo *entity.Todo) error {
 	return nil
 }
 
+func (r *DetailedMockRepository) CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error) {
+	progress := make(map[string]entity.SubtaskProgress)
+	for _, parentID := range parentIDs {
+		for _, todo := range r.todos {
+			if todo.UserID != userID || todo.ParentID == nil || *todo.ParentID != parentID {
+				continue
+			}
+			p := progress[parentID]
+			p.Total++
+			if todo.Completed {
+				p.Done++
+			}
+			progress[parentID] = p
+		}
+	}
+	return progress, nil
+}
+
+func (r *DetailedMockRepository) CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error) {
+	counts := make(map[string]entity.TodoCounts)
+	for _, userID := range userIDs {
+		for _, todo := range r.todos {
+			if todo.UserID != userID {
+				continue
+			}
+			c := counts[userID]
+			c.Total++
+			if todo.Completed {
+				c.Completed++
+			}
+			counts[userID] = c
+		}
+	}
+	return counts, nil
+}
+
+func (r *DetailedMockRepository) DeleteAllByUserID(userID string) error {
+	for id, todo := range r.todos {
+		if todo.UserID == userID {
+			delete(r.todos, id)
+		}
+	}
+	return nil
+}
+
+func (r *DetailedMockRepository) UpdateMany(todos []*entity.Todo) error {
+	r.UpdateCalled = true
+	if r.UpdateError != nil {
+		return r.UpdateError
+	}
+	for _, todo := range todos {
+		r.UpdateInput = todo
+		r.todos[todo.ID] = todo
+	}
+	return nil
+}
+
+func (r *DetailedMockRepository) CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error {
+	if err := r.Create(created); err != nil {
+		return err
+	}
+	return r.UpdateMany(updated)
+}
+
 func (r *DetailedMockRepository) Delete(id, userID string) error {
 	r.DeleteCalled = true
 	r.DeleteInput = []string{id, userID}
@@ -154,6 +275,10 @@ func (r *DetailedMockRepository) Reset() {
 	r.ListCompletedByUserIDReturn = nil
 	r.ListCompletedByUserIDError = nil
 
+	r.ListCalled = false
+	r.ListInput = repository.TodoQuery{}
+	r.ListError = nil
+
 	r.UpdateCalled = false
 	r.UpdateInput = nil
 	r.UpdateError = nil
@@ -165,9 +290,76 @@ func (r *DetailedMockRepository) Reset() {
 	r.todos = make(map[string]*entity.Todo)
 }
 
+// stubTagRepository is an empty tag store; tag behaviour is covered by the
+// behaviour tests
+type stubTagRepository struct{}
+
+func (stubTagRepository) Create(tag *entity.Tag) error { return nil }
+func (stubTagRepository) GetByID(id, userID string) (*entity.Tag, error) {
+	return nil, repository.ErrTagNotFound
+}
+func (stubTagRepository) GetByName(userID, name string) (*entity.Tag, error) {
+	return nil, repository.ErrTagNotFound
+}
+func (stubTagRepository) ListByUserID(userID string) ([]*entity.Tag, error) { return nil, nil }
+func (stubTagRepository) ListByIDs(userID string, ids []string) ([]*entity.Tag, error) {
+	return nil, nil
+}
+func (stubTagRepository) Update(tag *entity.Tag) error   { return nil }
+func (stubTagRepository) Delete(id, userID string) error { return repository.ErrTagNotFound }
+
+// stubProjectRepository is an empty project store; project behaviour is
+// covered by the behaviour tests
+type stubProjectRepository struct{}
+
+func (stubProjectRepository) Create(project *entity.Project) error { return nil }
+func (stubProjectRepository) GetByID(id, userID string) (*entity.Project, error) {
+	return nil, repository.ErrProjectNotFound
+}
+func (stubProjectRepository) ListByUserID(userID string) ([]*entity.Project, error) { return nil, nil }
+func (stubProjectRepository) Update(project *entity.Project) error                  { return nil }
+func (stubProjectRepository) Delete(id, userID string) error {
+	return repository.ErrProjectNotFound
+}
+func (stubProjectRepository) CountTodos(userID string) (map[string]entity.ProjectCounts, error) {
+	return nil, nil
+}
+
+// stubSavedViewRepository is an empty view store; saved views are covered by
+// the behaviour tests
+type stubSavedViewRepository struct{}
+
+func (stubSavedViewRepository) Create(view *entity.SavedView) error { return nil }
+func (stubSavedViewRepository) GetByID(id, userID string) (*entity.SavedView, error) {
+	return nil, repository.ErrSavedViewNotFound
+}
+func (stubSavedViewRepository) ListByUserID(userID string) ([]*entity.SavedView, error) {
+	return nil, nil
+}
+func (stubSavedViewRepository) Update(view *entity.SavedView) error { return nil }
+func (stubSavedViewRepository) Delete(id, userID string) error {
+	return repository.ErrSavedViewNotFound
+}
+
+// stubCommentRepository - コメントを使わないテスト用のスタブ
+type stubCommentRepository struct{}
+
+func (stubCommentRepository) Create(comment *entity.Comment) error { return nil }
+func (stubCommentRepository) GetByID(id, todoID string) (*entity.Comment, error) {
+	return nil, repository.ErrCommentNotFound
+}
+func (stubCommentRepository) ListByTodoID(todoID string) ([]*entity.Comment, error) {
+	return nil, nil
+}
+func (stubCommentRepository) Update(comment *entity.Comment) error { return nil }
+
 func createTodoServerWithMockRepo(repo *DetailedMockRepository) *TodoServer {
 	todoService := service.NewTodoService(repo)
-	return NewTodoServer(todoService)
+	tagService := service.NewTagService(stubTagRepository{})
+	projectService := service.NewProjectService(stubProjectRepository{})
+	viewService := service.NewSavedViewService(stubSavedViewRepository{}, todoService)
+	commentService := service.NewCommentService(stubCommentRepository{}, todoService)
+	return NewTodoServer(todoService, tagService, projectService, viewService, commentService)
 }
 
 func TestTodoServer_CreateTodo_Implementation(t *testing.T) {
@@ -313,6 +505,51 @@ func TestTodoServer_ListTodos_Implementation(t *testing.T) {
 	}
 }
 
+func TestTodoServer_ListTodos_SortImplementation(t *testing.T) {
+	repo := NewDetailedMockRepository()
+	server := createTodoServerWithMockRepo(repo)
+
+	resp, err := server.ListTodos(context.Background(), &pb.ListTodosRequest{
+		UserId:        "user123",
+		SortBy:        pb.SortField_SORT_FIELD_PRIORITY,
+		SortDirection: pb.SortDirection_SORT_DIRECTION_ASC,
+	})
+	if err != nil || resp.Error != "" {
+		t.Fatalf("ListTodos failed: %v %s", err, resp.Error)
+	}
+
+	// Verify the sort spec is passed down to the repository
+	if !repo.ListCalled {
+		t.Fatal("Expected List to be called on repository")
+	}
+	expected := repository.TodoSort{Field: repository.SortByPriority, Direction: repository.SortAscending}
+	if repo.ListInput.Sort != expected {
+		t.Errorf("Expected sort %+v, got %+v", expected, repo.ListInput.Sort)
+	}
+	if repo.ListByUserIDCalled {
+		t.Error("ListByUserID should not be called when a sort is requested")
+	}
+}
+
+func TestTodoServer_ListTodos_TagFilterImplementation(t *testing.T) {
+	repo := NewDetailedMockRepository()
+	server := createTodoServerWithMockRepo(repo)
+
+	server.ListTodos(context.Background(), &pb.ListTodosRequest{
+		UserId:    "user123",
+		AnyTagIds: []string{"a", "b"},
+		AllTagIds: []string{"c"},
+	})
+
+	// Verify tag filters are passed down to the repository
+	if !repo.ListCalled {
+		t.Fatal("Expected List to be called on repository")
+	}
+	if len(repo.ListInput.AnyTagIDs) != 2 || len(repo.ListInput.AllTagIDs) != 1 {
+		t.Errorf("Unexpected tag filters: any=%v all=%v", repo.ListInput.AnyTagIDs, repo.ListInput.AllTagIDs)
+	}
+}
+
 func TestTodoServer_UpdateTodo_Implementation(t *testing.T) {
 	repo := NewDetailedMockRepository()
 	server := createTodoServerWithMockRepo(repo)
@@ -410,7 +647,7 @@ func TestTodoServer_todoToProto_Implementation(t *testing.T) {
 		UpdatedAt:   updatedAt,
 	}
 
-	proto := server.todoToProto(entity)
+	proto := server.todoToProto(entity, time.UTC)
 
 	if proto.Id != entity.ID {
 		t.Errorf("Expected ID %s, got %s", entity.ID, proto.Id)
@@ -434,3 +671,48 @@ func TestTodoServer_todoToProto_Implementation(t *testing.T) {
 		t.Errorf("Expected updated at %s, got %s", entity.UpdatedAt.Format(time.RFC3339), proto.UpdatedAt)
 	}
 }
+
+func TestTodoServer_todoToProto_Timezone(t *testing.T) {
+	server := &TodoServer{}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Time zone data is not available: %v", err)
+	}
+
+	dueAt := time.Date(2023, 1, 1, 20, 0, 0, 0, time.UTC)
+	todo := &entity.Todo{
+		ID:        "todo123",
+		CreatedAt: dueAt,
+		UpdatedAt: dueAt,
+		DueAt:     &dueAt,
+	}
+
+	// 利用者のタイムゾーンのオフセットで返す
+	proto := server.todoToProto(todo, tokyo)
+	if proto.DueAt != "2023-01-02T05:00:00+09:00" {
+		t.Errorf("Expected the due date in Tokyo time, got %s", proto.DueAt)
+	}
+	if proto.CreatedAt != "2023-01-02T05:00:00+09:00" {
+		t.Errorf("Expected the creation time in Tokyo time, got %s", proto.CreatedAt)
+	}
+}
+
+func TestLocationFromContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"no metadata", context.Background(), time.Local.String()},
+		{"time zone", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-timezone", "Europe/Berlin")), "Europe/Berlin"},
+		{"unknown time zone", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-timezone", "Mars/Olympus")), time.Local.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if loc := locationFromContext(tt.ctx); loc.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, loc)
+			}
+		})
+	}
+}
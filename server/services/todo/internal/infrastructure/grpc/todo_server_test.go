@@ -2,7 +2,10 @@ package grpc_test
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/services/todo/internal/domain/entity"
@@ -45,6 +48,22 @@ func (r *SimpleMockRepository) ListByUserID(userID string) ([]*entity.Todo, erro
 	return userTodos, nil
 }
 
+func (r *SimpleMockRepository) ListPage(query repository.TodoQuery, page repository.PageRequest) (*repository.TodoPage, error) {
+	todos, _ := r.List(query)
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+	return repository.PaginateInMemory(todos, query, page)
+}
+
+func (r *SimpleMockRepository) Search(query repository.SearchQuery) ([]*repository.TodoSearchHit, error) {
+	var hits []*repository.TodoSearchHit
+	for _, todo := range r.todos {
+		if query.Matches(todo) {
+			hits = append(hits, &repository.TodoSearchHit{Todo: todo, TitleHighlight: todo.Title})
+		}
+	}
+	return hits, nil
+}
+
 func (r *SimpleMockRepository) ListCompletedByUserID(userID string) ([]*entity.Todo, error) {
 	var userTodos []*entity.Todo
 	for _, todo := range r.todos {
@@ -55,11 +74,78 @@ func (r *SimpleMockRepository) ListCompletedByUserID(userID string) ([]*entity.T
 	return userTodos, nil
 }
 
+func (r *SimpleMockRepository) List(query repository.TodoQuery) ([]*entity.Todo, error) {
+	var userTodos []*entity.Todo
+	for _, todo := range r.todos {
+		if query.Matches(todo) {
+			userTodos = append(userTodos, todo)
+		}
+	}
+	return userTodos, nil
+}
+
 func (r *SimpleMockRepository) Update(todo *entity.Todo) error {
 	r.todos[todo.ID] = todo
 	return nil
 }
 
+func (r *SimpleMockRepository) CountSubtasks(userID string, parentIDs []string) (map[string]entity.SubtaskProgress, error) {
+	progress := make(map[string]entity.SubtaskProgress)
+	for _, parentID := range parentIDs {
+		for _, todo := range r.todos {
+			if todo.UserID != userID || todo.ParentID == nil || *todo.ParentID != parentID {
+				continue
+			}
+			p := progress[parentID]
+			p.Total++
+			if todo.Completed {
+				p.Done++
+			}
+			progress[parentID] = p
+		}
+	}
+	return progress, nil
+}
+
+func (r *SimpleMockRepository) CountByUserIDs(userIDs []string) (map[string]entity.TodoCounts, error) {
+	counts := make(map[string]entity.TodoCounts)
+	for _, userID := range userIDs {
+		for _, todo := range r.todos {
+			if todo.UserID != userID {
+				continue
+			}
+			c := counts[userID]
+			c.Total++
+			if todo.Completed {
+				c.Completed++
+			}
+			counts[userID] = c
+		}
+	}
+	return counts, nil
+}
+
+func (r *SimpleMockRepository) DeleteAllByUserID(userID string) error {
+	for id, todo := range r.todos {
+		if todo.UserID == userID {
+			delete(r.todos, id)
+		}
+	}
+	return nil
+}
+
+func (r *SimpleMockRepository) UpdateMany(todos []*entity.Todo) error {
+	for _, todo := range todos {
+		r.todos[todo.ID] = todo
+	}
+	return nil
+}
+
+func (r *SimpleMockRepository) CreateWithUpdates(created *entity.Todo, updated []*entity.Todo) error {
+	r.todos[created.ID] = created
+	return r.UpdateMany(updated)
+}
+
 func (r *SimpleMockRepository) Delete(id, userID string) error {
 	todo, exists := r.todos[id]
 	if exists && todo.UserID == userID {
@@ -68,9 +154,236 @@ func (r *SimpleMockRepository) Delete(id, userID string) error {
 	return nil
 }
 
-func createTodoServer(repo repository.TodoRepository) *grpcServer.TodoServer {
+// SimpleMockTagRepository for external testing
+type SimpleMockTagRepository struct {
+	tags map[string]*entity.Tag
+}
+
+func NewSimpleMockTagRepository() *SimpleMockTagRepository {
+	return &SimpleMockTagRepository{
+		tags: make(map[string]*entity.Tag),
+	}
+}
+
+func (r *SimpleMockTagRepository) Create(tag *entity.Tag) error {
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *SimpleMockTagRepository) GetByID(id, userID string) (*entity.Tag, error) {
+	tag, exists := r.tags[id]
+	if !exists || tag.UserID != userID {
+		return nil, repository.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (r *SimpleMockTagRepository) GetByName(userID, name string) (*entity.Tag, error) {
+	for _, tag := range r.tags {
+		if tag.UserID == userID && strings.EqualFold(tag.Name, name) {
+			return tag, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+func (r *SimpleMockTagRepository) ListByUserID(userID string) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	for _, tag := range r.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func (r *SimpleMockTagRepository) ListByIDs(userID string, ids []string) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	for _, id := range ids {
+		if tag, exists := r.tags[id]; exists && tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func (r *SimpleMockTagRepository) Update(tag *entity.Tag) error {
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *SimpleMockTagRepository) Delete(id, userID string) error {
+	tag, exists := r.tags[id]
+	if !exists || tag.UserID != userID {
+		return repository.ErrTagNotFound
+	}
+	delete(r.tags, id)
+	return nil
+}
+
+// SimpleMockProjectRepository - Todoの件数集計のためにTodoリポジトリを参照する
+type SimpleMockProjectRepository struct {
+	projects map[string]*entity.Project
+	todoRepo *SimpleMockRepository
+}
+
+func NewSimpleMockProjectRepository(todoRepo *SimpleMockRepository) *SimpleMockProjectRepository {
+	return &SimpleMockProjectRepository{
+		projects: make(map[string]*entity.Project),
+		todoRepo: todoRepo,
+	}
+}
+
+func (r *SimpleMockProjectRepository) Create(project *entity.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) GetByID(id, userID string) (*entity.Project, error) {
+	project, exists := r.projects[id]
+	if !exists || project.UserID != userID {
+		return nil, repository.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (r *SimpleMockProjectRepository) ListByUserID(userID string) ([]*entity.Project, error) {
+	var projects []*entity.Project
+	for _, project := range r.projects {
+		if project.UserID == userID {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (r *SimpleMockProjectRepository) Update(project *entity.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) Delete(id, userID string) error {
+	if _, err := r.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(r.projects, id)
+	for _, todo := range r.todoRepo.todos {
+		if todo.InProject(id) {
+			todo.ProjectID = nil
+		}
+	}
+	return nil
+}
+
+func (r *SimpleMockProjectRepository) CountTodos(userID string) (map[string]entity.ProjectCounts, error) {
+	counts := make(map[string]entity.ProjectCounts)
+	for _, todo := range r.todoRepo.todos {
+		if todo.UserID != userID || todo.ProjectID == nil {
+			continue
+		}
+		c := counts[*todo.ProjectID]
+		if todo.Completed {
+			c.Completed++
+		} else {
+			c.Open++
+		}
+		counts[*todo.ProjectID] = c
+	}
+	return counts, nil
+}
+
+// SimpleMockSavedViewRepository - テスト用の簡単なビューリポジトリ
+type SimpleMockSavedViewRepository struct {
+	views map[string]*entity.SavedView
+}
+
+func NewSimpleMockSavedViewRepository() *SimpleMockSavedViewRepository {
+	return &SimpleMockSavedViewRepository{
+		views: make(map[string]*entity.SavedView),
+	}
+}
+
+func (r *SimpleMockSavedViewRepository) Create(view *entity.SavedView) error {
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *SimpleMockSavedViewRepository) GetByID(id, userID string) (*entity.SavedView, error) {
+	view, exists := r.views[id]
+	if !exists || view.UserID != userID {
+		return nil, repository.ErrSavedViewNotFound
+	}
+	return view, nil
+}
+
+func (r *SimpleMockSavedViewRepository) ListByUserID(userID string) ([]*entity.SavedView, error) {
+	var views []*entity.SavedView
+	for _, view := range r.views {
+		if view.UserID == userID {
+			views = append(views, view)
+		}
+	}
+	return views, nil
+}
+
+func (r *SimpleMockSavedViewRepository) Update(view *entity.SavedView) error {
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *SimpleMockSavedViewRepository) Delete(id, userID string) error {
+	if _, err := r.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(r.views, id)
+	return nil
+}
+
+// SimpleMockCommentRepository - テスト用の簡単なコメントリポジトリ
+type SimpleMockCommentRepository struct {
+	comments []*entity.Comment
+}
+
+func NewSimpleMockCommentRepository() *SimpleMockCommentRepository {
+	return &SimpleMockCommentRepository{}
+}
+
+func (r *SimpleMockCommentRepository) Create(comment *entity.Comment) error {
+	r.comments = append(r.comments, comment)
+	return nil
+}
+
+func (r *SimpleMockCommentRepository) GetByID(id, todoID string) (*entity.Comment, error) {
+	for _, comment := range r.comments {
+		if comment.ID == id && comment.TodoID == todoID {
+			return comment, nil
+		}
+	}
+	return nil, repository.ErrCommentNotFound
+}
+
+func (r *SimpleMockCommentRepository) ListByTodoID(todoID string) ([]*entity.Comment, error) {
+	var comments []*entity.Comment
+	for _, comment := range r.comments {
+		if comment.TodoID == todoID {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (r *SimpleMockCommentRepository) Update(comment *entity.Comment) error {
+	_, err := r.GetByID(comment.ID, comment.TodoID)
+	return err
+}
+
+func createTodoServer(repo *SimpleMockRepository) *grpcServer.TodoServer {
 	todoService := service.NewTodoService(repo)
-	return grpcServer.NewTodoServer(todoService)
+	tagService := service.NewTagService(NewSimpleMockTagRepository())
+	projectService := service.NewProjectService(NewSimpleMockProjectRepository(repo))
+	viewService := service.NewSavedViewService(NewSimpleMockSavedViewRepository(), todoService)
+	commentService := service.NewCommentService(NewSimpleMockCommentRepository(), todoService)
+	return grpcServer.NewTodoServer(todoService, tagService, projectService, viewService, commentService)
 }
 
 func TestTodoServer_CreateTodo_Behavior(t *testing.T) {
@@ -264,3 +577,808 @@ func TestTodoServer_DeleteTodo_Behavior(t *testing.T) {
 		t.Error("Expected todo to be nil after deletion")
 	}
 }
+
+func TestTodoServer_DueDates_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	overdue := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	upcoming := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+
+	createResp, err := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		Title:  "Overdue Todo",
+		UserId: "user123",
+		DueAt:  overdue,
+	})
+	if err != nil || createResp.Error != "" {
+		t.Fatalf("CreateTodo failed: %v %s", err, createResp.Error)
+	}
+	if createResp.Todo.DueAt != overdue {
+		t.Errorf("Expected due_at %s, got %s", overdue, createResp.Todo.DueAt)
+	}
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		Title:    "Upcoming Todo",
+		UserId:   "user123",
+		DueAt:    upcoming,
+		RemindAt: overdue,
+	})
+
+	// 期限切れのみ
+	listResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{
+		UserId:    "user123",
+		DueFilter: pb.DueFilter_DUE_FILTER_OVERDUE,
+	})
+	if len(listResp.Todos) != 1 || listResp.Todos[0].Title != "Overdue Todo" {
+		t.Errorf("Expected only the overdue todo, got %v", listResp.Todos)
+	}
+
+	// 3日以内
+	listResp, _ = server.ListTodos(ctx, &pb.ListTodosRequest{
+		UserId:        "user123",
+		DueFilter:     pb.DueFilter_DUE_FILTER_WITHIN_DAYS,
+		DueWithinDays: 3,
+	})
+	if len(listResp.Todos) != 1 || listResp.Todos[0].Title != "Upcoming Todo" {
+		t.Errorf("Expected only the upcoming todo, got %v", listResp.Todos)
+	}
+
+	// 期限をクリア
+	updateResp, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:         createResp.Todo.Id,
+		UserId:     "user123",
+		ClearDueAt: true,
+	})
+	if updateResp.Todo.DueAt != "" {
+		t.Errorf("Expected due_at to be cleared, got %s", updateResp.Todo.DueAt)
+	}
+
+	// 不正な日時
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		Title:  "Bad",
+		UserId: "user123",
+		DueAt:  "tomorrow",
+	})
+	if badResp.Error == "" {
+		t.Error("Expected error for invalid due_at")
+	}
+}
+
+func TestTodoServer_Priority_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	createResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		Title:    "Urgent Todo",
+		UserId:   "user123",
+		Priority: pb.Priority_PRIORITY_URGENT,
+	})
+	if createResp.Todo.Priority != pb.Priority_PRIORITY_URGENT {
+		t.Errorf("Expected priority urgent, got %v", createResp.Todo.Priority)
+	}
+
+	// 優先度を指定しない更新は優先度を変えない
+	updateResp, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:     createResp.Todo.Id,
+		UserId: "user123",
+		Title:  "Renamed",
+	})
+	if updateResp.Todo.Priority != pb.Priority_PRIORITY_URGENT {
+		t.Errorf("Expected priority to stay urgent, got %v", updateResp.Todo.Priority)
+	}
+
+	// 明示的に none に戻せる
+	none := pb.Priority_PRIORITY_NONE
+	updateResp, _ = server.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:       createResp.Todo.Id,
+		UserId:   "user123",
+		Priority: &none,
+	})
+	if updateResp.Todo.Priority != pb.Priority_PRIORITY_NONE {
+		t.Errorf("Expected priority none, got %v", updateResp.Todo.Priority)
+	}
+
+	// 未知の優先度はエラー
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		Title:    "Bad",
+		UserId:   "user123",
+		Priority: pb.Priority(99),
+	})
+	if badResp.Error == "" {
+		t.Error("Expected error for unknown priority")
+	}
+}
+
+func TestTodoServer_Tags_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	// タグの作成
+	backendResp, _ := server.CreateTag(ctx, &pb.CreateTagRequest{UserId: "user123", Name: "backend", Color: "#0000ff"})
+	if backendResp.Error != "" {
+		t.Fatalf("CreateTag failed: %s", backendResp.Error)
+	}
+	urgentResp, _ := server.CreateTag(ctx, &pb.CreateTagRequest{UserId: "user123", Name: "urgent"})
+	otherResp, _ := server.CreateTag(ctx, &pb.CreateTagRequest{UserId: "other", Name: "secret"})
+
+	// タグ付きTodoの作成
+	createResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		UserId: "user123",
+		Title:  "Tagged",
+		TagIds: []string{backendResp.Tag.Id, urgentResp.Tag.Id},
+	})
+	if createResp.Error != "" {
+		t.Fatalf("CreateTodo failed: %s", createResp.Error)
+	}
+	if len(createResp.Todo.Tags) != 2 {
+		t.Errorf("Expected 2 tags on todo, got %d", len(createResp.Todo.Tags))
+	}
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Untagged"})
+
+	// 他ユーザーのタグは付けられない
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		UserId: "user123",
+		Title:  "Foreign tag",
+		TagIds: []string{otherResp.Tag.Id},
+	})
+	if badResp.Error == "" {
+		t.Error("Expected error when tagging with another user's tag")
+	}
+
+	// all-of フィルタ
+	listResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{
+		UserId:    "user123",
+		AllTagIds: []string{backendResp.Tag.Id, urgentResp.Tag.Id},
+	})
+	if len(listResp.Todos) != 1 || listResp.Todos[0].Title != "Tagged" {
+		t.Errorf("Expected only the tagged todo, got %v", listResp.Todos)
+	}
+
+	// タグの付け替え
+	updateResp, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:      createResp.Todo.Id,
+		UserId:  "user123",
+		TagIds:  []string{urgentResp.Tag.Id},
+		SetTags: true,
+	})
+	if len(updateResp.Todo.Tags) != 1 || updateResp.Todo.Tags[0].Name != "urgent" {
+		t.Errorf("Expected only the urgent tag, got %v", updateResp.Todo.Tags)
+	}
+
+	// 名前と色の変更
+	tagResp, _ := server.UpdateTag(ctx, &pb.UpdateTagRequest{
+		Id:     backendResp.Tag.Id,
+		UserId: "user123",
+		Name:   "api",
+		Color:  "#00ff00",
+	})
+	if tagResp.Error != "" || tagResp.Tag.Name != "api" || tagResp.Tag.Color != "#00ff00" {
+		t.Errorf("Unexpected UpdateTag response: %v", tagResp)
+	}
+
+	// 一覧と削除
+	tagsResp, _ := server.ListTags(ctx, &pb.ListTagsRequest{UserId: "user123"})
+	if len(tagsResp.Tags) != 2 {
+		t.Errorf("Expected 2 tags, got %d", len(tagsResp.Tags))
+	}
+	deleteResp, _ := server.DeleteTag(ctx, &pb.DeleteTagRequest{Id: backendResp.Tag.Id, UserId: "other"})
+	if deleteResp.Success {
+		t.Error("Other users should not be able to delete the tag")
+	}
+}
+
+func TestTodoServer_Projects_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	// プロジェクトの作成
+	createResp, _ := server.CreateProject(ctx, &pb.CreateProjectRequest{UserId: "user123", Name: "Website"})
+	if createResp.Error != "" {
+		t.Fatalf("CreateProject failed: %s", createResp.Error)
+	}
+	projectID := createResp.Project.Id
+	otherResp, _ := server.CreateProject(ctx, &pb.CreateProjectRequest{UserId: "other", Name: "Secret"})
+
+	// プロジェクトに属するTodoと属さないTodoを作成
+	inProject, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Landing page", ProjectId: projectID})
+	if inProject.Error != "" || inProject.Todo.ProjectId != projectID {
+		t.Fatalf("Expected todo in project, got %v", inProject)
+	}
+	done, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Domain", ProjectId: projectID})
+	server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: done.Todo.Id, UserId: "user123", Completed: true})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Inbox item"})
+
+	// 他ユーザーのプロジェクトには追加できない
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Sneaky", ProjectId: otherResp.Project.Id})
+	if badResp.Error == "" {
+		t.Error("Expected error when using another user's project")
+	}
+
+	// プロジェクトで絞り込み
+	listResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", ProjectId: projectID})
+	if len(listResp.Todos) != 2 {
+		t.Errorf("Expected 2 todos in project, got %d", len(listResp.Todos))
+	}
+	inboxResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", NoProject: true})
+	if len(inboxResp.Todos) != 1 || inboxResp.Todos[0].Title != "Inbox item" {
+		t.Errorf("Expected only the inbox item, got %v", inboxResp.Todos)
+	}
+
+	// 件数の集計
+	projectsResp, _ := server.ListProjects(ctx, &pb.ListProjectsRequest{UserId: "user123"})
+	if len(projectsResp.Projects) != 1 {
+		t.Fatalf("Expected 1 project, got %d", len(projectsResp.Projects))
+	}
+	if projectsResp.Projects[0].OpenCount != 1 || projectsResp.Projects[0].CompletedCount != 1 {
+		t.Errorf("Expected 1 open and 1 completed, got %v", projectsResp.Projects[0])
+	}
+
+	// プロジェクトから外す
+	updateResp, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{Id: inProject.Todo.Id, UserId: "user123", ClearProject: true})
+	if updateResp.Todo.ProjectId != "" {
+		t.Errorf("Expected todo to leave the project, got %q", updateResp.Todo.ProjectId)
+	}
+
+	// 削除してもTodoは残る
+	deleteResp, _ := server.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: projectID, UserId: "user123"})
+	if !deleteResp.Success {
+		t.Fatalf("DeleteProject failed: %s", deleteResp.Error)
+	}
+	getResp, _ := server.GetTodo(ctx, &pb.GetTodoRequest{Id: done.Todo.Id, UserId: "user123"})
+	if getResp.Todo == nil || getResp.Todo.ProjectId != "" {
+		t.Errorf("Expected todo to survive without project, got %v", getResp.Todo)
+	}
+}
+
+func TestTodoServer_Subtasks_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	// 親子関係のあるTodoを作成
+	parent, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Epic"})
+	child, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Story", ParentId: parent.Todo.Id})
+	if child.Error != "" || child.Todo.ParentId != parent.Todo.Id {
+		t.Fatalf("Expected subtask of parent, got %v", child)
+	}
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Task", ParentId: child.Todo.Id})
+
+	// 他ユーザーのTodoは親にできない
+	other, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "other", Title: "Other"})
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Sneaky", ParentId: other.Todo.Id})
+	if badResp.Error == "" {
+		t.Error("Expected error when using another user's todo as parent")
+	}
+
+	// GetTodo はツリーを返す
+	getResp, _ := server.GetTodo(ctx, &pb.GetTodoRequest{Id: parent.Todo.Id, UserId: "user123"})
+	if len(getResp.Todo.Subtasks) != 1 || len(getResp.Todo.Subtasks[0].Subtasks) != 1 {
+		t.Fatalf("Expected a two level tree, got %v", getResp.Todo)
+	}
+	if getResp.Todo.SubtasksTotal != 1 || getResp.Todo.SubtasksDone != 0 {
+		t.Errorf("Expected progress 0/1, got %d/%d", getResp.Todo.SubtasksDone, getResp.Todo.SubtasksTotal)
+	}
+
+	// 一覧にも進捗が含まれる
+	listResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", TopLevelOnly: true})
+	if len(listResp.Todos) != 1 || listResp.Todos[0].SubtasksTotal != 1 {
+		t.Errorf("Expected only the parent with progress, got %v", listResp.Todos)
+	}
+
+	// 子が未完了なら既定では完了できない
+	refused, _ := server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: parent.Todo.Id, UserId: "user123", Completed: true})
+	if refused.Error == "" {
+		t.Error("Expected completion to be refused while subtasks are open")
+	}
+
+	// COMPLETE_ALL なら子孫もまとめて完了する
+	completed, _ := server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{
+		Id:          parent.Todo.Id,
+		UserId:      "user123",
+		Completed:   true,
+		SubtaskRule: pb.SubtaskRule_SUBTASK_RULE_COMPLETE_ALL,
+	})
+	if completed.Error != "" || !completed.Todo.Completed {
+		t.Fatalf("Expected parent to be completed, got %v", completed)
+	}
+	if completed.Todo.SubtasksDone != 1 || !completed.Todo.Subtasks[0].Subtasks[0].Completed {
+		t.Errorf("Expected the whole subtree to be completed, got %v", completed.Todo)
+	}
+}
+
+func TestTodoServer_Recurrence_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	due := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	createResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		UserId: "user123",
+		Title:  "Rotate on-call",
+		DueAt:  due.Format(time.RFC3339),
+		Recurrence: &pb.Recurrence{
+			Frequency: pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_DAILY,
+			Interval:  7,
+		},
+	})
+	if createResp.Error != "" {
+		t.Fatalf("CreateTodo failed: %s", createResp.Error)
+	}
+	if createResp.Todo.Recurrence == nil || createResp.Todo.Recurrence.Interval != 7 {
+		t.Fatalf("Expected recurrence on created todo, got %v", createResp.Todo.Recurrence)
+	}
+
+	// 不正なルールは拒否される
+	badResp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{
+		UserId:     "user123",
+		Title:      "Bad",
+		Recurrence: &pb.Recurrence{Frequency: pb.RecurrenceFrequency_RECURRENCE_FREQUENCY_WEEKLY, Interval: 1},
+	})
+	if badResp.Error == "" {
+		t.Error("Expected error for weekly rule without weekdays")
+	}
+
+	// 完了すると次回分が作られる
+	completeResp, _ := server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{
+		Id:        createResp.Todo.Id,
+		UserId:    "user123",
+		Completed: true,
+	})
+	if completeResp.Error != "" {
+		t.Fatalf("MarkTodoComplete failed: %s", completeResp.Error)
+	}
+	if completeResp.NextTodo == nil {
+		t.Fatal("Expected next occurrence")
+	}
+	if completeResp.NextTodo.DueAt != due.AddDate(0, 0, 7).Format(time.RFC3339) {
+		t.Errorf("Expected next due %s, got %s", due.AddDate(0, 0, 7).Format(time.RFC3339), completeResp.NextTodo.DueAt)
+	}
+	if completeResp.Todo.Recurrence != nil {
+		t.Errorf("Expected the rule to move to the next occurrence")
+	}
+
+	// ルールの解除
+	updateResp, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{
+		Id:              completeResp.NextTodo.Id,
+		UserId:          "user123",
+		ClearRecurrence: true,
+	})
+	if updateResp.Todo.Recurrence != nil {
+		t.Errorf("Expected recurrence to be cleared")
+	}
+}
+
+func TestTodoServer_Pagination_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	for i := 0; i < 5; i++ {
+		resp, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Todo"})
+		if i < 3 {
+			server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: resp.Todo.Id, UserId: "user123", Completed: true})
+		}
+	}
+
+	// page_size を指定すると次ページのトークンと総件数が返る
+	var ids []string
+	req := &pb.ListTodosRequest{UserId: "user123", PageSize: 2}
+	for pages := 0; pages < 5; pages++ {
+		resp, _ := server.ListTodos(ctx, req)
+		if resp.Error != "" {
+			t.Fatalf("ListTodos failed: %s", resp.Error)
+		}
+		if resp.TotalCount != 5 {
+			t.Errorf("Expected total count 5, got %d", resp.TotalCount)
+		}
+		for _, todo := range resp.Todos {
+			ids = append(ids, todo.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if len(ids) != 5 {
+		t.Errorf("Expected 5 todos across pages, got %d", len(ids))
+	}
+
+	// ページ指定なしでは従来通り全件が返る
+	allResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123"})
+	if len(allResp.Todos) != 5 || allResp.NextPageToken != "" || allResp.TotalCount != 5 {
+		t.Errorf("Expected all 5 todos without a page token, got %d (next %q)", len(allResp.Todos), allResp.NextPageToken)
+	}
+
+	// 不正なトークンはエラー
+	badResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", PageToken: "not-a-token"})
+	if badResp.Error == "" {
+		t.Error("Expected error for invalid page token")
+	}
+
+	// 完了済み一覧もページングできる
+	completedResp, _ := server.ListCompletedTodos(ctx, &pb.ListCompletedTodosRequest{UserId: "user123", PageSize: 2})
+	if completedResp.Error != "" {
+		t.Fatalf("ListCompletedTodos failed: %s", completedResp.Error)
+	}
+	if len(completedResp.Todos) != 2 || completedResp.NextPageToken == "" || completedResp.TotalCount != 3 {
+		t.Errorf("Expected first page of 2 out of 3 completed todos, got %d of %d", len(completedResp.Todos), completedResp.TotalCount)
+	}
+	lastResp, _ := server.ListCompletedTodos(ctx, &pb.ListCompletedTodosRequest{UserId: "user123", PageSize: 2, PageToken: completedResp.NextPageToken})
+	if len(lastResp.Todos) != 1 || lastResp.NextPageToken != "" {
+		t.Errorf("Expected last page with 1 todo, got %d (next %q)", len(lastResp.Todos), lastResp.NextPageToken)
+	}
+}
+
+func TestTodoServer_Filter_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	deploy, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Deploy API", Priority: pb.Priority_PRIORITY_HIGH})
+	docs, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Write docs"})
+	server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: docs.Todo.Id, UserId: "user123", Completed: true})
+
+	// フィルタ式に一致するTodoだけが返る
+	resp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", Filter: "completed:false AND (title:~deploy OR priority>=high)"})
+	if resp.Error != "" {
+		t.Fatalf("ListTodos failed: %s", resp.Error)
+	}
+	if len(resp.Todos) != 1 || resp.Todos[0].Id != deploy.Todo.Id {
+		t.Errorf("Expected only the deploy todo, got %d todos", len(resp.Todos))
+	}
+
+	// ページングと組み合わせられる
+	pageResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", Filter: "completed:true", PageSize: 10})
+	if len(pageResp.Todos) != 1 || pageResp.TotalCount != 1 {
+		t.Errorf("Expected 1 completed todo in the page, got %d of %d", len(pageResp.Todos), pageResp.TotalCount)
+	}
+
+	// 構文エラーはエラー位置付きで返る
+	badResp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123", Filter: "completed:false AND status:open"})
+	if badResp.Error == "" {
+		t.Fatal("Expected error for invalid filter")
+	}
+	if badResp.FilterErrorPosition != 21 {
+		t.Errorf("Expected error position 21, got %d", badResp.FilterErrorPosition)
+	}
+}
+
+func TestTodoServer_SearchTodos_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Patch servers", Description: "monthly"})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Groceries"})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "other", Title: "Patch laptop"})
+
+	// 自分のTodoだけが見つかる
+	resp, err := server.SearchTodos(ctx, &pb.SearchTodosRequest{UserId: "user123", Query: "patch"})
+	if err != nil {
+		t.Fatalf("SearchTodos returned error: %v", err)
+	}
+	if resp.Error != "" {
+		t.Fatalf("SearchTodos failed: %s", resp.Error)
+	}
+	if len(resp.Hits) != 1 || resp.Hits[0].Todo.Title != "Patch servers" {
+		t.Errorf("Expected one hit for the user's todo, got %v", resp.Hits)
+	}
+
+	// 空の検索語はエラー
+	emptyResp, _ := server.SearchTodos(ctx, &pb.SearchTodosRequest{UserId: "user123", Query: " "})
+	if emptyResp.Error == "" || !emptyResp.InvalidQuery {
+		t.Errorf("Expected an invalid query error for empty query, got %+v", emptyResp)
+	}
+	limitResp, _ := server.SearchTodos(ctx, &pb.SearchTodosRequest{UserId: "user123", Query: "patch", Limit: 1000})
+	if limitResp.Error == "" || !limitResp.InvalidQuery {
+		t.Errorf("Expected an invalid query error for limit above the maximum, got %+v", limitResp)
+	}
+}
+
+func TestTodoServer_CountTodosByUser_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	done, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Done"})
+	server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: done.Todo.Id, UserId: "user123", Completed: true})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Open"})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "other", Title: "Other"})
+
+	// 指定した順に、Todoのあるユーザーだけが返る
+	resp, err := server.CountTodosByUser(ctx, &pb.CountTodosByUserRequest{UserIds: []string{"nobody", "user123"}})
+	if err != nil {
+		t.Fatalf("CountTodosByUser returned error: %v", err)
+	}
+	if resp.Error != "" {
+		t.Fatalf("CountTodosByUser failed: %s", resp.Error)
+	}
+	if len(resp.Counts) != 1 {
+		t.Fatalf("Expected counts for one user, got %v", resp.Counts)
+	}
+	if c := resp.Counts[0]; c.UserId != "user123" || c.Total != 2 || c.Completed != 1 {
+		t.Errorf("Expected user123 to have 1 of 2 todos completed, got %v", c)
+	}
+}
+
+func TestTodoServer_DeleteUserData_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Mine"})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "other", Title: "Other"})
+
+	// 指定したユーザーのTodoだけが削除される
+	resp, err := server.DeleteUserData(ctx, &pb.DeleteUserDataRequest{UserId: "user123"})
+	if err != nil {
+		t.Fatalf("DeleteUserData returned error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("DeleteUserData failed: %s", resp.Error)
+	}
+	if list, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "user123"}); len(list.Todos) != 0 {
+		t.Errorf("Expected user123's todos to be deleted, got %d", len(list.Todos))
+	}
+	if list, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "other"}); len(list.Todos) != 1 {
+		t.Errorf("Expected the other user's todo to remain, got %d", len(list.Todos))
+	}
+
+	// ユーザーIDは必須
+	if resp, _ := server.DeleteUserData(ctx, &pb.DeleteUserDataRequest{}); resp.Success {
+		t.Error("Expected error for missing user ID")
+	}
+}
+
+func TestTodoServer_SavedViews_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	server := createTodoServer(repo)
+
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Fix login", Priority: pb.Priority_PRIORITY_HIGH})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Fix typo", Priority: pb.Priority_PRIORITY_LOW})
+	done, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "user123", Title: "Fix crash", Priority: pb.Priority_PRIORITY_HIGH})
+	server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: done.Todo.Id, UserId: "user123", Completed: true})
+
+	// ビューを作成すると条件がそのまま保存される
+	createResp, _ := server.CreateSavedView(ctx, &pb.CreateSavedViewRequest{
+		UserId: "user123",
+		Name:   "My open P1s",
+		Icon:   "🔥",
+		Criteria: &pb.ViewCriteria{
+			Completion:    pb.ViewCompletion_VIEW_COMPLETION_OPEN,
+			TitleContains: "fix",
+			Filter:        "priority>=high",
+			SortBy:        pb.SortField_SORT_FIELD_TITLE,
+		},
+	})
+	if createResp.Error != "" {
+		t.Fatalf("CreateSavedView failed: %s", createResp.Error)
+	}
+	view := createResp.View
+	if view.Criteria.Completion != pb.ViewCompletion_VIEW_COMPLETION_OPEN || view.Criteria.SortBy != pb.SortField_SORT_FIELD_TITLE {
+		t.Errorf("Expected criteria to round-trip, got %+v", view.Criteria)
+	}
+
+	// 保存した条件で一覧できる
+	listResp, _ := server.ListTodosByView(ctx, &pb.ListTodosByViewRequest{ViewId: view.Id, UserId: "user123"})
+	if listResp.Error != "" {
+		t.Fatalf("ListTodosByView failed: %s", listResp.Error)
+	}
+	if len(listResp.Todos) != 1 || listResp.Todos[0].Title != "Fix login" || listResp.TotalCount != 1 {
+		t.Errorf("Expected only the open high priority todo, got %d todos", len(listResp.Todos))
+	}
+
+	// 不正な条件は保存されない
+	badResp, _ := server.CreateSavedView(ctx, &pb.CreateSavedViewRequest{
+		UserId:   "user123",
+		Name:     "Broken",
+		Criteria: &pb.ViewCriteria{UpdatedBefore: "last week"},
+	})
+	if badResp.Error == "" {
+		t.Error("Expected error for invalid criteria")
+	}
+
+	// 名前だけを更新すると条件は保持される
+	updateResp, _ := server.UpdateSavedView(ctx, &pb.UpdateSavedViewRequest{Id: view.Id, UserId: "user123", Name: "P1s"})
+	if updateResp.Error != "" || updateResp.View.Name != "P1s" || updateResp.View.Icon != "🔥" || updateResp.View.Criteria.Filter != "priority>=high" {
+		t.Errorf("Expected rename to keep icon and criteria, got %+v (%s)", updateResp.View, updateResp.Error)
+	}
+
+	// 他ユーザーからは見えない
+	otherResp, _ := server.ListTodosByView(ctx, &pb.ListTodosByViewRequest{ViewId: view.Id, UserId: "other"})
+	if otherResp.Error == "" {
+		t.Error("Expected error when listing another user's view")
+	}
+	listViewsResp, _ := server.ListSavedViews(ctx, &pb.ListSavedViewsRequest{UserId: "user123"})
+	if len(listViewsResp.Views) != 1 {
+		t.Errorf("Expected 1 view, got %d", len(listViewsResp.Views))
+	}
+
+	deleteResp, _ := server.DeleteSavedView(ctx, &pb.DeleteSavedViewRequest{Id: view.Id, UserId: "user123"})
+	if !deleteResp.Success {
+		t.Errorf("DeleteSavedView failed: %s", deleteResp.Error)
+	}
+}
+
+// fakeMemberships はワークスペースごとのメンバーの役割を返す
+type fakeMemberships map[string]map[string]string
+
+func (f fakeMemberships) WorkspaceRole(workspaceID, userID string) (string, error) {
+	return f[workspaceID][userID], nil
+}
+
+func (f fakeMemberships) WorkspaceIDs(userID string) ([]string, error) {
+	var ids []string
+	for workspaceID, members := range f {
+		if members[userID] != "" {
+			ids = append(ids, workspaceID)
+		}
+	}
+	return ids, nil
+}
+
+func TestTodoServer_Workspace_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	memberships := fakeMemberships{"ws-1": {"alice": "owner", "bob": "member", "carol": "viewer"}}
+	todoService := service.NewTodoServiceWithMembership(repo, memberships)
+	server := grpcServer.NewTodoServer(todoService,
+		service.NewTagService(NewSimpleMockTagRepository()),
+		service.NewProjectService(NewSimpleMockProjectRepository(repo)),
+		service.NewSavedViewService(NewSimpleMockSavedViewRepository(), todoService),
+		service.NewCommentService(NewSimpleMockCommentRepository(), todoService))
+
+	// メンバーが作成したTodoはワークスペースのもので、作成者が記録される
+	created, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "bob", WorkspaceId: "ws-1", Title: "Shared"})
+	if created.Error != "" {
+		t.Fatalf("CreateTodo failed: %s", created.Error)
+	}
+	if created.Todo.WorkspaceId != "ws-1" || created.Todo.CreatedBy != "bob" {
+		t.Errorf("Expected a workspace todo created by bob, got %+v", created.Todo)
+	}
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "bob", Title: "Private"})
+
+	// ほかのメンバーにも見えるが、個人のTodoは含まれない
+	list, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "alice", WorkspaceId: "ws-1"})
+	if len(list.Todos) != 1 || list.Todos[0].Title != "Shared" {
+		t.Fatalf("Expected only the shared todo, got %v", list.Todos)
+	}
+	if personal, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "alice"}); len(personal.Todos) != 0 {
+		t.Errorf("Expected alice to have no personal todos, got %d", len(personal.Todos))
+	}
+
+	// 閲覧者は読めるが変更できない
+	if got, _ := server.GetTodo(ctx, &pb.GetTodoRequest{Id: created.Todo.Id, UserId: "carol", WorkspaceId: "ws-1"}); got.Error != "" {
+		t.Errorf("Expected the viewer to read the todo, got %s", got.Error)
+	}
+	if resp, _ := server.MarkTodoComplete(ctx, &pb.MarkTodoCompleteRequest{Id: created.Todo.Id, UserId: "carol", WorkspaceId: "ws-1", Completed: true}); resp.Error != service.ErrWorkspaceReadOnly.Error() {
+		t.Errorf("Expected ErrWorkspaceReadOnly, got %q", resp.Error)
+	}
+	if resp, _ := server.DeleteTodo(ctx, &pb.DeleteTodoRequest{Id: created.Todo.Id, UserId: "carol", WorkspaceId: "ws-1"}); resp.Success {
+		t.Error("Expected the viewer not to delete the todo")
+	}
+
+	// メンバーでなければワークスペースは見えない
+	if resp, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "mallory", WorkspaceId: "ws-1"}); resp.Error == "" || len(resp.Todos) != 0 {
+		t.Errorf("Expected an outsider to be denied, got %+v", resp)
+	}
+
+	// ワークスペースのタグとプロジェクトも共有される
+	tag, _ := server.CreateTag(ctx, &pb.CreateTagRequest{UserId: "alice", WorkspaceId: "ws-1", Name: "team"})
+	if tag.Error != "" {
+		t.Fatalf("CreateTag failed: %s", tag.Error)
+	}
+	updated, _ := server.UpdateTodo(ctx, &pb.UpdateTodoRequest{Id: created.Todo.Id, UserId: "bob", WorkspaceId: "ws-1", SetTags: true, TagIds: []string{tag.Tag.Id}})
+	if updated.Error != "" || len(updated.Todo.Tags) != 1 {
+		t.Errorf("Expected bob to use the workspace tag, got %+v", updated)
+	}
+	if tags, _ := server.ListTags(ctx, &pb.ListTagsRequest{UserId: "bob"}); len(tags.Tags) != 0 {
+		t.Errorf("Expected the tag to stay out of bob's own tags, got %d", len(tags.Tags))
+	}
+}
+
+func TestTodoServer_Assignment_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	memberships := fakeMemberships{"ws-1": {"alice": "owner", "bob": "member", "carol": "viewer"}}
+	todoService := service.NewTodoServiceWithMembership(repo, memberships)
+	server := grpcServer.NewTodoServer(todoService,
+		service.NewTagService(NewSimpleMockTagRepository()),
+		service.NewProjectService(NewSimpleMockProjectRepository(repo)),
+		service.NewSavedViewService(NewSimpleMockSavedViewRepository(), todoService),
+		service.NewCommentService(NewSimpleMockCommentRepository(), todoService))
+
+	created, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "alice", WorkspaceId: "ws-1", Title: "Delegated"})
+	server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "alice", WorkspaceId: "ws-1", Title: "Kept"})
+
+	// オーナーがメンバーに割り当てる
+	assigned, _ := server.AssignTodo(ctx, &pb.AssignTodoRequest{Id: created.Todo.Id, UserId: "alice", WorkspaceId: "ws-1", AssigneeId: "bob"})
+	if assigned.Error != "" || assigned.Todo.AssigneeId != "bob" || assigned.Todo.CreatedBy != "alice" {
+		t.Fatalf("Expected a todo created by alice and assigned to bob, got %+v", assigned)
+	}
+
+	// 閲覧者は割り当てを変えられない
+	if resp, _ := server.UnassignTodo(ctx, &pb.UnassignTodoRequest{Id: created.Todo.Id, UserId: "carol", WorkspaceId: "ws-1"}); resp.Error != service.ErrWorkspaceReadOnly.Error() {
+		t.Errorf("Expected ErrWorkspaceReadOnly, got %q", resp.Error)
+	}
+
+	// 割り当てられた人は、ワークスペースを指定しなくても自分の一覧で見られる
+	list, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "bob", AssignedToMe: true})
+	if list.Error != "" || len(list.Todos) != 1 || list.Todos[0].Id != created.Todo.Id {
+		t.Fatalf("Expected the delegated todo, got %+v", list)
+	}
+	paged, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "bob", AssignedToMe: true, PageSize: 10})
+	if paged.Error != "" || paged.TotalCount != 1 {
+		t.Errorf("Expected 1 assigned todo on the page, got %+v", paged)
+	}
+	if mine, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "alice", AssignedToMe: true}); len(mine.Todos) != 0 {
+		t.Errorf("Expected nothing assigned to alice, got %d", len(mine.Todos))
+	}
+
+	// 割り当てを外すと一覧から消える
+	if resp, _ := server.UnassignTodo(ctx, &pb.UnassignTodoRequest{Id: created.Todo.Id, UserId: "bob", WorkspaceId: "ws-1"}); resp.Error != "" || resp.Todo.AssigneeId != "" {
+		t.Errorf("Expected the todo to be unassigned, got %+v", resp)
+	}
+	if list, _ := server.ListTodos(ctx, &pb.ListTodosRequest{UserId: "bob", AssignedToMe: true}); len(list.Todos) != 0 {
+		t.Errorf("Expected nothing assigned to bob, got %d", len(list.Todos))
+	}
+}
+
+func TestTodoServer_Comments_Behavior(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSimpleMockRepository()
+	memberships := fakeMemberships{"ws-1": {"alice": "owner", "bob": "member", "carol": "viewer"}}
+	todoService := service.NewTodoServiceWithMembership(repo, memberships)
+	server := grpcServer.NewTodoServer(todoService,
+		service.NewTagService(NewSimpleMockTagRepository()),
+		service.NewProjectService(NewSimpleMockProjectRepository(repo)),
+		service.NewSavedViewService(NewSimpleMockSavedViewRepository(), todoService),
+		service.NewCommentService(NewSimpleMockCommentRepository(), todoService))
+
+	created, _ := server.CreateTodo(ctx, &pb.CreateTodoRequest{UserId: "alice", WorkspaceId: "ws-1", Title: "Discussed"})
+	todoID := created.Todo.Id
+
+	byAlice, _ := server.AddComment(ctx, &pb.AddCommentRequest{TodoId: todoID, UserId: "alice", WorkspaceId: "ws-1", Body: "Can you take this?"})
+	byBob, _ := server.AddComment(ctx, &pb.AddCommentRequest{TodoId: todoID, UserId: "bob", WorkspaceId: "ws-1", Body: "  Sure  "})
+	if byBob.Error != "" || byBob.Comment.AuthorId != "bob" || byBob.Comment.Body != "Sure" {
+		t.Fatalf("Expected bob's trimmed comment, got %+v", byBob)
+	}
+
+	// 閲覧者はスレッドを読めるが書き込めない
+	if resp, _ := server.AddComment(ctx, &pb.AddCommentRequest{TodoId: todoID, UserId: "carol", WorkspaceId: "ws-1", Body: "Hi"}); resp.Error != service.ErrWorkspaceReadOnly.Error() {
+		t.Errorf("Expected ErrWorkspaceReadOnly, got %q", resp.Error)
+	}
+
+	// 編集できるのは投稿者だけ
+	if resp, _ := server.EditComment(ctx, &pb.EditCommentRequest{Id: byBob.Comment.Id, TodoId: todoID, UserId: "alice", WorkspaceId: "ws-1", Body: "No"}); resp.Error != service.ErrNotCommentAuthor.Error() {
+		t.Errorf("Expected ErrNotCommentAuthor, got %q", resp.Error)
+	}
+	edited, _ := server.EditComment(ctx, &pb.EditCommentRequest{Id: byBob.Comment.Id, TodoId: todoID, UserId: "bob", WorkspaceId: "ws-1", Body: "Sure, today"})
+	if edited.Error != "" || edited.Comment.Body != "Sure, today" || edited.Comment.EditedAt == "" {
+		t.Errorf("Expected an edited comment, got %+v", edited)
+	}
+
+	// メンバーはTodoのオーナーのコメントを削除できないが、オーナーはできる
+	if resp, _ := server.DeleteComment(ctx, &pb.DeleteCommentRequest{Id: byAlice.Comment.Id, TodoId: todoID, UserId: "bob", WorkspaceId: "ws-1"}); resp.Error != service.ErrCommentDeleteDenied.Error() {
+		t.Errorf("Expected ErrCommentDeleteDenied, got %q", resp.Error)
+	}
+	if resp, _ := server.DeleteComment(ctx, &pb.DeleteCommentRequest{Id: byBob.Comment.Id, TodoId: todoID, UserId: "alice", WorkspaceId: "ws-1"}); !resp.Success {
+		t.Errorf("Expected the owner to delete bob's comment, got %q", resp.Error)
+	}
+
+	// 削除したコメントは本文なしでスレッドに残る
+	list, _ := server.ListComments(ctx, &pb.ListCommentsRequest{TodoId: todoID, UserId: "carol", WorkspaceId: "ws-1"})
+	if list.Error != "" || len(list.Comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %+v", list)
+	}
+	if deleted := list.Comments[1]; !deleted.Deleted || deleted.Body != "" {
+		t.Errorf("Expected bob's comment to be deleted without a body, got %+v", deleted)
+	}
+	if list.Comments[0].Body != "Can you take this?" {
+		t.Errorf("Expected alice's comment first, got %+v", list.Comments[0])
+	}
+}
@@ -0,0 +1,82 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+)
+
+// requestTimeout bounds each membership lookup, which runs on the path of
+// every workspace request
+const requestTimeout = 5 * time.Second
+
+// MembershipClient asks the user service for workspace roles
+type MembershipClient struct {
+	client pb.UserServiceClient
+	conn   *grpc.ClientConn
+}
+
+func NewMembershipClient(address string) (*MembershipClient, error) {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %v", err)
+	}
+
+	return &MembershipClient{
+		client: pb.NewUserServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// WorkspaceRole returns the user's role, or "" when they aren't a member
+func (c *MembershipClient) WorkspaceRole(workspaceID, userID string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := c.client.GetWorkspaceMembership(ctx, &pb.GetWorkspaceMembershipRequest{
+		WorkspaceId: workspaceID,
+		UserId:      userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+	if resp.NotMember {
+		return "", nil
+	}
+	return resp.Role, nil
+}
+
+// WorkspaceIDs returns the IDs of the workspaces the user belongs to
+func (c *MembershipClient) WorkspaceIDs(userID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := c.client.ListWorkspaces(ctx, &pb.ListWorkspacesRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	ids := make([]string, len(resp.Workspaces))
+	for i, workspace := range resp.Workspaces {
+		ids[i] = workspace.Id
+	}
+	return ids, nil
+}
+
+func (c *MembershipClient) Close() error {
+	return c.conn.Close()
+}
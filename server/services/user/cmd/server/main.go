@@ -1,15 +1,26 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	// Preferences name IANA time zones, which must not depend on the host
+	_ "time/tzdata"
 
 	"google.golang.org/grpc"
 
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/auth"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/breach"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
 	grpcServer "github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/grpc"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/mail"
 )
 
 func main() {
@@ -19,12 +30,101 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 	defer userRepo.Close()
+	sessionRepo := database.NewSQLiteSessionRepository(userRepo)
+	refreshTokenRepo := database.NewSQLiteRefreshTokenRepository(userRepo)
+	passwordResetRepo := database.NewSQLitePasswordResetTokenRepository(userRepo)
+	emailVerificationRepo := database.NewSQLiteEmailVerificationTokenRepository(userRepo)
+	totpRepo := database.NewSQLiteTOTPCredentialRepository(userRepo)
+	recoveryCodeRepo := database.NewSQLiteRecoveryCodeRepository(userRepo)
+	twoFactorChallengeRepo := database.NewSQLiteTwoFactorChallengeRepository(userRepo)
+	loginAttemptRepo := database.NewSQLiteLoginAttemptRepository(userRepo)
+	personalAccessTokenRepo := database.NewSQLitePersonalAccessTokenRepository(userRepo)
+	preferencesRepo := database.NewSQLitePreferencesRepository(userRepo)
+	workspaceRepo := database.NewSQLiteWorkspaceRepository(userRepo)
+
+	// Access tokens are signed with the keys of the key directory; the BFF
+	// verifies them with the public keys served by GetJWKS
+	keyDir := os.Getenv("JWT_KEY_DIR")
+	if keyDir == "" {
+		keyDir = "./keys"
+	}
+	keys, err := loadSigningKeys(keyDir)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+
+	mailer, err := newMailer()
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+	resetURL := os.Getenv("PASSWORD_RESET_URL")
+	if resetURL == "" {
+		resetURL = "http://localhost:5173/reset-password"
+	}
+	verifyURL := os.Getenv("EMAIL_VERIFICATION_URL")
+	if verifyURL == "" {
+		verifyURL = "http://localhost:5173/verify-email"
+	}
+	invitationURL := os.Getenv("WORKSPACE_INVITATION_URL")
+	if invitationURL == "" {
+		invitationURL = "http://localhost:5173/invitations"
+	}
+
+	// TOTP_ISSUER names the service in authenticator apps
+	totpIssuer := os.Getenv("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "MyTodo"
+	}
+
+	// EMAIL_VERIFICATION_POLICY decides what unverified accounts may do: none,
+	// login (they can't sign in) or write (enforced by the BFF)
+	verificationPolicy, err := service.ParseEmailVerificationPolicy(os.Getenv("EMAIL_VERIFICATION_POLICY"))
+	if err != nil {
+		log.Fatalf("Invalid EMAIL_VERIFICATION_POLICY: %v", err)
+	}
+
+	passwordPolicy, err := loadPasswordPolicy()
+	if err != nil {
+		log.Fatalf("Invalid password policy setting: %v", err)
+	}
+
+	throttleConfig, err := loginThrottleConfig()
+	if err != nil {
+		log.Fatalf("Invalid login throttle setting: %v", err)
+	}
+
+	// ACCOUNT_DELETION_GRACE_PERIOD is how long users can cancel the
+	// deletion of their account
+	deletionGracePeriod := service.DefaultAccountDeletionGracePeriod
+	if env := os.Getenv("ACCOUNT_DELETION_GRACE_PERIOD"); env != "" {
+		deletionGracePeriod, err = time.ParseDuration(env)
+		if err != nil || deletionGracePeriod < 0 {
+			log.Fatalf("Invalid ACCOUNT_DELETION_GRACE_PERIOD: %q", env)
+		}
+	}
 
 	// Initialize domain service
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserServiceWithPasswordPolicy(userRepo, passwordPolicy)
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshTokenRepo, auth.NewSigner(keys))
+	sessionService := service.NewSessionService(sessionRepo, refreshTokenRepo)
+	passwordResetService := service.NewPasswordResetService(userRepo, passwordResetRepo, sessionService, mailer, resetURL, passwordPolicy)
+	emailVerificationService := service.NewEmailVerificationService(userRepo, emailVerificationRepo, mailer, verifyURL, verificationPolicy)
+	loginThrottle := service.NewLoginThrottle(loginAttemptRepo, throttleConfig)
+	twoFactorService := service.NewTwoFactorService(userRepo, totpRepo, recoveryCodeRepo, twoFactorChallengeRepo, tokenService, loginThrottle, totpIssuer)
+	personalAccessTokenService := service.NewPersonalAccessTokenService(userRepo, personalAccessTokenRepo)
+	adminService := service.NewAdminService(userRepo, sessionService, passwordResetService)
+	accountDeletionService := service.NewAccountDeletionService(userRepo, workspaceRepo, mailer, deletionGracePeriod)
+	preferencesService := service.NewPreferencesService(userRepo, preferencesRepo)
+	workspaceService := service.NewWorkspaceService(userRepo, workspaceRepo, mailer, invitationURL)
+
+	// ADMIN_EMAILS lists accounts that get the admin role at startup, so the
+	// first administrator can be set up without editing the database
+	if err := promoteAdmins(adminService, os.Getenv("ADMIN_EMAILS")); err != nil {
+		log.Fatalf("Failed to promote ADMIN_EMAILS: %v", err)
+	}
 
 	// Initialize gRPC server
-	userGRPCServer := grpcServer.NewUserServer(userService)
+	userGRPCServer := grpcServer.NewUserServer(userService, tokenService, sessionService, passwordResetService, emailVerificationService, twoFactorService, loginThrottle, personalAccessTokenService, adminService, accountDeletionService, preferencesService, workspaceService, keys)
 
 	// Create gRPC server
 	s := grpc.NewServer()
@@ -41,3 +141,152 @@ func main() {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// loadSigningKeys loads the key directory, creating a first Ed25519 key when
+// it has none so that development setups work out of the box
+func loadSigningKeys(dir string) (*auth.KeySet, error) {
+	keys, err := auth.LoadKeySet(dir)
+	if !errors.Is(err, auth.ErrNoSigningKeys) {
+		return keys, err
+	}
+
+	key, err := auth.GenerateEd25519Key(time.Now().UTC().Format("20060102T150405Z"))
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.WriteKey(dir, key); err != nil {
+		return nil, err
+	}
+	log.Printf("No signing keys in %s; generated key %s", dir, key.ID)
+	return auth.NewKeySet(key)
+}
+
+// promoteAdmins gives the admin role to the comma-separated emails.
+// Addresses without an account are skipped; restart after they sign up.
+func promoteAdmins(adminService *service.AdminService, list string) error {
+	var emails []string
+	for _, email := range strings.Split(list, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	missing, err := adminService.PromoteAdmins(emails)
+	if err != nil {
+		return err
+	}
+	for _, email := range missing {
+		log.Printf("ADMIN_EMAILS: no account for %s yet", email)
+	}
+	return nil
+}
+
+// newMailer sends mail through SMTP_HOST when it is set. Otherwise messages
+// are appended to MAIL_FILE, or printed to stdout, for local development.
+func newMailer() (service.Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		return mail.NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	}
+
+	if path := os.Getenv("MAIL_FILE"); path != "" {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return mail.NewWriterMailer(file, from), nil
+	}
+
+	log.Println("SMTP_HOST is not set; printing mail to stdout")
+	return mail.NewWriterMailer(os.Stdout, from), nil
+}
+
+// loadPasswordPolicy overrides the default password policy with the
+// PASSWORD_* environment variables that are set. BREACHED_PASSWORDS_FILE
+// names a local list of breached passwords to reject.
+func loadPasswordPolicy() (service.PasswordPolicy, error) {
+	policy := service.DefaultPasswordPolicy()
+
+	ints := map[string]*int{
+		"PASSWORD_MIN_LENGTH":            &policy.MinLength,
+		"PASSWORD_MAX_LENGTH":            &policy.MaxLength,
+		"PASSWORD_MIN_CHARACTER_CLASSES": &policy.MinCharacterClasses,
+	}
+	for name, value := range ints {
+		if env := os.Getenv(name); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil || n < 0 {
+				return policy, fmt.Errorf("%s must be a non-negative integer: %q", name, env)
+			}
+			*value = n
+		}
+	}
+	if env := os.Getenv("PASSWORD_FORBID_EMAIL"); env != "" {
+		forbid, err := strconv.ParseBool(env)
+		if err != nil {
+			return policy, fmt.Errorf("PASSWORD_FORBID_EMAIL must be a boolean: %q", env)
+		}
+		policy.ForbidEmail = forbid
+	}
+
+	if path := os.Getenv("BREACHED_PASSWORDS_FILE"); path != "" {
+		list, err := breach.LoadFile(path)
+		if err != nil {
+			return policy, fmt.Errorf("BREACHED_PASSWORDS_FILE: %w", err)
+		}
+		log.Printf("Loaded %d breached passwords from %s", list.Len(), path)
+		policy.Breached = list
+	}
+
+	return policy, nil
+}
+
+// loginThrottleConfig overrides the default login throttle with the
+// LOGIN_* environment variables that are set
+func loginThrottleConfig() (service.LoginThrottleConfig, error) {
+	config := service.DefaultLoginThrottleConfig()
+
+	ints := map[string]*int{
+		"LOGIN_FREE_ATTEMPTS":        &config.FreeAttempts,
+		"LOGIN_LOCKOUT_THRESHOLD":    &config.AccountLockoutThreshold,
+		"LOGIN_IP_LOCKOUT_THRESHOLD": &config.IPLockoutThreshold,
+	}
+	for name, value := range ints {
+		if env := os.Getenv(name); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil || n < 0 {
+				return config, fmt.Errorf("%s must be a non-negative integer: %q", name, env)
+			}
+			*value = n
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"LOGIN_BACKOFF_BASE":     &config.BaseDelay,
+		"LOGIN_BACKOFF_MAX":      &config.MaxDelay,
+		"LOGIN_LOCKOUT_DURATION": &config.LockoutDuration,
+		"LOGIN_FAILURE_WINDOW":   &config.FailureWindow,
+	}
+	for name, value := range durations {
+		if env := os.Getenv(name); env != "" {
+			d, err := time.ParseDuration(env)
+			if err != nil || d < 0 {
+				return config, fmt.Errorf("%s must be a non-negative duration: %q", name, env)
+			}
+			*value = d
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+)
+
+// EmailVerificationToken proves that its holder can read mail sent to Email.
+// Email is the address being verified, which is the user's pending address
+// during an email change. Only a hash is stored and each token works once.
+type EmailVerificationToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Email     string     `json:"email"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewEmailVerificationToken creates a verification token valid for ttl and
+// returns it together with the secret mailed to the address
+func NewEmailVerificationToken(id, userID, email string, ttl time.Duration) (*EmailVerificationToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &EmailVerificationToken{
+		ID:        id,
+		UserID:    userID,
+		Email:     email,
+		TokenHash: HashEmailVerificationToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashEmailVerificationToken returns the value stored in place of a
+// verification token
+func HashEmailVerificationToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// Usable reports whether the token can still be used at the given time
+func (t *EmailVerificationToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
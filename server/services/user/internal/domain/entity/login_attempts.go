@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+)
+
+// LoginAttempts counts the recent failed sign-ins for one key, an account or
+// a source address
+type LoginAttempts struct {
+	Key           string     `json:"key"`
+	Failures      int        `json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+// Locked reports whether sign-ins are refused outright at the given time
+func (a *LoginAttempts) Locked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
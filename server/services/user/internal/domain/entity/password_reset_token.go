@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+)
+
+// PasswordResetToken lets the owner of an email address choose a new
+// password. Only a hash is stored and each token works once.
+type PasswordResetToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewPasswordResetToken creates a reset token valid for ttl and returns it
+// together with the secret mailed to the user
+func NewPasswordResetToken(id, userID string, ttl time.Duration) (*PasswordResetToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &PasswordResetToken{
+		ID:        id,
+		UserID:    userID,
+		TokenHash: HashPasswordResetToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashPasswordResetToken returns the value stored in place of a reset token
+func HashPasswordResetToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// Usable reports whether the token can still be used at the given time
+func (t *PasswordResetToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	WeekStartSunday   = "sunday"
+	WeekStartMonday   = "monday"
+	WeekStartSaturday = "saturday"
+)
+
+// DateFormats are the date formats clients know how to render
+var DateFormats = []string{"YYYY-MM-DD", "YYYY/MM/DD", "DD.MM.YYYY", "DD/MM/YYYY", "MM/DD/YYYY"}
+
+// Preferences are a user's display and listing settings. Timezone decides
+// where "today" starts for the todo service; the rest is only stored for
+// clients. Empty defaults leave the choice to the client.
+type Preferences struct {
+	UserID           string    `json:"user_id"`
+	Timezone         string    `json:"timezone"`
+	Locale           string    `json:"locale"`
+	WeekStart        string    `json:"week_start"`
+	DateFormat       string    `json:"date_format"`
+	DefaultSort      string    `json:"default_sort,omitempty"`
+	DefaultSortOrder string    `json:"default_sort_order,omitempty"`
+	DefaultProjectID string    `json:"default_project_id,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// DefaultPreferences are used until the user saves their own
+func DefaultPreferences(userID string) *Preferences {
+	return &Preferences{
+		UserID:     userID,
+		Timezone:   "UTC",
+		Locale:     "en",
+		WeekStart:  WeekStartMonday,
+		DateFormat: DateFormats[0],
+	}
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+)
+
+// RecoveryCodeCount is the number of recovery codes generated at a time
+const RecoveryCodeCount = 10
+
+// recoveryCodeBytes is the randomness of a recovery code, which is shown as
+// two groups of base32 characters
+const recoveryCodeBytes = 10
+
+// RecoveryCode signs a user in once in place of a TOTP code, for when the
+// authenticator is lost. Only a hash is stored.
+type RecoveryCode struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewRecoveryCodes creates a set of recovery codes and returns them together
+// with the codes shown to the user. newID supplies the record IDs.
+func NewRecoveryCodes(userID string, newID func() string) ([]*RecoveryCode, []string, error) {
+	now := time.Now()
+	codes := make([]*RecoveryCode, 0, RecoveryCodeCount)
+	plain := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		secret := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))
+		code = code[:len(code)/2] + "-" + code[len(code)/2:]
+
+		codes = append(codes, &RecoveryCode{
+			ID:        newID(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(code),
+			CreatedAt: now,
+		})
+		plain = append(plain, code)
+	}
+	return codes, plain, nil
+}
+
+// HashRecoveryCode returns the value stored in place of a recovery code.
+// Case, spaces and dashes are ignored so codes can be typed loosely.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+	return hashSecretToken(normalized)
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+// RefreshToken is a long-lived credential exchanged for new access tokens.
+// Only a hash of the token is stored. Every refresh replaces the token with
+// a new one of the same family, which identifies the original sign-in.
+type RefreshToken struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewRefreshToken creates a refresh token valid for ttl and returns it
+// together with the secret handed to the client
+func NewRefreshToken(id, userID, familyID string, ttl time.Duration) (*RefreshToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &RefreshToken{
+		ID:        id,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashRefreshToken returns the value stored in place of a refresh token
+func HashRefreshToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// Active reports whether the token can still be used at the given time
+func (t *RefreshToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
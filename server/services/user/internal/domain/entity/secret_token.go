@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// secretTokenBytes is the amount of randomness in tokens handed to clients
+const secretTokenBytes = 32
+
+// newSecretToken returns a random URL-safe token
+func newSecretToken() (string, error) {
+	secret := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashSecretToken returns the value stored in place of a token
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
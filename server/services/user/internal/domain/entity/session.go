@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxSessionDeviceLength caps the stored user agent
+const MaxSessionDeviceLength = 255
+
+// Session is one sign-in of a user. Its ID is the jti of every access token
+// issued for it and the family of its refresh tokens, so revoking the session
+// ends both.
+type Session struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	// Device is the user agent the session was started from
+	Device     string     `json:"device"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewSession starts a session for a sign-in from the given device
+func NewSession(id, userID, device, ipAddress string) *Session {
+	device = strings.TrimSpace(device)
+	if runes := []rune(device); len(runes) > MaxSessionDeviceLength {
+		device = string(runes[:MaxSessionDeviceLength])
+	}
+
+	now := time.Now()
+	return &Session{
+		ID:         id,
+		UserID:     userID,
+		Device:     device,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+}
+
+// Active reports whether the session has not been revoked
+func (s *Session) Active() bool {
+	return s.RevokedAt == nil
+}
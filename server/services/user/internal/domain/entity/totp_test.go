@@ -0,0 +1,105 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+// RFC 6238 付録Bのテストベクタ（SHA1、秘密鍵 "12345678901234567890"）の下6桁
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := entity.TOTPCode(rfc6238Secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("TOTPCode returned error: %v", err)
+		}
+		if code != tt.code {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestTOTPCredential_Validate(t *testing.T) {
+	credential := &entity.TOTPCredential{UserID: "user-123", Secret: rfc6238Secret}
+	now := time.Unix(1111111111, 0)
+
+	// 前後1ステップまでのコードは受け付ける
+	previous, _ := entity.TOTPCode(rfc6238Secret, now.Add(-entity.TOTPPeriod))
+	step, ok := credential.Validate(previous, now)
+	if !ok || step != entity.TOTPStep(now)-1 {
+		t.Errorf("Code of the previous step should be accepted, got %d %v", step, ok)
+	}
+
+	// それより古いコードは拒否する
+	old, _ := entity.TOTPCode(rfc6238Secret, now.Add(-3*entity.TOTPPeriod))
+	if _, ok := credential.Validate(old, now); ok {
+		t.Errorf("Code three steps old should be rejected")
+	}
+
+	// 使用済みのステップのコードは再利用できない
+	current, _ := entity.TOTPCode(rfc6238Secret, now)
+	credential.LastUsedStep = entity.TOTPStep(now)
+	if _, ok := credential.Validate(current, now); ok {
+		t.Errorf("Code of a used step should be rejected")
+	}
+
+	if _, ok := credential.Validate("12345", now); ok {
+		t.Errorf("Codes of the wrong length should be rejected")
+	}
+}
+
+func TestTOTPCredential_URI(t *testing.T) {
+	credential, err := entity.NewTOTPCredential("user-123")
+	if err != nil {
+		t.Fatalf("NewTOTPCredential returned error: %v", err)
+	}
+	if credential.Confirmed() {
+		t.Errorf("New credential should not be confirmed")
+	}
+
+	uri := credential.URI("MyTodo", "test@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/MyTodo:test@example.com?") {
+		t.Errorf("Unexpected URI: %s", uri)
+	}
+	if !strings.Contains(uri, "secret="+credential.Secret) || !strings.Contains(uri, "issuer=MyTodo") {
+		t.Errorf("URI should contain the secret and issuer: %s", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	n := 0
+	codes, plain, err := entity.NewRecoveryCodes("user-123", func() string {
+		n++
+		return "code-" + string(rune('a'+n))
+	})
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes returned error: %v", err)
+	}
+	if len(codes) != entity.RecoveryCodeCount || len(plain) != entity.RecoveryCodeCount {
+		t.Fatalf("Expected %d codes, got %d", entity.RecoveryCodeCount, len(codes))
+	}
+
+	// 大文字やハイフンの有無に関係なく同じハッシュになる
+	loose := strings.ToUpper(strings.ReplaceAll(plain[0], "-", ""))
+	if entity.HashRecoveryCode(loose) != codes[0].CodeHash {
+		t.Errorf("Recovery code should match regardless of case and dashes")
+	}
+	if codes[0].CodeHash == plain[0] {
+		t.Errorf("Recovery code should be stored hashed")
+	}
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+)
+
+// MaxTwoFactorAttempts is the number of wrong codes after which a challenge
+// stops working and the user has to sign in again
+const MaxTwoFactorAttempts = 5
+
+// TwoFactorChallenge is a sign-in that passed the password check and waits
+// for a second factor. It remembers the device so that the session started
+// on success describes the original sign-in. Only a hash of the token is
+// stored and each challenge works once.
+type TwoFactorChallenge struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	TokenHash string     `json:"-"`
+	Device    string     `json:"device"`
+	IPAddress string     `json:"ip_address"`
+	Attempts  int        `json:"attempts"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewTwoFactorChallenge creates a challenge valid for ttl and returns it
+// together with the token handed to the client
+func NewTwoFactorChallenge(id, userID, device, ipAddress string, ttl time.Duration) (*TwoFactorChallenge, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &TwoFactorChallenge{
+		ID:        id,
+		UserID:    userID,
+		TokenHash: HashTwoFactorChallengeToken(token),
+		Device:    device,
+		IPAddress: ipAddress,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashTwoFactorChallengeToken returns the value stored in place of a
+// challenge token
+func HashTwoFactorChallengeToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// Usable reports whether the challenge can still be answered at the given
+// time
+func (c *TwoFactorChallenge) Usable(now time.Time) bool {
+	return c.UsedAt == nil && c.Attempts < MaxTwoFactorAttempts && now.Before(c.ExpiresAt)
+}
@@ -6,12 +6,27 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Roles decide what a user may do besides managing their own data
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	PasswordHash    string     `json:"password_hash"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// PendingEmail is a new address that replaces Email once it is verified
+	PendingEmail string `json:"pending_email,omitempty"`
+	Role         string `json:"role"`
+	// DisabledAt is set while an administrator has disabled the account
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	// DeletionScheduledAt is when the account is deleted, set while the
+	// user's request to delete it is in its grace period
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
 // NewUser creates a new user with hashed password
@@ -26,6 +41,7 @@ func NewUser(id, email, password string) (*User, error) {
 		ID:           id,
 		Email:        email,
 		PasswordHash: string(hashedPassword),
+		Role:         RoleUser,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
@@ -53,3 +69,94 @@ func (u *User) UpdateEmail(email string) {
 	u.Email = email
 	u.UpdatedAt = time.Now()
 }
+
+// EmailVerified reports whether the user has confirmed owning Email
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// RequestEmailChange records a new address that takes effect only after it
+// is verified. Asking for the current address cancels a pending change.
+func (u *User) RequestEmailChange(email string) {
+	if email == u.Email {
+		u.PendingEmail = ""
+	} else {
+		u.PendingEmail = email
+	}
+	u.UpdatedAt = time.Now()
+}
+
+// VerifyEmail marks email as verified. When it is the pending address it
+// replaces the current one.
+func (u *User) VerifyEmail(email string, at time.Time) {
+	if email == u.PendingEmail {
+		u.Email = email
+		u.PendingEmail = ""
+	}
+	verifiedAt := at
+	u.EmailVerifiedAt = &verifiedAt
+	u.UpdatedAt = at
+}
+
+// IsRole reports whether role is a known role
+func IsRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
+}
+
+// IsAdmin reports whether the user may administer other accounts
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// SetRole changes the user's role
+func (u *User) SetRole(role string) {
+	u.Role = role
+	u.UpdatedAt = time.Now()
+}
+
+// Disabled reports whether an administrator has disabled the account
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// Disable blocks the account until Enable is called
+func (u *User) Disable(at time.Time) {
+	disabledAt := at
+	u.DisabledAt = &disabledAt
+	u.UpdatedAt = at
+}
+
+// Enable lifts Disable
+func (u *User) Enable() {
+	u.DisabledAt = nil
+	u.UpdatedAt = time.Now()
+}
+
+// DeletionScheduled reports whether the user has asked for the account to be
+// deleted
+func (u *User) DeletionScheduled() bool {
+	return u.DeletionScheduledAt != nil
+}
+
+// ScheduleDeletion marks the account for deletion at the given time
+func (u *User) ScheduleDeletion(at time.Time) {
+	deleteAt := at
+	u.DeletionScheduledAt = &deleteAt
+	u.UpdatedAt = time.Now()
+}
+
+// CancelDeletion lifts ScheduleDeletion
+func (u *User) CancelDeletion() {
+	u.DeletionScheduledAt = nil
+	u.UpdatedAt = time.Now()
+}
+
+// ScramblePassword replaces the password with a random one nobody knows,
+// so that only a password reset lets the user sign in again
+func (u *User) ScramblePassword() error {
+	secret, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+	return u.UpdatePassword(secret)
+}
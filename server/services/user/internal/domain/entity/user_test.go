@@ -202,3 +202,86 @@ func TestUser_EmailValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_RequestEmailChange(t *testing.T) {
+	user, err := entity.NewUser("user-123", "test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	user.RequestEmailChange("new@example.com")
+
+	// 確認されるまでメールアドレスは変わらない
+	if user.Email != "test@example.com" {
+		t.Errorf("Email should not change before verification, got %s", user.Email)
+	}
+	if user.PendingEmail != "new@example.com" {
+		t.Errorf("Expected PendingEmail new@example.com, got %s", user.PendingEmail)
+	}
+
+	// 現在のアドレスを指定すると保留中の変更を取り消す
+	user.RequestEmailChange("test@example.com")
+	if user.PendingEmail != "" {
+		t.Errorf("PendingEmail should be cleared, got %s", user.PendingEmail)
+	}
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	user, err := entity.NewUser("user-123", "test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if user.EmailVerified() {
+		t.Fatalf("New user should not be verified")
+	}
+
+	user.RequestEmailChange("new@example.com")
+	at := time.Now()
+	user.VerifyEmail("new@example.com", at)
+
+	if !user.EmailVerified() {
+		t.Errorf("User should be verified")
+	}
+	if user.Email != "new@example.com" {
+		t.Errorf("Pending email should take effect, got %s", user.Email)
+	}
+	if user.PendingEmail != "" {
+		t.Errorf("PendingEmail should be cleared, got %s", user.PendingEmail)
+	}
+	if !user.EmailVerifiedAt.Equal(at) {
+		t.Errorf("Expected EmailVerifiedAt %v, got %v", at, user.EmailVerifiedAt)
+	}
+}
+
+func TestUser_RoleAndDisable(t *testing.T) {
+	user, err := entity.NewUser("user-123", "test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	// 新規ユーザーは一般ユーザーで、無効化されていない
+	if user.Role != entity.RoleUser || user.IsAdmin() || user.Disabled() {
+		t.Fatalf("New user should be an enabled regular user, got role %q disabled %v", user.Role, user.Disabled())
+	}
+
+	user.SetRole(entity.RoleAdmin)
+	if !user.IsAdmin() {
+		t.Errorf("User should be an admin")
+	}
+
+	user.Disable(time.Now())
+	if !user.Disabled() {
+		t.Errorf("User should be disabled")
+	}
+	user.Enable()
+	if user.Disabled() {
+		t.Errorf("User should be enabled again")
+	}
+
+	// パスワードを無作為な値にすると元のパスワードは使えない
+	if err := user.ScramblePassword(); err != nil {
+		t.Fatalf("ScramblePassword should succeed: %v", err)
+	}
+	if user.CheckPassword("password123") {
+		t.Errorf("Old password should stop working")
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Roles of workspace members
+const (
+	// WorkspaceRoleOwner manages the workspace and its members
+	WorkspaceRoleOwner = "owner"
+	// WorkspaceRoleMember reads and changes the workspace's todos
+	WorkspaceRoleMember = "member"
+	// WorkspaceRoleViewer only reads them
+	WorkspaceRoleViewer = "viewer"
+)
+
+// IsWorkspaceRole reports whether role is one of the workspace roles
+func IsWorkspaceRole(role string) bool {
+	return role == WorkspaceRoleOwner || role == WorkspaceRoleMember || role == WorkspaceRoleViewer
+}
+
+// Workspace is a group of users sharing todos. The todos themselves live in
+// the todo service, which asks for the members' roles.
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewWorkspace(id, name string) *Workspace {
+	now := time.Now()
+	return &Workspace{
+		ID:        id,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// WorkspaceMember is a user's membership of a workspace. Email is filled in
+// when members are listed.
+type WorkspaceMember struct {
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email,omitempty"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// CanWrite reports whether the member may change the workspace's todos
+func (m *WorkspaceMember) CanWrite() bool {
+	return m.Role == WorkspaceRoleOwner || m.Role == WorkspaceRoleMember
+}
+
+// WorkspaceMembership is a workspace as seen by one of its members
+type WorkspaceMembership struct {
+	Workspace *Workspace
+	Role      string
+}
+
+// WorkspaceInvitation asks the user with the given email address to join a
+// workspace. WorkspaceName is filled in when invitations are listed.
+type WorkspaceInvitation struct {
+	ID            string     `json:"id"`
+	WorkspaceID   string     `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name,omitempty"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	InvitedBy     string     `json:"invited_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	AcceptedAt    *time.Time `json:"accepted_at,omitempty"`
+}
+
+// Pending reports whether the invitation can still be accepted at the given time
+func (i *WorkspaceInvitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
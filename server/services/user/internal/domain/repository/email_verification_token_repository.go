@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrEmailVerificationTokenUsed     = errors.New("email verification token already used")
+)
+
+type EmailVerificationTokenRepository interface {
+	Create(token *entity.EmailVerificationToken) error
+	GetByHash(hash string) (*entity.EmailVerificationToken, error)
+	// MarkUsed consumes a token. It fails with ErrEmailVerificationTokenUsed
+	// when the token has already been used, so a token works only once.
+	MarkUsed(id string, at time.Time) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrLoginAttemptsNotFound = errors.New("login attempts not found")
+)
+
+type LoginAttemptRepository interface {
+	Get(key string) (*entity.LoginAttempts, error)
+	// RecordFailure counts a failed sign-in and returns the updated record.
+	// Counting starts over when the previous failure happened before
+	// resetBefore.
+	RecordFailure(key string, at, resetBefore time.Time) (*entity.LoginAttempts, error)
+	Lock(key string, until time.Time) error
+	Delete(key string) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenUsed     = errors.New("password reset token already used")
+)
+
+type PasswordResetTokenRepository interface {
+	Create(token *entity.PasswordResetToken) error
+	GetByHash(hash string) (*entity.PasswordResetToken, error)
+	// MarkUsed consumes a token. It fails with ErrPasswordResetTokenUsed
+	// when the token has already been used, so a token works only once.
+	MarkUsed(id string, at time.Time) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(token *entity.PersonalAccessToken) error
+	GetByHash(hash string) (*entity.PersonalAccessToken, error)
+	// ListByUserID lists the tokens of a user that have not been revoked,
+	// newest first
+	ListByUserID(userID string) ([]*entity.PersonalAccessToken, error)
+	// Revoke fails with ErrPersonalAccessTokenNotFound unless the user owns
+	// the token; revoking a token again succeeds
+	Revoke(userID, id string, at time.Time) error
+	Touch(id string, at time.Time) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrPreferencesNotFound = errors.New("preferences not found")
+)
+
+type PreferencesRepository interface {
+	// Get fails with ErrPreferencesNotFound when the user never saved any
+	Get(userID string) (*entity.Preferences, error)
+	// Save creates or replaces the user's preferences
+	Save(prefs *entity.Preferences) error
+}
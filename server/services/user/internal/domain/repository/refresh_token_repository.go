@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenRevoked  = errors.New("refresh token already revoked")
+)
+
+type RefreshTokenRepository interface {
+	Create(token *entity.RefreshToken) error
+	GetByHash(hash string) (*entity.RefreshToken, error)
+	// Rotate revokes old and stores its replacement atomically. It fails
+	// with ErrRefreshTokenRevoked when old has already been revoked, so a
+	// token can only be rotated once.
+	Rotate(old, replacement *entity.RefreshToken, at time.Time) error
+	// RevokeFamily revokes every active token of a family
+	RevokeFamily(familyID string, at time.Time) error
+}
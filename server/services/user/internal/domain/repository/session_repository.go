@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type SessionRepository interface {
+	Create(session *entity.Session) error
+	GetByID(id string) (*entity.Session, error)
+	// ListActiveByUserID lists the sessions that have not been revoked, most
+	// recently seen first
+	ListActiveByUserID(userID string) ([]*entity.Session, error)
+	// Touch records activity on a session; an empty ipAddress keeps the
+	// stored one
+	Touch(id, ipAddress string, at time.Time) error
+	Revoke(id string, at time.Time) error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrTOTPCredentialNotFound     = errors.New("totp credential not found")
+	ErrTOTPStepUsed               = errors.New("totp code already used")
+	ErrRecoveryCodeNotFound       = errors.New("recovery code not found")
+	ErrTwoFactorChallengeNotFound = errors.New("two-factor challenge not found")
+	ErrTwoFactorChallengeUsed     = errors.New("two-factor challenge already used")
+)
+
+type TOTPCredentialRepository interface {
+	GetByUserID(userID string) (*entity.TOTPCredential, error)
+	// Save stores the credential of a user, replacing any previous one
+	Save(credential *entity.TOTPCredential) error
+	// UseStep records the time step of an accepted code. It fails with
+	// ErrTOTPStepUsed when that step or a later one has been used already,
+	// so a code works only once.
+	UseStep(userID string, step int64) error
+	DeleteByUserID(userID string) error
+}
+
+type RecoveryCodeRepository interface {
+	// Replace swaps the codes of a user for a new set
+	Replace(userID string, codes []*entity.RecoveryCode) error
+	// Use consumes the unused code with the given hash. It fails with
+	// ErrRecoveryCodeNotFound when there is none.
+	Use(userID, hash string, at time.Time) error
+	CountUnused(userID string) (int, error)
+	DeleteByUserID(userID string) error
+}
+
+type TwoFactorChallengeRepository interface {
+	Create(challenge *entity.TwoFactorChallenge) error
+	GetByHash(hash string) (*entity.TwoFactorChallenge, error)
+	// RecordFailure counts a wrong code against the challenge
+	RecordFailure(id string) error
+	// MarkUsed consumes a challenge. It fails with ErrTwoFactorChallengeUsed
+	// when the challenge has already been used.
+	MarkUsed(id string, at time.Time) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 )
@@ -10,10 +11,36 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 )
 
+// UserQuery selects the users listed for administrators, ordered by email
+type UserQuery struct {
+	// Search matches part of the email address; empty matches everyone
+	Search string
+	// AfterEmail continues a listing after the user with this email
+	AfterEmail string
+	// Limit caps the number of users returned; 0 returns all of them
+	Limit int
+}
+
+// UserPage is the result of UserRepository.List
+type UserPage struct {
+	Users []*entity.User
+	// Total is the number of users matching Search across all pages
+	Total int
+}
+
 type UserRepository interface {
 	Create(user *entity.User) error
 	GetByID(id string) (*entity.User, error)
 	GetByEmail(email string) (*entity.User, error)
+	List(query UserQuery) (*UserPage, error)
+	// ListDeletionDue returns up to limit users whose deletion was scheduled
+	// for before the given time, the longest overdue first
+	ListDeletionDue(before time.Time, limit int) ([]*entity.User, error)
+	// CountActiveAdmins counts the admins whose account isn't disabled
+	CountActiveAdmins() (int, error)
 	Update(user *entity.User) error
+	// Delete removes the user along with their sessions, tokens, second
+	// factor, preferences, workspace memberships and the invitations to
+	// their email address
 	Delete(id string) error
 }
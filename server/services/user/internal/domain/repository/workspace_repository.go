@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+var (
+	ErrWorkspaceNotFound           = errors.New("workspace not found")
+	ErrWorkspaceMemberNotFound     = errors.New("workspace member not found")
+	ErrWorkspaceInvitationNotFound = errors.New("workspace invitation not found")
+)
+
+type WorkspaceRepository interface {
+	// Create stores a workspace together with its first owner
+	Create(workspace *entity.Workspace, owner *entity.WorkspaceMember) error
+	GetByID(id string) (*entity.Workspace, error)
+	// ListByUserID lists the workspaces the user belongs to by name
+	ListByUserID(userID string) ([]*entity.WorkspaceMembership, error)
+	// Delete removes the workspace with its members and invitations
+	Delete(id string) error
+
+	GetMember(workspaceID, userID string) (*entity.WorkspaceMember, error)
+	// ListMembers lists the members with their email addresses, in the
+	// order they joined
+	ListMembers(workspaceID string) ([]*entity.WorkspaceMember, error)
+	UpdateMember(member *entity.WorkspaceMember) error
+	RemoveMember(workspaceID, userID string) error
+
+	CreateInvitation(invitation *entity.WorkspaceInvitation) error
+	GetInvitation(id string) (*entity.WorkspaceInvitation, error)
+	// ListPendingInvitations lists the invitations to an email address that
+	// are neither accepted nor expired at the given time, newest first
+	ListPendingInvitations(email string, now time.Time) ([]*entity.WorkspaceInvitation, error)
+	// AcceptInvitation marks the invitation accepted and adds the member
+	// atomically; a user who already belongs to the workspace keeps their role
+	AcceptInvitation(invitation *entity.WorkspaceInvitation, member *entity.WorkspaceMember) error
+}
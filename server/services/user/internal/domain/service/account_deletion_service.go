@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// DefaultAccountDeletionGracePeriod is how long a user has to change their
+// mind after asking for their account to be deleted
+const DefaultAccountDeletionGracePeriod = 14 * 24 * time.Hour
+
+// AccountDeletionService lets users delete their own account. Deletion is
+// scheduled first and only carried out once the grace period is over, by
+// whoever lists the due accounts with Due; the account can be used, and the
+// deletion cancelled, until then.
+type AccountDeletionService struct {
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	mailer        Mailer
+	gracePeriod   time.Duration
+	now           func() time.Time
+}
+
+func NewAccountDeletionService(userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, mailer Mailer, gracePeriod time.Duration) *AccountDeletionService {
+	return &AccountDeletionService{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		mailer:        mailer,
+		gracePeriod:   gracePeriod,
+		now:           time.Now,
+	}
+}
+
+// Schedule marks the account for deletion after the grace period. Asking
+// again keeps the original date. The last owner of a workspace that others
+// belong to has to make another member an owner first.
+func (s *AccountDeletionService) Schedule(userID, currentPassword string) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CheckPassword(currentPassword) {
+		return nil, ErrIncorrectPassword
+	}
+	if user.DeletionScheduled() {
+		return user, nil
+	}
+	if err := s.checkWorkspaces(userID); err != nil {
+		return nil, err
+	}
+
+	user.ScheduleDeletion(s.now().Add(s.gracePeriod))
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SendNotice tells the user when their account is going to be deleted
+func (s *AccountDeletionService) SendNotice(user *entity.User) error {
+	if !user.DeletionScheduled() {
+		return nil
+	}
+
+	return s.mailer.Send(MailMessage{
+		To:      user.Email,
+		Subject: "Your account will be deleted",
+		Body: fmt.Sprintf("You asked for your account and all of your todos to be deleted.\n\n"+
+			"They will be deleted on %s. Until then you can sign in and cancel the deletion.\n\n"+
+			"If it wasn't you, sign in, cancel the deletion and change your password.\n",
+			user.DeletionScheduledAt.UTC().Format("January 2, 2006 15:04 MST")),
+	})
+}
+
+// Cancel keeps an account whose deletion was scheduled
+func (s *AccountDeletionService) Cancel(userID string) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.DeletionScheduled() {
+		return user, nil
+	}
+
+	user.CancelDeletion()
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Due lists up to limit accounts whose grace period is over, the longest
+// overdue first
+func (s *AccountDeletionService) Due(limit int) ([]*entity.User, error) {
+	return s.userRepo.ListDeletionDue(s.now(), limit)
+}
+
+// Delete removes the account right away with its sessions, tokens, settings
+// and workspace memberships, as if the user left each workspace. It returns
+// the workspaces deleted because the user was their last member, even when
+// it fails afterwards. A workspace left without an owner since the deletion
+// was scheduled passes to the member who joined first. The user's data in
+// the other services must be deleted before, and that of the deleted
+// workspaces after.
+func (s *AccountDeletionService) Delete(userID string) ([]string, error) {
+	memberships, err := s.workspaceRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, membership := range memberships {
+		workspaceID := membership.Workspace.ID
+		members, err := s.workspaceRepo.ListMembers(workspaceID)
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(members) == 1 {
+			if err := s.workspaceRepo.Delete(workspaceID); err != nil {
+				return deleted, err
+			}
+			deleted = append(deleted, workspaceID)
+			continue
+		}
+		if membership.Role == entity.WorkspaceRoleOwner && countOwners(members) == 1 {
+			next := successor(members, userID)
+			next.Role = entity.WorkspaceRoleOwner
+			if err := s.workspaceRepo.UpdateMember(next); err != nil {
+				return deleted, err
+			}
+		}
+	}
+
+	return deleted, s.userRepo.Delete(userID)
+}
+
+// checkWorkspaces fails with ErrLastWorkspaceOwner when the user is the only
+// owner of a workspace with other members
+func (s *AccountDeletionService) checkWorkspaces(userID string) error {
+	memberships, err := s.workspaceRepo.ListByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.Role != entity.WorkspaceRoleOwner {
+			continue
+		}
+		members, err := s.workspaceRepo.ListMembers(membership.Workspace.ID)
+		if err != nil {
+			return err
+		}
+		if len(members) > 1 && countOwners(members) == 1 {
+			return ErrLastWorkspaceOwner
+		}
+	}
+	return nil
+}
+
+// successor returns the member who joined first, other than the leaving user
+func successor(members []*entity.WorkspaceMember, leavingID string) *entity.WorkspaceMember {
+	var next *entity.WorkspaceMember
+	for _, member := range members {
+		if member.UserID != leavingID && (next == nil || member.JoinedAt.Before(next.JoinedAt)) {
+			next = member
+		}
+	}
+	return next
+}
@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+func TestAccountDeletionService_Schedule(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+
+	// Act & Assert - 現在のパスワードが違えば予定しない
+	if _, err := f.deletionService.Schedule(user.ID, "wrong-password"); err != service.ErrIncorrectPassword {
+		t.Errorf("Expected ErrIncorrectPassword, got %v", err)
+	}
+
+	// Act
+	scheduled, err := f.deletionService.Schedule(user.ID, "password123")
+
+	// Assert - 猶予期間の後に削除が予定される
+	if err != nil {
+		t.Fatalf("Schedule should succeed: %v", err)
+	}
+	if !scheduled.DeletionScheduled() {
+		t.Fatalf("Deletion should be scheduled")
+	}
+	if until := time.Until(*scheduled.DeletionScheduledAt); until < 13*24*time.Hour {
+		t.Errorf("Deletion should wait for the grace period, scheduled in %v", until)
+	}
+	deleteAt := *scheduled.DeletionScheduledAt
+
+	// Act & Assert - もう一度頼んでも日時は変わらない
+	again, _ := f.deletionService.Schedule(user.ID, "password123")
+	if !again.DeletionScheduledAt.Equal(deleteAt) {
+		t.Errorf("Scheduling again should keep %v, got %v", deleteAt, again.DeletionScheduledAt)
+	}
+
+	// Act & Assert - 通知メールが届く
+	if err := f.deletionService.SendNotice(scheduled); err != nil {
+		t.Fatalf("SendNotice should succeed: %v", err)
+	}
+	if len(f.mailer.messages) != 1 || f.mailer.messages[0].To != "test@example.com" {
+		t.Errorf("Expected a notice to the user, got %+v", f.mailer.messages)
+	}
+}
+
+func TestAccountDeletionService_CancelAndDue(t *testing.T) {
+	// Arrange - 期限を過ぎたユーザーとまだのユーザー
+	f := newAccountFixture()
+	due, _ := f.userService.CreateUser("due@example.com", "password123")
+	due.ScheduleDeletion(time.Now().Add(-time.Hour))
+	f.userRepo.Update(due)
+	waiting, _ := f.userService.CreateUser("waiting@example.com", "password123")
+	f.deletionService.Schedule(waiting.ID, "password123")
+
+	// Act
+	users, err := f.deletionService.Due(10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Due should succeed: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != due.ID {
+		t.Fatalf("Expected only due@example.com to be due, got %v", users)
+	}
+
+	// Act & Assert - 取り消すと対象から外れる
+	cancelled, err := f.deletionService.Cancel(due.ID)
+	if err != nil || cancelled.DeletionScheduled() {
+		t.Fatalf("Cancel should lift the deletion: %v", err)
+	}
+	if users, _ := f.deletionService.Due(10); len(users) != 0 {
+		t.Errorf("Expected nothing to be due after cancelling, got %v", users)
+	}
+}
+
+func TestAccountDeletionService_Delete(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+
+	// Act
+	deleted, err := f.deletionService.Delete(user.ID)
+
+	// Assert - アカウントが消える（関連データの削除はリポジトリのテストで確認）
+	if err != nil {
+		t.Fatalf("Delete should succeed: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no workspace to be deleted, got %v", deleted)
+	}
+	if _, err := f.userRepo.GetByID(user.ID); err != repository.ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestAccountDeletionService_Workspaces(t *testing.T) {
+	// Arrange - 1人だけのワークスペースと、ほかのメンバーがいるワークスペースの owner
+	f := newWorkspaceFixture()
+	deletionService := service.NewAccountDeletionService(f.userRepo, f.workspaceRepo, f.mailer, time.Hour)
+	user := f.verifiedUser(t, "test@example.com")
+	member := f.verifiedUser(t, "member@example.com")
+	solo, _ := f.workspaceService.Create(user.ID, "Solo")
+	shared, _ := f.workspaceService.Create(user.ID, "Shared")
+	f.join(t, user, shared.Workspace.ID, member, entity.WorkspaceRoleMember)
+
+	// Act & Assert - 最後の owner は削除を予約できない
+	if _, err := deletionService.Schedule(user.ID, "password123"); err != service.ErrLastWorkspaceOwner {
+		t.Fatalf("Expected ErrLastWorkspaceOwner, got %v", err)
+	}
+
+	// Act & Assert - ほかのメンバーを owner にすれば予約できる
+	f.workspaceService.SetMemberRole(user.ID, shared.Workspace.ID, member.ID, entity.WorkspaceRoleOwner)
+	if _, err := deletionService.Schedule(user.ID, "password123"); err != nil {
+		t.Fatalf("Schedule should succeed once another member owns the workspace: %v", err)
+	}
+
+	// Act - 猶予期間中に owner でなくなった場合も削除は進む
+	f.workspaceService.SetMemberRole(user.ID, shared.Workspace.ID, member.ID, entity.WorkspaceRoleMember)
+	deleted, err := deletionService.Delete(user.ID)
+
+	// Assert - 1人だけのワークスペースは削除され、残るワークスペースは最初に参加したメンバーが引き継ぐ
+	if err != nil {
+		t.Fatalf("Delete should succeed: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != solo.Workspace.ID {
+		t.Errorf("Expected only the solo workspace to be deleted, got %v", deleted)
+	}
+	if _, err := f.workspaceRepo.GetByID(solo.Workspace.ID); err != repository.ErrWorkspaceNotFound {
+		t.Errorf("Expected the solo workspace to be gone, got %v", err)
+	}
+	if role, _ := f.workspaceService.Role(shared.Workspace.ID, member.ID); role != entity.WorkspaceRoleOwner {
+		t.Errorf("Expected the remaining member to own the workspace, got %q", role)
+	}
+}
@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// accountFixture wires the services that act on whole accounts to in-memory
+// repositories; the admin and account deletion tests share it
+type accountFixture struct {
+	userRepo        *SimpleMockUserRepository
+	mailer          *RecordingMailer
+	userService     *service.UserService
+	tokenService    *service.TokenService
+	sessionService  *service.SessionService
+	adminService    *service.AdminService
+	deletionService *service.AccountDeletionService
+}
+
+func newAccountFixture() *accountFixture {
+	f := &accountFixture{
+		userRepo: NewSimpleMockUserRepository(),
+		mailer:   &RecordingMailer{},
+	}
+	sessionRepo := NewSimpleMockSessionRepository()
+	refreshRepo := NewSimpleMockRefreshTokenRepository()
+	f.userService = service.NewUserService(f.userRepo)
+	f.tokenService = service.NewTokenService(f.userRepo, sessionRepo, refreshRepo, fakeIssuer{})
+	f.sessionService = service.NewSessionService(sessionRepo, refreshRepo)
+	resetService := service.NewPasswordResetService(f.userRepo, NewSimpleMockPasswordResetTokenRepository(), f.sessionService, f.mailer, "https://todo.example.com/reset", service.DefaultPasswordPolicy())
+	f.adminService = service.NewAdminService(f.userRepo, f.sessionService, resetService)
+	f.deletionService = service.NewAccountDeletionService(f.userRepo, NewSimpleMockWorkspaceRepository(), f.mailer, 14*24*time.Hour)
+	return f
+}
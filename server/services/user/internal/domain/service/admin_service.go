@@ -0,0 +1,218 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const (
+	// DefaultUserPageSize is used when a page token is passed without a page size
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 200
+)
+
+var (
+	ErrAccountDisabled      = errors.New("account disabled")
+	ErrInvalidRole          = errors.New("role must be user or admin")
+	ErrInvalidUserPageSize  = errors.New("page size must be between 0 and 200")
+	ErrInvalidUserPageToken = errors.New("invalid page token")
+	ErrChangeOwnAccount     = errors.New("administrators can't disable or change the role of their own account")
+	ErrLastAdmin            = errors.New("the last active administrator can't be disabled or demoted")
+)
+
+// UserPage is one page of the admin user listing
+type UserPage struct {
+	Users []*entity.User
+	// NextPageToken is empty on the last page
+	NextPageToken string
+	// Total is the number of matching users across all pages
+	Total int
+}
+
+// AdminService holds the operations administrators perform on other
+// people's accounts. Callers are expected to have checked the caller's role.
+type AdminService struct {
+	userRepo             repository.UserRepository
+	sessionService       *SessionService
+	passwordResetService *PasswordResetService
+	now                  func() time.Time
+}
+
+func NewAdminService(userRepo repository.UserRepository, sessionService *SessionService, passwordResetService *PasswordResetService) *AdminService {
+	return &AdminService{
+		userRepo:             userRepo,
+		sessionService:       sessionService,
+		passwordResetService: passwordResetService,
+		now:                  time.Now,
+	}
+}
+
+// ListUsers lists one page of the users whose email contains search,
+// ordered by email. pageToken is the NextPageToken of the previous page, or
+// empty for the first page.
+func (s *AdminService) ListUsers(search string, pageSize int, pageToken string) (*UserPage, error) {
+	if pageSize < 0 || pageSize > MaxUserPageSize {
+		return nil, ErrInvalidUserPageSize
+	}
+	if pageSize == 0 {
+		pageSize = DefaultUserPageSize
+	}
+
+	query := repository.UserQuery{Search: search, Limit: pageSize + 1}
+	if pageToken != "" {
+		after, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil || len(after) == 0 {
+			return nil, ErrInvalidUserPageToken
+		}
+		query.AfterEmail = string(after)
+	}
+
+	result, err := s.userRepo.List(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1件多く読んで次のページがあるかを判定する
+	page := &UserPage{Users: result.Users, Total: result.Total}
+	if len(page.Users) > pageSize {
+		page.Users = page.Users[:pageSize]
+		last := page.Users[pageSize-1]
+		page.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(last.Email))
+	}
+	return page, nil
+}
+
+// SetDisabled disables or re-enables an account on behalf of the admin
+// actorID. Disabling signs the user out of every session; their password
+// and personal access tokens are refused until the account is enabled again.
+func (s *AdminService) SetDisabled(actorID, userID string, disabled bool) (*entity.User, error) {
+	if actorID == userID {
+		return nil, ErrChangeOwnAccount
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled() == disabled {
+		return user, nil
+	}
+	if disabled && user.IsAdmin() {
+		if err := s.checkOtherAdmins(); err != nil {
+			return nil, err
+		}
+	}
+
+	if disabled {
+		user.Disable(s.now())
+	} else {
+		user.Enable()
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if disabled {
+		if _, err := s.sessionService.RevokeAllSessions(user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// SetRole changes a user's role on behalf of the admin actorID, which is
+// empty when the change doesn't come from an admin. Access tokens carry the
+// role, so taking the admin role away also signs the user out; a new role
+// otherwise takes effect with the next token refresh.
+func (s *AdminService) SetRole(actorID, userID, role string) (*entity.User, error) {
+	if !entity.IsRole(role) {
+		return nil, ErrInvalidRole
+	}
+	if actorID == userID {
+		return nil, ErrChangeOwnAccount
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	demoted := user.IsAdmin()
+	if demoted && !user.Disabled() {
+		if err := s.checkOtherAdmins(); err != nil {
+			return nil, err
+		}
+	}
+	user.SetRole(role)
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if demoted {
+		if _, err := s.sessionService.RevokeAllSessions(user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// checkOtherAdmins fails with ErrLastAdmin unless an active admin would be
+// left after one of them is disabled or demoted
+func (s *AdminService) checkOtherAdmins() error {
+	count, err := s.userRepo.CountActiveAdmins()
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// ForcePasswordReset makes the current password stop working, signs the
+// user out everywhere and mails them a password reset link
+func (s *AdminService) ForcePasswordReset(userID string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.ScramblePassword(); err != nil {
+		return err
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	if _, err := s.sessionService.RevokeAllSessions(user.ID, ""); err != nil {
+		return err
+	}
+
+	return s.passwordResetService.RequestPasswordReset(user.Email)
+}
+
+// PromoteAdmins gives the admin role to the accounts with the given emails
+// and returns the emails that have no account yet
+func (s *AdminService) PromoteAdmins(emails []string) ([]string, error) {
+	var missing []string
+	for _, email := range emails {
+		user, err := s.userRepo.GetByEmail(email)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			missing = append(missing, email)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.SetRole("", user.ID, entity.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+func TestAdminService_ListUsers(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	for _, email := range []string{"carol@example.com", "alice@example.com", "bob@example.org"} {
+		f.userService.CreateUser(email, "password123")
+	}
+
+	// Act - 1ページ2件で取得
+	page, err := f.adminService.ListUsers("", 2, "")
+
+	// Assert - メールアドレス順で、続きのトークンが返る
+	if err != nil {
+		t.Fatalf("ListUsers should succeed: %v", err)
+	}
+	if page.Total != 3 || len(page.Users) != 2 || page.Users[0].Email != "alice@example.com" || page.NextPageToken == "" {
+		t.Fatalf("Unexpected first page: %+v", page)
+	}
+	next, err := f.adminService.ListUsers("", 2, page.NextPageToken)
+	if err != nil {
+		t.Fatalf("ListUsers should accept the page token: %v", err)
+	}
+	if len(next.Users) != 1 || next.Users[0].Email != "carol@example.com" || next.NextPageToken != "" {
+		t.Errorf("Unexpected last page: %+v", next)
+	}
+
+	// Act & Assert - 検索と不正な指定
+	if found, _ := f.adminService.ListUsers("EXAMPLE.ORG", 0, ""); found.Total != 1 || found.Users[0].Email != "bob@example.org" {
+		t.Errorf("Expected only bob to match, got %+v", found)
+	}
+	if _, err := f.adminService.ListUsers("", service.MaxUserPageSize+1, ""); err != service.ErrInvalidUserPageSize {
+		t.Errorf("Expected ErrInvalidUserPageSize, got %v", err)
+	}
+	if _, err := f.adminService.ListUsers("", 0, "not base64!"); err != service.ErrInvalidUserPageToken {
+		t.Errorf("Expected ErrInvalidUserPageToken, got %v", err)
+	}
+}
+
+func TestAdminService_SetDisabled(t *testing.T) {
+	// Arrange - ログイン中のユーザー
+	f := newAccountFixture()
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+	pair, _ := f.tokenService.IssueTokens(user, "laptop", "")
+
+	// Act
+	disabled, err := f.adminService.SetDisabled("admin-id", user.ID, true)
+
+	// Assert - 全セッションが失効し、トークンを発行できない
+	if err != nil {
+		t.Fatalf("SetDisabled should succeed: %v", err)
+	}
+	if !disabled.Disabled() {
+		t.Errorf("User should be disabled")
+	}
+	if sessions, _ := f.sessionService.ListSessions(user.ID); len(sessions) != 0 {
+		t.Errorf("All sessions should be revoked, %d remain", len(sessions))
+	}
+	if _, _, err := f.tokenService.Refresh(pair.RefreshToken, ""); err != service.ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken, got %v", err)
+	}
+	if _, err := f.tokenService.IssueTokens(disabled, "laptop", ""); err != service.ErrAccountDisabled {
+		t.Errorf("Expected ErrAccountDisabled, got %v", err)
+	}
+
+	// Act & Assert - 有効に戻すとログインできる
+	enabled, err := f.adminService.SetDisabled("admin-id", user.ID, false)
+	if err != nil || enabled.Disabled() {
+		t.Fatalf("User should be enabled again: %v", err)
+	}
+	if _, err := f.tokenService.IssueTokens(enabled, "laptop", ""); err != nil {
+		t.Errorf("IssueTokens should succeed after enabling: %v", err)
+	}
+}
+
+func TestAdminService_SetRole(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	admin, _ := f.userService.CreateUser("admin@example.com", "password123")
+	f.adminService.PromoteAdmins([]string{"admin@example.com"})
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+
+	// Act & Assert - 不明な役割は拒否する
+	if _, err := f.adminService.SetRole(admin.ID, user.ID, "owner"); err != service.ErrInvalidRole {
+		t.Errorf("Expected ErrInvalidRole, got %v", err)
+	}
+
+	// Act & Assert - 昇格してもセッションは残る
+	f.tokenService.IssueTokens(user, "laptop", "")
+	promoted, err := f.adminService.SetRole(admin.ID, user.ID, entity.RoleAdmin)
+	if err != nil || !promoted.IsAdmin() {
+		t.Fatalf("User should be promoted: %v", err)
+	}
+	if sessions, _ := f.sessionService.ListSessions(user.ID); len(sessions) != 1 {
+		t.Errorf("Promotion should keep the session, got %d", len(sessions))
+	}
+
+	// Act & Assert - 降格すると管理者のトークンが使えなくなるようサインアウトさせる
+	if _, err := f.adminService.SetRole(admin.ID, user.ID, entity.RoleUser); err != nil {
+		t.Fatalf("SetRole should succeed: %v", err)
+	}
+	if sessions, _ := f.sessionService.ListSessions(user.ID); len(sessions) != 0 {
+		t.Errorf("Demotion should revoke the sessions, %d remain", len(sessions))
+	}
+}
+
+func TestAdminService_OwnAccount(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	f.userService.CreateUser("admin@example.com", "password123")
+	f.userService.CreateUser("other@example.com", "password123")
+	f.adminService.PromoteAdmins([]string{"admin@example.com", "other@example.com"})
+	admin, _ := f.userRepo.GetByEmail("admin@example.com")
+
+	// Act & Assert - ほかに管理者がいても自分のアカウントは変えられない
+	if _, err := f.adminService.SetDisabled(admin.ID, admin.ID, true); err != service.ErrChangeOwnAccount {
+		t.Errorf("Expected ErrChangeOwnAccount, got %v", err)
+	}
+	if _, err := f.adminService.SetRole(admin.ID, admin.ID, entity.RoleUser); err != service.ErrChangeOwnAccount {
+		t.Errorf("Expected ErrChangeOwnAccount, got %v", err)
+	}
+	if user, _ := f.userRepo.GetByID(admin.ID); !user.IsAdmin() || user.Disabled() {
+		t.Errorf("Own account should be left unchanged: %+v", user)
+	}
+}
+
+func TestAdminService_LastAdmin(t *testing.T) {
+	// Arrange - 管理者が2人
+	f := newAccountFixture()
+	f.userService.CreateUser("first@example.com", "password123")
+	f.userService.CreateUser("second@example.com", "password123")
+	f.adminService.PromoteAdmins([]string{"first@example.com", "second@example.com"})
+	first, _ := f.userRepo.GetByEmail("first@example.com")
+	second, _ := f.userRepo.GetByEmail("second@example.com")
+
+	// Act - 1人目を無効化すると、有効な管理者は2人目だけになる
+	if _, err := f.adminService.SetDisabled(second.ID, first.ID, true); err != nil {
+		t.Fatalf("SetDisabled should succeed while another admin is active: %v", err)
+	}
+
+	// Assert - 最後の有効な管理者は無効化も降格もできない
+	if _, err := f.adminService.SetDisabled(first.ID, second.ID, true); err != service.ErrLastAdmin {
+		t.Errorf("Expected ErrLastAdmin, got %v", err)
+	}
+	if _, err := f.adminService.SetRole(first.ID, second.ID, entity.RoleUser); err != service.ErrLastAdmin {
+		t.Errorf("Expected ErrLastAdmin, got %v", err)
+	}
+	if user, _ := f.userRepo.GetByID(second.ID); !user.IsAdmin() || user.Disabled() {
+		t.Errorf("The last admin should be left unchanged: %+v", user)
+	}
+
+	// Act & Assert - 無効化された管理者の降格は数に影響しない
+	if _, err := f.adminService.SetRole(second.ID, first.ID, entity.RoleUser); err != nil {
+		t.Errorf("Demoting a disabled admin should succeed: %v", err)
+	}
+}
+
+func TestAdminService_ForcePasswordReset(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+	f.tokenService.IssueTokens(user, "laptop", "")
+
+	// Act
+	err := f.adminService.ForcePasswordReset(user.ID)
+
+	// Assert - 元のパスワードは使えず、再設定メールが届く
+	if err != nil {
+		t.Fatalf("ForcePasswordReset should succeed: %v", err)
+	}
+	if _, err := f.userService.AuthenticateUser("test@example.com", "password123"); err == nil {
+		t.Errorf("Old password should stop working")
+	}
+	if sessions, _ := f.sessionService.ListSessions(user.ID); len(sessions) != 0 {
+		t.Errorf("All sessions should be revoked, %d remain", len(sessions))
+	}
+	if len(f.mailer.messages) != 1 || f.mailer.messages[0].To != "test@example.com" {
+		t.Errorf("Expected a reset mail to the user, got %+v", f.mailer.messages)
+	}
+}
+
+func TestAdminService_PromoteAdmins(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	f.userService.CreateUser("admin@example.com", "password123")
+
+	// Act
+	missing, err := f.adminService.PromoteAdmins([]string{"admin@example.com", "later@example.com"})
+
+	// Assert - 未登録のアドレスは返して、登録済みのアカウントだけ昇格する
+	if err != nil {
+		t.Fatalf("PromoteAdmins should succeed: %v", err)
+	}
+	if len(missing) != 1 || missing[0] != "later@example.com" {
+		t.Errorf("Expected later@example.com to be missing, got %v", missing)
+	}
+	if admin, _ := f.userRepo.GetByEmail("admin@example.com"); !admin.IsAdmin() {
+		t.Errorf("admin@example.com should be an admin")
+	}
+}
+
+func TestPersonalAccessTokenService_DisabledUser(t *testing.T) {
+	// Arrange
+	f := newAccountFixture()
+	tokenService := service.NewPersonalAccessTokenService(f.userRepo, NewSimpleMockPersonalAccessTokenRepository())
+	user, _ := f.userService.CreateUser("test@example.com", "password123")
+	_, secret, _ := tokenService.CreateToken(user.ID, "CI", []string{entity.ScopeTodosRead}, nil)
+
+	// Act
+	f.adminService.SetDisabled("admin-id", user.ID, true)
+
+	// Assert - 無効化されたアカウントのトークンは使えない
+	if _, _, err := tokenService.Authenticate(secret); !errors.Is(err, service.ErrInvalidPersonalAccessToken) {
+		t.Errorf("Expected ErrInvalidPersonalAccessToken, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+var (
+	ErrInvalidEmailVerificationToken = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified              = errors.New("email not verified")
+	ErrEmailInUse                    = errors.New("email already in use")
+)
+
+// EmailVerificationPolicy decides what accounts with an unverified email
+// address may do
+type EmailVerificationPolicy string
+
+const (
+	// EmailVerificationPolicyNone lets unverified accounts do everything
+	EmailVerificationPolicyNone EmailVerificationPolicy = "none"
+	// EmailVerificationPolicyLogin refuses to sign unverified accounts in
+	EmailVerificationPolicyLogin EmailVerificationPolicy = "login"
+	// EmailVerificationPolicyWrite lets unverified accounts sign in and read
+	// but not change data. The BFF enforces it using the email_verified
+	// claim of the access token.
+	EmailVerificationPolicyWrite EmailVerificationPolicy = "write"
+)
+
+// ParseEmailVerificationPolicy parses a policy name; empty means none
+func ParseEmailVerificationPolicy(name string) (EmailVerificationPolicy, error) {
+	switch policy := EmailVerificationPolicy(name); policy {
+	case "":
+		return EmailVerificationPolicyNone, nil
+	case EmailVerificationPolicyNone, EmailVerificationPolicyLogin, EmailVerificationPolicyWrite:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown email verification policy %q", name)
+}
+
+type EmailVerificationService struct {
+	userRepo   repository.UserRepository
+	verifyRepo repository.EmailVerificationTokenRepository
+	mailer     Mailer
+	// verifyURL is the client page the mailed link points to; the token is
+	// added as the token query parameter
+	verifyURL string
+	policy    EmailVerificationPolicy
+	now       func() time.Time
+}
+
+func NewEmailVerificationService(userRepo repository.UserRepository, verifyRepo repository.EmailVerificationTokenRepository, mailer Mailer, verifyURL string, policy EmailVerificationPolicy) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:   userRepo,
+		verifyRepo: verifyRepo,
+		mailer:     mailer,
+		verifyURL:  verifyURL,
+		policy:     policy,
+		now:        time.Now,
+	}
+}
+
+// Policy returns the configured policy
+func (s *EmailVerificationService) Policy() EmailVerificationPolicy {
+	return s.policy
+}
+
+// CheckLogin fails with ErrEmailNotVerified when the policy keeps the user
+// from signing in
+func (s *EmailVerificationService) CheckLogin(user *entity.User) error {
+	if s.policy == EmailVerificationPolicyLogin && !user.EmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// SendVerification mails a verification link for the user's pending
+// address, or for the current one while it is unverified. It does nothing
+// when there is nothing to verify.
+func (s *EmailVerificationService) SendVerification(user *entity.User) error {
+	email := user.PendingEmail
+	if email == "" {
+		if user.EmailVerified() {
+			return nil
+		}
+		email = user.Email
+	}
+
+	verification, secret, err := entity.NewEmailVerificationToken(uuid.New().String(), user.ID, email, EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyRepo.Create(verification); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(MailMessage{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Confirm that this address belongs to your account within %d hours:\n%s\n\n"+
+			"If you didn't ask for this, ignore this email.\n",
+			int(EmailVerificationTokenTTL.Hours()), s.verifyLink(secret)),
+	})
+}
+
+// ResendVerification sends a new link to the account of an address. Unknown
+// addresses succeed without sending anything, so the result doesn't reveal
+// whether an account exists.
+func (s *EmailVerificationService) ResendVerification(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.SendVerification(user)
+}
+
+// VerifyEmail marks the address of a mailed token as verified. A pending
+// address replaces the current one at this point.
+func (s *EmailVerificationService) VerifyEmail(token string) (*entity.User, error) {
+	verification, err := s.verifyRepo.GetByHash(entity.HashEmailVerificationToken(token))
+	if errors.Is(err, repository.ErrEmailVerificationTokenNotFound) {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if !verification.Usable(now) {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+
+	user, err := s.userRepo.GetByID(verification.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 変更が取り消されたか別のアドレスに変わった場合、古いリンクは使えない
+	switch verification.Email {
+	case user.Email:
+	case user.PendingEmail:
+		if existing, _ := s.userRepo.GetByEmail(verification.Email); existing != nil {
+			return nil, ErrEmailInUse
+		}
+	default:
+		return nil, ErrInvalidEmailVerificationToken
+	}
+
+	err = s.verifyRepo.MarkUsed(verification.ID, now)
+	if errors.Is(err, repository.ErrEmailVerificationTokenUsed) {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user.VerifyEmail(verification.Email, now)
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *EmailVerificationService) verifyLink(token string) string {
+	link, err := url.Parse(s.verifyURL)
+	if err != nil {
+		return s.verifyURL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockEmailVerificationTokenRepository は確認トークン用のシンプルなモック
+type SimpleMockEmailVerificationTokenRepository struct {
+	tokens map[string]*entity.EmailVerificationToken
+}
+
+func NewSimpleMockEmailVerificationTokenRepository() *SimpleMockEmailVerificationTokenRepository {
+	return &SimpleMockEmailVerificationTokenRepository{
+		tokens: make(map[string]*entity.EmailVerificationToken),
+	}
+}
+
+func (m *SimpleMockEmailVerificationTokenRepository) Create(token *entity.EmailVerificationToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *SimpleMockEmailVerificationTokenRepository) GetByHash(hash string) (*entity.EmailVerificationToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrEmailVerificationTokenNotFound
+}
+
+func (m *SimpleMockEmailVerificationTokenRepository) MarkUsed(id string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			if token.UsedAt != nil {
+				return repository.ErrEmailVerificationTokenUsed
+			}
+			token.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrEmailVerificationTokenNotFound
+}
+
+func TestEmailVerificationService_VerifyNewAccount(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	mailer := &RecordingMailer{}
+	verifyService := service.NewEmailVerificationService(userRepo, NewSimpleMockEmailVerificationTokenRepository(),
+		mailer, "https://todo.example.com/verify-email", service.EmailVerificationPolicyLogin)
+	userService := service.NewUserService(userRepo)
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Assert - ログインポリシーでは未確認のユーザーはログインできない
+	if err := verifyService.CheckLogin(user); err != service.ErrEmailNotVerified {
+		t.Errorf("Expected ErrEmailNotVerified, got %v", err)
+	}
+
+	// Act - 確認メールを送信する
+	if err := verifyService.SendVerification(user); err != nil {
+		t.Fatalf("SendVerification should succeed: %v", err)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "test@example.com" {
+		t.Fatalf("Expected one mail to test@example.com, got %+v", mailer.messages)
+	}
+	token := tokenFromLink(mailer.messages[0].Body)
+
+	// Act - トークンで確認する
+	verified, err := verifyService.VerifyEmail(token)
+	if err != nil {
+		t.Fatalf("VerifyEmail should succeed: %v", err)
+	}
+
+	// Assert
+	if !verified.EmailVerified() {
+		t.Errorf("User should be verified")
+	}
+	if err := verifyService.CheckLogin(verified); err != nil {
+		t.Errorf("Verified user should be able to log in: %v", err)
+	}
+	if _, err := verifyService.VerifyEmail(token); err != service.ErrInvalidEmailVerificationToken {
+		t.Errorf("Token should work only once, got %v", err)
+	}
+
+	// Assert - 確認済みなら再送しない
+	if err := verifyService.ResendVerification("test@example.com"); err != nil {
+		t.Fatalf("ResendVerification should succeed: %v", err)
+	}
+	if len(mailer.messages) != 1 {
+		t.Errorf("Verified address should not get another mail, got %d", len(mailer.messages))
+	}
+}
+
+func TestEmailVerificationService_EmailChange(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	mailer := &RecordingMailer{}
+	verifyService := service.NewEmailVerificationService(userRepo, NewSimpleMockEmailVerificationTokenRepository(),
+		mailer, "https://todo.example.com/verify-email", service.EmailVerificationPolicyNone)
+	userService := service.NewUserService(userRepo)
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	user.VerifyEmail(user.Email, time.Now())
+
+	// Act - アドレスを変更すると新しいアドレスに確認メールが届く
+	updated, err := userService.UpdateUser(user.ID, "new@example.com", "")
+	if err != nil {
+		t.Fatalf("UpdateUser should succeed: %v", err)
+	}
+	if err := verifyService.SendVerification(updated); err != nil {
+		t.Fatalf("SendVerification should succeed: %v", err)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "new@example.com" {
+		t.Fatalf("Expected one mail to new@example.com, got %+v", mailer.messages)
+	}
+
+	// Assert - 確認前は古いアドレスでログインする
+	if _, err := userService.AuthenticateUser("new@example.com", "password123"); err == nil {
+		t.Errorf("Pending address should not be usable before verification")
+	}
+
+	// Act
+	verified, err := verifyService.VerifyEmail(tokenFromLink(mailer.messages[0].Body))
+	if err != nil {
+		t.Fatalf("VerifyEmail should succeed: %v", err)
+	}
+
+	// Assert
+	if verified.Email != "new@example.com" || verified.PendingEmail != "" {
+		t.Errorf("Pending address should take effect, got %+v", verified)
+	}
+	if _, err := userService.AuthenticateUser("new@example.com", "password123"); err != nil {
+		t.Errorf("New address should be usable after verification: %v", err)
+	}
+}
+
+func TestEmailVerificationService_SupersededChange(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	mailer := &RecordingMailer{}
+	verifyService := service.NewEmailVerificationService(userRepo, NewSimpleMockEmailVerificationTokenRepository(),
+		mailer, "https://todo.example.com/verify-email", service.EmailVerificationPolicyNone)
+	userService := service.NewUserService(userRepo)
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	user.VerifyEmail(user.Email, time.Now())
+
+	first, _ := userService.UpdateUser(user.ID, "first@example.com", "")
+	verifyService.SendVerification(first)
+	userService.UpdateUser(user.ID, "second@example.com", "")
+
+	// Act - 取り消された変更のリンクは使えない
+	_, err := verifyService.VerifyEmail(tokenFromLink(mailer.messages[0].Body))
+
+	// Assert
+	if err != service.ErrInvalidEmailVerificationToken {
+		t.Errorf("Expected ErrInvalidEmailVerificationToken, got %v", err)
+	}
+}
+
+func TestParseEmailVerificationPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected service.EmailVerificationPolicy
+		wantErr  bool
+	}{
+		{"", service.EmailVerificationPolicyNone, false},
+		{"none", service.EmailVerificationPolicyNone, false},
+		{"login", service.EmailVerificationPolicyLogin, false},
+		{"write", service.EmailVerificationPolicyWrite, false},
+		{"always", "", true},
+	}
+
+	for _, tt := range tests {
+		policy, err := service.ParseEmailVerificationPolicy(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEmailVerificationPolicy(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if policy != tt.expected {
+			t.Errorf("ParseEmailVerificationPolicy(%q) = %q, want %q", tt.name, policy, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+var (
+	ErrAccountLocked   = errors.New("account temporarily locked after too many failed sign-ins")
+	ErrTooManyAttempts = errors.New("too many failed sign-ins, try again later")
+)
+
+// LoginBlockedError is returned when a sign-in is refused before the
+// password is checked. Err is ErrAccountLocked or ErrTooManyAttempts.
+type LoginBlockedError struct {
+	Err     error
+	RetryAt time.Time
+}
+
+func (e *LoginBlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// LoginThrottleConfig sets how failed sign-ins are limited. Every failure
+// counts against both the account and the source address.
+type LoginThrottleConfig struct {
+	// FreeAttempts failures are allowed without any wait
+	FreeAttempts int
+	// BaseDelay is the wait after the first failure beyond FreeAttempts; it
+	// doubles with every further failure up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// AccountLockoutThreshold failures lock the account for LockoutDuration
+	AccountLockoutThreshold int
+	// IPLockoutThreshold failures from one address lock that address out
+	// for LockoutDuration; it is higher because addresses can be shared
+	IPLockoutThreshold int
+	LockoutDuration    time.Duration
+	// FailureWindow is how long a failure is remembered when no other
+	// failure follows it
+	FailureWindow time.Duration
+}
+
+func DefaultLoginThrottleConfig() LoginThrottleConfig {
+	return LoginThrottleConfig{
+		FreeAttempts:            3,
+		BaseDelay:               time.Second,
+		MaxDelay:                5 * time.Minute,
+		AccountLockoutThreshold: 10,
+		IPLockoutThreshold:      50,
+		LockoutDuration:         15 * time.Minute,
+		FailureWindow:           time.Hour,
+	}
+}
+
+// LoginThrottle slows down password guessing with exponential backoff and
+// temporary lockouts
+type LoginThrottle struct {
+	attemptRepo repository.LoginAttemptRepository
+	config      LoginThrottleConfig
+	now         func() time.Time
+}
+
+func NewLoginThrottle(attemptRepo repository.LoginAttemptRepository, config LoginThrottleConfig) *LoginThrottle {
+	return &LoginThrottle{
+		attemptRepo: attemptRepo,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// Check fails with a *LoginBlockedError when a sign-in for the account from
+// the address must not be tried yet
+func (t *LoginThrottle) Check(email, ipAddress string) error {
+	now := t.now()
+
+	account, err := t.attempts(accountThrottleKey(email))
+	if err != nil {
+		return err
+	}
+	if account != nil && account.Locked(now) {
+		return &LoginBlockedError{Err: ErrAccountLocked, RetryAt: *account.LockedUntil}
+	}
+
+	retryAt := t.backoffUntil(account, now)
+	if ipAddress != "" {
+		address, err := t.attempts(ipThrottleKey(ipAddress))
+		if err != nil {
+			return err
+		}
+		if address != nil && address.Locked(now) {
+			return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAt: *address.LockedUntil}
+		}
+		if until := t.backoffUntil(address, now); until.After(retryAt) {
+			retryAt = until
+		}
+	}
+
+	if retryAt.After(now) {
+		return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAt: retryAt}
+	}
+	return nil
+}
+
+// RecordFailure counts a wrong password against the account and the address,
+// locking either once it reaches its threshold
+func (t *LoginThrottle) RecordFailure(email, ipAddress string) error {
+	now := t.now()
+	if err := t.recordFailure(accountThrottleKey(email), t.config.AccountLockoutThreshold, now); err != nil {
+		return err
+	}
+	if ipAddress == "" {
+		return nil
+	}
+	return t.recordFailure(ipThrottleKey(ipAddress), t.config.IPLockoutThreshold, now)
+}
+
+// RecordSuccess clears the failures of the account. Those of the address
+// only expire, so signing in to one account can't hide guessing at others.
+func (t *LoginThrottle) RecordSuccess(email string) error {
+	return t.attemptRepo.Delete(accountThrottleKey(email))
+}
+
+func (t *LoginThrottle) recordFailure(key string, threshold int, now time.Time) error {
+	attempts, err := t.attemptRepo.RecordFailure(key, now, now.Add(-t.config.FailureWindow))
+	if err != nil {
+		return err
+	}
+	if threshold > 0 && attempts.Failures >= threshold {
+		return t.attemptRepo.Lock(key, now.Add(t.config.LockoutDuration))
+	}
+	return nil
+}
+
+func (t *LoginThrottle) attempts(key string) (*entity.LoginAttempts, error) {
+	attempts, err := t.attemptRepo.Get(key)
+	if errors.Is(err, repository.ErrLoginAttemptsNotFound) {
+		return nil, nil
+	}
+	return attempts, err
+}
+
+// backoffUntil returns when the next attempt is allowed after the recorded
+// failures
+func (t *LoginThrottle) backoffUntil(attempts *entity.LoginAttempts, now time.Time) time.Time {
+	if attempts == nil || now.Sub(attempts.LastFailureAt) > t.config.FailureWindow {
+		return time.Time{}
+	}
+	extra := attempts.Failures - t.config.FreeAttempts
+	if extra <= 0 || t.config.BaseDelay <= 0 {
+		return time.Time{}
+	}
+
+	delay := t.config.BaseDelay
+	for i := 1; i < extra && delay < t.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if t.config.MaxDelay > 0 && delay > t.config.MaxDelay {
+		delay = t.config.MaxDelay
+	}
+	return attempts.LastFailureAt.Add(delay)
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockLoginAttemptRepository はログイン失敗回数用のシンプルなモック
+type SimpleMockLoginAttemptRepository struct {
+	attempts map[string]*entity.LoginAttempts
+}
+
+func NewSimpleMockLoginAttemptRepository() *SimpleMockLoginAttemptRepository {
+	return &SimpleMockLoginAttemptRepository{
+		attempts: make(map[string]*entity.LoginAttempts),
+	}
+}
+
+func (m *SimpleMockLoginAttemptRepository) Get(key string) (*entity.LoginAttempts, error) {
+	if attempts, exists := m.attempts[key]; exists {
+		copied := *attempts
+		return &copied, nil
+	}
+	return nil, repository.ErrLoginAttemptsNotFound
+}
+
+func (m *SimpleMockLoginAttemptRepository) RecordFailure(key string, at, resetBefore time.Time) (*entity.LoginAttempts, error) {
+	attempts, exists := m.attempts[key]
+	if !exists {
+		attempts = &entity.LoginAttempts{Key: key}
+		m.attempts[key] = attempts
+	}
+	if attempts.LastFailureAt.Before(resetBefore) {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = at
+	return m.Get(key)
+}
+
+func (m *SimpleMockLoginAttemptRepository) Lock(key string, until time.Time) error {
+	if attempts, exists := m.attempts[key]; exists {
+		attempts.LockedUntil = &until
+	}
+	return nil
+}
+
+func (m *SimpleMockLoginAttemptRepository) Delete(key string) error {
+	delete(m.attempts, key)
+	return nil
+}
+
+func newLoginThrottle() (*service.LoginThrottle, *SimpleMockLoginAttemptRepository) {
+	repo := NewSimpleMockLoginAttemptRepository()
+	config := service.LoginThrottleConfig{
+		FreeAttempts:            2,
+		BaseDelay:               time.Minute,
+		MaxDelay:                4 * time.Minute,
+		AccountLockoutThreshold: 5,
+		IPLockoutThreshold:      8,
+		LockoutDuration:         15 * time.Minute,
+		FailureWindow:           time.Hour,
+	}
+	return service.NewLoginThrottle(repo, config), repo
+}
+
+// rewind は最後の失敗を過去にずらし、待ち時間が経過した状態にする
+func (m *SimpleMockLoginAttemptRepository) rewind(key string, d time.Duration) {
+	if attempts, exists := m.attempts[key]; exists {
+		attempts.LastFailureAt = attempts.LastFailureAt.Add(-d)
+	}
+}
+
+func TestLoginThrottle_Backoff(t *testing.T) {
+	throttle, repo := newLoginThrottle()
+
+	// 無料の試行回数までは待たずに試せる
+	for i := 0; i < 2; i++ {
+		if err := throttle.Check("test@example.com", "192.0.2.1"); err != nil {
+			t.Fatalf("Attempt %d: expected no error, got %v", i+1, err)
+		}
+		throttle.RecordFailure("test@example.com", "192.0.2.1")
+	}
+	if err := throttle.Check("test@example.com", "192.0.2.1"); err != nil {
+		t.Fatalf("Expected the free attempts not to delay, got %v", err)
+	}
+
+	// それを超えると待ち時間が発生する
+	throttle.RecordFailure("test@example.com", "192.0.2.1")
+	err := throttle.Check("TEST@example.com", "192.0.2.1")
+	var blocked *service.LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, service.ErrTooManyAttempts) {
+		t.Fatalf("Expected ErrTooManyAttempts, got %v", err)
+	}
+	if wait := time.Until(blocked.RetryAt); wait <= 0 || wait > time.Minute {
+		t.Errorf("Expected to wait up to a minute, got %v", wait)
+	}
+
+	// 待ち時間は失敗ごとに倍になる
+	repo.rewind("account:test@example.com", time.Minute)
+	repo.rewind("ip:192.0.2.1", time.Minute)
+	if err := throttle.Check("test@example.com", "192.0.2.1"); err != nil {
+		t.Fatalf("Expected the delay to have passed, got %v", err)
+	}
+	throttle.RecordFailure("test@example.com", "192.0.2.1")
+	err = throttle.Check("test@example.com", "192.0.2.1")
+	if !errors.As(err, &blocked) {
+		t.Fatalf("Expected a LoginBlockedError, got %v", err)
+	}
+	if wait := time.Until(blocked.RetryAt); wait <= time.Minute || wait > 2*time.Minute {
+		t.Errorf("Expected the delay to double, got %v", wait)
+	}
+
+	// 別のアカウント・別のアドレスには影響しない
+	if err := throttle.Check("other@example.com", "192.0.2.2"); err != nil {
+		t.Errorf("Expected other accounts to be unaffected, got %v", err)
+	}
+}
+
+func TestLoginThrottle_AccountLockout(t *testing.T) {
+	throttle, repo := newLoginThrottle()
+
+	// 毎回別のアドレスから失敗してもアカウント単位で数える
+	for i := 0; i < 5; i++ {
+		repo.rewind("account:test@example.com", time.Hour-time.Minute)
+		throttle.RecordFailure("test@example.com", fmt.Sprintf("192.0.2.%d", i+1))
+	}
+
+	err := throttle.Check("test@example.com", "198.51.100.1")
+	var blocked *service.LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, service.ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked, got %v", err)
+	}
+	if wait := time.Until(blocked.RetryAt); wait <= 14*time.Minute || wait > 15*time.Minute {
+		t.Errorf("Expected a 15 minute lockout, got %v", wait)
+	}
+}
+
+func TestLoginThrottle_IPLockout(t *testing.T) {
+	throttle, repo := newLoginThrottle()
+
+	// 1つのアドレスから多数のアカウントを試す
+	for i := 0; i < 8; i++ {
+		throttle.RecordFailure(fmt.Sprintf("user%d@example.com", i), "192.0.2.1")
+		repo.rewind("ip:192.0.2.1", 10*time.Minute)
+	}
+
+	err := throttle.Check("new@example.com", "192.0.2.1")
+	if !errors.Is(err, service.ErrTooManyAttempts) {
+		t.Fatalf("Expected ErrTooManyAttempts, got %v", err)
+	}
+	if err := throttle.Check("new@example.com", "192.0.2.2"); err != nil {
+		t.Errorf("Expected other addresses to be unaffected, got %v", err)
+	}
+}
+
+func TestLoginThrottle_RecordSuccess(t *testing.T) {
+	throttle, _ := newLoginThrottle()
+
+	for i := 0; i < 3; i++ {
+		throttle.RecordFailure("test@example.com", "192.0.2.1")
+	}
+	if err := throttle.RecordSuccess("Test@Example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// アカウントの失敗はリセットされるが、アドレスの失敗は残る
+	if err := throttle.Check("test@example.com", ""); err != nil {
+		t.Errorf("Expected the account to be reset, got %v", err)
+	}
+	if err := throttle.Check("test@example.com", "192.0.2.1"); !errors.Is(err, service.ErrTooManyAttempts) {
+		t.Errorf("Expected the address to stay delayed, got %v", err)
+	}
+}
+
+func TestLoginThrottle_FailureWindow(t *testing.T) {
+	throttle, repo := newLoginThrottle()
+
+	for i := 0; i < 4; i++ {
+		throttle.RecordFailure("test@example.com", "")
+	}
+
+	// 期間を過ぎた失敗は数えない
+	repo.rewind("account:test@example.com", 2*time.Hour)
+	if err := throttle.Check("test@example.com", ""); err != nil {
+		t.Fatalf("Expected old failures to be ignored, got %v", err)
+	}
+	throttle.RecordFailure("test@example.com", "")
+	attempts, _ := repo.Get("account:test@example.com")
+	if attempts.Failures != 1 {
+		t.Errorf("Expected the count to start over, got %d", attempts.Failures)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Password violation codes, stable for clients to map to their own messages
+const (
+	PasswordTooShort         = "too_short"
+	PasswordTooLong          = "too_long"
+	PasswordCharacterClasses = "character_classes"
+	PasswordContainsEmail    = "contains_email"
+	PasswordBreached         = "breached"
+)
+
+const (
+	maxBcryptPasswordBytes    = 72
+	breachedHashPrefixLength  = 5
+	minEmailLocalPartToForbid = 3
+)
+
+// PasswordViolation is one rule of the policy a password breaks
+type PasswordViolation struct {
+	Code    string
+	Message string
+}
+
+// PasswordPolicyError lists every rule a rejected password breaks
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return "password does not meet the policy: " + strings.Join(messages, "; ")
+}
+
+// BreachedPasswords looks up passwords known from data breaches by the first
+// five hex characters of their SHA-1 hash, like the Pwned Passwords range
+// API, so the full hash never has to leave the service
+type BreachedPasswords interface {
+	// Range returns the remaining upper-case hex characters of every breached
+	// hash that starts with prefix
+	Range(prefix string) ([]string, error)
+}
+
+// PasswordPolicy decides which new passwords are accepted
+type PasswordPolicy struct {
+	MinLength int
+	// MaxLength is capped by bcrypt, which ignores bytes past the 72nd
+	MaxLength int
+	// MinCharacterClasses of lower case, upper case, digits and symbols
+	MinCharacterClasses int
+	// ForbidEmail rejects passwords containing the address or its local part
+	ForbidEmail bool
+	// Breached is optional; without it no breach check is made
+	Breached BreachedPasswords
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:           8,
+		MaxLength:           maxBcryptPasswordBytes,
+		MinCharacterClasses: 1,
+		ForbidEmail:         true,
+	}
+}
+
+// Validate returns a *PasswordPolicyError when the password breaks the
+// policy for an account known by the given email addresses
+func (p PasswordPolicy) Validate(password string, emails ...string) error {
+	var violations []PasswordViolation
+
+	if length := utf8.RuneCountInString(password); length < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooShort,
+			Message: fmt.Sprintf("must be at least %d characters", p.MinLength),
+		})
+	}
+	maxLength := p.MaxLength
+	if maxLength <= 0 || maxLength > maxBcryptPasswordBytes {
+		maxLength = maxBcryptPasswordBytes
+	}
+	if len(password) > maxLength {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooLong,
+			Message: fmt.Sprintf("must be at most %d bytes", maxLength),
+		})
+	}
+	if classes := characterClasses(password); classes < p.MinCharacterClasses {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordCharacterClasses,
+			Message: fmt.Sprintf("must mix at least %d of lower case letters, upper case letters, digits and symbols", p.MinCharacterClasses),
+		})
+	}
+	if p.ForbidEmail {
+		for _, email := range emails {
+			if containsEmail(password, email) {
+				violations = append(violations, PasswordViolation{
+					Code:    PasswordContainsEmail,
+					Message: "must not contain your email address",
+				})
+				break
+			}
+		}
+	}
+
+	if p.Breached != nil && password != "" {
+		breached, err := p.breached(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			violations = append(violations, PasswordViolation{
+				Code:    PasswordBreached,
+				Message: "appears in a list of breached passwords; choose another one",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (p PasswordPolicy) breached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := p.Breached.Range(hash[:breachedHashPrefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[breachedHashPrefixLength:]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+func containsEmail(password, email string) bool {
+	password = strings.ToLower(password)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+	if strings.Contains(password, email) {
+		return true
+	}
+
+	local, _, found := strings.Cut(email, "@")
+	return found && len(local) >= minEmailLocalPartToForbid && strings.Contains(password, local)
+}
@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// fakeBreachedPasswords は1件だけ漏洩済みとして扱う
+type fakeBreachedPasswords struct {
+	prefixes []string
+}
+
+func (f *fakeBreachedPasswords) Range(prefix string) ([]string, error) {
+	f.prefixes = append(f.prefixes, prefix)
+	// "password" の SHA-1 は 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	if prefix == "5BAA6" {
+		return []string{"0000000000000000000000000000000000A", "1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, nil
+	}
+	return nil, nil
+}
+
+func violationCodes(err error) []string {
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	codes := make([]string, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		codes[i] = violation.Code
+	}
+	return codes
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := service.PasswordPolicy{
+		MinLength:           10,
+		MaxLength:           64,
+		MinCharacterClasses: 3,
+		ForbidEmail:         true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		emails   []string
+		want     []string
+	}{
+		{"valid", "Correct-horse-7", []string{"test@example.com"}, nil},
+		{"too short", "Ab1!", nil, []string{service.PasswordTooShort}},
+		{"too long", "Aa1" + strings.Repeat("x", 62), nil, []string{service.PasswordTooLong}},
+		{"one class", "correcthorsebattery", nil, []string{service.PasswordCharacterClasses}},
+		{"contains email", "X1-test@example.com", []string{"test@example.com"}, []string{service.PasswordContainsEmail}},
+		{"contains local part", "My-Alice-2024", []string{"alice@example.com"}, []string{service.PasswordContainsEmail}},
+		{"contains new email", "My-Alice-2024", []string{"test@example.com", "alice@example.com"}, []string{service.PasswordContainsEmail}},
+		// 短いローカル部は偶然含まれやすいので対象外
+		{"short local part", "Jo-horse-2024", []string{"jo@example.com"}, nil},
+		{"every rule", "test", []string{"test@example.com"}, []string{service.PasswordTooShort, service.PasswordCharacterClasses, service.PasswordContainsEmail}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.emails...)
+			got := violationCodes(err)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected violations %v, got %v (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Breached(t *testing.T) {
+	// Arrange
+	breached := &fakeBreachedPasswords{}
+	policy := service.DefaultPasswordPolicy()
+	policy.Breached = breached
+
+	// Act
+	err := policy.Validate("password", "test@example.com")
+
+	// Assert - ハッシュの先頭5文字だけで問い合わせる
+	if codes := violationCodes(err); len(codes) != 1 || codes[0] != service.PasswordBreached {
+		t.Errorf("Expected the breached violation, got %v", err)
+	}
+	if len(breached.prefixes) != 1 || breached.prefixes[0] != "5BAA6" {
+		t.Errorf("Expected a lookup by hash prefix, got %v", breached.prefixes)
+	}
+	if err := policy.Validate("password-not-breached", "test@example.com"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestUserService_PasswordPolicy(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(userRepo)
+
+	// Act & Assert - 登録時に検証される
+	if _, err := userService.CreateUser("test@example.com", "short"); violationCodes(err) == nil {
+		t.Errorf("Expected a PasswordPolicyError, got %v", err)
+	}
+	user, err := userService.CreateUser("test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("CreateUser should succeed: %v", err)
+	}
+
+	// Act & Assert - 変更時は現在と新しいアドレスの両方と比べる
+	_, err = userService.UpdateUser(user.ID, "alice@example.com", "alice-password")
+	if codes := violationCodes(err); len(codes) != 1 || codes[0] != service.PasswordContainsEmail {
+		t.Errorf("Expected the contains_email violation, got %v", err)
+	}
+	if _, err := userService.UpdateUser(user.ID, "", "test-password"); violationCodes(err) == nil {
+		t.Errorf("Expected a PasswordPolicyError, got %v", err)
+	}
+	if _, err := userService.UpdateUser(user.ID, "", "another-password"); err != nil {
+		t.Errorf("UpdateUser should succeed: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const PasswordResetTokenTTL = time.Hour
+
+var (
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrPasswordRequired          = errors.New("password is required")
+)
+
+type PasswordResetService struct {
+	userRepo       repository.UserRepository
+	resetRepo      repository.PasswordResetTokenRepository
+	sessionService *SessionService
+	mailer         Mailer
+	// resetURL is the client page the mailed link points to; the token is
+	// added as the token query parameter
+	resetURL       string
+	passwordPolicy PasswordPolicy
+	now            func() time.Time
+}
+
+func NewPasswordResetService(userRepo repository.UserRepository, resetRepo repository.PasswordResetTokenRepository, sessionService *SessionService, mailer Mailer, resetURL string, passwordPolicy PasswordPolicy) *PasswordResetService {
+	return &PasswordResetService{
+		userRepo:       userRepo,
+		resetRepo:      resetRepo,
+		sessionService: sessionService,
+		mailer:         mailer,
+		resetURL:       resetURL,
+		passwordPolicy: passwordPolicy,
+		now:            time.Now,
+	}
+}
+
+// RequestPasswordReset mails a reset link to the address. Unknown addresses
+// succeed without sending anything, so the result doesn't reveal whether an
+// account exists.
+func (s *PasswordResetService) RequestPasswordReset(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	reset, secret, err := entity.NewPasswordResetToken(uuid.New().String(), user.ID, PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.Create(reset); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone asked to reset the password of your account.\n\n"+
+			"Choose a new password here within %d minutes:\n%s\n\n"+
+			"If it wasn't you, ignore this email; your password stays the same.\n",
+			int(PasswordResetTokenTTL.Minutes()), s.resetLink(secret)),
+	})
+}
+
+// ConfirmPasswordReset sets a new password using a mailed token and signs
+// the user out of every session
+func (s *PasswordResetService) ConfirmPasswordReset(token, newPassword string) error {
+	if newPassword == "" {
+		return ErrPasswordRequired
+	}
+
+	reset, err := s.resetRepo.GetByHash(entity.HashPasswordResetToken(token))
+	if errors.Is(err, repository.ErrPasswordResetTokenNotFound) {
+		return ErrInvalidPasswordResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	if !reset.Usable(now) {
+		return ErrInvalidPasswordResetToken
+	}
+
+	user, err := s.userRepo.GetByID(reset.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrInvalidPasswordResetToken
+	}
+	if err != nil {
+		return err
+	}
+	// 弱いパスワードで拒否してもトークンは使えるまま残す
+	if err := s.passwordPolicy.Validate(newPassword, user.Email); err != nil {
+		return err
+	}
+	if err := user.UpdatePassword(newPassword); err != nil {
+		return err
+	}
+
+	// 同じトークンでの同時リセットを防ぐため、更新前に使用済みにする
+	err = s.resetRepo.MarkUsed(reset.ID, now)
+	if errors.Is(err, repository.ErrPasswordResetTokenUsed) {
+		return ErrInvalidPasswordResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	_, err = s.sessionService.RevokeAllSessions(user.ID, "")
+	return err
+}
+
+func (s *PasswordResetService) resetLink(token string) string {
+	link, err := url.Parse(s.resetURL)
+	if err != nil {
+		return s.resetURL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
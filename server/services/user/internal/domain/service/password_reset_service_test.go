@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockPasswordResetTokenRepository はリセットトークン用のシンプルなモック
+type SimpleMockPasswordResetTokenRepository struct {
+	tokens map[string]*entity.PasswordResetToken
+}
+
+func NewSimpleMockPasswordResetTokenRepository() *SimpleMockPasswordResetTokenRepository {
+	return &SimpleMockPasswordResetTokenRepository{
+		tokens: make(map[string]*entity.PasswordResetToken),
+	}
+}
+
+func (m *SimpleMockPasswordResetTokenRepository) Create(token *entity.PasswordResetToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *SimpleMockPasswordResetTokenRepository) GetByHash(hash string) (*entity.PasswordResetToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrPasswordResetTokenNotFound
+}
+
+func (m *SimpleMockPasswordResetTokenRepository) MarkUsed(id string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			if token.UsedAt != nil {
+				return repository.ErrPasswordResetTokenUsed
+			}
+			token.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrPasswordResetTokenNotFound
+}
+
+// RecordingMailer は送信されたメールを記録する
+type RecordingMailer struct {
+	messages []service.MailMessage
+}
+
+func (m *RecordingMailer) Send(msg service.MailMessage) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// tokenFromLink はメール本文のリンクからトークンを取り出す
+func tokenFromLink(body string) string {
+	i := strings.Index(body, "token=")
+	if i < 0 {
+		return ""
+	}
+	return strings.Fields(body[i+len("token="):])[0]
+}
+
+func TestPasswordResetService_Flow(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	sessionRepo := NewSimpleMockSessionRepository()
+	refreshRepo := NewSimpleMockRefreshTokenRepository()
+	resetRepo := NewSimpleMockPasswordResetTokenRepository()
+	mailer := &RecordingMailer{}
+	sessionService := service.NewSessionService(sessionRepo, refreshRepo)
+	resetService := service.NewPasswordResetService(userRepo, resetRepo, sessionService, mailer, "https://todo.example.com/reset?lang=ja", service.DefaultPasswordPolicy())
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, fakeIssuer{})
+	userService := service.NewUserService(userRepo)
+
+	user, _ := userService.CreateUser("test@example.com", "old-password")
+	tokenService.IssueTokens(user, "laptop", "")
+
+	// Act
+	if err := resetService.RequestPasswordReset("test@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset should succeed: %v", err)
+	}
+
+	// Assert - リンクは既存のクエリを保持し、トークンは平文で保存されない
+	if len(mailer.messages) != 1 {
+		t.Fatalf("Expected 1 mail, got %d", len(mailer.messages))
+	}
+	body := mailer.messages[0].Body
+	if !strings.Contains(body, "https://todo.example.com/reset?lang=ja&token=") {
+		t.Errorf("Mail should link to the reset page:\n%s", body)
+	}
+	token := tokenFromLink(body)
+	if _, exists := resetRepo.tokens[token]; exists {
+		t.Errorf("Reset token should not be stored in plain text")
+	}
+
+	// 空のパスワードではトークンを消費しない
+	if err := resetService.ConfirmPasswordReset(token, ""); err != service.ErrPasswordRequired {
+		t.Errorf("Expected ErrPasswordRequired, got %v", err)
+	}
+	// ポリシーに合わないパスワードでもトークンを消費しない
+	var policyErr *service.PasswordPolicyError
+	if err := resetService.ConfirmPasswordReset(token, "short"); !errors.As(err, &policyErr) {
+		t.Errorf("Expected a PasswordPolicyError, got %v", err)
+	}
+
+	// Act & Assert - パスワード変更と全セッションの失効
+	if err := resetService.ConfirmPasswordReset(token, "new-password"); err != nil {
+		t.Fatalf("ConfirmPasswordReset should succeed: %v", err)
+	}
+	if _, err := userService.AuthenticateUser("test@example.com", "new-password"); err != nil {
+		t.Errorf("New password should work: %v", err)
+	}
+	if sessions, _ := sessionService.ListSessions(user.ID); len(sessions) != 0 {
+		t.Errorf("All sessions should be revoked, %d remain", len(sessions))
+	}
+	if err := resetService.ConfirmPasswordReset(token, "other-password"); err != service.ErrInvalidPasswordResetToken {
+		t.Errorf("Expected ErrInvalidPasswordResetToken on reuse, got %v", err)
+	}
+}
+
+func TestPasswordResetService_UnknownEmail(t *testing.T) {
+	// Arrange
+	mailer := &RecordingMailer{}
+	resetRepo := NewSimpleMockPasswordResetTokenRepository()
+	sessionService := service.NewSessionService(NewSimpleMockSessionRepository(), NewSimpleMockRefreshTokenRepository())
+	resetService := service.NewPasswordResetService(NewSimpleMockUserRepository(), resetRepo, sessionService, mailer, "https://todo.example.com/reset", service.DefaultPasswordPolicy())
+
+	// Act
+	err := resetService.RequestPasswordReset("nobody@example.com")
+
+	// Assert - アカウントの有無を明かさない
+	if err != nil {
+		t.Errorf("Unknown addresses should not fail: %v", err)
+	}
+	if len(mailer.messages) != 0 || len(resetRepo.tokens) != 0 {
+		t.Errorf("Nothing should be sent or stored for unknown addresses")
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// personalAccessTokenTouchInterval limits how often the last use of a token
+// is written, since scripts may call the API many times a second
+const personalAccessTokenTouchInterval = time.Minute
+
+var (
+	ErrInvalidPersonalAccessToken      = errors.New("invalid personal access token")
+	ErrPersonalAccessTokenNameRequired = errors.New("token name is required")
+	ErrPersonalAccessTokenScopes       = errors.New("at least one scope is required")
+	ErrPersonalAccessTokenExpiry       = errors.New("expiry must be in the future")
+)
+
+type PersonalAccessTokenService struct {
+	userRepo  repository.UserRepository
+	tokenRepo repository.PersonalAccessTokenRepository
+	now       func() time.Time
+}
+
+func NewPersonalAccessTokenService(userRepo repository.UserRepository, tokenRepo repository.PersonalAccessTokenRepository) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		now:       time.Now,
+	}
+}
+
+// CreateToken issues a token for the user and returns it together with the
+// secret, which can't be retrieved later. A nil expiresAt never expires.
+func (s *PersonalAccessTokenService) CreateToken(userID, name string, scopes []string, expiresAt *time.Time) (*entity.PersonalAccessToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrPersonalAccessTokenNameRequired
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, "", ErrPersonalAccessTokenExpiry
+	}
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, "", err
+	}
+
+	token, secret, err := entity.NewPersonalAccessToken(uuid.New().String(), userID, name, scopes, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
+// ListTokens lists a user's tokens that have not been revoked, including
+// expired ones so that they can be cleaned up
+func (s *PersonalAccessTokenService) ListTokens(userID string) ([]*entity.PersonalAccessToken, error) {
+	return s.tokenRepo.ListByUserID(userID)
+}
+
+func (s *PersonalAccessTokenService) RevokeToken(userID, id string) error {
+	return s.tokenRepo.Revoke(userID, id, s.now())
+}
+
+// Authenticate returns the owner of a token and the token itself, recording
+// the use
+func (s *PersonalAccessTokenService) Authenticate(secret string) (*entity.User, *entity.PersonalAccessToken, error) {
+	if !entity.IsPersonalAccessToken(secret) {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	token, err := s.tokenRepo.GetByHash(entity.HashPersonalAccessToken(secret))
+	if errors.Is(err, repository.ErrPersonalAccessTokenNotFound) {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	if !token.Usable(now) {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Disabled() {
+		return nil, nil, ErrInvalidPersonalAccessToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= personalAccessTokenTouchInterval {
+		if err := s.tokenRepo.Touch(token.ID, now); err != nil {
+			return nil, nil, err
+		}
+		token.LastUsedAt = &now
+	}
+	return user, token, nil
+}
+
+// normalizeScopes checks the scopes and returns them sorted without
+// duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !entity.IsPersonalAccessTokenScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q; valid scopes are %s", scope,
+				strings.Join(entity.PersonalAccessTokenScopes, ", "))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrPersonalAccessTokenScopes
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
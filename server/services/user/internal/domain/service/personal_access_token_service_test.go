@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockPersonalAccessTokenRepository はアクセストークン用のシンプルなモック
+type SimpleMockPersonalAccessTokenRepository struct {
+	tokens  map[string]*entity.PersonalAccessToken
+	touches int
+}
+
+func NewSimpleMockPersonalAccessTokenRepository() *SimpleMockPersonalAccessTokenRepository {
+	return &SimpleMockPersonalAccessTokenRepository{
+		tokens: make(map[string]*entity.PersonalAccessToken),
+	}
+}
+
+func (m *SimpleMockPersonalAccessTokenRepository) Create(token *entity.PersonalAccessToken) error {
+	copied := *token
+	m.tokens[token.ID] = &copied
+	return nil
+}
+
+func (m *SimpleMockPersonalAccessTokenRepository) GetByHash(hash string) (*entity.PersonalAccessToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == hash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrPersonalAccessTokenNotFound
+}
+
+func (m *SimpleMockPersonalAccessTokenRepository) ListByUserID(userID string) ([]*entity.PersonalAccessToken, error) {
+	var tokens []*entity.PersonalAccessToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *SimpleMockPersonalAccessTokenRepository) Revoke(userID, id string, at time.Time) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UserID != userID {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	if token.RevokedAt == nil {
+		token.RevokedAt = &at
+	}
+	return nil
+}
+
+func (m *SimpleMockPersonalAccessTokenRepository) Touch(id string, at time.Time) error {
+	token, exists := m.tokens[id]
+	if !exists {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	token.LastUsedAt = &at
+	m.touches++
+	return nil
+}
+
+func TestPersonalAccessTokenService_Flow(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	tokenRepo := NewSimpleMockPersonalAccessTokenRepository()
+	tokenService := service.NewPersonalAccessTokenService(userRepo, tokenRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+
+	// Act - 重複したスコープは1つにまとめて並べ替える
+	token, secret, err := tokenService.CreateToken(user.ID, " CI ", []string{"todos:write", "todos:read", "todos:write"}, nil)
+
+	// Assert - 接頭辞で見分けられ、平文は保存されない
+	if err != nil {
+		t.Fatalf("CreateToken should succeed: %v", err)
+	}
+	if !strings.HasPrefix(secret, entity.PersonalAccessTokenPrefix) || !strings.HasPrefix(secret, token.Hint) {
+		t.Errorf("Unexpected secret %q for hint %q", secret, token.Hint)
+	}
+	if token.Name != "CI" || strings.Join(token.Scopes, " ") != "todos:read todos:write" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	if token.TokenHash == secret {
+		t.Errorf("Token should not be stored in plain text")
+	}
+
+	// Act & Assert - 認証すると最終使用日時が記録される
+	authUser, authToken, err := tokenService.Authenticate(secret)
+	if err != nil {
+		t.Fatalf("Authenticate should succeed: %v", err)
+	}
+	if authUser.ID != user.ID || authToken.ID != token.ID || authToken.LastUsedAt == nil {
+		t.Errorf("Unexpected authentication result: %+v %+v", authUser, authToken)
+	}
+	// 続けて使っても毎回は書き込まない
+	tokenService.Authenticate(secret)
+	if tokenRepo.touches != 1 {
+		t.Errorf("Expected 1 touch, got %d", tokenRepo.touches)
+	}
+
+	// Act & Assert - 失効後は使えない
+	if err := tokenService.RevokeToken("other-user", token.ID); !errors.Is(err, repository.ErrPersonalAccessTokenNotFound) {
+		t.Errorf("Expected ErrPersonalAccessTokenNotFound, got %v", err)
+	}
+	if err := tokenService.RevokeToken(user.ID, token.ID); err != nil {
+		t.Fatalf("RevokeToken should succeed: %v", err)
+	}
+	if _, _, err := tokenService.Authenticate(secret); err != service.ErrInvalidPersonalAccessToken {
+		t.Errorf("Expected ErrInvalidPersonalAccessToken, got %v", err)
+	}
+	if tokens, _ := tokenService.ListTokens(user.ID); len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %d", len(tokens))
+	}
+}
+
+func TestPersonalAccessTokenService_Validation(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	tokenRepo := NewSimpleMockPersonalAccessTokenRepository()
+	tokenService := service.NewPersonalAccessTokenService(userRepo, tokenRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+	past := time.Now().Add(-time.Hour)
+
+	// Act & Assert
+	if _, _, err := tokenService.CreateToken(user.ID, "", []string{"todos:read"}, nil); err != service.ErrPersonalAccessTokenNameRequired {
+		t.Errorf("Expected ErrPersonalAccessTokenNameRequired, got %v", err)
+	}
+	if _, _, err := tokenService.CreateToken(user.ID, "CI", nil, nil); err != service.ErrPersonalAccessTokenScopes {
+		t.Errorf("Expected ErrPersonalAccessTokenScopes, got %v", err)
+	}
+	if _, _, err := tokenService.CreateToken(user.ID, "CI", []string{"admin"}, nil); err == nil || !strings.Contains(err.Error(), "admin") {
+		t.Errorf("Expected an unknown scope error, got %v", err)
+	}
+	if _, _, err := tokenService.CreateToken(user.ID, "CI", []string{"todos:read"}, &past); err != service.ErrPersonalAccessTokenExpiry {
+		t.Errorf("Expected ErrPersonalAccessTokenExpiry, got %v", err)
+	}
+
+	// 期限切れのトークンや形式の違うトークンは認証できない
+	expired, secret, _ := entity.NewPersonalAccessToken("expired", user.ID, "old", []string{"todos:read"}, &past)
+	tokenRepo.Create(expired)
+	for _, token := range []string{secret, "not-a-token", entity.PersonalAccessTokenPrefix + "unknown"} {
+		if _, _, err := tokenService.Authenticate(token); err != service.ErrInvalidPersonalAccessToken {
+			t.Errorf("Expected ErrInvalidPersonalAccessToken for %q, got %v", token, err)
+		}
+	}
+}
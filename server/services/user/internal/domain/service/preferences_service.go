@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// preferencesSortFields are the todo service's sort fields
+var preferencesSortFields = []string{"priority", "due_at", "created_at", "updated_at", "title"}
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+var ErrInvalidTimezone = errors.New("timezone must be an IANA time zone name such as Asia/Tokyo")
+
+// PreferencesUpdate holds the fields to change; nil fields are kept
+type PreferencesUpdate struct {
+	Timezone         *string
+	Locale           *string
+	WeekStart        *string
+	DateFormat       *string
+	DefaultSort      *string
+	DefaultSortOrder *string
+	DefaultProjectID *string
+}
+
+type PreferencesService struct {
+	userRepo  repository.UserRepository
+	prefsRepo repository.PreferencesRepository
+	now       func() time.Time
+}
+
+func NewPreferencesService(userRepo repository.UserRepository, prefsRepo repository.PreferencesRepository) *PreferencesService {
+	return &PreferencesService{
+		userRepo:  userRepo,
+		prefsRepo: prefsRepo,
+		now:       time.Now,
+	}
+}
+
+// GetPreferences returns the user's preferences, or the defaults when they
+// never saved any
+func (s *PreferencesService) GetPreferences(userID string) (*entity.Preferences, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.prefsRepo.Get(userID)
+	if errors.Is(err, repository.ErrPreferencesNotFound) {
+		return entity.DefaultPreferences(userID), nil
+	}
+	return prefs, err
+}
+
+// UpdatePreferences applies the update and saves the result. Clearing the
+// default sort also clears its order.
+func (s *PreferencesService) UpdatePreferences(userID string, update PreferencesUpdate) (*entity.Preferences, error) {
+	prefs, err := s.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct {
+		value *string
+		dest  *string
+	}{
+		{update.Timezone, &prefs.Timezone},
+		{update.Locale, &prefs.Locale},
+		{update.WeekStart, &prefs.WeekStart},
+		{update.DateFormat, &prefs.DateFormat},
+		{update.DefaultSort, &prefs.DefaultSort},
+		{update.DefaultSortOrder, &prefs.DefaultSortOrder},
+		{update.DefaultProjectID, &prefs.DefaultProjectID},
+	} {
+		if field.value != nil {
+			*field.dest = strings.TrimSpace(*field.value)
+		}
+	}
+	if prefs.DefaultSort == "" {
+		prefs.DefaultSortOrder = ""
+	}
+	if err := validatePreferences(prefs); err != nil {
+		return nil, err
+	}
+
+	prefs.UpdatedAt = s.now()
+	if err := s.prefsRepo.Save(prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func validatePreferences(prefs *entity.Preferences) error {
+	// LoadLocation also accepts "" and "Local", which mean the server's zone
+	if prefs.Timezone == "" || prefs.Timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(prefs.Timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	if !localePattern.MatchString(prefs.Locale) {
+		return fmt.Errorf("locale must be a language tag such as ja-JP, got %q", prefs.Locale)
+	}
+	switch prefs.WeekStart {
+	case entity.WeekStartSunday, entity.WeekStartMonday, entity.WeekStartSaturday:
+	default:
+		return fmt.Errorf("week_start must be sunday, monday or saturday, got %q", prefs.WeekStart)
+	}
+	if !contains(entity.DateFormats, prefs.DateFormat) {
+		return fmt.Errorf("date_format must be one of %s, got %q", strings.Join(entity.DateFormats, ", "), prefs.DateFormat)
+	}
+	if prefs.DefaultSort != "" && !contains(preferencesSortFields, prefs.DefaultSort) {
+		return fmt.Errorf("default_sort must be one of %s, got %q", strings.Join(preferencesSortFields, ", "), prefs.DefaultSort)
+	}
+	switch prefs.DefaultSortOrder {
+	case "", "asc", "desc":
+	default:
+		return fmt.Errorf("default_sort_order must be asc or desc, got %q", prefs.DefaultSortOrder)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
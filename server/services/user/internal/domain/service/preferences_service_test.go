@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockPreferencesRepository は設定用のシンプルなモック
+type SimpleMockPreferencesRepository struct {
+	prefs map[string]*entity.Preferences
+}
+
+func NewSimpleMockPreferencesRepository() *SimpleMockPreferencesRepository {
+	return &SimpleMockPreferencesRepository{
+		prefs: make(map[string]*entity.Preferences),
+	}
+}
+
+func (m *SimpleMockPreferencesRepository) Get(userID string) (*entity.Preferences, error) {
+	if prefs, exists := m.prefs[userID]; exists {
+		copied := *prefs
+		return &copied, nil
+	}
+	return nil, repository.ErrPreferencesNotFound
+}
+
+func (m *SimpleMockPreferencesRepository) Save(prefs *entity.Preferences) error {
+	copied := *prefs
+	m.prefs[prefs.UserID] = &copied
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestPreferencesService_GetPreferences(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(userRepo)
+	prefsService := service.NewPreferencesService(userRepo, NewSimpleMockPreferencesRepository())
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act
+	prefs, err := prefsService.GetPreferences(user.ID)
+
+	// Assert - 保存していなければ既定値を返す
+	if err != nil {
+		t.Fatalf("GetPreferences should succeed: %v", err)
+	}
+	if prefs.Timezone != "UTC" || prefs.Locale != "en" || prefs.WeekStart != entity.WeekStartMonday || prefs.DateFormat != "YYYY-MM-DD" {
+		t.Errorf("Expected the defaults, got %+v", prefs)
+	}
+
+	// Act & Assert - 存在しないユーザー
+	if _, err := prefsService.GetPreferences("missing"); err != repository.ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestPreferencesService_UpdatePreferences(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(userRepo)
+	prefsService := service.NewPreferencesService(userRepo, NewSimpleMockPreferencesRepository())
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act
+	prefs, err := prefsService.UpdatePreferences(user.ID, service.PreferencesUpdate{
+		Timezone:         stringPtr("Asia/Tokyo"),
+		Locale:           stringPtr("ja-JP"),
+		DefaultSort:      stringPtr("due_at"),
+		DefaultSortOrder: stringPtr("asc"),
+	})
+
+	// Assert - 指定したフィールドだけが変わる
+	if err != nil {
+		t.Fatalf("UpdatePreferences should succeed: %v", err)
+	}
+	if prefs.Timezone != "Asia/Tokyo" || prefs.Locale != "ja-JP" || prefs.DefaultSort != "due_at" || prefs.DefaultSortOrder != "asc" {
+		t.Errorf("Unexpected preferences: %+v", prefs)
+	}
+	if prefs.WeekStart != entity.WeekStartMonday || prefs.UpdatedAt.IsZero() {
+		t.Errorf("Unchanged fields should keep their values: %+v", prefs)
+	}
+
+	// Act & Assert - 保存した内容が次回も返る
+	stored, _ := prefsService.GetPreferences(user.ID)
+	if stored.Timezone != "Asia/Tokyo" {
+		t.Errorf("Expected the saved time zone, got %q", stored.Timezone)
+	}
+
+	// Act & Assert - 既定の並び順を消すと向きも消える
+	cleared, err := prefsService.UpdatePreferences(user.ID, service.PreferencesUpdate{DefaultSort: stringPtr("")})
+	if err != nil {
+		t.Fatalf("Clearing the sort should succeed: %v", err)
+	}
+	if cleared.DefaultSort != "" || cleared.DefaultSortOrder != "" {
+		t.Errorf("Expected the sort to be cleared, got %q %q", cleared.DefaultSort, cleared.DefaultSortOrder)
+	}
+}
+
+func TestPreferencesService_UpdatePreferences_Invalid(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(userRepo)
+	prefsService := service.NewPreferencesService(userRepo, NewSimpleMockPreferencesRepository())
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	tests := []struct {
+		name   string
+		update service.PreferencesUpdate
+	}{
+		{"unknown time zone", service.PreferencesUpdate{Timezone: stringPtr("Mars/Olympus")}},
+		{"server local time zone", service.PreferencesUpdate{Timezone: stringPtr("Local")}},
+		{"empty time zone", service.PreferencesUpdate{Timezone: stringPtr("")}},
+		{"malformed locale", service.PreferencesUpdate{Locale: stringPtr("japanese!")}},
+		{"unknown week start", service.PreferencesUpdate{WeekStart: stringPtr("friday")}},
+		{"unknown date format", service.PreferencesUpdate{DateFormat: stringPtr("YY-M-D")}},
+		{"unknown sort field", service.PreferencesUpdate{DefaultSort: stringPtr("color")}},
+		{"unknown sort order", service.PreferencesUpdate{DefaultSort: stringPtr("title"), DefaultSortOrder: stringPtr("up")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act & Assert - 不正な値は保存しない
+			if _, err := prefsService.UpdatePreferences(user.ID, tt.update); err == nil {
+				t.Errorf("Expected an error")
+			}
+			stored, _ := prefsService.GetPreferences(user.ID)
+			if stored.Timezone != "UTC" || !stored.UpdatedAt.IsZero() {
+				t.Errorf("Invalid update should not be saved: %+v", stored)
+			}
+		})
+	}
+}
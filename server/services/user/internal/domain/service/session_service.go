@@ -0,0 +1,94 @@
+package service
+
+import (
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+type SessionService struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+	now         func() time.Time
+}
+
+func NewSessionService(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository) *SessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+		now:         time.Now,
+	}
+}
+
+// ListSessions lists a user's active sessions, most recently seen first
+func (s *SessionService) ListSessions(userID string) ([]*entity.Session, error) {
+	return s.sessionRepo.ListActiveByUserID(userID)
+}
+
+// RevokeSession signs a session out. Revoking a session that has already
+// been revoked succeeds.
+func (s *SessionService) RevokeSession(id, userID string) error {
+	session, err := s.sessionRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return repository.ErrSessionNotFound
+	}
+	if !session.Active() {
+		return nil
+	}
+
+	return revokeSession(s.sessionRepo, s.refreshRepo, session.ID, s.now())
+}
+
+// RevokeAllSessions signs a user out everywhere except exceptID, which may be
+// empty, and returns the number of sessions revoked
+func (s *SessionService) RevokeAllSessions(userID, exceptID string) (int, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == exceptID {
+			continue
+		}
+		if err := revokeSession(s.sessionRepo, s.refreshRepo, session.ID, now); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// CheckSession reports whether access tokens of a session are still
+// accepted, recording the request as activity on the session
+func (s *SessionService) CheckSession(id, ipAddress string) (bool, error) {
+	session, err := s.sessionRepo.GetByID(id)
+	if err == repository.ErrSessionNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !session.Active() {
+		return false, nil
+	}
+
+	if err := s.sessionRepo.Touch(session.ID, ipAddress, s.now()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// revokeSession revokes a session together with its refresh tokens
+func revokeSession(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository, id string, at time.Time) error {
+	if err := sessionRepo.Revoke(id, at); err != nil {
+		return err
+	}
+	return refreshRepo.RevokeFamily(id, at)
+}
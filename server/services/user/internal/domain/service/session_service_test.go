@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+func TestSessionService_Revoke(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	sessionRepo := NewSimpleMockSessionRepository()
+	refreshRepo := NewSimpleMockRefreshTokenRepository()
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, fakeIssuer{})
+	sessionService := service.NewSessionService(sessionRepo, refreshRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+
+	laptop, _ := tokenService.IssueTokens(user, "laptop", "")
+	phone, _ := tokenService.IssueTokens(user, "phone", "")
+	sessionID := func(pair *service.TokenPair) string {
+		return refreshRepo.tokens[entity.HashRefreshToken(pair.RefreshToken)].FamilyID
+	}
+
+	// Act & Assert - 他ユーザーのセッションは見つからない扱い
+	if err := sessionService.RevokeSession(sessionID(laptop), "user-456"); err != repository.ErrSessionNotFound {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+
+	// Act & Assert - 失効するとチェックとリフレッシュが失敗する
+	if err := sessionService.RevokeSession(sessionID(laptop), user.ID); err != nil {
+		t.Fatalf("RevokeSession should succeed: %v", err)
+	}
+	if active, _ := sessionService.CheckSession(sessionID(laptop), ""); active {
+		t.Errorf("Revoked session should not be active")
+	}
+	if _, _, err := tokenService.Refresh(laptop.RefreshToken, ""); err != service.ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken for revoked session, got %v", err)
+	}
+	// 二重の失効は成功する
+	if err := sessionService.RevokeSession(sessionID(laptop), user.ID); err != nil {
+		t.Errorf("Revoking twice should succeed: %v", err)
+	}
+
+	// 他のセッションは有効なまま
+	if active, _ := sessionService.CheckSession(sessionID(phone), "198.51.100.2"); !active {
+		t.Errorf("Other sessions should stay active")
+	}
+	if sessionRepo.sessions[sessionID(phone)].IPAddress != "198.51.100.2" {
+		t.Errorf("CheckSession should record the address")
+	}
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	sessionRepo := NewSimpleMockSessionRepository()
+	refreshRepo := NewSimpleMockRefreshTokenRepository()
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, fakeIssuer{})
+	sessionService := service.NewSessionService(sessionRepo, refreshRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+
+	current, _ := tokenService.IssueTokens(user, "laptop", "")
+	tokenService.IssueTokens(user, "phone", "")
+	tokenService.IssueTokens(user, "tablet", "")
+	currentID := refreshRepo.tokens[entity.HashRefreshToken(current.RefreshToken)].FamilyID
+
+	// Act
+	revoked, err := sessionService.RevokeAllSessions(user.ID, currentID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RevokeAllSessions should succeed: %v", err)
+	}
+	if revoked != 2 {
+		t.Errorf("Expected 2 revoked sessions, got %d", revoked)
+	}
+	sessions, _ := sessionService.ListSessions(user.ID)
+	if len(sessions) != 1 || sessions[0].ID != currentID {
+		t.Errorf("Only the current session should remain, got %d sessions", len(sessions))
+	}
+
+	// 除外なしなら全セッションを失効
+	if revoked, _ := sessionService.RevokeAllSessions(user.ID, ""); revoked != 1 {
+		t.Errorf("Expected the last session to be revoked, got %d", revoked)
+	}
+}
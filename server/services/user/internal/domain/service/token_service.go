@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// AccessTokenIssuer signs the short-lived access tokens checked by the BFF.
+// sessionID is used as the token's jti.
+type AccessTokenIssuer interface {
+	Issue(user *entity.User, sessionID string, issuedAt, expiresAt time.Time) (string, error)
+}
+
+// TokenPair is the set of credentials handed to a client after sign-in or refresh
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type TokenService struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+	issuer      AccessTokenIssuer
+	now         func() time.Time
+}
+
+func NewTokenService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository, issuer AccessTokenIssuer) *TokenService {
+	return &TokenService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+		issuer:      issuer,
+		now:         time.Now,
+	}
+}
+
+// IssueTokens starts a session for a new sign-in and issues its tokens
+func (s *TokenService) IssueTokens(user *entity.User, device, ipAddress string) (*TokenPair, error) {
+	if user.Disabled() {
+		return nil, ErrAccountDisabled
+	}
+
+	session := entity.NewSession(uuid.New().String(), user.ID, device, ipAddress)
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	refresh, secret, err := entity.NewRefreshToken(uuid.New().String(), user.ID, session.ID, RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshRepo.Create(refresh); err != nil {
+		return nil, err
+	}
+
+	return s.tokenPair(user, refresh, secret)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token; the presented token stops working. Presenting a token that was
+// already exchanged means it has leaked, so its session is revoked and the
+// user has to sign in again.
+func (s *TokenService) Refresh(refreshToken, ipAddress string) (*entity.User, *TokenPair, error) {
+	current, err := s.refreshRepo.GetByHash(entity.HashRefreshToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(current.FamilyID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	if current.RevokedAt != nil && session.Active() {
+		if err := revokeSession(s.sessionRepo, s.refreshRepo, session.ID, now); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if !session.Active() || !current.Active(now) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(current.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Disabled() {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	replacement, secret, err := entity.NewRefreshToken(uuid.New().String(), user.ID, current.FamilyID, RefreshTokenTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.refreshRepo.Rotate(current, replacement, now)
+	if errors.Is(err, repository.ErrRefreshTokenRevoked) {
+		// 同じトークンで同時にリフレッシュされた
+		if err := revokeSession(s.sessionRepo, s.refreshRepo, session.ID, now); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.sessionRepo.Touch(session.ID, ipAddress, now); err != nil {
+		return nil, nil, err
+	}
+
+	pair, err := s.tokenPair(user, replacement, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, pair, nil
+}
+
+func (s *TokenService) tokenPair(user *entity.User, refresh *entity.RefreshToken, secret string) (*TokenPair, error) {
+	issuedAt := s.now()
+	expiresAt := issuedAt.Add(AccessTokenTTL)
+	accessToken, err := s.issuer.Issue(user, refresh.FamilyID, issuedAt, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          secret,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
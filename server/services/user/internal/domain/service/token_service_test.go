@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockRefreshTokenRepository はリフレッシュトークン用のシンプルなモック
+type SimpleMockRefreshTokenRepository struct {
+	tokens map[string]*entity.RefreshToken
+}
+
+func NewSimpleMockRefreshTokenRepository() *SimpleMockRefreshTokenRepository {
+	return &SimpleMockRefreshTokenRepository{
+		tokens: make(map[string]*entity.RefreshToken),
+	}
+}
+
+func (m *SimpleMockRefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *SimpleMockRefreshTokenRepository) GetByHash(hash string) (*entity.RefreshToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrRefreshTokenNotFound
+}
+
+func (m *SimpleMockRefreshTokenRepository) Rotate(old, replacement *entity.RefreshToken, at time.Time) error {
+	if old.RevokedAt != nil {
+		return repository.ErrRefreshTokenRevoked
+	}
+	old.RevokedAt = &at
+	m.tokens[replacement.TokenHash] = replacement
+	return nil
+}
+
+func (m *SimpleMockRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &at
+		}
+	}
+	return nil
+}
+
+// SimpleMockSessionRepository はセッション用のシンプルなモック
+type SimpleMockSessionRepository struct {
+	sessions map[string]*entity.Session
+}
+
+func NewSimpleMockSessionRepository() *SimpleMockSessionRepository {
+	return &SimpleMockSessionRepository{
+		sessions: make(map[string]*entity.Session),
+	}
+}
+
+func (m *SimpleMockSessionRepository) Create(session *entity.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *SimpleMockSessionRepository) GetByID(id string) (*entity.Session, error) {
+	if session, exists := m.sessions[id]; exists {
+		return session, nil
+	}
+	return nil, repository.ErrSessionNotFound
+}
+
+func (m *SimpleMockSessionRepository) ListActiveByUserID(userID string) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.Active() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *SimpleMockSessionRepository) Touch(id, ipAddress string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	session.LastSeenAt = at
+	if ipAddress != "" {
+		session.IPAddress = ipAddress
+	}
+	return nil
+}
+
+func (m *SimpleMockSessionRepository) Revoke(id string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	if session.RevokedAt == nil {
+		session.RevokedAt = &at
+	}
+	return nil
+}
+
+// fakeIssuer はユーザーID・セッションID・有効期限からトークンを作る
+type fakeIssuer struct{}
+
+func (fakeIssuer) Issue(user *entity.User, sessionID string, issuedAt, expiresAt time.Time) (string, error) {
+	return user.ID + "/" + sessionID + "@" + expiresAt.Format(time.RFC3339Nano), nil
+}
+
+func TestTokenService_IssueTokens(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	sessionRepo := NewSimpleMockSessionRepository()
+	refreshRepo := NewSimpleMockRefreshTokenRepository()
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, fakeIssuer{})
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+
+	// Act
+	pair, err := tokenService.IssueTokens(user, "Mozilla/5.0", "203.0.113.7")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("IssueTokens should succeed: %v", err)
+	}
+	if len(sessionRepo.sessions) != 1 {
+		t.Fatalf("Expected 1 session, got %d", len(sessionRepo.sessions))
+	}
+	for _, session := range sessionRepo.sessions {
+		if session.UserID != user.ID || session.Device != "Mozilla/5.0" || session.IPAddress != "203.0.113.7" {
+			t.Errorf("Unexpected session: %+v", session)
+		}
+		if pair.AccessToken != user.ID+"/"+session.ID+"@"+pair.AccessTokenExpiresAt.Format(time.RFC3339Nano) {
+			t.Errorf("Access token should be issued for the session, got %s", pair.AccessToken)
+		}
+	}
+	if ttl := time.Until(pair.AccessTokenExpiresAt); ttl <= 0 || ttl > service.AccessTokenTTL {
+		t.Errorf("Access token should expire within %s, got %s", service.AccessTokenTTL, ttl)
+	}
+	if !pair.RefreshTokenExpiresAt.After(pair.AccessTokenExpiresAt) {
+		t.Errorf("Refresh token should outlive the access token")
+	}
+
+	// 平文のトークンは保存しない
+	if _, exists := refreshRepo.tokens[pair.RefreshToken]; exists {
+		t.Errorf("Refresh token should not be stored in plain text")
+	}
+	stored, exists := refreshRepo.tokens[entity.HashRefreshToken(pair.RefreshToken)]
+	if !exists {
+		t.Fatalf("Refresh token hash should be stored")
+	}
+	if _, exists := sessionRepo.sessions[stored.FamilyID]; !exists {
+		t.Errorf("Refresh token family should be the session")
+	}
+}
+
+func TestTokenService_Refresh(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	sessionRepo := NewSimpleMockSessionRepository()
+	tokenService := service.NewTokenService(userRepo, sessionRepo, NewSimpleMockRefreshTokenRepository(), fakeIssuer{})
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+	first, _ := tokenService.IssueTokens(user, "laptop", "203.0.113.7")
+
+	// Act
+	refreshedUser, second, err := tokenService.Refresh(first.RefreshToken, "198.51.100.2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Refresh should succeed: %v", err)
+	}
+	if refreshedUser.ID != user.ID {
+		t.Errorf("Expected user %s, got %s", user.ID, refreshedUser.ID)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Errorf("Refresh token should be rotated")
+	}
+
+	// リフレッシュはセッションの最終アクセスとして記録される
+	var session *entity.Session
+	for _, s := range sessionRepo.sessions {
+		session = s
+	}
+	if session.IPAddress != "198.51.100.2" {
+		t.Errorf("Refresh should update the session address, got %s", session.IPAddress)
+	}
+
+	// 新しいトークンはさらにリフレッシュできる
+	_, third, err := tokenService.Refresh(second.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("Rotated token should be usable: %v", err)
+	}
+
+	// 使用済みトークンの再利用はセッション全体を失効させる
+	if _, _, err := tokenService.Refresh(first.RefreshToken, ""); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("Expected ErrInvalidRefreshToken on reuse, got %v", err)
+	}
+	if _, _, err := tokenService.Refresh(third.RefreshToken, ""); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("Latest token should be revoked after reuse, got %v", err)
+	}
+	if session.Active() {
+		t.Errorf("Session should be revoked after reuse")
+	}
+
+	// 別のサインインは影響を受けない
+	other, _ := tokenService.IssueTokens(user, "phone", "")
+	if _, _, err := tokenService.Refresh(other.RefreshToken, ""); err != nil {
+		t.Errorf("Other sign-ins should keep working: %v", err)
+	}
+	if _, _, err := tokenService.Refresh("unknown", ""); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("Expected ErrInvalidRefreshToken for unknown token, got %v", err)
+	}
+}
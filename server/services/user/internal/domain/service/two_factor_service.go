@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// TwoFactorChallengeTTL is how long a user has to enter the second factor
+// after the password check
+const TwoFactorChallengeTTL = 5 * time.Minute
+
+var (
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolling     = errors.New("two-factor enrollment has not been started")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+// TwoFactorEnrollment is what a user needs to add the account to an
+// authenticator app
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+type TwoFactorService struct {
+	userRepo      repository.UserRepository
+	totpRepo      repository.TOTPCredentialRepository
+	recoveryRepo  repository.RecoveryCodeRepository
+	challengeRepo repository.TwoFactorChallengeRepository
+	tokenService  *TokenService
+	// loginThrottle counts wrong codes against the account like wrong
+	// passwords, so the second factor can't be guessed across challenges
+	loginThrottle *LoginThrottle
+	// issuer names the service in authenticator apps
+	issuer string
+	now    func() time.Time
+}
+
+func NewTwoFactorService(userRepo repository.UserRepository, totpRepo repository.TOTPCredentialRepository, recoveryRepo repository.RecoveryCodeRepository, challengeRepo repository.TwoFactorChallengeRepository, tokenService *TokenService, loginThrottle *LoginThrottle, issuer string) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo:      userRepo,
+		totpRepo:      totpRepo,
+		recoveryRepo:  recoveryRepo,
+		challengeRepo: challengeRepo,
+		tokenService:  tokenService,
+		loginThrottle: loginThrottle,
+		issuer:        issuer,
+		now:           time.Now,
+	}
+}
+
+// Enroll creates a new authenticator secret for the user. It takes effect
+// once ConfirmEnrollment receives a code generated from it; enrolling again
+// before that replaces the secret.
+func (s *TwoFactorService) Enroll(userID string) (*TwoFactorEnrollment, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.totpRepo.GetByUserID(userID)
+	if err != nil && !errors.Is(err, repository.ErrTOTPCredentialNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Confirmed() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	credential, err := entity.NewTOTPCredential(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.totpRepo.Save(credential); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret: credential.Secret,
+		URI:    credential.URI(s.issuer, user.Email),
+	}, nil
+}
+
+// ConfirmEnrollment turns two-factor authentication on with a code from the
+// authenticator and returns the recovery codes, which are shown only once
+func (s *TwoFactorService) ConfirmEnrollment(userID, code string) ([]string, error) {
+	credential, err := s.totpRepo.GetByUserID(userID)
+	if errors.Is(err, repository.ErrTOTPCredentialNotFound) {
+		return nil, ErrTwoFactorNotEnrolling
+	}
+	if err != nil {
+		return nil, err
+	}
+	if credential.Confirmed() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	now := s.now()
+	step, ok := credential.Validate(code, now)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, plain, err := entity.NewRecoveryCodes(userID, func() string { return uuid.New().String() })
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recoveryRepo.Replace(userID, codes); err != nil {
+		return nil, err
+	}
+
+	credential.ConfirmedAt = &now
+	credential.LastUsedStep = step
+	if err := s.totpRepo.Save(credential); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// Disable turns two-factor authentication off. It takes a current code or a
+// recovery code, so a stolen session alone can't remove the second factor.
+func (s *TwoFactorService) Disable(userID, code string) error {
+	credential, err := s.enabledCredential(userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCode(credential, code, s.now()); err != nil {
+		return err
+	}
+
+	if err := s.totpRepo.DeleteByUserID(userID); err != nil {
+		return err
+	}
+	return s.recoveryRepo.DeleteByUserID(userID)
+}
+
+func (s *TwoFactorService) Status(userID string) (*TwoFactorStatus, error) {
+	credential, err := s.enabledCredential(userID)
+	if errors.Is(err, ErrTwoFactorNotEnabled) {
+		return &TwoFactorStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.recoveryRepo.CountUnused(credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+// Required reports whether signing the user in needs a second factor
+func (s *TwoFactorService) Required(userID string) (bool, error) {
+	_, err := s.enabledCredential(userID)
+	if errors.Is(err, ErrTwoFactorNotEnabled) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// StartChallenge records a sign-in that passed the password check and
+// returns the token the client presents together with the second factor
+func (s *TwoFactorService) StartChallenge(user *entity.User, device, ipAddress string) (string, time.Time, error) {
+	challenge, token, err := entity.NewTwoFactorChallenge(uuid.New().String(), user.ID, device, ipAddress, TwoFactorChallengeTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := s.challengeRepo.Create(challenge); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, challenge.ExpiresAt, nil
+}
+
+// VerifyChallenge completes a sign-in with a TOTP or recovery code and
+// starts its session. A challenge stops working after
+// entity.MaxTwoFactorAttempts wrong codes, and every wrong code also counts
+// as a failed sign-in of the account; a *LoginBlockedError is returned while
+// the account is throttled.
+func (s *TwoFactorService) VerifyChallenge(token, code string) (*entity.User, *TokenPair, error) {
+	challenge, err := s.challengeRepo.GetByHash(entity.HashTwoFactorChallengeToken(token))
+	if errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+		return nil, nil, ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	if !challenge.Usable(now) {
+		return nil, nil, ErrInvalidTwoFactorChallenge
+	}
+
+	credential, err := s.enabledCredential(challenge.UserID)
+	if errors.Is(err, ErrTwoFactorNotEnabled) {
+		return nil, nil, ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.loginThrottle.Check(user.Email, challenge.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkCode(credential, code, now); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			if err := s.challengeRepo.RecordFailure(challenge.ID); err != nil {
+				return nil, nil, err
+			}
+			if err := s.loginThrottle.RecordFailure(user.Email, challenge.IPAddress); err != nil {
+				return nil, nil, err
+			}
+		}
+		return nil, nil, err
+	}
+
+	err = s.challengeRepo.MarkUsed(challenge.ID, now)
+	if errors.Is(err, repository.ErrTwoFactorChallengeUsed) {
+		return nil, nil, ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// パスワードと二要素の両方が通って初めてアカウントの失敗回数を消す
+	if err := s.loginThrottle.RecordSuccess(user.Email); err != nil {
+		return nil, nil, err
+	}
+	tokens, err := s.tokenService.IssueTokens(user, challenge.Device, challenge.IPAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+func (s *TwoFactorService) enabledCredential(userID string) (*entity.TOTPCredential, error) {
+	credential, err := s.totpRepo.GetByUserID(userID)
+	if errors.Is(err, repository.ErrTOTPCredentialNotFound) {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !credential.Confirmed() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return credential, nil
+}
+
+// checkCode accepts a TOTP code, each at most once, or an unused recovery
+// code, which is consumed
+func (s *TwoFactorService) checkCode(credential *entity.TOTPCredential, code string, now time.Time) error {
+	if step, ok := credential.Validate(code, now); ok {
+		err := s.totpRepo.UseStep(credential.UserID, step)
+		if errors.Is(err, repository.ErrTOTPStepUsed) {
+			return ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+
+	err := s.recoveryRepo.Use(credential.UserID, entity.HashRecoveryCode(code), now)
+	if errors.Is(err, repository.ErrRecoveryCodeNotFound) {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
@@ -0,0 +1,308 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockTOTPCredentialRepository はTOTP秘密鍵用のシンプルなモック
+type SimpleMockTOTPCredentialRepository struct {
+	credentials map[string]*entity.TOTPCredential
+}
+
+func NewSimpleMockTOTPCredentialRepository() *SimpleMockTOTPCredentialRepository {
+	return &SimpleMockTOTPCredentialRepository{
+		credentials: make(map[string]*entity.TOTPCredential),
+	}
+}
+
+func (m *SimpleMockTOTPCredentialRepository) GetByUserID(userID string) (*entity.TOTPCredential, error) {
+	if credential, exists := m.credentials[userID]; exists {
+		copied := *credential
+		return &copied, nil
+	}
+	return nil, repository.ErrTOTPCredentialNotFound
+}
+
+func (m *SimpleMockTOTPCredentialRepository) Save(credential *entity.TOTPCredential) error {
+	copied := *credential
+	m.credentials[credential.UserID] = &copied
+	return nil
+}
+
+func (m *SimpleMockTOTPCredentialRepository) UseStep(userID string, step int64) error {
+	credential, exists := m.credentials[userID]
+	if !exists {
+		return repository.ErrTOTPCredentialNotFound
+	}
+	if credential.LastUsedStep >= step {
+		return repository.ErrTOTPStepUsed
+	}
+	credential.LastUsedStep = step
+	return nil
+}
+
+func (m *SimpleMockTOTPCredentialRepository) DeleteByUserID(userID string) error {
+	delete(m.credentials, userID)
+	return nil
+}
+
+// SimpleMockRecoveryCodeRepository はリカバリーコード用のシンプルなモック
+type SimpleMockRecoveryCodeRepository struct {
+	codes map[string][]*entity.RecoveryCode
+}
+
+func NewSimpleMockRecoveryCodeRepository() *SimpleMockRecoveryCodeRepository {
+	return &SimpleMockRecoveryCodeRepository{
+		codes: make(map[string][]*entity.RecoveryCode),
+	}
+}
+
+func (m *SimpleMockRecoveryCodeRepository) Replace(userID string, codes []*entity.RecoveryCode) error {
+	m.codes[userID] = codes
+	return nil
+}
+
+func (m *SimpleMockRecoveryCodeRepository) Use(userID, hash string, at time.Time) error {
+	for _, code := range m.codes[userID] {
+		if code.CodeHash == hash && code.UsedAt == nil {
+			code.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrRecoveryCodeNotFound
+}
+
+func (m *SimpleMockRecoveryCodeRepository) CountUnused(userID string) (int, error) {
+	count := 0
+	for _, code := range m.codes[userID] {
+		if code.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *SimpleMockRecoveryCodeRepository) DeleteByUserID(userID string) error {
+	delete(m.codes, userID)
+	return nil
+}
+
+// SimpleMockTwoFactorChallengeRepository は二要素認証チャレンジ用のシンプルなモック
+type SimpleMockTwoFactorChallengeRepository struct {
+	challenges map[string]*entity.TwoFactorChallenge
+}
+
+func NewSimpleMockTwoFactorChallengeRepository() *SimpleMockTwoFactorChallengeRepository {
+	return &SimpleMockTwoFactorChallengeRepository{
+		challenges: make(map[string]*entity.TwoFactorChallenge),
+	}
+}
+
+func (m *SimpleMockTwoFactorChallengeRepository) Create(challenge *entity.TwoFactorChallenge) error {
+	m.challenges[challenge.TokenHash] = challenge
+	return nil
+}
+
+func (m *SimpleMockTwoFactorChallengeRepository) GetByHash(hash string) (*entity.TwoFactorChallenge, error) {
+	if challenge, exists := m.challenges[hash]; exists {
+		return challenge, nil
+	}
+	return nil, repository.ErrTwoFactorChallengeNotFound
+}
+
+func (m *SimpleMockTwoFactorChallengeRepository) RecordFailure(id string) error {
+	for _, challenge := range m.challenges {
+		if challenge.ID == id {
+			challenge.Attempts++
+			return nil
+		}
+	}
+	return repository.ErrTwoFactorChallengeNotFound
+}
+
+func (m *SimpleMockTwoFactorChallengeRepository) MarkUsed(id string, at time.Time) error {
+	for _, challenge := range m.challenges {
+		if challenge.ID == id {
+			if challenge.UsedAt != nil {
+				return repository.ErrTwoFactorChallengeUsed
+			}
+			challenge.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrTwoFactorChallengeNotFound
+}
+
+func newTwoFactorService(userRepo *SimpleMockUserRepository) (*service.TwoFactorService, *SimpleMockSessionRepository) {
+	sessionRepo := NewSimpleMockSessionRepository()
+	tokenService := service.NewTokenService(userRepo, sessionRepo, NewSimpleMockRefreshTokenRepository(), fakeIssuer{})
+	// 待ち時間もロックもない設定で、チャレンジごとの上限だけを確かめる
+	throttle := service.NewLoginThrottle(NewSimpleMockLoginAttemptRepository(), service.LoginThrottleConfig{FailureWindow: time.Hour})
+	return service.NewTwoFactorService(userRepo, NewSimpleMockTOTPCredentialRepository(), NewSimpleMockRecoveryCodeRepository(),
+		NewSimpleMockTwoFactorChallengeRepository(), tokenService, throttle, "MyTodo"), sessionRepo
+}
+
+func TestTwoFactorService_Flow(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	twoFactorService, sessionRepo := newTwoFactorService(userRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+
+	// Act - 登録を開始しただけでは有効にならない
+	enrollment, err := twoFactorService.Enroll(user.ID)
+	if err != nil {
+		t.Fatalf("Enroll should succeed: %v", err)
+	}
+	if required, _ := twoFactorService.Required(user.ID); required {
+		t.Errorf("Two-factor should not be required before confirmation")
+	}
+	if _, err := twoFactorService.ConfirmEnrollment(user.ID, "000000"); err != service.ErrInvalidTwoFactorCode {
+		t.Errorf("Expected ErrInvalidTwoFactorCode, got %v", err)
+	}
+
+	// Act - 認証アプリのコードで確認する
+	now := time.Now()
+	code, _ := entity.TOTPCode(enrollment.Secret, now)
+	recoveryCodes, err := twoFactorService.ConfirmEnrollment(user.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment should succeed: %v", err)
+	}
+	if len(recoveryCodes) != entity.RecoveryCodeCount {
+		t.Errorf("Expected %d recovery codes, got %d", entity.RecoveryCodeCount, len(recoveryCodes))
+	}
+	if required, _ := twoFactorService.Required(user.ID); !required {
+		t.Errorf("Two-factor should be required after confirmation")
+	}
+	if _, err := twoFactorService.Enroll(user.ID); err != service.ErrTwoFactorAlreadyEnabled {
+		t.Errorf("Expected ErrTwoFactorAlreadyEnabled, got %v", err)
+	}
+
+	// Act - チャレンジに答えるとセッションが作られる
+	token, _, err := twoFactorService.StartChallenge(user, "Mozilla/5.0", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("StartChallenge should succeed: %v", err)
+	}
+	// 確認で使ったコードは再利用できない
+	if _, _, err := twoFactorService.VerifyChallenge(token, code); err != service.ErrInvalidTwoFactorCode {
+		t.Errorf("Used code should be rejected, got %v", err)
+	}
+	next, _ := entity.TOTPCode(enrollment.Secret, now.Add(entity.TOTPPeriod))
+	signedIn, tokens, err := twoFactorService.VerifyChallenge(token, next)
+	if err != nil {
+		t.Fatalf("VerifyChallenge should succeed: %v", err)
+	}
+	if signedIn.ID != user.ID || tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Errorf("Unexpected sign-in: %+v %+v", signedIn, tokens)
+	}
+	sessions, _ := sessionRepo.ListActiveByUserID(user.ID)
+	if len(sessions) != 1 || sessions[0].Device != "Mozilla/5.0" || sessions[0].IPAddress != "203.0.113.7" {
+		t.Errorf("Session should describe the original sign-in: %+v", sessions)
+	}
+	if _, _, err := twoFactorService.VerifyChallenge(token, next); err != service.ErrInvalidTwoFactorChallenge {
+		t.Errorf("Challenge should work only once, got %v", err)
+	}
+
+	// Act - リカバリーコードでも答えられ、一度しか使えない
+	token, _, _ = twoFactorService.StartChallenge(user, "", "")
+	if _, _, err := twoFactorService.VerifyChallenge(token, recoveryCodes[0]); err != nil {
+		t.Fatalf("Recovery code should be accepted: %v", err)
+	}
+	token, _, _ = twoFactorService.StartChallenge(user, "", "")
+	if _, _, err := twoFactorService.VerifyChallenge(token, recoveryCodes[0]); err != service.ErrInvalidTwoFactorCode {
+		t.Errorf("Used recovery code should be rejected, got %v", err)
+	}
+	status, _ := twoFactorService.Status(user.ID)
+	if !status.Enabled || status.RecoveryCodesRemaining != entity.RecoveryCodeCount-1 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	// Act - 無効化
+	if err := twoFactorService.Disable(user.ID, "wrong"); err != service.ErrInvalidTwoFactorCode {
+		t.Errorf("Expected ErrInvalidTwoFactorCode, got %v", err)
+	}
+	if err := twoFactorService.Disable(user.ID, recoveryCodes[1]); err != nil {
+		t.Fatalf("Disable should succeed: %v", err)
+	}
+	if required, _ := twoFactorService.Required(user.ID); required {
+		t.Errorf("Two-factor should not be required after disabling")
+	}
+}
+
+func TestTwoFactorService_ChallengeAttempts(t *testing.T) {
+	// Arrange
+	userRepo := NewSimpleMockUserRepository()
+	twoFactorService, _ := newTwoFactorService(userRepo)
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+	enrollment, _ := twoFactorService.Enroll(user.ID)
+	now := time.Now()
+	code, _ := entity.TOTPCode(enrollment.Secret, now)
+	twoFactorService.ConfirmEnrollment(user.ID, code)
+
+	token, _, _ := twoFactorService.StartChallenge(user, "", "")
+
+	// Act - 規定回数間違えるとチャレンジは使えなくなる
+	for i := 0; i < entity.MaxTwoFactorAttempts; i++ {
+		if _, _, err := twoFactorService.VerifyChallenge(token, "wrong"); err != service.ErrInvalidTwoFactorCode {
+			t.Fatalf("Expected ErrInvalidTwoFactorCode, got %v", err)
+		}
+	}
+	next, _ := entity.TOTPCode(enrollment.Secret, now.Add(entity.TOTPPeriod))
+	_, _, err := twoFactorService.VerifyChallenge(token, next)
+
+	// Assert
+	if err != service.ErrInvalidTwoFactorChallenge {
+		t.Errorf("Expected ErrInvalidTwoFactorChallenge, got %v", err)
+	}
+}
+
+func TestTwoFactorService_ThrottlesAcrossChallenges(t *testing.T) {
+	// Arrange - パスワードを知っている攻撃者がチャレンジを作り直し続ける
+	userRepo := NewSimpleMockUserRepository()
+	throttle, attemptRepo := newLoginThrottle()
+	tokenService := service.NewTokenService(userRepo, NewSimpleMockSessionRepository(), NewSimpleMockRefreshTokenRepository(), fakeIssuer{})
+	twoFactorService := service.NewTwoFactorService(userRepo, NewSimpleMockTOTPCredentialRepository(), NewSimpleMockRecoveryCodeRepository(),
+		NewSimpleMockTwoFactorChallengeRepository(), tokenService, throttle, "MyTodo")
+	user, _ := service.NewUserService(userRepo).CreateUser("test@example.com", "password123")
+	enrollment, _ := twoFactorService.Enroll(user.ID)
+	now := time.Now()
+	code, _ := entity.TOTPCode(enrollment.Secret, now)
+	twoFactorService.ConfirmEnrollment(user.ID, code)
+
+	// Act - 間違えたコードはチャレンジをまたいでアカウントの失敗として数えられる
+	for i := 0; i < 5; i++ {
+		attemptRepo.rewind("account:test@example.com", 5*time.Minute)
+		attemptRepo.rewind("ip:203.0.113.7", 5*time.Minute)
+		token, _, _ := twoFactorService.StartChallenge(user, "", "203.0.113.7")
+		if _, _, err := twoFactorService.VerifyChallenge(token, "wrong"); err != service.ErrInvalidTwoFactorCode {
+			t.Fatalf("Attempt %d: expected ErrInvalidTwoFactorCode, got %v", i+1, err)
+		}
+	}
+	token, _, _ := twoFactorService.StartChallenge(user, "", "203.0.113.7")
+	next, _ := entity.TOTPCode(enrollment.Secret, now.Add(entity.TOTPPeriod))
+	_, _, err := twoFactorService.VerifyChallenge(token, next)
+
+	// Assert - ロック中は正しいコードでも通らない
+	if !errors.Is(err, service.ErrAccountLocked) {
+		t.Errorf("Expected ErrAccountLocked, got %v", err)
+	}
+	if attemptRepo.attempts["ip:203.0.113.7"] == nil {
+		t.Errorf("Failures should be counted for the address of the sign-in")
+	}
+
+	// Act & Assert - 二要素まで通ると失敗回数はリセットされる
+	delete(attemptRepo.attempts, "account:test@example.com")
+	attemptRepo.RecordFailure("account:test@example.com", time.Now(), time.Now().Add(-time.Hour))
+	token, _, _ = twoFactorService.StartChallenge(user, "", "")
+	if _, _, err := twoFactorService.VerifyChallenge(token, next); err != nil {
+		t.Fatalf("VerifyChallenge should succeed: %v", err)
+	}
+	if _, exists := attemptRepo.attempts["account:test@example.com"]; exists {
+		t.Errorf("Failures of the account should be reset after the second factor")
+	}
+}
@@ -8,13 +8,21 @@ import (
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
 )
 
+var ErrIncorrectPassword = errors.New("current password is incorrect")
+
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo       repository.UserRepository
+	passwordPolicy PasswordPolicy
 }
 
 func NewUserService(userRepo repository.UserRepository) *UserService {
+	return NewUserServiceWithPasswordPolicy(userRepo, DefaultPasswordPolicy())
+}
+
+func NewUserServiceWithPasswordPolicy(userRepo repository.UserRepository, passwordPolicy PasswordPolicy) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		passwordPolicy: passwordPolicy,
 	}
 }
 
@@ -25,6 +33,10 @@ func (s *UserService) CreateUser(email, password string) (*entity.User, error) {
 		return nil, errors.New("user already exists")
 	}
 
+	if err := s.passwordPolicy.Validate(password, email); err != nil {
+		return nil, err
+	}
+
 	// Generate UUID for user
 	userID := uuid.New().String()
 
@@ -65,8 +77,20 @@ func (s *UserService) UpdateUser(id, email, password string) (*entity.User, erro
 		return nil, err
 	}
 
+	if password != "" {
+		if err := s.passwordPolicy.Validate(password, user.Email, email); err != nil {
+			return nil, err
+		}
+	}
+
+	// 新しいアドレスは確認されるまで保留し、現在のアドレスのまま使う
 	if email != "" {
-		user.UpdateEmail(email)
+		if email != user.Email {
+			if existing, _ := s.userRepo.GetByEmail(email); existing != nil {
+				return nil, ErrEmailInUse
+			}
+		}
+		user.RequestEmailChange(email)
 	}
 
 	if password != "" {
@@ -82,6 +106,20 @@ func (s *UserService) UpdateUser(id, email, password string) (*entity.User, erro
 	return user, nil
 }
 
+// ChangeCredentials is UpdateUser for users changing their own account, who
+// confirm the change with their current password
+func (s *UserService) ChangeCredentials(id, currentPassword, email, password string) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CheckPassword(currentPassword) {
+		return nil, ErrIncorrectPassword
+	}
+
+	return s.UpdateUser(id, email, password)
+}
+
 func (s *UserService) DeleteUser(id string) error {
 	return s.userRepo.Delete(id)
 }
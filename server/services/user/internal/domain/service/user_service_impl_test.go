@@ -1,7 +1,10 @@
 package service
 
 import (
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
@@ -57,6 +60,54 @@ func (m *DetailedMockUserRepository) GetByEmail(email string) (*entity.User, err
 	return nil, repository.ErrUserNotFound
 }
 
+func (m *DetailedMockUserRepository) List(query repository.UserQuery) (*repository.UserPage, error) {
+	var emails []string
+	for email := range m.emails {
+		if strings.Contains(strings.ToLower(email), strings.ToLower(query.Search)) {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	page := &repository.UserPage{Total: len(emails)}
+	for _, email := range emails {
+		if email <= query.AfterEmail {
+			continue
+		}
+		if query.Limit > 0 && len(page.Users) == query.Limit {
+			break
+		}
+		page.Users = append(page.Users, m.emails[email])
+	}
+	return page, nil
+}
+
+func (m *DetailedMockUserRepository) ListDeletionDue(before time.Time, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range m.users {
+		if user.DeletionScheduledAt != nil && user.DeletionScheduledAt.Before(before) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].DeletionScheduledAt.Before(*users[j].DeletionScheduledAt)
+	})
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (m *DetailedMockUserRepository) CountActiveAdmins() (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.IsAdmin() && !user.Disabled() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *DetailedMockUserRepository) Update(user *entity.User) error {
 	m.updateCalls = append(m.updateCalls, user.ID)
 	if _, exists := m.users[user.ID]; !exists {
@@ -219,14 +270,17 @@ func TestUserService_UpdateUser_CallSequence(t *testing.T) {
 		t.Fatalf("UpdatedUser should not be nil")
 	}
 
-	// Assert - 内部実装詳細
+	// Assert - 内部実装詳細（新しいアドレスが使われていないか確認する）
 	getCalls := repo.GetGetCalls()
-	if len(getCalls) != 1 {
-		t.Errorf("Expected 1 GetByID call, got %d", len(getCalls))
+	if len(getCalls) != 2 {
+		t.Fatalf("Expected GetByID and GetByEmail calls, got %v", getCalls)
 	}
 	if getCalls[0] != "ID:"+createdUser.ID {
 		t.Errorf("Expected GetByID call with %s, got %s", createdUser.ID, getCalls[0])
 	}
+	if getCalls[1] != "EMAIL:"+newEmail {
+		t.Errorf("Expected GetByEmail call with %s, got %s", newEmail, getCalls[1])
+	}
 	
 	updateCalls := repo.GetUpdateCalls()
 	if len(updateCalls) != 1 {
@@ -325,3 +379,131 @@ func TestUserService_RepositoryDependency(t *testing.T) {
 		t.Errorf("Different service instances should have separate repository instances")
 	}
 }
+
+func TestTokenService_RefreshExpired(t *testing.T) {
+	// Arrange
+	userRepo := NewDetailedMockUserRepository()
+	refreshRepo := &stubRefreshTokenRepository{tokens: make(map[string]*entity.RefreshToken)}
+	sessionRepo := &stubSessionRepository{sessions: make(map[string]*entity.Session)}
+	tokenService := NewTokenService(userRepo, sessionRepo, refreshRepo, stubIssuer{})
+	user, _ := entity.NewUser("user-123", "test@example.com", "password123")
+	userRepo.Create(user)
+	pair, _ := tokenService.IssueTokens(user, "", "")
+
+	// Act - 有効期限後に時計を進める
+	tokenService.now = func() time.Time { return time.Now().Add(RefreshTokenTTL + time.Minute) }
+	_, _, err := tokenService.Refresh(pair.RefreshToken, "")
+
+	// Assert
+	if err != ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken for expired token, got %v", err)
+	}
+	if len(refreshRepo.rotateCalls) != 0 {
+		t.Errorf("Expired token should not be rotated")
+	}
+}
+
+type stubRefreshTokenRepository struct {
+	tokens      map[string]*entity.RefreshToken
+	rotateCalls []string
+}
+
+func (m *stubRefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *stubRefreshTokenRepository) GetByHash(hash string) (*entity.RefreshToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrRefreshTokenNotFound
+}
+
+func (m *stubRefreshTokenRepository) Rotate(old, replacement *entity.RefreshToken, at time.Time) error {
+	m.rotateCalls = append(m.rotateCalls, old.ID)
+	m.tokens[replacement.TokenHash] = replacement
+	return nil
+}
+
+func (m *stubRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	return nil
+}
+
+type stubSessionRepository struct {
+	sessions map[string]*entity.Session
+}
+
+func (m *stubSessionRepository) Create(session *entity.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *stubSessionRepository) GetByID(id string) (*entity.Session, error) {
+	if session, exists := m.sessions[id]; exists {
+		return session, nil
+	}
+	return nil, repository.ErrSessionNotFound
+}
+
+func (m *stubSessionRepository) ListActiveByUserID(userID string) ([]*entity.Session, error) {
+	return nil, nil
+}
+
+func (m *stubSessionRepository) Touch(id, ipAddress string, at time.Time) error {
+	return nil
+}
+
+func (m *stubSessionRepository) Revoke(id string, at time.Time) error {
+	return nil
+}
+
+type stubIssuer struct{}
+
+func (stubIssuer) Issue(user *entity.User, sessionID string, issuedAt, expiresAt time.Time) (string, error) {
+	return "token-" + user.ID, nil
+}
+
+func TestPasswordResetService_ExpiredToken(t *testing.T) {
+	// Arrange
+	userRepo := NewDetailedMockUserRepository()
+	user, _ := entity.NewUser("user-123", "test@example.com", "old-password")
+	userRepo.Create(user)
+	reset, secret, _ := entity.NewPasswordResetToken("reset-1", user.ID, PasswordResetTokenTTL)
+	resetRepo := &stubPasswordResetTokenRepository{token: reset}
+	sessionService := NewSessionService(&stubSessionRepository{sessions: make(map[string]*entity.Session)}, &stubRefreshTokenRepository{tokens: make(map[string]*entity.RefreshToken)})
+	resetService := NewPasswordResetService(userRepo, resetRepo, sessionService, nil, "", DefaultPasswordPolicy())
+
+	// Act - 有効期限後に時計を進める
+	resetService.now = func() time.Time { return time.Now().Add(PasswordResetTokenTTL + time.Minute) }
+	err := resetService.ConfirmPasswordReset(secret, "new-password")
+
+	// Assert
+	if err != ErrInvalidPasswordResetToken {
+		t.Errorf("Expected ErrInvalidPasswordResetToken for expired token, got %v", err)
+	}
+	if reset.UsedAt != nil || !user.CheckPassword("old-password") {
+		t.Errorf("Expired token should change nothing")
+	}
+}
+
+type stubPasswordResetTokenRepository struct {
+	token *entity.PasswordResetToken
+}
+
+func (m *stubPasswordResetTokenRepository) Create(token *entity.PasswordResetToken) error {
+	m.token = token
+	return nil
+}
+
+func (m *stubPasswordResetTokenRepository) GetByHash(hash string) (*entity.PasswordResetToken, error) {
+	if m.token == nil || m.token.TokenHash != hash {
+		return nil, repository.ErrPasswordResetTokenNotFound
+	}
+	return m.token, nil
+}
+
+func (m *stubPasswordResetTokenRepository) MarkUsed(id string, at time.Time) error {
+	m.token.UsedAt = &at
+	return nil
+}
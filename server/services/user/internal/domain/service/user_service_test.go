@@ -1,7 +1,10 @@
 package service_test
 
 import (
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
@@ -47,6 +50,54 @@ func (m *SimpleMockUserRepository) GetByEmail(email string) (*entity.User, error
 	return nil, repository.ErrUserNotFound
 }
 
+func (m *SimpleMockUserRepository) List(query repository.UserQuery) (*repository.UserPage, error) {
+	var emails []string
+	for email := range m.emails {
+		if strings.Contains(strings.ToLower(email), strings.ToLower(query.Search)) {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	page := &repository.UserPage{Total: len(emails)}
+	for _, email := range emails {
+		if email <= query.AfterEmail {
+			continue
+		}
+		if query.Limit > 0 && len(page.Users) == query.Limit {
+			break
+		}
+		page.Users = append(page.Users, m.emails[email])
+	}
+	return page, nil
+}
+
+func (m *SimpleMockUserRepository) ListDeletionDue(before time.Time, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range m.users {
+		if user.DeletionScheduledAt != nil && user.DeletionScheduledAt.Before(before) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].DeletionScheduledAt.Before(*users[j].DeletionScheduledAt)
+	})
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (m *SimpleMockUserRepository) CountActiveAdmins() (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.IsAdmin() && !user.Disabled() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *SimpleMockUserRepository) Update(user *entity.User) error {
 	if _, exists := m.users[user.ID]; !exists {
 		return repository.ErrUserNotFound
@@ -252,8 +303,12 @@ func TestUserService_UpdateUser(t *testing.T) {
 	if updatedUser == nil {
 		t.Fatalf("UpdatedUser should not be nil")
 	}
-	if updatedUser.Email != newEmail {
-		t.Errorf("Expected Email %s, got %s", newEmail, updatedUser.Email)
+	// 新しいアドレスは確認されるまで保留される
+	if updatedUser.Email != email {
+		t.Errorf("Expected Email %s until verified, got %s", email, updatedUser.Email)
+	}
+	if updatedUser.PendingEmail != newEmail {
+		t.Errorf("Expected PendingEmail %s, got %s", newEmail, updatedUser.PendingEmail)
 	}
 	if !updatedUser.CheckPassword(newPassword) {
 		t.Errorf("User should have updated password")
@@ -280,14 +335,33 @@ func TestUserService_UpdateUser_EmailOnly(t *testing.T) {
 	if err != nil {
 		t.Errorf("UpdateUser should not return error: %v", err)
 	}
-	if updatedUser.Email != newEmail {
-		t.Errorf("Expected Email %s, got %s", newEmail, updatedUser.Email)
+	if updatedUser.Email != email {
+		t.Errorf("Expected Email %s until verified, got %s", email, updatedUser.Email)
+	}
+	if updatedUser.PendingEmail != newEmail {
+		t.Errorf("Expected PendingEmail %s, got %s", newEmail, updatedUser.PendingEmail)
 	}
 	if !updatedUser.CheckPassword(password) {
 		t.Errorf("User should keep original password")
 	}
 }
 
+func TestUserService_UpdateUser_EmailInUse(t *testing.T) {
+	// Arrange
+	repo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(repo)
+	createdUser, _ := userService.CreateUser("test@example.com", "password123")
+	userService.CreateUser("taken@example.com", "password123")
+
+	// Act
+	_, err := userService.UpdateUser(createdUser.ID, "taken@example.com", "")
+
+	// Assert
+	if err != service.ErrEmailInUse {
+		t.Errorf("Expected ErrEmailInUse, got %v", err)
+	}
+}
+
 func TestUserService_UpdateUser_PasswordOnly(t *testing.T) {
 	// Arrange
 	repo := NewSimpleMockUserRepository()
@@ -367,3 +441,27 @@ func TestUserService_DeleteUser_NotFound(t *testing.T) {
 		t.Errorf("DeleteUser should return error for nonexistent user")
 	}
 }
+
+func TestUserService_ChangeCredentials(t *testing.T) {
+	// Arrange
+	repo := NewSimpleMockUserRepository()
+	userService := service.NewUserService(repo)
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act & Assert - 現在のパスワードが違えば変更しない
+	if _, err := userService.ChangeCredentials(user.ID, "wrong-password", "", "newpassword456"); err != service.ErrIncorrectPassword {
+		t.Errorf("Expected ErrIncorrectPassword, got %v", err)
+	}
+	if unchanged, _ := repo.GetByID(user.ID); !unchanged.CheckPassword("password123") {
+		t.Errorf("Password should not change with a wrong current password")
+	}
+
+	// Act & Assert - 正しければ変更される
+	updated, err := userService.ChangeCredentials(user.ID, "password123", "", "newpassword456")
+	if err != nil {
+		t.Fatalf("ChangeCredentials should succeed: %v", err)
+	}
+	if !updated.CheckPassword("newpassword456") {
+		t.Errorf("Password should be changed")
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const WorkspaceInvitationTTL = 7 * 24 * time.Hour
+
+var (
+	ErrWorkspaceNameRequired      = errors.New("workspace name is required")
+	ErrInvalidWorkspaceRole       = errors.New("role must be owner, member or viewer")
+	ErrWorkspaceOwnerRequired     = errors.New("only workspace owners can manage members")
+	ErrLastWorkspaceOwner         = errors.New("the workspace needs another owner first")
+	ErrAlreadyWorkspaceMember     = errors.New("user is already a member of the workspace")
+	ErrInvalidWorkspaceInvitation = errors.New("invalid or expired workspace invitation")
+	ErrRemoveSelfFromWorkspace    = errors.New("leave the workspace to remove yourself")
+	ErrInvitationEmailRequired    = errors.New("email is required")
+)
+
+// WorkspaceService manages workspaces and who belongs to them. Users who
+// aren't members are told the workspace doesn't exist.
+type WorkspaceService struct {
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	mailer        Mailer
+	// invitationURL is the client page where invitations are accepted
+	invitationURL string
+	now           func() time.Time
+}
+
+func NewWorkspaceService(userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, mailer Mailer, invitationURL string) *WorkspaceService {
+	return &WorkspaceService{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		mailer:        mailer,
+		invitationURL: invitationURL,
+		now:           time.Now,
+	}
+}
+
+// Create makes a workspace owned by the user
+func (s *WorkspaceService) Create(userID, name string) (*entity.WorkspaceMembership, error) {
+	workspace := entity.NewWorkspace(uuid.New().String(), name)
+	if workspace.Name == "" {
+		return nil, ErrWorkspaceNameRequired
+	}
+
+	owner := &entity.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      userID,
+		Role:        entity.WorkspaceRoleOwner,
+		JoinedAt:    workspace.CreatedAt,
+	}
+	if err := s.workspaceRepo.Create(workspace, owner); err != nil {
+		return nil, err
+	}
+	return &entity.WorkspaceMembership{Workspace: workspace, Role: owner.Role}, nil
+}
+
+// List returns the workspaces the user belongs to
+func (s *WorkspaceService) List(userID string) ([]*entity.WorkspaceMembership, error) {
+	return s.workspaceRepo.ListByUserID(userID)
+}
+
+// Get returns a workspace with its members
+func (s *WorkspaceService) Get(userID, workspaceID string) (*entity.WorkspaceMembership, []*entity.WorkspaceMember, error) {
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.workspaceRepo.ListMembers(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &entity.WorkspaceMembership{Workspace: workspace, Role: member.Role}, members, nil
+}
+
+// Role returns the user's role in the workspace, or "" when they aren't a
+// member
+func (s *WorkspaceService) Role(workspaceID, userID string) (string, error) {
+	member, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// Invite asks the owner of an email address to join the workspace. They
+// accept once signed in with that address.
+func (s *WorkspaceService) Invite(userID, workspaceID, email, role string) (*entity.WorkspaceInvitation, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, ErrInvitationEmailRequired
+	}
+	if role == "" {
+		role = entity.WorkspaceRoleMember
+	}
+	if !entity.IsWorkspaceRole(role) {
+		return nil, ErrInvalidWorkspaceRole
+	}
+
+	if _, err := s.owner(workspaceID, userID); err != nil {
+		return nil, err
+	}
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitee, err := s.userRepo.GetByEmail(email); err == nil {
+		if _, err := s.workspaceRepo.GetMember(workspaceID, invitee.ID); err == nil {
+			return nil, ErrAlreadyWorkspaceMember
+		}
+	}
+
+	now := s.now()
+	invitation := &entity.WorkspaceInvitation{
+		ID:            uuid.New().String(),
+		WorkspaceID:   workspaceID,
+		WorkspaceName: workspace.Name,
+		Email:         email,
+		Role:          role,
+		InvitedBy:     userID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(WorkspaceInvitationTTL),
+	}
+	if err := s.workspaceRepo.CreateInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// SendInvitation mails the invitee about an invitation
+func (s *WorkspaceService) SendInvitation(invitation *entity.WorkspaceInvitation) error {
+	inviter := "A user"
+	if user, err := s.userRepo.GetByID(invitation.InvitedBy); err == nil {
+		inviter = user.Email
+	}
+
+	return s.mailer.Send(MailMessage{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You're invited to %s", invitation.WorkspaceName),
+		Body: fmt.Sprintf("%s invited you to join the workspace %q as a %s.\n\n"+
+			"Sign in with this address within %d days to accept:\n%s\n\n"+
+			"If you don't know the sender, ignore this email.\n",
+			inviter, invitation.WorkspaceName, invitation.Role, int(WorkspaceInvitationTTL.Hours()/24), s.invitationURL),
+	})
+}
+
+// ListInvitations returns the pending invitations to the user's email address
+func (s *WorkspaceService) ListInvitations(userID string) ([]*entity.WorkspaceInvitation, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.ListPendingInvitations(strings.ToLower(user.Email), s.now())
+}
+
+// Accept adds the user to the workspace of an invitation sent to their
+// verified email address
+func (s *WorkspaceService) Accept(userID, invitationID string) (*entity.WorkspaceMembership, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.workspaceRepo.GetInvitation(invitationID)
+	if errors.Is(err, repository.ErrWorkspaceInvitationNotFound) {
+		return nil, ErrInvalidWorkspaceInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if !invitation.Pending(now) || !strings.EqualFold(invitation.Email, user.Email) {
+		return nil, ErrInvalidWorkspaceInvitation
+	}
+	// Otherwise anyone could claim an invitation by signing up with the address
+	if !user.EmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	invitation.AcceptedAt = &now
+	member := &entity.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitation.Role,
+		JoinedAt:    now,
+	}
+	err = s.workspaceRepo.AcceptInvitation(invitation, member)
+	if errors.Is(err, repository.ErrWorkspaceInvitationNotFound) {
+		return nil, ErrInvalidWorkspaceInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(invitation.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.Role(workspace.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.WorkspaceMembership{Workspace: workspace, Role: role}, nil
+}
+
+// Leave removes the user from the workspace. The last member leaving deletes
+// it, which is reported so that its todos can be deleted too.
+func (s *WorkspaceService) Leave(userID, workspaceID string) (deleted bool, err error) {
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return false, err
+	}
+	members, err := s.workspaceRepo.ListMembers(workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	if len(members) == 1 {
+		return true, s.workspaceRepo.Delete(workspaceID)
+	}
+	if member.Role == entity.WorkspaceRoleOwner && countOwners(members) == 1 {
+		return false, ErrLastWorkspaceOwner
+	}
+	return false, s.workspaceRepo.RemoveMember(workspaceID, userID)
+}
+
+// SetMemberRole changes the role of another member; only owners can
+func (s *WorkspaceService) SetMemberRole(userID, workspaceID, memberID, role string) (*entity.WorkspaceMember, error) {
+	if !entity.IsWorkspaceRole(role) {
+		return nil, ErrInvalidWorkspaceRole
+	}
+	if _, err := s.owner(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.workspaceRepo.GetMember(workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == role {
+		return member, nil
+	}
+	if member.Role == entity.WorkspaceRoleOwner {
+		members, err := s.workspaceRepo.ListMembers(workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if countOwners(members) == 1 {
+			return nil, ErrLastWorkspaceOwner
+		}
+	}
+
+	member.Role = role
+	if err := s.workspaceRepo.UpdateMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes another member out of the workspace; only owners can
+func (s *WorkspaceService) RemoveMember(userID, workspaceID, memberID string) error {
+	if memberID == userID {
+		return ErrRemoveSelfFromWorkspace
+	}
+	if _, err := s.owner(workspaceID, userID); err != nil {
+		return err
+	}
+	return s.workspaceRepo.RemoveMember(workspaceID, memberID)
+}
+
+// member returns the user's membership, hiding workspaces they don't belong to
+func (s *WorkspaceService) member(workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		return nil, repository.ErrWorkspaceNotFound
+	}
+	return member, err
+}
+
+func (s *WorkspaceService) owner(workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != entity.WorkspaceRoleOwner {
+		return nil, ErrWorkspaceOwnerRequired
+	}
+	return member, nil
+}
+
+func countOwners(members []*entity.WorkspaceMember) int {
+	owners := 0
+	for _, member := range members {
+		if member.Role == entity.WorkspaceRoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
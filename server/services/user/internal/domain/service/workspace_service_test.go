@@ -0,0 +1,399 @@
+package service_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SimpleMockWorkspaceRepository はワークスペース用のシンプルなモック
+type SimpleMockWorkspaceRepository struct {
+	workspaces  map[string]*entity.Workspace
+	members     map[string]map[string]*entity.WorkspaceMember
+	invitations map[string]*entity.WorkspaceInvitation
+}
+
+func NewSimpleMockWorkspaceRepository() *SimpleMockWorkspaceRepository {
+	return &SimpleMockWorkspaceRepository{
+		workspaces:  make(map[string]*entity.Workspace),
+		members:     make(map[string]map[string]*entity.WorkspaceMember),
+		invitations: make(map[string]*entity.WorkspaceInvitation),
+	}
+}
+
+func (m *SimpleMockWorkspaceRepository) Create(workspace *entity.Workspace, owner *entity.WorkspaceMember) error {
+	m.workspaces[workspace.ID] = workspace
+	m.members[workspace.ID] = map[string]*entity.WorkspaceMember{owner.UserID: owner}
+	return nil
+}
+
+func (m *SimpleMockWorkspaceRepository) GetByID(id string) (*entity.Workspace, error) {
+	if workspace, exists := m.workspaces[id]; exists {
+		return workspace, nil
+	}
+	return nil, repository.ErrWorkspaceNotFound
+}
+
+func (m *SimpleMockWorkspaceRepository) ListByUserID(userID string) ([]*entity.WorkspaceMembership, error) {
+	var memberships []*entity.WorkspaceMembership
+	for id, members := range m.members {
+		if member, exists := members[userID]; exists {
+			memberships = append(memberships, &entity.WorkspaceMembership{Workspace: m.workspaces[id], Role: member.Role})
+		}
+	}
+	sort.Slice(memberships, func(i, j int) bool { return memberships[i].Workspace.Name < memberships[j].Workspace.Name })
+	return memberships, nil
+}
+
+func (m *SimpleMockWorkspaceRepository) Delete(id string) error {
+	if _, exists := m.workspaces[id]; !exists {
+		return repository.ErrWorkspaceNotFound
+	}
+	delete(m.workspaces, id)
+	delete(m.members, id)
+	return nil
+}
+
+func (m *SimpleMockWorkspaceRepository) GetMember(workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	if member, exists := m.members[workspaceID][userID]; exists {
+		copied := *member
+		return &copied, nil
+	}
+	return nil, repository.ErrWorkspaceMemberNotFound
+}
+
+func (m *SimpleMockWorkspaceRepository) ListMembers(workspaceID string) ([]*entity.WorkspaceMember, error) {
+	var members []*entity.WorkspaceMember
+	for _, member := range m.members[workspaceID] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (m *SimpleMockWorkspaceRepository) UpdateMember(member *entity.WorkspaceMember) error {
+	if _, exists := m.members[member.WorkspaceID][member.UserID]; !exists {
+		return repository.ErrWorkspaceMemberNotFound
+	}
+	copied := *member
+	m.members[member.WorkspaceID][member.UserID] = &copied
+	return nil
+}
+
+func (m *SimpleMockWorkspaceRepository) RemoveMember(workspaceID, userID string) error {
+	if _, exists := m.members[workspaceID][userID]; !exists {
+		return repository.ErrWorkspaceMemberNotFound
+	}
+	delete(m.members[workspaceID], userID)
+	return nil
+}
+
+func (m *SimpleMockWorkspaceRepository) CreateInvitation(invitation *entity.WorkspaceInvitation) error {
+	m.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (m *SimpleMockWorkspaceRepository) GetInvitation(id string) (*entity.WorkspaceInvitation, error) {
+	if invitation, exists := m.invitations[id]; exists {
+		copied := *invitation
+		return &copied, nil
+	}
+	return nil, repository.ErrWorkspaceInvitationNotFound
+}
+
+func (m *SimpleMockWorkspaceRepository) ListPendingInvitations(email string, now time.Time) ([]*entity.WorkspaceInvitation, error) {
+	var invitations []*entity.WorkspaceInvitation
+	for _, invitation := range m.invitations {
+		if invitation.Email == email && invitation.Pending(now) {
+			invitations = append(invitations, invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (m *SimpleMockWorkspaceRepository) AcceptInvitation(invitation *entity.WorkspaceInvitation, member *entity.WorkspaceMember) error {
+	stored, exists := m.invitations[invitation.ID]
+	if !exists || stored.AcceptedAt != nil {
+		return repository.ErrWorkspaceInvitationNotFound
+	}
+	stored.AcceptedAt = invitation.AcceptedAt
+	if _, exists := m.members[member.WorkspaceID][member.UserID]; !exists {
+		m.members[member.WorkspaceID][member.UserID] = member
+	}
+	return nil
+}
+
+type workspaceFixture struct {
+	userRepo         *SimpleMockUserRepository
+	workspaceRepo    *SimpleMockWorkspaceRepository
+	mailer           *RecordingMailer
+	userService      *service.UserService
+	workspaceService *service.WorkspaceService
+}
+
+func newWorkspaceFixture() *workspaceFixture {
+	f := &workspaceFixture{
+		userRepo:      NewSimpleMockUserRepository(),
+		workspaceRepo: NewSimpleMockWorkspaceRepository(),
+		mailer:        &RecordingMailer{},
+	}
+	f.userService = service.NewUserService(f.userRepo)
+	f.workspaceService = service.NewWorkspaceService(f.userRepo, f.workspaceRepo, f.mailer, "http://localhost:5173/invitations")
+	return f
+}
+
+// verifiedUser は確認済みのメールアドレスを持つユーザーを作成する
+func (f *workspaceFixture) verifiedUser(t *testing.T, email string) *entity.User {
+	t.Helper()
+	user, err := f.userService.CreateUser(email, "password123")
+	if err != nil {
+		t.Fatalf("CreateUser should succeed: %v", err)
+	}
+	user.VerifyEmail(email, time.Now())
+	f.userRepo.Update(user)
+	return user
+}
+
+// join は招待と受け入れを通してユーザーをメンバーにする
+func (f *workspaceFixture) join(t *testing.T, owner *entity.User, workspaceID string, user *entity.User, role string) {
+	t.Helper()
+	invitation, err := f.workspaceService.Invite(owner.ID, workspaceID, user.Email, role)
+	if err != nil {
+		t.Fatalf("Invite should succeed: %v", err)
+	}
+	if _, err := f.workspaceService.Accept(user.ID, invitation.ID); err != nil {
+		t.Fatalf("Accept should succeed: %v", err)
+	}
+}
+
+func TestWorkspaceService_Create(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+
+	// Act & Assert - 名前は必須
+	if _, err := f.workspaceService.Create(owner.ID, "  "); err != service.ErrWorkspaceNameRequired {
+		t.Errorf("Expected ErrWorkspaceNameRequired, got %v", err)
+	}
+
+	// Act
+	membership, err := f.workspaceService.Create(owner.ID, " Team ")
+
+	// Assert - 作成者がオーナーになる
+	if err != nil {
+		t.Fatalf("Create should succeed: %v", err)
+	}
+	if membership.Workspace.Name != "Team" || membership.Role != entity.WorkspaceRoleOwner {
+		t.Errorf("Unexpected workspace %+v as %s", membership.Workspace, membership.Role)
+	}
+	workspaces, _ := f.workspaceService.List(owner.ID)
+	if len(workspaces) != 1 || workspaces[0].Workspace.ID != membership.Workspace.ID {
+		t.Errorf("Expected the workspace to be listed, got %d", len(workspaces))
+	}
+}
+
+func TestWorkspaceService_InviteAndAccept(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+	invitee := f.verifiedUser(t, "invitee@example.com")
+	workspace, _ := f.workspaceService.Create(owner.ID, "Team")
+
+	// Act & Assert - 役割は owner, member, viewer のいずれか
+	if _, err := f.workspaceService.Invite(owner.ID, workspace.Workspace.ID, invitee.Email, "admin"); err != service.ErrInvalidWorkspaceRole {
+		t.Errorf("Expected ErrInvalidWorkspaceRole, got %v", err)
+	}
+
+	// Act
+	invitation, err := f.workspaceService.Invite(owner.ID, workspace.Workspace.ID, " INVITEE@example.com ", entity.WorkspaceRoleMember)
+
+	// Assert - 招待は7日間有効で、メールアドレスは小文字で保存される
+	if err != nil {
+		t.Fatalf("Invite should succeed: %v", err)
+	}
+	if invitation.Email != "invitee@example.com" || invitation.WorkspaceName != "Team" {
+		t.Errorf("Unexpected invitation %+v", invitation)
+	}
+	if ttl := invitation.ExpiresAt.Sub(invitation.CreatedAt); ttl != service.WorkspaceInvitationTTL {
+		t.Errorf("Expected the invitation to last a week, got %v", ttl)
+	}
+
+	// Act & Assert - 招待メールにはワークスペース名と受け入れ用のリンクが含まれる
+	if err := f.workspaceService.SendInvitation(invitation); err != nil {
+		t.Fatalf("SendInvitation should succeed: %v", err)
+	}
+	if len(f.mailer.messages) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(f.mailer.messages))
+	}
+	msg := f.mailer.messages[0]
+	if msg.To != "invitee@example.com" || !strings.Contains(msg.Body, "Team") || !strings.Contains(msg.Body, "http://localhost:5173/invitations") {
+		t.Errorf("Unexpected invitation email %+v", msg)
+	}
+
+	// Act & Assert - 招待された本人の一覧にだけ表示される
+	if invitations, _ := f.workspaceService.ListInvitations(invitee.ID); len(invitations) != 1 {
+		t.Errorf("Expected 1 pending invitation, got %d", len(invitations))
+	}
+	if invitations, _ := f.workspaceService.ListInvitations(owner.ID); len(invitations) != 0 {
+		t.Errorf("Expected no invitations for the owner, got %d", len(invitations))
+	}
+
+	// Act & Assert - 別のユーザーは受け入れられない
+	if _, err := f.workspaceService.Accept(owner.ID, invitation.ID); err != service.ErrInvalidWorkspaceInvitation {
+		t.Errorf("Expected ErrInvalidWorkspaceInvitation, got %v", err)
+	}
+
+	// Act
+	membership, err := f.workspaceService.Accept(invitee.ID, invitation.ID)
+
+	// Assert - 招待された役割でメンバーになる
+	if err != nil {
+		t.Fatalf("Accept should succeed: %v", err)
+	}
+	if membership.Role != entity.WorkspaceRoleMember {
+		t.Errorf("Expected member role, got %s", membership.Role)
+	}
+	if role, _ := f.workspaceService.Role(workspace.Workspace.ID, invitee.ID); role != entity.WorkspaceRoleMember {
+		t.Errorf("Expected member role, got %q", role)
+	}
+
+	// Act & Assert - 同じ招待は二度使えない
+	if _, err := f.workspaceService.Accept(invitee.ID, invitation.ID); err != service.ErrInvalidWorkspaceInvitation {
+		t.Errorf("Expected ErrInvalidWorkspaceInvitation, got %v", err)
+	}
+
+	// Act & Assert - すでにメンバーなら招待できない
+	if _, err := f.workspaceService.Invite(owner.ID, workspace.Workspace.ID, invitee.Email, entity.WorkspaceRoleViewer); err != service.ErrAlreadyWorkspaceMember {
+		t.Errorf("Expected ErrAlreadyWorkspaceMember, got %v", err)
+	}
+
+	// Act & Assert - オーナー以外は招待できない
+	if _, err := f.workspaceService.Invite(invitee.ID, workspace.Workspace.ID, "other@example.com", entity.WorkspaceRoleViewer); err != service.ErrWorkspaceOwnerRequired {
+		t.Errorf("Expected ErrWorkspaceOwnerRequired, got %v", err)
+	}
+}
+
+func TestWorkspaceService_Accept_RequiresVerifiedEmail(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+	invitee, _ := f.userService.CreateUser("invitee@example.com", "password123")
+	workspace, _ := f.workspaceService.Create(owner.ID, "Team")
+	invitation, _ := f.workspaceService.Invite(owner.ID, workspace.Workspace.ID, invitee.Email, entity.WorkspaceRoleViewer)
+
+	// Act
+	_, err := f.workspaceService.Accept(invitee.ID, invitation.ID)
+
+	// Assert - 未確認のメールアドレスでは受け入れられない
+	if err != service.ErrEmailNotVerified {
+		t.Errorf("Expected ErrEmailNotVerified, got %v", err)
+	}
+	if role, _ := f.workspaceService.Role(workspace.Workspace.ID, invitee.ID); role != "" {
+		t.Errorf("Expected no membership, got %q", role)
+	}
+}
+
+func TestWorkspaceService_Get_HidesOtherWorkspaces(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+	outsider := f.verifiedUser(t, "outsider@example.com")
+	workspace, _ := f.workspaceService.Create(owner.ID, "Team")
+
+	// Act & Assert - メンバーにはメンバー一覧も返す
+	membership, members, err := f.workspaceService.Get(owner.ID, workspace.Workspace.ID)
+	if err != nil {
+		t.Fatalf("Get should succeed: %v", err)
+	}
+	if membership.Role != entity.WorkspaceRoleOwner || len(members) != 1 {
+		t.Errorf("Unexpected role %s with %d members", membership.Role, len(members))
+	}
+
+	// Act & Assert - メンバーでなければ存在しないものとして扱う
+	if _, _, err := f.workspaceService.Get(outsider.ID, workspace.Workspace.ID); err != repository.ErrWorkspaceNotFound {
+		t.Errorf("Expected ErrWorkspaceNotFound, got %v", err)
+	}
+	if role, err := f.workspaceService.Role(workspace.Workspace.ID, outsider.ID); err != nil || role != "" {
+		t.Errorf("Expected no role, got %q (%v)", role, err)
+	}
+}
+
+func TestWorkspaceService_Leave(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+	member := f.verifiedUser(t, "member@example.com")
+	workspace, _ := f.workspaceService.Create(owner.ID, "Team")
+	workspaceID := workspace.Workspace.ID
+	f.join(t, owner, workspaceID, member, entity.WorkspaceRoleMember)
+
+	// Act & Assert - ほかのメンバーがいる間、最後のオーナーは抜けられない
+	if _, err := f.workspaceService.Leave(owner.ID, workspaceID); err != service.ErrLastWorkspaceOwner {
+		t.Errorf("Expected ErrLastWorkspaceOwner, got %v", err)
+	}
+
+	// Act & Assert - メンバーは抜けられる
+	deleted, err := f.workspaceService.Leave(member.ID, workspaceID)
+	if err != nil || deleted {
+		t.Fatalf("Leave should succeed without deleting the workspace: %v, %v", deleted, err)
+	}
+
+	// Act
+	deleted, err = f.workspaceService.Leave(owner.ID, workspaceID)
+
+	// Assert - 最後のメンバーが抜けるとワークスペースは削除される
+	if err != nil || !deleted {
+		t.Fatalf("Leaving as the last member should delete the workspace: %v, %v", deleted, err)
+	}
+	if _, err := f.workspaceRepo.GetByID(workspaceID); err != repository.ErrWorkspaceNotFound {
+		t.Errorf("Expected the workspace to be deleted, got %v", err)
+	}
+}
+
+func TestWorkspaceService_ManageMembers(t *testing.T) {
+	// Arrange
+	f := newWorkspaceFixture()
+	owner := f.verifiedUser(t, "owner@example.com")
+	member := f.verifiedUser(t, "member@example.com")
+	workspace, _ := f.workspaceService.Create(owner.ID, "Team")
+	workspaceID := workspace.Workspace.ID
+	f.join(t, owner, workspaceID, member, entity.WorkspaceRoleMember)
+
+	// Act & Assert - オーナー以外は役割を変えられない
+	if _, err := f.workspaceService.SetMemberRole(member.ID, workspaceID, owner.ID, entity.WorkspaceRoleViewer); err != service.ErrWorkspaceOwnerRequired {
+		t.Errorf("Expected ErrWorkspaceOwnerRequired, got %v", err)
+	}
+
+	// Act & Assert - 最後のオーナーは降格できない
+	if _, err := f.workspaceService.SetMemberRole(owner.ID, workspaceID, owner.ID, entity.WorkspaceRoleMember); err != service.ErrLastWorkspaceOwner {
+		t.Errorf("Expected ErrLastWorkspaceOwner, got %v", err)
+	}
+
+	// Act
+	updated, err := f.workspaceService.SetMemberRole(owner.ID, workspaceID, member.ID, entity.WorkspaceRoleViewer)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SetMemberRole should succeed: %v", err)
+	}
+	if updated.Role != entity.WorkspaceRoleViewer {
+		t.Errorf("Expected viewer role, got %s", updated.Role)
+	}
+
+	// Act & Assert - 自分自身は削除できない
+	if err := f.workspaceService.RemoveMember(owner.ID, workspaceID, owner.ID); err != service.ErrRemoveSelfFromWorkspace {
+		t.Errorf("Expected ErrRemoveSelfFromWorkspace, got %v", err)
+	}
+
+	// Act & Assert - オーナーはメンバーを削除できる
+	if err := f.workspaceService.RemoveMember(owner.ID, workspaceID, member.ID); err != nil {
+		t.Fatalf("RemoveMember should succeed: %v", err)
+	}
+	if role, _ := f.workspaceService.Role(workspaceID, member.ID); role != "" {
+		t.Errorf("Expected the member to be removed, got %q", role)
+	}
+}
@@ -0,0 +1,198 @@
+// Package auth signs the JWT access tokens issued by the user service
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted as a signing key
+const minRSAKeyBits = 2048
+
+var (
+	ErrNoSigningKeys = errors.New("no signing keys found")
+)
+
+// SigningKey is a private key identified by the kid put in token headers
+type SigningKey struct {
+	ID     string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+// NewSigningKey wraps an RSA or Ed25519 private key
+func NewSigningKey(id string, key crypto.Signer) (*SigningKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("key %s: RSA keys must be at least %d bits", id, minRSAKeyBits)
+		}
+		return &SigningKey{ID: id, method: jwt.SigningMethodRS256, key: k}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, method: jwt.SigningMethodEdDSA, key: k}, nil
+	}
+	return nil, fmt.Errorf("key %s: unsupported key type %T", id, key)
+}
+
+// GenerateEd25519Key creates a new Ed25519 signing key
+func GenerateEd25519Key(id string) (*SigningKey, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigningKey(id, private)
+}
+
+// Algorithm returns the JWS algorithm of the key, RS256 or EdDSA
+func (k *SigningKey) Algorithm() string {
+	return k.method.Alg()
+}
+
+// PublicKey returns the key that verifies tokens signed with k
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.key.Public()
+}
+
+// JWK is the public part of a signing key in JSON Web Key form
+type JWK struct {
+	KeyID     string
+	KeyType   string
+	Algorithm string
+	// N and E are set for RSA keys
+	N string
+	E string
+	// Curve and X are set for Ed25519 keys
+	Curve string
+	X     string
+}
+
+// JWK returns the public part of the key
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{KeyID: k.ID, Algorithm: k.Algorithm()}
+	switch public := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// KeySet holds the keys of the key directory. Every key verifies tokens; the
+// one whose ID sorts last signs new ones, so rotating means adding a key with
+// a later ID and removing the old one once its tokens have expired.
+type KeySet struct {
+	keys []*SigningKey
+}
+
+// NewKeySet creates a key set from one or more keys
+func NewKeySet(keys ...*SigningKey) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoSigningKeys
+	}
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].ID == sorted[i-1].ID {
+			return nil, fmt.Errorf("duplicate key id %s", sorted[i].ID)
+		}
+	}
+	return &KeySet{keys: sorted}, nil
+}
+
+// LoadKeySet reads the PEM encoded private keys (PKCS#8, or PKCS#1 for RSA)
+// of a directory. A key's ID is its file name without the .pem extension.
+func LoadKeySet(dir string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*SigningKey
+	for _, path := range paths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return NewKeySet(keys...)
+}
+
+// WriteKey stores a key in dir in the form read by LoadKeySet
+func WriteKey(dir string, key *SigningKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return os.WriteFile(filepath.Join(dir, key.ID+".pem"), data, 0o600)
+}
+
+// Current returns the key that signs new tokens
+func (s *KeySet) Current() *SigningKey {
+	return s.keys[len(s.keys)-1]
+}
+
+// JWKs returns the public keys of the set
+func (s *KeySet) JWKs() []JWK {
+	jwks := make([]JWK, 0, len(s.keys))
+	for _, key := range s.keys {
+		jwks = append(jwks, key.JWK())
+	}
+	return jwks
+}
+
+func readKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	id := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data", id)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %v", id, err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key %s: unsupported key type %T", id, parsed)
+	}
+	return NewSigningKey(id, signer)
+}
@@ -0,0 +1,114 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/auth"
+)
+
+func TestLoadKeySet(t *testing.T) {
+	// Arrange - RSA(PKCS#1)とEd25519(PKCS#8)の鍵を置く
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	if err := os.WriteFile(filepath.Join(dir, "2026-01.pem"), data, 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	edKey, _ := auth.GenerateEd25519Key("2026-07")
+	if err := auth.WriteKey(dir, edKey); err != nil {
+		t.Fatalf("WriteKey should succeed: %v", err)
+	}
+
+	// Act
+	keys, err := auth.LoadKeySet(dir)
+
+	// Assert - IDが最後の鍵で署名し、すべての鍵を公開する
+	if err != nil {
+		t.Fatalf("LoadKeySet should succeed: %v", err)
+	}
+	if keys.Current().ID != "2026-07" || keys.Current().Algorithm() != "EdDSA" {
+		t.Errorf("Expected 2026-07 (EdDSA) to sign, got %s (%s)", keys.Current().ID, keys.Current().Algorithm())
+	}
+	jwks := keys.JWKs()
+	if len(jwks) != 2 {
+		t.Fatalf("Expected 2 JWKs, got %d", len(jwks))
+	}
+	if jwks[0].KeyID != "2026-01" || jwks[0].KeyType != "RSA" || jwks[0].Algorithm != "RS256" || jwks[0].E != "AQAB" || jwks[0].N == "" {
+		t.Errorf("Unexpected RSA JWK: %+v", jwks[0])
+	}
+	if jwks[1].KeyType != "OKP" || jwks[1].Curve != "Ed25519" || jwks[1].X == "" {
+		t.Errorf("Unexpected Ed25519 JWK: %+v", jwks[1])
+	}
+}
+
+func TestLoadKeySet_Invalid(t *testing.T) {
+	// 鍵がない
+	if _, err := auth.LoadKeySet(t.TempDir()); err != auth.ErrNoSigningKeys {
+		t.Errorf("Expected ErrNoSigningKeys, got %v", err)
+	}
+
+	// 短すぎるRSA鍵
+	dir := t.TempDir()
+	weak, _ := rsa.GenerateKey(rand.Reader, 1024)
+	der, _ := x509.MarshalPKCS8PrivateKey(weak)
+	os.WriteFile(filepath.Join(dir, "weak.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	if _, err := auth.LoadKeySet(dir); err == nil {
+		t.Errorf("1024-bit RSA keys should be rejected")
+	}
+
+	// PEMではないファイル
+	dir = t.TempDir()
+	os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("not a key"), 0o600)
+	if _, err := auth.LoadKeySet(dir); err == nil {
+		t.Errorf("Files without PEM data should be rejected")
+	}
+}
+
+func TestSigner_Issue(t *testing.T) {
+	// Arrange - ローテーション中の2つの鍵
+	oldKey, _ := auth.GenerateEd25519Key("2026-01")
+	newKey, _ := auth.GenerateEd25519Key("2026-07")
+	keys, _ := auth.NewKeySet(newKey, oldKey)
+	user, _ := entity.NewUser("user-123", "test@example.com", "password123")
+	now := time.Now()
+
+	// Act
+	signed, err := auth.NewSigner(keys).Issue(user, "session-1", now, now.Add(time.Minute))
+
+	// Assert - 新しい鍵で署名され、古い鍵では検証できない
+	if err != nil {
+		t.Fatalf("Issue should succeed: %v", err)
+	}
+	claims := &auth.Claims{}
+	token, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (interface{}, error) {
+		return newKey.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
+	if err != nil {
+		t.Fatalf("Token should verify with the current key: %v", err)
+	}
+	if token.Header["kid"] != "2026-07" || claims.ID != "session-1" || claims.UserID != "user-123" {
+		t.Errorf("Unexpected token: %v %+v", token.Header, claims)
+	}
+	if _, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+		return oldKey.PublicKey(), nil
+	}); err == nil {
+		t.Errorf("Token should not verify with another key")
+	}
+
+	// 重複したIDは拒否される
+	if _, err := auth.NewKeySet(oldKey, oldKey); err == nil {
+		t.Errorf("Duplicate key IDs should be rejected")
+	}
+}
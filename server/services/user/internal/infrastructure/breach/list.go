@@ -0,0 +1,83 @@
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+)
+
+const prefixLength = 5
+
+// List is an in-memory breached password list indexed by SHA-1 prefix. It
+// implements service.BreachedPasswords for offline use.
+type List struct {
+	suffixes map[string][]string
+}
+
+// LoadFile reads a list from a file; see Read for the format
+func LoadFile(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+// Read parses one entry per line. An entry is either a SHA-1 hash in hex,
+// optionally followed by ":count" as in the Pwned Passwords downloads, or a
+// plain password. Blank lines and lines starting with # are skipped.
+func Read(r io.Reader) (*List, error) {
+	list := &List{suffixes: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash := strings.ToUpper(strings.TrimSpace(line))
+		if i := strings.IndexByte(hash, ':'); i >= 0 {
+			hash = hash[:i]
+		}
+		if !isSHA1Hex(hash) {
+			sum := sha1.Sum([]byte(line))
+			hash = strings.ToUpper(hex.EncodeToString(sum[:]))
+		}
+
+		prefix := hash[:prefixLength]
+		list.suffixes[prefix] = append(list.suffixes[prefix], hash[prefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Range returns the hash suffixes of the listed passwords starting with prefix
+func (l *List) Range(prefix string) ([]string, error) {
+	return l.suffixes[strings.ToUpper(prefix)], nil
+}
+
+// Len returns the number of entries
+func (l *List) Len() int {
+	n := 0
+	for _, suffixes := range l.suffixes {
+		n += len(suffixes)
+	}
+	return n
+}
+
+func isSHA1Hex(s string) bool {
+	if len(s) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
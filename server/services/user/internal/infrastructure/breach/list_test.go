@@ -0,0 +1,59 @@
+package breach_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/breach"
+)
+
+func TestList_Range(t *testing.T) {
+	// Arrange - ハッシュ（件数付き）と平文が混在したリスト
+	list, err := breach.Read(strings.NewReader(strings.Join([]string{
+		"# breached passwords",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493",
+		"",
+		"letmein123",
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("Read should succeed: %v", err)
+	}
+	if list.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", list.Len())
+	}
+
+	// Act - 先頭5文字で検索すると残りの部分が返る
+	suffixes, err := list.Range("5baa6")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Range should succeed: %v", err)
+	}
+	if len(suffixes) != 1 || suffixes[0] != "1E4C9B93F3F0682250B6CF8331B7EE68FD8" {
+		t.Errorf("Unexpected suffixes: %v", suffixes)
+	}
+}
+
+func TestList_PasswordPolicy(t *testing.T) {
+	// Arrange
+	list, _ := breach.Read(strings.NewReader("password\nletmein123\n"))
+	policy := service.DefaultPasswordPolicy()
+	policy.Breached = list
+
+	// Act & Assert - リストにあるパスワードは拒否される
+	err := policy.Validate("letmein123", "test@example.com")
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("Expected a PasswordPolicyError, got %v", err)
+	}
+	if len(policyErr.Violations) != 1 || policyErr.Violations[0].Code != service.PasswordBreached {
+		t.Errorf("Expected only the breached violation, got %+v", policyErr.Violations)
+	}
+
+	// Act & Assert - リストにないパスワードは受け付ける
+	if err := policy.Validate("correct horse battery staple", "test@example.com"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLiteEmailVerificationTokenRepository stores verification token hashes in
+// the users database. The table is created by NewSQLiteUserRepository.
+type SQLiteEmailVerificationTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteEmailVerificationTokenRepository(userRepo *SQLiteUserRepository) *SQLiteEmailVerificationTokenRepository {
+	return &SQLiteEmailVerificationTokenRepository{db: userRepo.db}
+}
+
+func (r *SQLiteEmailVerificationTokenRepository) Create(token *entity.EmailVerificationToken) error {
+	query := `
+	INSERT INTO email_verification_tokens (id, user_id, email, token_hash, created_at, expires_at, used_at)
+	VALUES (?, ?, ?, ?, ?, ?, NULL)`
+
+	_, err := r.db.Exec(query, token.ID, token.UserID, token.Email, token.TokenHash,
+		formatAuthTime(token.CreatedAt), formatAuthTime(token.ExpiresAt))
+	return err
+}
+
+func (r *SQLiteEmailVerificationTokenRepository) GetByHash(hash string) (*entity.EmailVerificationToken, error) {
+	query := `
+	SELECT id, user_id, email, token_hash, created_at, expires_at, used_at
+	FROM email_verification_tokens WHERE token_hash = ?`
+
+	var token entity.EmailVerificationToken
+	var createdAt, expiresAt string
+	var usedAt sql.NullString
+	err := r.db.QueryRow(query, hash).Scan(&token.ID, &token.UserID, &token.Email, &token.TokenHash,
+		&createdAt, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrEmailVerificationTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	token.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	if usedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, usedAt.String)
+		token.UsedAt = &t
+	}
+	return &token, nil
+}
+
+func (r *SQLiteEmailVerificationTokenRepository) MarkUsed(id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE email_verification_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		formatAuthTime(at), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		var exists int
+		err := r.db.QueryRow(`SELECT 1 FROM email_verification_tokens WHERE id = ?`, id).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return repository.ErrEmailVerificationTokenNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return repository.ErrEmailVerificationTokenUsed
+	}
+	return nil
+}
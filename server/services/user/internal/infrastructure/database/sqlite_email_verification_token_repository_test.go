@@ -0,0 +1,50 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestEmailVerificationTokenRepository_MarkUsed(t *testing.T) {
+	// Arrange
+	dbPath := "test_email_verification.db"
+	defer os.Remove(dbPath)
+
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer userRepo.Close()
+
+	var repo repository.EmailVerificationTokenRepository = database.NewSQLiteEmailVerificationTokenRepository(userRepo)
+
+	verification, secret, _ := entity.NewEmailVerificationToken("verify-1", "user-123", "new@example.com", time.Hour)
+	if err := repo.Create(verification); err != nil {
+		t.Fatalf("Failed to create verification token: %v", err)
+	}
+
+	// Act & Assert - ハッシュで取得でき、確認するアドレスも保存されている
+	found, err := repo.GetByHash(entity.HashEmailVerificationToken(secret))
+	if err != nil {
+		t.Fatalf("Failed to get verification token: %v", err)
+	}
+	if found.UserID != "user-123" || found.Email != "new@example.com" || !found.Usable(time.Now()) {
+		t.Errorf("Unexpected verification token: %+v", found)
+	}
+
+	// Act & Assert - 一度だけ使用済みにできる
+	if err := repo.MarkUsed("verify-1", time.Now()); err != nil {
+		t.Fatalf("MarkUsed should succeed: %v", err)
+	}
+	if err := repo.MarkUsed("verify-1", time.Now()); err != repository.ErrEmailVerificationTokenUsed {
+		t.Errorf("Expected ErrEmailVerificationTokenUsed, got %v", err)
+	}
+	if err := repo.MarkUsed("unknown", time.Now()); err != repository.ErrEmailVerificationTokenNotFound {
+		t.Errorf("Expected ErrEmailVerificationTokenNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLiteLoginAttemptRepository stores failed sign-in counters in the users
+// database. The table is created by NewSQLiteUserRepository.
+type SQLiteLoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteLoginAttemptRepository(userRepo *SQLiteUserRepository) *SQLiteLoginAttemptRepository {
+	return &SQLiteLoginAttemptRepository{db: userRepo.db}
+}
+
+func (r *SQLiteLoginAttemptRepository) Get(key string) (*entity.LoginAttempts, error) {
+	query := `SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = ?`
+
+	var attempts entity.LoginAttempts
+	var lastFailureAt string
+	var lockedUntil sql.NullString
+	err := r.db.QueryRow(query, key).Scan(&attempts.Key, &attempts.Failures, &lastFailureAt, &lockedUntil)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrLoginAttemptsNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attempts.LastFailureAt, _ = time.Parse(time.RFC3339, lastFailureAt)
+	if lockedUntil.Valid {
+		t, _ := time.Parse(time.RFC3339, lockedUntil.String)
+		attempts.LockedUntil = &t
+	}
+	return &attempts, nil
+}
+
+func (r *SQLiteLoginAttemptRepository) RecordFailure(key string, at, resetBefore time.Time) (*entity.LoginAttempts, error) {
+	// 同時に失敗しても取りこぼさないよう、加算はSQLの中で行う
+	query := `
+	INSERT INTO login_attempts (key, failures, last_failure_at, locked_until)
+	VALUES (?, 1, ?, NULL)
+	ON CONFLICT (key) DO UPDATE SET
+		failures = CASE WHEN last_failure_at < ? THEN 1 ELSE failures + 1 END,
+		last_failure_at = excluded.last_failure_at`
+
+	if _, err := r.db.Exec(query, key, formatAuthTime(at), formatAuthTime(resetBefore)); err != nil {
+		return nil, err
+	}
+	return r.Get(key)
+}
+
+func (r *SQLiteLoginAttemptRepository) Lock(key string, until time.Time) error {
+	_, err := r.db.Exec(`UPDATE login_attempts SET locked_until = ? WHERE key = ?`, formatAuthTime(until), key)
+	return err
+}
+
+func (r *SQLiteLoginAttemptRepository) Delete(key string) error {
+	_, err := r.db.Exec(`DELETE FROM login_attempts WHERE key = ?`, key)
+	return err
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestLoginAttemptRepository_RecordFailure(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_login_attempts.db")
+	var repo repository.LoginAttemptRepository = database.NewSQLiteLoginAttemptRepository(userRepo)
+	key := "account:test@example.com"
+	now := time.Now()
+
+	// Act & Assert - 失敗のたびに加算される
+	for i := 1; i <= 3; i++ {
+		attempts, err := repo.RecordFailure(key, now, now.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to record failure: %v", err)
+		}
+		if attempts.Failures != i {
+			t.Errorf("Expected %d failures, got %d", i, attempts.Failures)
+		}
+	}
+
+	// Act & Assert - ロックの期限を保存できる
+	lockedUntil := now.Add(15 * time.Minute)
+	if err := repo.Lock(key, lockedUntil); err != nil {
+		t.Fatalf("Failed to lock: %v", err)
+	}
+	attempts, err := repo.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get attempts: %v", err)
+	}
+	if !attempts.Locked(now) || attempts.Locked(lockedUntil.Add(time.Second)) {
+		t.Errorf("Unexpected lock: %v", attempts.LockedUntil)
+	}
+
+	// Act & Assert - 期間より前の失敗しかなければ数え直す
+	later := now.Add(2 * time.Hour)
+	attempts, err = repo.RecordFailure(key, later, later.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to record failure: %v", err)
+	}
+	if attempts.Failures != 1 {
+		t.Errorf("Expected the count to start over, got %d", attempts.Failures)
+	}
+
+	// Act & Assert - 削除すると見つからない
+	if err := repo.Delete(key); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	if _, err := repo.Get(key); err != repository.ErrLoginAttemptsNotFound {
+		t.Errorf("Expected ErrLoginAttemptsNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLitePasswordResetTokenRepository stores reset token hashes in the users
+// database. The table is created by NewSQLiteUserRepository.
+type SQLitePasswordResetTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSQLitePasswordResetTokenRepository(userRepo *SQLiteUserRepository) *SQLitePasswordResetTokenRepository {
+	return &SQLitePasswordResetTokenRepository{db: userRepo.db}
+}
+
+func (r *SQLitePasswordResetTokenRepository) Create(token *entity.PasswordResetToken) error {
+	query := `
+	INSERT INTO password_reset_tokens (id, user_id, token_hash, created_at, expires_at, used_at)
+	VALUES (?, ?, ?, ?, ?, NULL)`
+
+	_, err := r.db.Exec(query, token.ID, token.UserID, token.TokenHash,
+		formatAuthTime(token.CreatedAt), formatAuthTime(token.ExpiresAt))
+	return err
+}
+
+func (r *SQLitePasswordResetTokenRepository) GetByHash(hash string) (*entity.PasswordResetToken, error) {
+	query := `
+	SELECT id, user_id, token_hash, created_at, expires_at, used_at
+	FROM password_reset_tokens WHERE token_hash = ?`
+
+	var token entity.PasswordResetToken
+	var createdAt, expiresAt string
+	var usedAt sql.NullString
+	err := r.db.QueryRow(query, hash).Scan(&token.ID, &token.UserID, &token.TokenHash,
+		&createdAt, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPasswordResetTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	token.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	if usedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, usedAt.String)
+		token.UsedAt = &t
+	}
+	return &token, nil
+}
+
+func (r *SQLitePasswordResetTokenRepository) MarkUsed(id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		formatAuthTime(at), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		var exists int
+		err := r.db.QueryRow(`SELECT 1 FROM password_reset_tokens WHERE id = ?`, id).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return repository.ErrPasswordResetTokenNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return repository.ErrPasswordResetTokenUsed
+	}
+	return nil
+}
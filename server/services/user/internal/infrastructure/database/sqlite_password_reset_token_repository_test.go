@@ -0,0 +1,55 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestPasswordResetTokenRepository_MarkUsed(t *testing.T) {
+	// Arrange
+	dbPath := "test_password_reset.db"
+	defer os.Remove(dbPath)
+
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer userRepo.Close()
+
+	var repo repository.PasswordResetTokenRepository = database.NewSQLitePasswordResetTokenRepository(userRepo)
+
+	reset, secret, _ := entity.NewPasswordResetToken("reset-1", "user-123", time.Hour)
+	if err := repo.Create(reset); err != nil {
+		t.Fatalf("Failed to create reset token: %v", err)
+	}
+
+	// Act & Assert - ハッシュで取得できる
+	found, err := repo.GetByHash(entity.HashPasswordResetToken(secret))
+	if err != nil {
+		t.Fatalf("Failed to get reset token: %v", err)
+	}
+	if found.UserID != "user-123" || !found.Usable(time.Now()) {
+		t.Errorf("Unexpected reset token: %+v", found)
+	}
+
+	// Act & Assert - 一度だけ使用済みにできる
+	if err := repo.MarkUsed("reset-1", time.Now()); err != nil {
+		t.Fatalf("MarkUsed should succeed: %v", err)
+	}
+	if err := repo.MarkUsed("reset-1", time.Now()); err != repository.ErrPasswordResetTokenUsed {
+		t.Errorf("Expected ErrPasswordResetTokenUsed, got %v", err)
+	}
+	found, _ = repo.GetByHash(entity.HashPasswordResetToken(secret))
+	if found.UsedAt == nil || found.Usable(time.Now()) {
+		t.Errorf("Used token should not be usable")
+	}
+
+	if err := repo.MarkUsed("unknown", time.Now()); err != repository.ErrPasswordResetTokenNotFound {
+		t.Errorf("Expected ErrPasswordResetTokenNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const personalAccessTokenColumns = `id, user_id, name, token_hash, hint, scopes, created_at, expires_at, last_used_at, revoked_at`
+
+// SQLitePersonalAccessTokenRepository stores personal access token hashes in
+// the users database. The table is created by NewSQLiteUserRepository.
+type SQLitePersonalAccessTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSQLitePersonalAccessTokenRepository(userRepo *SQLiteUserRepository) *SQLitePersonalAccessTokenRepository {
+	return &SQLitePersonalAccessTokenRepository{db: userRepo.db}
+}
+
+func (r *SQLitePersonalAccessTokenRepository) Create(token *entity.PersonalAccessToken) error {
+	query := `
+	INSERT INTO personal_access_tokens (` + personalAccessTokenColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULL, NULL)`
+
+	_, err := r.db.Exec(query, token.ID, token.UserID, token.Name, token.TokenHash, token.Hint,
+		strings.Join(token.Scopes, " "), formatAuthTime(token.CreatedAt), formatOptionalAuthTime(token.ExpiresAt))
+	return err
+}
+
+func (r *SQLitePersonalAccessTokenRepository) GetByHash(hash string) (*entity.PersonalAccessToken, error) {
+	query := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = ?`
+
+	token, err := r.scanToken(r.db.QueryRow(query, hash))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPersonalAccessTokenNotFound
+	}
+	return token, err
+}
+
+func (r *SQLitePersonalAccessTokenRepository) ListByUserID(userID string) ([]*entity.PersonalAccessToken, error) {
+	query := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens
+	WHERE user_id = ? AND revoked_at IS NULL
+	ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*entity.PersonalAccessToken
+	for rows.Next() {
+		token, err := r.scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *SQLitePersonalAccessTokenRepository) Revoke(userID, id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE personal_access_tokens SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ? AND user_id = ?`,
+		formatAuthTime(at), id, userID)
+	if err != nil {
+		return err
+	}
+	return requirePersonalAccessTokenRow(result)
+}
+
+func (r *SQLitePersonalAccessTokenRepository) Touch(id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?`,
+		formatAuthTime(at), id)
+	if err != nil {
+		return err
+	}
+	return requirePersonalAccessTokenRow(result)
+}
+
+func (r *SQLitePersonalAccessTokenRepository) scanToken(row rowScanner) (*entity.PersonalAccessToken, error) {
+	var token entity.PersonalAccessToken
+	var scopes, createdAt string
+	var expiresAt, lastUsedAt, revokedAt sql.NullString
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.Hint,
+		&scopes, &createdAt, &expiresAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Scopes = strings.Fields(scopes)
+	token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	token.ExpiresAt = parseOptionalAuthTime(expiresAt)
+	token.LastUsedAt = parseOptionalAuthTime(lastUsedAt)
+	token.RevokedAt = parseOptionalAuthTime(revokedAt)
+	return &token, nil
+}
+
+func requirePersonalAccessTokenRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	return nil
+}
+
+func formatOptionalAuthTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatAuthTime(*t)
+}
+
+func parseOptionalAuthTime(value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t, _ := time.Parse(time.RFC3339, value.String)
+	return &t
+}
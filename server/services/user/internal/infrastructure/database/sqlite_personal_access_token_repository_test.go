@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestPersonalAccessTokenRepository(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_personal_access_tokens.db")
+	var repo repository.PersonalAccessTokenRepository = database.NewSQLitePersonalAccessTokenRepository(userRepo)
+
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	token, secret, _ := entity.NewPersonalAccessToken("token-1", "user-123", "CI",
+		[]string{entity.ScopeTodosRead, entity.ScopeTodosWrite}, &expiresAt)
+	other, _, _ := entity.NewPersonalAccessToken("token-2", "user-456", "cron", []string{entity.ScopeTodosRead}, nil)
+
+	// Act & Assert - 保存したトークンをハッシュで引ける
+	if err := repo.Create(token); err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if err := repo.Create(other); err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	found, err := repo.GetByHash(entity.HashPersonalAccessToken(secret))
+	if err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+	if found.Name != "CI" || len(found.Scopes) != 2 || found.Scopes[1] != entity.ScopeTodosWrite {
+		t.Errorf("Unexpected token: %+v", found)
+	}
+	if found.ExpiresAt == nil || !found.ExpiresAt.Equal(expiresAt) || found.LastUsedAt != nil {
+		t.Errorf("Unexpected times: %+v", found)
+	}
+
+	// Act & Assert - 最終使用日時を記録できる
+	usedAt := time.Now().Truncate(time.Second)
+	if err := repo.Touch(token.ID, usedAt); err != nil {
+		t.Fatalf("Failed to touch token: %v", err)
+	}
+	tokens, err := repo.ListByUserID("user-123")
+	if err != nil {
+		t.Fatalf("Failed to list tokens: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].LastUsedAt == nil || !tokens[0].LastUsedAt.Equal(usedAt) {
+		t.Errorf("Unexpected tokens: %+v", tokens)
+	}
+
+	// Act & Assert - 他のユーザーのトークンは失効できない
+	if err := repo.Revoke("user-123", other.ID, time.Now()); err != repository.ErrPersonalAccessTokenNotFound {
+		t.Errorf("Expected ErrPersonalAccessTokenNotFound, got %v", err)
+	}
+	if err := repo.Revoke("user-123", token.ID, time.Now()); err != nil {
+		t.Fatalf("Failed to revoke token: %v", err)
+	}
+	if tokens, _ := repo.ListByUserID("user-123"); len(tokens) != 0 {
+		t.Errorf("Revoked tokens should not be listed, got %d", len(tokens))
+	}
+	revoked, _ := repo.GetByHash(token.TokenHash)
+	if revoked == nil || revoked.RevokedAt == nil {
+		t.Errorf("Revoked token should keep its revocation time: %+v", revoked)
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLitePreferencesRepository stores user preferences in the users database.
+// The table is created by NewSQLiteUserRepository.
+type SQLitePreferencesRepository struct {
+	db *sql.DB
+}
+
+func NewSQLitePreferencesRepository(userRepo *SQLiteUserRepository) *SQLitePreferencesRepository {
+	return &SQLitePreferencesRepository{db: userRepo.db}
+}
+
+func (r *SQLitePreferencesRepository) Get(userID string) (*entity.Preferences, error) {
+	query := `
+	SELECT user_id, timezone, locale, week_start, date_format, default_sort, default_sort_order, default_project_id, updated_at
+	FROM preferences WHERE user_id = ?`
+
+	var prefs entity.Preferences
+	var updatedAt string
+	err := r.db.QueryRow(query, userID).Scan(
+		&prefs.UserID, &prefs.Timezone, &prefs.Locale, &prefs.WeekStart, &prefs.DateFormat,
+		&prefs.DefaultSort, &prefs.DefaultSortOrder, &prefs.DefaultProjectID, &updatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPreferencesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefs.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &prefs, nil
+}
+
+func (r *SQLitePreferencesRepository) Save(prefs *entity.Preferences) error {
+	query := `
+	INSERT INTO preferences (user_id, timezone, locale, week_start, date_format, default_sort, default_sort_order, default_project_id, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET
+		timezone = excluded.timezone,
+		locale = excluded.locale,
+		week_start = excluded.week_start,
+		date_format = excluded.date_format,
+		default_sort = excluded.default_sort,
+		default_sort_order = excluded.default_sort_order,
+		default_project_id = excluded.default_project_id,
+		updated_at = excluded.updated_at`
+
+	_, err := r.db.Exec(query,
+		prefs.UserID, prefs.Timezone, prefs.Locale, prefs.WeekStart, prefs.DateFormat,
+		prefs.DefaultSort, prefs.DefaultSortOrder, prefs.DefaultProjectID, formatAuthTime(prefs.UpdatedAt),
+	)
+	return err
+}
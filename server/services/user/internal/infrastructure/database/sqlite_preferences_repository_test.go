@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestPreferencesRepository_Save(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_preferences.db")
+	var repo repository.PreferencesRepository = database.NewSQLitePreferencesRepository(userRepo)
+
+	// Act & Assert - 保存前は見つからない
+	if _, err := repo.Get("user-1"); !errors.Is(err, repository.ErrPreferencesNotFound) {
+		t.Fatalf("Expected ErrPreferencesNotFound, got %v", err)
+	}
+
+	// Act & Assert - 保存した内容を取得できる
+	prefs := entity.DefaultPreferences("user-1")
+	prefs.Timezone = "Asia/Tokyo"
+	prefs.DefaultSort = "due_at"
+	prefs.DefaultSortOrder = "asc"
+	prefs.UpdatedAt = time.Now()
+	if err := repo.Save(prefs); err != nil {
+		t.Fatalf("Failed to save preferences: %v", err)
+	}
+	got, err := repo.Get("user-1")
+	if err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if got.Timezone != "Asia/Tokyo" || got.DefaultSort != "due_at" || got.DefaultSortOrder != "asc" || got.Locale != "en" {
+		t.Errorf("Unexpected preferences: %+v", got)
+	}
+
+	// Act & Assert - 2回目の保存は上書きになる
+	prefs.Timezone = "Europe/Berlin"
+	prefs.DefaultSort = ""
+	if err := repo.Save(prefs); err != nil {
+		t.Fatalf("Failed to save preferences again: %v", err)
+	}
+	got, err = repo.Get("user-1")
+	if err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if got.Timezone != "Europe/Berlin" || got.DefaultSort != "" {
+		t.Errorf("Expected the preferences to be replaced, got %+v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLiteRecoveryCodeRepository stores recovery code hashes in the users
+// database. The table is created by NewSQLiteUserRepository.
+type SQLiteRecoveryCodeRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRecoveryCodeRepository(userRepo *SQLiteUserRepository) *SQLiteRecoveryCodeRepository {
+	return &SQLiteRecoveryCodeRepository{db: userRepo.db}
+}
+
+func (r *SQLiteRecoveryCodeRepository) Replace(userID string, codes []*entity.RecoveryCode) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, code := range codes {
+		_, err := tx.Exec(`
+		INSERT INTO recovery_codes (id, user_id, code_hash, created_at, used_at)
+		VALUES (?, ?, ?, ?, NULL)`,
+			code.ID, userID, code.CodeHash, formatAuthTime(code.CreatedAt))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteRecoveryCodeRepository) Use(userID, hash string, at time.Time) error {
+	// 同じコードでの同時ログインを防ぐため、未使用の1件だけを使用済みにする
+	query := `
+	UPDATE recovery_codes SET used_at = ?
+	WHERE id = (
+		SELECT id FROM recovery_codes
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+		LIMIT 1
+	)`
+
+	result, err := r.db.Exec(query, formatAuthTime(at), userID, hash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRecoveryCodeRepository) CountUnused(userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM recovery_codes WHERE user_id = ? AND used_at IS NULL`,
+		userID).Scan(&count)
+	return count, err
+}
+
+func (r *SQLiteRecoveryCodeRepository) DeleteByUserID(userID string) error {
+	_, err := r.db.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, userID)
+	return err
+}
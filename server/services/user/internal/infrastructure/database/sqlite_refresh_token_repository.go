@@ -0,0 +1,107 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const refreshTokenColumns = `id, user_id, family_id, token_hash, created_at, expires_at, revoked_at`
+
+// SQLiteRefreshTokenRepository stores refresh token hashes in the users
+// database. The table is created by NewSQLiteUserRepository.
+type SQLiteRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRefreshTokenRepository(userRepo *SQLiteUserRepository) *SQLiteRefreshTokenRepository {
+	return &SQLiteRefreshTokenRepository{db: userRepo.db}
+}
+
+func (r *SQLiteRefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	return insertRefreshToken(r.db, token)
+}
+
+func (r *SQLiteRefreshTokenRepository) GetByHash(hash string) (*entity.RefreshToken, error) {
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE token_hash = ?`
+
+	var token entity.RefreshToken
+	var createdAt, expiresAt string
+	var revokedAt sql.NullString
+	err := r.db.QueryRow(query, hash).Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash,
+		&createdAt, &expiresAt, &revokedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	token.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	if revokedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, revokedAt.String)
+		token.RevokedAt = &t
+	}
+	return &token, nil
+}
+
+func (r *SQLiteRefreshTokenRepository) Rotate(old, replacement *entity.RefreshToken, at time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 失効済みでない場合だけ失効させ、同じトークンの二重使用を防ぐ
+	result, err := tx.Exec(`UPDATE refresh_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`,
+		formatAuthTime(at), old.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRefreshTokenRevoked
+	}
+
+	if err := insertRefreshToken(tx, replacement); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	revokedAt := at
+	old.RevokedAt = &revokedAt
+	return nil
+}
+
+func (r *SQLiteRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	_, err := r.db.Exec(`UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`,
+		formatAuthTime(at), familyID)
+	return err
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertRefreshToken(db execer, token *entity.RefreshToken) error {
+	query := `
+	INSERT INTO refresh_tokens (` + refreshTokenColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, NULL)`
+
+	_, err := db.Exec(query, token.ID, token.UserID, token.FamilyID, token.TokenHash,
+		formatAuthTime(token.CreatedAt), formatAuthTime(token.ExpiresAt))
+	return err
+}
+
+func formatAuthTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
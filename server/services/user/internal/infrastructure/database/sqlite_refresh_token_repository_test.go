@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestRefreshTokenRepository_Rotate(t *testing.T) {
+	// Arrange
+	dbPath := "test_refresh_tokens.db"
+	defer os.Remove(dbPath)
+
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer userRepo.Close()
+
+	var repo repository.RefreshTokenRepository = database.NewSQLiteRefreshTokenRepository(userRepo)
+
+	first, secret, _ := entity.NewRefreshToken("token-1", "user-123", "family-1", time.Hour)
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Failed to create refresh token: %v", err)
+	}
+
+	// Act & Assert - ハッシュで取得できる
+	found, err := repo.GetByHash(entity.HashRefreshToken(secret))
+	if err != nil {
+		t.Fatalf("Failed to get refresh token: %v", err)
+	}
+	if found.ID != first.ID || found.FamilyID != "family-1" || found.RevokedAt != nil {
+		t.Errorf("Unexpected refresh token: %+v", found)
+	}
+	if !found.ExpiresAt.Equal(first.ExpiresAt.Truncate(time.Second)) {
+		t.Errorf("Expected ExpiresAt %s, got %s", first.ExpiresAt, found.ExpiresAt)
+	}
+	if _, err := repo.GetByHash(secret); err != repository.ErrRefreshTokenNotFound {
+		t.Errorf("Plain token should not be found, got %v", err)
+	}
+
+	// Act & Assert - ローテーション
+	second, secondSecret, _ := entity.NewRefreshToken("token-2", "user-123", "family-1", time.Hour)
+	if err := repo.Rotate(found, second, time.Now()); err != nil {
+		t.Fatalf("Rotate should succeed: %v", err)
+	}
+	stale, _ := repo.GetByHash(entity.HashRefreshToken(secret))
+	if stale.RevokedAt == nil {
+		t.Errorf("Rotated token should be revoked")
+	}
+
+	// 同じトークンの二重ローテーションは拒否される
+	third, _, _ := entity.NewRefreshToken("token-3", "user-123", "family-1", time.Hour)
+	if err := repo.Rotate(stale, third, time.Now()); err != repository.ErrRefreshTokenRevoked {
+		t.Errorf("Expected ErrRefreshTokenRevoked, got %v", err)
+	}
+	if _, err := repo.GetByHash(third.TokenHash); err != repository.ErrRefreshTokenNotFound {
+		t.Errorf("Replacement of a rejected rotation should not be stored")
+	}
+
+	// Act & Assert - ファミリー全体の失効
+	other, otherSecret, _ := entity.NewRefreshToken("token-4", "user-123", "family-2", time.Hour)
+	repo.Create(other)
+	if err := repo.RevokeFamily("family-1", time.Now()); err != nil {
+		t.Fatalf("RevokeFamily should succeed: %v", err)
+	}
+	latest, _ := repo.GetByHash(entity.HashRefreshToken(secondSecret))
+	if latest.RevokedAt == nil {
+		t.Errorf("Family tokens should be revoked")
+	}
+	untouched, _ := repo.GetByHash(entity.HashRefreshToken(otherSecret))
+	if untouched.RevokedAt != nil {
+		t.Errorf("Other families should not be revoked")
+	}
+}
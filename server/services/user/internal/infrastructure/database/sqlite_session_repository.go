@@ -0,0 +1,119 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const sessionColumns = `id, user_id, device, ip_address, created_at, last_seen_at, revoked_at`
+
+// SQLiteSessionRepository stores sessions in the users database. The table
+// is created by NewSQLiteUserRepository.
+type SQLiteSessionRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteSessionRepository(userRepo *SQLiteUserRepository) *SQLiteSessionRepository {
+	return &SQLiteSessionRepository{db: userRepo.db}
+}
+
+func (r *SQLiteSessionRepository) Create(session *entity.Session) error {
+	query := `
+	INSERT INTO sessions (` + sessionColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, NULL)`
+
+	_, err := r.db.Exec(query, session.ID, session.UserID, session.Device, session.IPAddress,
+		formatAuthTime(session.CreatedAt), formatAuthTime(session.LastSeenAt))
+	return err
+}
+
+func (r *SQLiteSessionRepository) GetByID(id string) (*entity.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = ?`
+
+	session, err := r.scanSession(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSessionNotFound
+	}
+	return session, err
+}
+
+func (r *SQLiteSessionRepository) ListActiveByUserID(userID string) ([]*entity.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions
+	WHERE user_id = ? AND revoked_at IS NULL
+	ORDER BY last_seen_at DESC, created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*entity.Session
+	for rows.Next() {
+		session, err := r.scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *SQLiteSessionRepository) Touch(id, ipAddress string, at time.Time) error {
+	query := `
+	UPDATE sessions
+	SET last_seen_at = ?, ip_address = CASE WHEN ? = '' THEN ip_address ELSE ? END
+	WHERE id = ?`
+
+	result, err := r.db.Exec(query, formatAuthTime(at), ipAddress, ipAddress, id)
+	if err != nil {
+		return err
+	}
+	return requireSessionRow(result)
+}
+
+func (r *SQLiteSessionRepository) Revoke(id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE sessions SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`,
+		formatAuthTime(at), id)
+	if err != nil {
+		return err
+	}
+	return requireSessionRow(result)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *SQLiteSessionRepository) scanSession(row rowScanner) (*entity.Session, error) {
+	var session entity.Session
+	var createdAt, lastSeenAt string
+	var revokedAt sql.NullString
+	err := row.Scan(&session.ID, &session.UserID, &session.Device, &session.IPAddress,
+		&createdAt, &lastSeenAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	session.LastSeenAt, _ = time.Parse(time.RFC3339, lastSeenAt)
+	if revokedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, revokedAt.String)
+		session.RevokedAt = &t
+	}
+	return &session, nil
+}
+
+func requireSessionRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrSessionNotFound
+	}
+	return nil
+}
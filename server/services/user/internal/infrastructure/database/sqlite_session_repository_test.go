@@ -0,0 +1,82 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestSessionRepository_Lifecycle(t *testing.T) {
+	// Arrange
+	dbPath := "test_sessions.db"
+	defer os.Remove(dbPath)
+
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer userRepo.Close()
+
+	var repo repository.SessionRepository = database.NewSQLiteSessionRepository(userRepo)
+
+	laptop := entity.NewSession("session-1", "user-123", "Mozilla/5.0", "203.0.113.7")
+	phone := entity.NewSession("session-2", "user-123", "MobileSafari", "198.51.100.2")
+	other := entity.NewSession("session-3", "user-456", "curl", "")
+	for _, session := range []*entity.Session{laptop, phone, other} {
+		if err := repo.Create(session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+
+	// Act & Assert - 取得
+	found, err := repo.GetByID("session-1")
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if found.Device != "Mozilla/5.0" || found.IPAddress != "203.0.113.7" || !found.Active() {
+		t.Errorf("Unexpected session: %+v", found)
+	}
+
+	// Act & Assert - 最終アクセスの更新（空のアドレスは保持）
+	seen := time.Now().Add(time.Hour)
+	if err := repo.Touch("session-1", "", seen); err != nil {
+		t.Fatalf("Touch should succeed: %v", err)
+	}
+	found, _ = repo.GetByID("session-1")
+	if found.IPAddress != "203.0.113.7" || !found.LastSeenAt.Equal(seen.Truncate(time.Second)) {
+		t.Errorf("Unexpected session after touch: %+v", found)
+	}
+
+	// 最近アクセスしたセッションが先頭
+	sessions, err := repo.ListActiveByUserID("user-123")
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "session-1" {
+		t.Errorf("Expected session-1 first of 2 sessions, got %d", len(sessions))
+	}
+
+	// Act & Assert - 失効したセッションは一覧に出ない
+	if err := repo.Revoke("session-1", time.Now()); err != nil {
+		t.Fatalf("Revoke should succeed: %v", err)
+	}
+	found, _ = repo.GetByID("session-1")
+	if found.Active() {
+		t.Errorf("Session should be revoked")
+	}
+	sessions, _ = repo.ListActiveByUserID("user-123")
+	if len(sessions) != 1 || sessions[0].ID != "session-2" {
+		t.Errorf("Expected only session-2 to remain active")
+	}
+
+	if err := repo.Revoke("unknown", time.Now()); err != repository.ErrSessionNotFound {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := repo.GetByID("unknown"); err != repository.ErrSessionNotFound {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLiteTOTPCredentialRepository stores authenticator secrets in the users
+// database. The table is created by NewSQLiteUserRepository.
+type SQLiteTOTPCredentialRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteTOTPCredentialRepository(userRepo *SQLiteUserRepository) *SQLiteTOTPCredentialRepository {
+	return &SQLiteTOTPCredentialRepository{db: userRepo.db}
+}
+
+func (r *SQLiteTOTPCredentialRepository) GetByUserID(userID string) (*entity.TOTPCredential, error) {
+	query := `
+	SELECT user_id, secret, created_at, confirmed_at, last_used_step
+	FROM totp_credentials WHERE user_id = ?`
+
+	var credential entity.TOTPCredential
+	var createdAt string
+	var confirmedAt sql.NullString
+	err := r.db.QueryRow(query, userID).Scan(&credential.UserID, &credential.Secret,
+		&createdAt, &confirmedAt, &credential.LastUsedStep)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrTOTPCredentialNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credential.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if confirmedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, confirmedAt.String)
+		credential.ConfirmedAt = &t
+	}
+	return &credential, nil
+}
+
+func (r *SQLiteTOTPCredentialRepository) Save(credential *entity.TOTPCredential) error {
+	query := `
+	INSERT OR REPLACE INTO totp_credentials (user_id, secret, created_at, confirmed_at, last_used_step)
+	VALUES (?, ?, ?, ?, ?)`
+
+	var confirmedAt interface{}
+	if credential.ConfirmedAt != nil {
+		confirmedAt = formatAuthTime(*credential.ConfirmedAt)
+	}
+	_, err := r.db.Exec(query, credential.UserID, credential.Secret,
+		formatAuthTime(credential.CreatedAt), confirmedAt, credential.LastUsedStep)
+	return err
+}
+
+func (r *SQLiteTOTPCredentialRepository) UseStep(userID string, step int64) error {
+	result, err := r.db.Exec(`UPDATE totp_credentials SET last_used_step = ? WHERE user_id = ? AND last_used_step < ?`,
+		step, userID, step)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		var exists int
+		err := r.db.QueryRow(`SELECT 1 FROM totp_credentials WHERE user_id = ?`, userID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return repository.ErrTOTPCredentialNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return repository.ErrTOTPStepUsed
+	}
+	return nil
+}
+
+func (r *SQLiteTOTPCredentialRepository) DeleteByUserID(userID string) error {
+	_, err := r.db.Exec(`DELETE FROM totp_credentials WHERE user_id = ?`, userID)
+	return err
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+// SQLiteTwoFactorChallengeRepository stores pending second-factor sign-ins
+// in the users database. The table is created by NewSQLiteUserRepository.
+type SQLiteTwoFactorChallengeRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteTwoFactorChallengeRepository(userRepo *SQLiteUserRepository) *SQLiteTwoFactorChallengeRepository {
+	return &SQLiteTwoFactorChallengeRepository{db: userRepo.db}
+}
+
+func (r *SQLiteTwoFactorChallengeRepository) Create(challenge *entity.TwoFactorChallenge) error {
+	query := `
+	INSERT INTO two_factor_challenges (id, user_id, token_hash, device, ip_address, attempts, created_at, expires_at, used_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULL)`
+
+	_, err := r.db.Exec(query, challenge.ID, challenge.UserID, challenge.TokenHash,
+		challenge.Device, challenge.IPAddress, challenge.Attempts,
+		formatAuthTime(challenge.CreatedAt), formatAuthTime(challenge.ExpiresAt))
+	return err
+}
+
+func (r *SQLiteTwoFactorChallengeRepository) GetByHash(hash string) (*entity.TwoFactorChallenge, error) {
+	query := `
+	SELECT id, user_id, token_hash, device, ip_address, attempts, created_at, expires_at, used_at
+	FROM two_factor_challenges WHERE token_hash = ?`
+
+	var challenge entity.TwoFactorChallenge
+	var createdAt, expiresAt string
+	var usedAt sql.NullString
+	err := r.db.QueryRow(query, hash).Scan(&challenge.ID, &challenge.UserID, &challenge.TokenHash,
+		&challenge.Device, &challenge.IPAddress, &challenge.Attempts, &createdAt, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrTwoFactorChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	challenge.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	challenge.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	if usedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, usedAt.String)
+		challenge.UsedAt = &t
+	}
+	return &challenge, nil
+}
+
+func (r *SQLiteTwoFactorChallengeRepository) RecordFailure(id string) error {
+	result, err := r.db.Exec(`UPDATE two_factor_challenges SET attempts = attempts + 1 WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrTwoFactorChallengeNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteTwoFactorChallengeRepository) MarkUsed(id string, at time.Time) error {
+	result, err := r.db.Exec(`UPDATE two_factor_challenges SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		formatAuthTime(at), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		var exists int
+		err := r.db.QueryRow(`SELECT 1 FROM two_factor_challenges WHERE id = ?`, id).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return repository.ErrTwoFactorChallengeNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return repository.ErrTwoFactorChallengeUsed
+	}
+	return nil
+}
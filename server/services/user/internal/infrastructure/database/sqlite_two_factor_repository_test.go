@@ -0,0 +1,143 @@
+package database_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func newTwoFactorTestRepository(t *testing.T, dbPath string) *database.SQLiteUserRepository {
+	t.Helper()
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	t.Cleanup(func() {
+		userRepo.Close()
+		os.Remove(dbPath)
+	})
+	return userRepo
+}
+
+func TestTOTPCredentialRepository_UseStep(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_totp.db")
+	var repo repository.TOTPCredentialRepository = database.NewSQLiteTOTPCredentialRepository(userRepo)
+
+	credential, _ := entity.NewTOTPCredential("user-123")
+	if err := repo.Save(credential); err != nil {
+		t.Fatalf("Failed to save credential: %v", err)
+	}
+
+	// Act & Assert - 確認済みにして保存し直せる
+	confirmedAt := time.Now()
+	credential.ConfirmedAt = &confirmedAt
+	if err := repo.Save(credential); err != nil {
+		t.Fatalf("Failed to save credential: %v", err)
+	}
+	found, err := repo.GetByUserID("user-123")
+	if err != nil {
+		t.Fatalf("Failed to get credential: %v", err)
+	}
+	if found.Secret != credential.Secret || !found.Confirmed() {
+		t.Errorf("Unexpected credential: %+v", found)
+	}
+
+	// Act & Assert - 同じステップは一度しか使えない
+	if err := repo.UseStep("user-123", 100); err != nil {
+		t.Fatalf("UseStep should succeed: %v", err)
+	}
+	if err := repo.UseStep("user-123", 100); err != repository.ErrTOTPStepUsed {
+		t.Errorf("Expected ErrTOTPStepUsed, got %v", err)
+	}
+	if err := repo.UseStep("user-123", 99); err != repository.ErrTOTPStepUsed {
+		t.Errorf("Earlier steps should be rejected, got %v", err)
+	}
+
+	// Act & Assert - 削除
+	if err := repo.DeleteByUserID("user-123"); err != nil {
+		t.Fatalf("DeleteByUserID should succeed: %v", err)
+	}
+	if _, err := repo.GetByUserID("user-123"); err != repository.ErrTOTPCredentialNotFound {
+		t.Errorf("Expected ErrTOTPCredentialNotFound, got %v", err)
+	}
+}
+
+func TestRecoveryCodeRepository_Use(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_recovery_codes.db")
+	var repo repository.RecoveryCodeRepository = database.NewSQLiteRecoveryCodeRepository(userRepo)
+
+	n := 0
+	newID := func() string {
+		n++
+		return fmt.Sprintf("code-%d", n)
+	}
+	codes, plain, _ := entity.NewRecoveryCodes("user-123", newID)
+	if err := repo.Replace("user-123", codes); err != nil {
+		t.Fatalf("Failed to store recovery codes: %v", err)
+	}
+
+	// Act & Assert - コードは一度だけ使える
+	if err := repo.Use("user-123", entity.HashRecoveryCode(plain[0]), time.Now()); err != nil {
+		t.Fatalf("Use should succeed: %v", err)
+	}
+	if err := repo.Use("user-123", entity.HashRecoveryCode(plain[0]), time.Now()); err != repository.ErrRecoveryCodeNotFound {
+		t.Errorf("Expected ErrRecoveryCodeNotFound, got %v", err)
+	}
+	if err := repo.Use("user-456", entity.HashRecoveryCode(plain[1]), time.Now()); err != repository.ErrRecoveryCodeNotFound {
+		t.Errorf("Codes of other users should not match, got %v", err)
+	}
+	count, _ := repo.CountUnused("user-123")
+	if count != entity.RecoveryCodeCount-1 {
+		t.Errorf("Expected %d unused codes, got %d", entity.RecoveryCodeCount-1, count)
+	}
+
+	// Act & Assert - 作り直すと古いコードは使えなくなる
+	newCodes, _, _ := entity.NewRecoveryCodes("user-123", newID)
+	if err := repo.Replace("user-123", newCodes); err != nil {
+		t.Fatalf("Failed to replace recovery codes: %v", err)
+	}
+	if err := repo.Use("user-123", entity.HashRecoveryCode(plain[1]), time.Now()); err != repository.ErrRecoveryCodeNotFound {
+		t.Errorf("Replaced codes should no longer work, got %v", err)
+	}
+}
+
+func TestTwoFactorChallengeRepository(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_two_factor_challenges.db")
+	var repo repository.TwoFactorChallengeRepository = database.NewSQLiteTwoFactorChallengeRepository(userRepo)
+
+	challenge, secret, _ := entity.NewTwoFactorChallenge("challenge-1", "user-123", "Mozilla/5.0", "203.0.113.7", 5*time.Minute)
+	if err := repo.Create(challenge); err != nil {
+		t.Fatalf("Failed to create challenge: %v", err)
+	}
+
+	// Act & Assert - 失敗回数が記録される
+	if err := repo.RecordFailure("challenge-1"); err != nil {
+		t.Fatalf("RecordFailure should succeed: %v", err)
+	}
+	found, err := repo.GetByHash(entity.HashTwoFactorChallengeToken(secret))
+	if err != nil {
+		t.Fatalf("Failed to get challenge: %v", err)
+	}
+	if found.Attempts != 1 || found.Device != "Mozilla/5.0" || found.IPAddress != "203.0.113.7" {
+		t.Errorf("Unexpected challenge: %+v", found)
+	}
+
+	// Act & Assert - 一度だけ使用済みにできる
+	if err := repo.MarkUsed("challenge-1", time.Now()); err != nil {
+		t.Fatalf("MarkUsed should succeed: %v", err)
+	}
+	if err := repo.MarkUsed("challenge-1", time.Now()); err != repository.ErrTwoFactorChallengeUsed {
+		t.Errorf("Expected ErrTwoFactorChallengeUsed, got %v", err)
+	}
+	if err := repo.MarkUsed("unknown", time.Now()); err != repository.ErrTwoFactorChallengeNotFound {
+		t.Errorf("Expected ErrTwoFactorChallengeNotFound, got %v", err)
+	}
+}
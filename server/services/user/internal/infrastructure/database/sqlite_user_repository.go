@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -34,19 +35,308 @@ func (r *SQLiteUserRepository) createTable() error {
 		id TEXT PRIMARY KEY,
 		email TEXT UNIQUE NOT NULL,
 		password_hash TEXT NOT NULL,
+		email_verified_at DATETIME,
+		pending_email TEXT NOT NULL DEFAULT '',
+		role TEXT NOT NULL DEFAULT 'user',
+		disabled_at DATETIME,
+		deletion_scheduled_at DATETIME,
 		created_at DATETIME NOT NULL,
 		updated_at DATETIME NOT NULL
 	)`
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+
+	// 既存のデータベースに後から追加されたカラムを補う
+	added, err := r.addColumnIfMissing("users", "email_verified_at", "DATETIME")
+	if err != nil {
+		return err
+	}
+	if added {
+		// 確認機能より前に登録されたユーザーは確認済みとして扱う
+		if _, err := r.db.Exec(`UPDATE users SET email_verified_at = created_at`); err != nil {
+			return err
+		}
+	}
+	if _, err := r.addColumnIfMissing("users", "pending_email", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if _, err := r.addColumnIfMissing("users", "role", "TEXT NOT NULL DEFAULT 'user'"); err != nil {
+		return err
+	}
+	if _, err := r.addColumnIfMissing("users", "disabled_at", "DATETIME"); err != nil {
+		return err
+	}
+	if _, err := r.addColumnIfMissing("users", "deletion_scheduled_at", "DATETIME"); err != nil {
+		return err
+	}
+
+	if err := r.createSessionTable(); err != nil {
+		return err
+	}
+	if err := r.createRefreshTokenTable(); err != nil {
+		return err
+	}
+	if err := r.createPasswordResetTokenTable(); err != nil {
+		return err
+	}
+	if err := r.createEmailVerificationTokenTable(); err != nil {
+		return err
+	}
+	if err := r.createTwoFactorTables(); err != nil {
+		return err
+	}
+	if err := r.createLoginAttemptTable(); err != nil {
+		return err
+	}
+	if err := r.createPersonalAccessTokenTable(); err != nil {
+		return err
+	}
+	if err := r.createPreferencesTable(); err != nil {
+		return err
+	}
+	return r.createWorkspaceTables()
+}
+
+func (r *SQLiteUserRepository) createWorkspaceTables() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS workspaces (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	)`, `
+	CREATE TABLE IF NOT EXISTS workspace_members (
+		workspace_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		role TEXT NOT NULL,
+		joined_at DATETIME NOT NULL,
+		PRIMARY KEY (workspace_id, user_id)
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_workspace_members_user ON workspace_members (user_id)`, `
+	CREATE TABLE IF NOT EXISTS workspace_invitations (
+		id TEXT PRIMARY KEY,
+		workspace_id TEXT NOT NULL,
+		email TEXT NOT NULL,
+		role TEXT NOT NULL,
+		invited_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL,
+		accepted_at DATETIME
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_workspace_invitations_email ON workspace_invitations (email)`,
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteUserRepository) createPreferencesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS preferences (
+		user_id TEXT PRIMARY KEY,
+		timezone TEXT NOT NULL,
+		locale TEXT NOT NULL,
+		week_start TEXT NOT NULL,
+		date_format TEXT NOT NULL,
+		default_sort TEXT NOT NULL DEFAULT '',
+		default_sort_order TEXT NOT NULL DEFAULT '',
+		default_project_id TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME NOT NULL
+	)`
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *SQLiteUserRepository) createPersonalAccessTokenTable() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS personal_access_tokens (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		token_hash TEXT NOT NULL UNIQUE,
+		hint TEXT NOT NULL,
+		scopes TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME,
+		last_used_at DATETIME,
+		revoked_at DATETIME
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens(user_id)`,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteUserRepository) createLoginAttemptTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS login_attempts (
+		key TEXT PRIMARY KEY,
+		failures INTEGER NOT NULL DEFAULT 0,
+		last_failure_at DATETIME NOT NULL,
+		locked_until DATETIME
+	)`
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *SQLiteUserRepository) createTwoFactorTables() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS totp_credentials (
+		user_id TEXT PRIMARY KEY,
+		secret TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		confirmed_at DATETIME,
+		last_used_step INTEGER NOT NULL DEFAULT 0
+	)`, `
+	CREATE TABLE IF NOT EXISTS recovery_codes (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		code_hash TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		used_at DATETIME
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_recovery_codes_user ON recovery_codes (user_id)`, `
+	CREATE TABLE IF NOT EXISTS two_factor_challenges (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		device TEXT NOT NULL DEFAULT '',
+		ip_address TEXT NOT NULL DEFAULT '',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME
+	)`,
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to a table created by an older version of
+// the schema and reports whether it was added
+func (r *SQLiteUserRepository) addColumnIfMissing(table, column, definition string) (bool, error) {
+	rows, err := r.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, dataType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return false, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	if _, err := r.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *SQLiteUserRepository) createEmailVerificationTokenTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS email_verification_tokens (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		email TEXT NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME
+	)`
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *SQLiteUserRepository) createPasswordResetTokenTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME
+	)`
 	_, err := r.db.Exec(query)
 	return err
 }
 
+func (r *SQLiteUserRepository) createSessionTable() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS sessions (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		device TEXT NOT NULL DEFAULT '',
+		ip_address TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		last_seen_at DATETIME NOT NULL,
+		revoked_at DATETIME
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions (user_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteUserRepository) createRefreshTokenTable() error {
+	queries := []string{`
+	CREATE TABLE IF NOT EXISTS refresh_tokens (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		family_id TEXT NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL,
+		revoked_at DATETIME
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *SQLiteUserRepository) Create(user *entity.User) error {
 	query := `
-	INSERT INTO users (id, email, password_hash, created_at, updated_at)
-	VALUES (?, ?, ?, ?, ?)`
+	INSERT INTO users (id, email, password_hash, email_verified_at, pending_email, role, disabled_at, deletion_scheduled_at, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := r.db.Exec(query, user.ID, user.Email, user.PasswordHash,
+		formatUserTime(user.EmailVerifiedAt), user.PendingEmail,
+		userRole(user), formatUserTime(user.DisabledAt), formatUserTime(user.DeletionScheduledAt),
 		user.CreatedAt.Format("2006-01-02 15:04:05"), 
 		user.UpdatedAt.Format("2006-01-02 15:04:05"))
 	return err
@@ -54,7 +344,7 @@ func (r *SQLiteUserRepository) Create(user *entity.User) error {
 
 func (r *SQLiteUserRepository) GetByID(id string) (*entity.User, error) {
 	query := `
-	SELECT id, email, password_hash, created_at, updated_at
+	SELECT `+userColumns+`
 	FROM users WHERE id = ?`
 
 	row := r.db.QueryRow(query, id)
@@ -67,7 +357,7 @@ func (r *SQLiteUserRepository) GetByID(id string) (*entity.User, error) {
 
 func (r *SQLiteUserRepository) GetByEmail(email string) (*entity.User, error) {
 	query := `
-	SELECT id, email, password_hash, created_at, updated_at
+	SELECT `+userColumns+`
 	FROM users WHERE email = ?`
 
 	row := r.db.QueryRow(query, email)
@@ -78,12 +368,89 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*entity.User, error) {
 	return user, err
 }
 
+// List pages through the users ordered by email. The email is unique, so
+// it is enough to mark where the previous page ended.
+func (r *SQLiteUserRepository) List(q repository.UserQuery) (*repository.UserPage, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if q.Search != "" {
+		where += ` AND email LIKE ? ESCAPE '\'`
+		args = append(args, "%"+escapeLike(q.Search)+"%")
+	}
+
+	page := &repository.UserPage{}
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM users WHERE `+where, args...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	if q.AfterEmail != "" {
+		where += " AND email > ?"
+		args = append(args, q.AfterEmail)
+	}
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + where + ` ORDER BY email`
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := r.scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Users = append(page.Users, user)
+	}
+	return page, rows.Err()
+}
+
+func (r *SQLiteUserRepository) CountActiveAdmins() (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM users WHERE role = ? AND disabled_at IS NULL`, entity.RoleAdmin).Scan(&count)
+	return count, err
+}
+
+func (r *SQLiteUserRepository) ListDeletionDue(before time.Time, limit int) ([]*entity.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+	WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at < ?
+	ORDER BY deletion_scheduled_at`
+	args := []interface{}{formatUserTime(&before)}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := r.scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 func (r *SQLiteUserRepository) Update(user *entity.User) error {
 	query := `
-	UPDATE users SET email = ?, password_hash = ?, updated_at = ?
+	UPDATE users SET email = ?, password_hash = ?, email_verified_at = ?, pending_email = ?,
+		role = ?, disabled_at = ?, deletion_scheduled_at = ?, updated_at = ?
 	WHERE id = ?`
 
 	result, err := r.db.Exec(query, user.Email, user.PasswordHash,
+		formatUserTime(user.EmailVerifiedAt), user.PendingEmail,
+		userRole(user), formatUserTime(user.DisabledAt), formatUserTime(user.DeletionScheduledAt),
 		user.UpdatedAt.Format("2006-01-02 15:04:05"), user.ID)
 	if err != nil {
 		return err
@@ -101,31 +468,67 @@ func (r *SQLiteUserRepository) Update(user *entity.User) error {
 	return nil
 }
 
+// userDataTables hold rows that belong to a single user and go with the
+// account
+var userDataTables = []string{
+	"sessions",
+	"refresh_tokens",
+	"password_reset_tokens",
+	"email_verification_tokens",
+	"totp_credentials",
+	"recovery_codes",
+	"two_factor_challenges",
+	"personal_access_tokens",
+	"preferences",
+	"workspace_members",
+}
+
+// Delete removes the user together with everything stored for them, in one
+// transaction so that no sign-in secret outlives the account
 func (r *SQLiteUserRepository) Delete(id string) error {
-	query := `DELETE FROM users WHERE id = ?`
-	result, err := r.db.Exec(query, id)
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
-	
-	rowsAffected, err := result.RowsAffected()
+	defer tx.Rollback()
+
+	var email string
+	err = tx.QueryRow(`SELECT email FROM users WHERE id = ?`, id).Scan(&email)
+	if err == sql.ErrNoRows {
+		return repository.ErrUserNotFound
+	}
 	if err != nil {
 		return err
 	}
-	
-	if rowsAffected == 0 {
-		return repository.ErrUserNotFound
+
+	for _, table := range userDataTables {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE user_id = ?", table), id); err != nil {
+			return err
+		}
 	}
-	
-	return nil
+	// ログイン試行はメールアドレスのキーで記録されている
+	if _, err := tx.Exec(`DELETE FROM login_attempts WHERE key = ?`, "account:"+strings.ToLower(email)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM workspace_invitations WHERE email = ?`, strings.ToLower(email)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM users WHERE id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *SQLiteUserRepository) scanUser(row *sql.Row) (*entity.User, error) {
+const userColumns = `id, email, password_hash, email_verified_at, pending_email, role, disabled_at, deletion_scheduled_at, created_at, updated_at`
+
+func (r *SQLiteUserRepository) scanUser(row rowScanner) (*entity.User, error) {
 	var user entity.User
 	var createdAt, updatedAt string
+	var emailVerifiedAt, disabledAt, deletionScheduledAt sql.NullString
 
 	err := row.Scan(&user.ID, &user.Email, &user.PasswordHash,
-		&createdAt, &updatedAt)
+		&emailVerifiedAt, &user.PendingEmail, &user.Role, &disabledAt, &deletionScheduledAt, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -159,9 +562,72 @@ func (r *SQLiteUserRepository) scanUser(row *sql.Row) (*entity.User, error) {
 		return nil, fmt.Errorf("failed to parse UpdatedAt '%s': %w", updatedAt, err)
 	}
 
+	if emailVerifiedAt.Valid {
+		var verifiedAt time.Time
+		for _, format := range timeFormats {
+			if verifiedAt, err = time.Parse(format, emailVerifiedAt.String); err == nil {
+				verifiedAt = verifiedAt.UTC()
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EmailVerifiedAt '%s': %w", emailVerifiedAt.String, err)
+		}
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
+	if disabledAt.Valid {
+		var at time.Time
+		for _, format := range timeFormats {
+			if at, err = time.Parse(format, disabledAt.String); err == nil {
+				at = at.UTC()
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse DisabledAt '%s': %w", disabledAt.String, err)
+		}
+		user.DisabledAt = &at
+	}
+
+	if deletionScheduledAt.Valid {
+		var at time.Time
+		for _, format := range timeFormats {
+			if at, err = time.Parse(format, deletionScheduledAt.String); err == nil {
+				at = at.UTC()
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse DeletionScheduledAt '%s': %w", deletionScheduledAt.String, err)
+		}
+		user.DeletionScheduledAt = &at
+	}
+
 	return &user, nil
 }
 
+// userRole stores users created without a role as regular users
+func userRole(user *entity.User) string {
+	if user.Role == "" {
+		return entity.RoleUser
+	}
+	return user.Role
+}
+
+// escapeLike escapes the LIKE wildcards in s so that it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// formatUserTime formats an optional time the way the users table stores times
+func formatUserTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
 func (r *SQLiteUserRepository) Close() error {
 	return r.db.Close()
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"os"
 	"testing"
 	"time"
@@ -284,6 +285,78 @@ func TestSQLiteUserRepository_ErrorHandling(t *testing.T) {
 }
 
 // Helper function for time comparison
+func TestSQLiteUserRepository_EmailVerificationColumns(t *testing.T) {
+	// Arrange - 確認用カラムがない古いスキーマのデータベース
+	dbPath := "test_email_verification_migration.db"
+	defer os.Remove(dbPath)
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+	CREATE TABLE users (
+		id TEXT PRIMARY KEY,
+		email TEXT UNIQUE NOT NULL,
+		password_hash TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	)`)
+	if err != nil {
+		t.Fatalf("Failed to create old table: %v", err)
+	}
+	_, err = db.Exec(`INSERT INTO users (id, email, password_hash, created_at, updated_at)
+		VALUES ('old-user', 'old@example.com', 'hash', '2024-01-01 10:00:00', '2024-01-01 10:00:00')`)
+	if err != nil {
+		t.Fatalf("Failed to insert old user: %v", err)
+	}
+	db.Close()
+
+	// Act
+	repo, err := NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to migrate repository: %v", err)
+	}
+	defer repo.Close()
+
+	// Assert - 既存ユーザーは確認済みとして扱われる
+	oldUser, err := repo.GetByID("old-user")
+	if err != nil {
+		t.Fatalf("Failed to get old user: %v", err)
+	}
+	if !oldUser.EmailVerified() {
+		t.Errorf("Existing users should be treated as verified")
+	}
+
+	// Assert - 新しいユーザーの確認状態と保留中のアドレスが保存される
+	user, _ := entity.NewUser("new-user", "new@example.com", "password123")
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	created, _ := repo.GetByID("new-user")
+	if created.EmailVerified() {
+		t.Errorf("New users should not be verified")
+	}
+
+	user.RequestEmailChange("changed@example.com")
+	if err := repo.Update(user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+	updated, _ := repo.GetByID("new-user")
+	if updated.Email != "new@example.com" || updated.PendingEmail != "changed@example.com" {
+		t.Errorf("Unexpected email change: %+v", updated)
+	}
+
+	user.VerifyEmail("changed@example.com", time.Now())
+	if err := repo.Update(user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+	verified, _ := repo.GetByID("new-user")
+	if verified.Email != "changed@example.com" || verified.PendingEmail != "" || !verified.EmailVerified() {
+		t.Errorf("Unexpected verified user: %+v", verified)
+	}
+}
+
 func abs(d time.Duration) time.Duration {
 	if d < 0 {
 		return -d
@@ -3,6 +3,7 @@ package database_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
@@ -230,6 +231,128 @@ func TestUserRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestUserRepository_Delete_RemovesUserData(t *testing.T) {
+	// Arrange - サインインに関わるデータを持つユーザーと、別のユーザー
+	dbPath := "test_users_delete_data.db"
+	defer os.Remove(dbPath)
+
+	userRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer userRepo.Close()
+
+	sessionRepo := database.NewSQLiteSessionRepository(userRepo)
+	refreshRepo := database.NewSQLiteRefreshTokenRepository(userRepo)
+	totpRepo := database.NewSQLiteTOTPCredentialRepository(userRepo)
+	recoveryRepo := database.NewSQLiteRecoveryCodeRepository(userRepo)
+	tokenRepo := database.NewSQLitePersonalAccessTokenRepository(userRepo)
+	prefsRepo := database.NewSQLitePreferencesRepository(userRepo)
+	attemptRepo := database.NewSQLiteLoginAttemptRepository(userRepo)
+	workspaceRepo := database.NewSQLiteWorkspaceRepository(userRepo)
+
+	user, _ := entity.NewUser("user-123", "test@example.com", "password123")
+	other, _ := entity.NewUser("user-456", "other@example.com", "password123")
+	userRepo.Create(user)
+	userRepo.Create(other)
+
+	now := time.Now()
+	refreshHashes := map[string]string{}
+	for _, userID := range []string{user.ID, other.ID} {
+		sessionRepo.Create(entity.NewSession("session-"+userID, userID, "Mozilla/5.0", ""))
+		refreshToken, _, _ := entity.NewRefreshToken("refresh-"+userID, userID, "session-"+userID, time.Hour)
+		refreshRepo.Create(refreshToken)
+		refreshHashes[userID] = refreshToken.TokenHash
+		credential, _ := entity.NewTOTPCredential(userID)
+		totpRepo.Save(credential)
+		codes, _, _ := entity.NewRecoveryCodes(userID, func() string { return "code-" + userID })
+		recoveryRepo.Replace(userID, codes[:1])
+		token, _, _ := entity.NewPersonalAccessToken("token-"+userID, userID, "CI", []string{entity.ScopeTodosRead}, nil)
+		tokenRepo.Create(token)
+		prefsRepo.Save(entity.DefaultPreferences(userID))
+	}
+	attemptRepo.RecordFailure("account:test@example.com", now, now.Add(-time.Hour))
+	attemptRepo.RecordFailure("account:other@example.com", now, now.Add(-time.Hour))
+
+	// 別のユーザーのワークスペースへの参加と、未承諾の招待
+	workspace := entity.NewWorkspace("ws-1", "Team")
+	workspaceRepo.Create(workspace, &entity.WorkspaceMember{WorkspaceID: "ws-1", UserID: other.ID, Role: entity.WorkspaceRoleOwner, JoinedAt: now})
+	for _, id := range []string{"invitation-1", "invitation-2"} {
+		workspaceRepo.CreateInvitation(&entity.WorkspaceInvitation{ID: id, WorkspaceID: "ws-1", Email: "test@example.com",
+			Role: entity.WorkspaceRoleMember, InvitedBy: other.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	}
+	accepted, _ := workspaceRepo.GetInvitation("invitation-1")
+	accepted.AcceptedAt = &now
+	if err := workspaceRepo.AcceptInvitation(accepted, &entity.WorkspaceMember{WorkspaceID: "ws-1", UserID: user.ID, Role: entity.WorkspaceRoleMember, JoinedAt: now}); err != nil {
+		t.Fatalf("Failed to join the workspace: %v", err)
+	}
+
+	// Act
+	if err := userRepo.Delete(user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+
+	// Assert - 削除したユーザーのデータだけが消える
+	if _, err := sessionRepo.GetByID("session-user-123"); err != repository.ErrSessionNotFound {
+		t.Errorf("Expected the session to be deleted, got %v", err)
+	}
+	if _, err := refreshRepo.GetByHash(refreshHashes[user.ID]); err != repository.ErrRefreshTokenNotFound {
+		t.Errorf("Expected the refresh token to be deleted, got %v", err)
+	}
+	if _, err := totpRepo.GetByUserID(user.ID); err != repository.ErrTOTPCredentialNotFound {
+		t.Errorf("Expected the TOTP secret to be deleted, got %v", err)
+	}
+	if count, _ := recoveryRepo.CountUnused(user.ID); count != 0 {
+		t.Errorf("Expected the recovery codes to be deleted, %d remain", count)
+	}
+	if tokens, _ := tokenRepo.ListByUserID(user.ID); len(tokens) != 0 {
+		t.Errorf("Expected the access tokens to be deleted, %d remain", len(tokens))
+	}
+	if _, err := prefsRepo.Get(user.ID); err != repository.ErrPreferencesNotFound {
+		t.Errorf("Expected the preferences to be deleted, got %v", err)
+	}
+	if _, err := attemptRepo.Get("account:test@example.com"); err != repository.ErrLoginAttemptsNotFound {
+		t.Errorf("Expected the login attempts to be deleted, got %v", err)
+	}
+
+	if _, err := workspaceRepo.GetMember("ws-1", user.ID); err != repository.ErrWorkspaceMemberNotFound {
+		t.Errorf("Expected the workspace membership to be deleted, got %v", err)
+	}
+	if _, err := workspaceRepo.GetInvitation("invitation-2"); err != repository.ErrWorkspaceInvitationNotFound {
+		t.Errorf("Expected the pending invitation to be deleted, got %v", err)
+	}
+
+	if _, err := workspaceRepo.GetMember("ws-1", other.ID); err != nil {
+		t.Errorf("Other user's workspace membership should remain: %v", err)
+	}
+	if _, err := sessionRepo.GetByID("session-user-456"); err != nil {
+		t.Errorf("Other user's session should remain: %v", err)
+	}
+	if _, err := refreshRepo.GetByHash(refreshHashes[other.ID]); err != nil {
+		t.Errorf("Other user's refresh token should remain: %v", err)
+	}
+	if _, err := totpRepo.GetByUserID(other.ID); err != nil {
+		t.Errorf("Other user's TOTP secret should remain: %v", err)
+	}
+	if count, _ := recoveryRepo.CountUnused(other.ID); count != 1 {
+		t.Errorf("Other user's recovery code should remain, got %d", count)
+	}
+	if tokens, _ := tokenRepo.ListByUserID(other.ID); len(tokens) != 1 {
+		t.Errorf("Other user's access token should remain, got %d", len(tokens))
+	}
+	if _, err := prefsRepo.Get(other.ID); err != nil {
+		t.Errorf("Other user's preferences should remain: %v", err)
+	}
+	if _, err := attemptRepo.Get("account:other@example.com"); err != nil {
+		t.Errorf("Other user's login attempts should remain: %v", err)
+	}
+
+	// 存在しないユーザーの削除は ErrUserNotFound
+	if err := userRepo.Delete(user.ID); err != repository.ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
 func TestUserRepository_EmailUniqueness(t *testing.T) {
 	// Arrange
 	dbPath := "test_users_unique.db"
@@ -359,3 +482,120 @@ func TestUserRepository_ConcurrentAccess(t *testing.T) {
 		t.Errorf("Concurrent read should return correct user")
 	}
 }
+
+func TestUserRepository_RoleAndList(t *testing.T) {
+	// Arrange
+	dbPath := "test_users_list.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.UserRepository
+	sqliteRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	for _, email := range []string{"carol@example.com", "alice@example.com", "bob_admin@example.org", "dave@example.org"} {
+		user, _ := entity.NewUser("id-"+email, email, "password123")
+		if err := repo.Create(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Act & Assert - 役割と無効化が保存される
+	user, _ := repo.GetByEmail("alice@example.com")
+	if user.Role != entity.RoleUser || user.Disabled() {
+		t.Fatalf("New user should be an enabled regular user, got %+v", user)
+	}
+	user.SetRole(entity.RoleAdmin)
+	user.Disable(user.UpdatedAt)
+	if err := repo.Update(user); err != nil {
+		t.Fatalf("Update should succeed: %v", err)
+	}
+	user, _ = repo.GetByID(user.ID)
+	if !user.IsAdmin() || !user.Disabled() {
+		t.Errorf("Role and DisabledAt should be stored, got role %q disabled %v", user.Role, user.Disabled())
+	}
+
+	// Act & Assert - メールアドレス順にページングできる
+	page, err := repo.List(repository.UserQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if page.Total != 4 || len(page.Users) != 2 || page.Users[0].Email != "alice@example.com" || page.Users[1].Email != "bob_admin@example.org" {
+		t.Errorf("Unexpected first page: total %d, %v", page.Total, page.Users)
+	}
+	page, _ = repo.List(repository.UserQuery{AfterEmail: "bob_admin@example.org", Limit: 2})
+	if len(page.Users) != 2 || page.Users[0].Email != "carol@example.com" {
+		t.Errorf("Unexpected second page: %v", page.Users)
+	}
+
+	// Act & Assert - 検索語のワイルドカードは文字どおりに扱う
+	page, _ = repo.List(repository.UserQuery{Search: "B_A"})
+	if page.Total != 1 || page.Users[0].Email != "bob_admin@example.org" {
+		t.Errorf("Expected only bob_admin to match, got %v", page.Users)
+	}
+	page, _ = repo.List(repository.UserQuery{Search: "%"})
+	if page.Total != 0 {
+		t.Errorf("A literal %% should match nobody, got %d", page.Total)
+	}
+}
+
+func TestUserRepository_ListDeletionDue(t *testing.T) {
+	// Arrange
+	dbPath := "test_users_deletion.db"
+	defer os.Remove(dbPath)
+
+	var repo repository.UserRepository
+	sqliteRepo, err := database.NewSQLiteUserRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	defer sqliteRepo.Close()
+	repo = sqliteRepo
+
+	now := time.Now().UTC().Truncate(time.Second)
+	schedule := []struct {
+		email    string
+		deleteIn time.Duration
+	}{
+		{"later@example.com", 24 * time.Hour},
+		{"recent@example.com", -time.Hour},
+		{"oldest@example.com", -48 * time.Hour},
+		{"staying@example.com", 0},
+	}
+	for _, s := range schedule {
+		user, _ := entity.NewUser("id-"+s.email, s.email, "password123")
+		if s.deleteIn != 0 {
+			user.ScheduleDeletion(now.Add(s.deleteIn))
+		}
+		if err := repo.Create(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Act
+	users, err := repo.ListDeletionDue(now, 0)
+
+	// Assert - 期限を過ぎたユーザーだけが古い順に返る
+	if err != nil {
+		t.Fatalf("ListDeletionDue should succeed: %v", err)
+	}
+	if len(users) != 2 || users[0].Email != "oldest@example.com" || users[1].Email != "recent@example.com" {
+		t.Fatalf("Expected oldest and recent to be due, got %v", users)
+	}
+	if !users[0].DeletionScheduledAt.Equal(now.Add(-48 * time.Hour)) {
+		t.Errorf("DeletionScheduledAt should be stored, got %v", users[0].DeletionScheduledAt)
+	}
+
+	// Act & Assert - 件数の上限と取り消し
+	if users, _ := repo.ListDeletionDue(now, 1); len(users) != 1 {
+		t.Errorf("Expected the limit to apply, got %d users", len(users))
+	}
+	users[0].CancelDeletion()
+	repo.Update(users[0])
+	if users, _ := repo.ListDeletionDue(now, 0); len(users) != 1 || users[0].Email != "recent@example.com" {
+		t.Errorf("A cancelled deletion should not be due, got %v", users)
+	}
+}
@@ -0,0 +1,277 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+)
+
+const workspaceInvitationColumns = `i.id, i.workspace_id, w.name, i.email, i.role, i.invited_by, i.created_at, i.expires_at, i.accepted_at`
+
+// SQLiteWorkspaceRepository stores workspaces, their members and
+// invitations in the users database. The tables are created by
+// NewSQLiteUserRepository.
+type SQLiteWorkspaceRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteWorkspaceRepository(userRepo *SQLiteUserRepository) *SQLiteWorkspaceRepository {
+	return &SQLiteWorkspaceRepository{db: userRepo.db}
+}
+
+func (r *SQLiteWorkspaceRepository) Create(workspace *entity.Workspace, owner *entity.WorkspaceMember) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO workspaces (id, name, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		workspace.ID, workspace.Name, formatAuthTime(workspace.CreatedAt), formatAuthTime(workspace.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	if err := insertWorkspaceMember(tx, owner); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteWorkspaceRepository) GetByID(id string) (*entity.Workspace, error) {
+	var workspace entity.Workspace
+	var createdAt, updatedAt string
+	err := r.db.QueryRow(`SELECT id, name, created_at, updated_at FROM workspaces WHERE id = ?`, id).
+		Scan(&workspace.ID, &workspace.Name, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	workspace.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &workspace, nil
+}
+
+func (r *SQLiteWorkspaceRepository) ListByUserID(userID string) ([]*entity.WorkspaceMembership, error) {
+	query := `
+	SELECT w.id, w.name, w.created_at, w.updated_at, m.role
+	FROM workspace_members m JOIN workspaces w ON w.id = m.workspace_id
+	WHERE m.user_id = ?
+	ORDER BY w.name, w.id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*entity.WorkspaceMembership
+	for rows.Next() {
+		var workspace entity.Workspace
+		var createdAt, updatedAt, role string
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &createdAt, &updatedAt, &role); err != nil {
+			return nil, err
+		}
+		workspace.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		workspace.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		memberships = append(memberships, &entity.WorkspaceMembership{Workspace: &workspace, Role: role})
+	}
+	return memberships, rows.Err()
+}
+
+func (r *SQLiteWorkspaceRepository) Delete(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		`DELETE FROM workspace_invitations WHERE workspace_id = ?`,
+		`DELETE FROM workspace_members WHERE workspace_id = ?`,
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+	result, err := tx.Exec(`DELETE FROM workspaces WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if err := requireRow(result, repository.ErrWorkspaceNotFound); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteWorkspaceRepository) GetMember(workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	query := `
+	SELECT m.workspace_id, m.user_id, COALESCE(u.email, ''), m.role, m.joined_at
+	FROM workspace_members m LEFT JOIN users u ON u.id = m.user_id
+	WHERE m.workspace_id = ? AND m.user_id = ?`
+
+	member, err := scanWorkspaceMember(r.db.QueryRow(query, workspaceID, userID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrWorkspaceMemberNotFound
+	}
+	return member, err
+}
+
+func (r *SQLiteWorkspaceRepository) ListMembers(workspaceID string) ([]*entity.WorkspaceMember, error) {
+	query := `
+	SELECT m.workspace_id, m.user_id, COALESCE(u.email, ''), m.role, m.joined_at
+	FROM workspace_members m LEFT JOIN users u ON u.id = m.user_id
+	WHERE m.workspace_id = ?
+	ORDER BY m.joined_at, m.user_id`
+
+	rows, err := r.db.Query(query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*entity.WorkspaceMember
+	for rows.Next() {
+		member, err := scanWorkspaceMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *SQLiteWorkspaceRepository) UpdateMember(member *entity.WorkspaceMember) error {
+	result, err := r.db.Exec(`UPDATE workspace_members SET role = ? WHERE workspace_id = ? AND user_id = ?`,
+		member.Role, member.WorkspaceID, member.UserID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, repository.ErrWorkspaceMemberNotFound)
+}
+
+func (r *SQLiteWorkspaceRepository) RemoveMember(workspaceID, userID string) error {
+	result, err := r.db.Exec(`DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?`, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, repository.ErrWorkspaceMemberNotFound)
+}
+
+func (r *SQLiteWorkspaceRepository) CreateInvitation(invitation *entity.WorkspaceInvitation) error {
+	query := `
+	INSERT INTO workspace_invitations (id, workspace_id, email, role, invited_by, created_at, expires_at, accepted_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, NULL)`
+
+	_, err := r.db.Exec(query, invitation.ID, invitation.WorkspaceID, invitation.Email, invitation.Role,
+		invitation.InvitedBy, formatAuthTime(invitation.CreatedAt), formatAuthTime(invitation.ExpiresAt))
+	return err
+}
+
+func (r *SQLiteWorkspaceRepository) GetInvitation(id string) (*entity.WorkspaceInvitation, error) {
+	query := `SELECT ` + workspaceInvitationColumns + `
+	FROM workspace_invitations i JOIN workspaces w ON w.id = i.workspace_id
+	WHERE i.id = ?`
+
+	invitation, err := scanWorkspaceInvitation(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrWorkspaceInvitationNotFound
+	}
+	return invitation, err
+}
+
+func (r *SQLiteWorkspaceRepository) ListPendingInvitations(email string, now time.Time) ([]*entity.WorkspaceInvitation, error) {
+	query := `SELECT ` + workspaceInvitationColumns + `
+	FROM workspace_invitations i JOIN workspaces w ON w.id = i.workspace_id
+	WHERE i.email = ? AND i.accepted_at IS NULL AND i.expires_at > ?
+	ORDER BY i.created_at DESC, i.id`
+
+	rows, err := r.db.Query(query, email, formatAuthTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*entity.WorkspaceInvitation
+	for rows.Next() {
+		invitation, err := scanWorkspaceInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *SQLiteWorkspaceRepository) AcceptInvitation(invitation *entity.WorkspaceInvitation, member *entity.WorkspaceMember) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 同じ招待を同時に受け入れても、使えるのは1回だけにする
+	result, err := tx.Exec(`UPDATE workspace_invitations SET accepted_at = ? WHERE id = ? AND accepted_at IS NULL`,
+		formatOptionalAuthTime(invitation.AcceptedAt), invitation.ID)
+	if err != nil {
+		return err
+	}
+	if err := requireRow(result, repository.ErrWorkspaceInvitationNotFound); err != nil {
+		return err
+	}
+	if err := insertWorkspaceMember(tx, member); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertWorkspaceMember adds a member, keeping the role of one who already
+// belongs to the workspace
+func insertWorkspaceMember(tx *sql.Tx, member *entity.WorkspaceMember) error {
+	_, err := tx.Exec(`
+	INSERT INTO workspace_members (workspace_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)
+	ON CONFLICT (workspace_id, user_id) DO NOTHING`,
+		member.WorkspaceID, member.UserID, member.Role, formatAuthTime(member.JoinedAt))
+	return err
+}
+
+func scanWorkspaceMember(row rowScanner) (*entity.WorkspaceMember, error) {
+	var member entity.WorkspaceMember
+	var joinedAt string
+	if err := row.Scan(&member.WorkspaceID, &member.UserID, &member.Email, &member.Role, &joinedAt); err != nil {
+		return nil, err
+	}
+	member.JoinedAt, _ = time.Parse(time.RFC3339, joinedAt)
+	return &member, nil
+}
+
+func scanWorkspaceInvitation(row rowScanner) (*entity.WorkspaceInvitation, error) {
+	var invitation entity.WorkspaceInvitation
+	var createdAt, expiresAt string
+	var acceptedAt sql.NullString
+	err := row.Scan(&invitation.ID, &invitation.WorkspaceID, &invitation.WorkspaceName, &invitation.Email,
+		&invitation.Role, &invitation.InvitedBy, &createdAt, &expiresAt, &acceptedAt)
+	if err != nil {
+		return nil, err
+	}
+	invitation.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	invitation.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	invitation.AcceptedAt = parseOptionalAuthTime(acceptedAt)
+	return &invitation, nil
+}
+
+// requireRow returns notFound when a statement changed no rows
+func requireRow(result sql.Result, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
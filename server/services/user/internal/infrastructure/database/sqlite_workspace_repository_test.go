@@ -0,0 +1,130 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/database"
+)
+
+func TestWorkspaceRepository_Members(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_workspaces.db")
+	var repo repository.WorkspaceRepository = database.NewSQLiteWorkspaceRepository(userRepo)
+	owner, _ := entity.NewUser("owner-1", "owner@example.com", "password123")
+	userRepo.Create(owner)
+	workspace := entity.NewWorkspace("ws-1", "Team")
+	now := time.Now()
+
+	// Act
+	err := repo.Create(workspace, &entity.WorkspaceMember{WorkspaceID: "ws-1", UserID: "owner-1", Role: entity.WorkspaceRoleOwner, JoinedAt: now})
+
+	// Assert - 作成者がオーナーとして登録され、メールアドレス付きで取得できる
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+	member, err := repo.GetMember("ws-1", "owner-1")
+	if err != nil {
+		t.Fatalf("Failed to get member: %v", err)
+	}
+	if member.Role != entity.WorkspaceRoleOwner || member.Email != "owner@example.com" {
+		t.Errorf("Unexpected member %+v", member)
+	}
+	memberships, err := repo.ListByUserID("owner-1")
+	if err != nil || len(memberships) != 1 || memberships[0].Workspace.Name != "Team" || memberships[0].Role != entity.WorkspaceRoleOwner {
+		t.Fatalf("Expected the workspace to be listed, got %v (%v)", memberships, err)
+	}
+
+	// Act & Assert - 役割を変更できる
+	member.Role = entity.WorkspaceRoleViewer
+	if err := repo.UpdateMember(member); err != nil {
+		t.Fatalf("Failed to update member: %v", err)
+	}
+	if got, _ := repo.GetMember("ws-1", "owner-1"); got.Role != entity.WorkspaceRoleViewer {
+		t.Errorf("Expected viewer role, got %s", got.Role)
+	}
+
+	// Act & Assert - メンバーを外すと見つからなくなる
+	if err := repo.RemoveMember("ws-1", "owner-1"); err != nil {
+		t.Fatalf("Failed to remove member: %v", err)
+	}
+	if _, err := repo.GetMember("ws-1", "owner-1"); !errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		t.Errorf("Expected ErrWorkspaceMemberNotFound, got %v", err)
+	}
+	if err := repo.RemoveMember("ws-1", "owner-1"); !errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		t.Errorf("Expected ErrWorkspaceMemberNotFound, got %v", err)
+	}
+
+	// Act & Assert - 削除するとワークスペースは見つからなくなる
+	if err := repo.Delete("ws-1"); err != nil {
+		t.Fatalf("Failed to delete workspace: %v", err)
+	}
+	if _, err := repo.GetByID("ws-1"); !errors.Is(err, repository.ErrWorkspaceNotFound) {
+		t.Errorf("Expected ErrWorkspaceNotFound, got %v", err)
+	}
+}
+
+func TestWorkspaceRepository_Invitations(t *testing.T) {
+	// Arrange
+	userRepo := newTwoFactorTestRepository(t, "test_workspace_invitations.db")
+	repo := database.NewSQLiteWorkspaceRepository(userRepo)
+	now := time.Now()
+	repo.Create(entity.NewWorkspace("ws-1", "Team"), &entity.WorkspaceMember{WorkspaceID: "ws-1", UserID: "owner-1", Role: entity.WorkspaceRoleOwner, JoinedAt: now})
+	invitation := &entity.WorkspaceInvitation{
+		ID:          "inv-1",
+		WorkspaceID: "ws-1",
+		Email:       "invitee@example.com",
+		Role:        entity.WorkspaceRoleMember,
+		InvitedBy:   "owner-1",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	expired := *invitation
+	expired.ID = "inv-2"
+	expired.ExpiresAt = now.Add(-time.Minute)
+
+	// Act
+	if err := repo.CreateInvitation(invitation); err != nil {
+		t.Fatalf("Failed to create invitation: %v", err)
+	}
+	if err := repo.CreateInvitation(&expired); err != nil {
+		t.Fatalf("Failed to create invitation: %v", err)
+	}
+
+	// Assert - 期限内の招待だけがワークスペース名付きで一覧される
+	pending, err := repo.ListPendingInvitations("invitee@example.com", now)
+	if err != nil {
+		t.Fatalf("Failed to list invitations: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != "inv-1" || pending[0].WorkspaceName != "Team" {
+		t.Fatalf("Expected only the pending invitation, got %v", pending)
+	}
+
+	// Act & Assert - 受け入れるとメンバーが追加される
+	acceptedAt := now.Add(time.Minute)
+	invitation.AcceptedAt = &acceptedAt
+	member := &entity.WorkspaceMember{WorkspaceID: "ws-1", UserID: "invitee-1", Role: invitation.Role, JoinedAt: acceptedAt}
+	if err := repo.AcceptInvitation(invitation, member); err != nil {
+		t.Fatalf("Failed to accept invitation: %v", err)
+	}
+	if got, err := repo.GetMember("ws-1", "invitee-1"); err != nil || got.Role != entity.WorkspaceRoleMember {
+		t.Errorf("Expected the invitee to be a member, got %v (%v)", got, err)
+	}
+	if got, _ := repo.GetInvitation("inv-1"); got.AcceptedAt == nil {
+		t.Errorf("Expected the invitation to be marked accepted")
+	}
+	if pending, _ := repo.ListPendingInvitations("invitee@example.com", now); len(pending) != 0 {
+		t.Errorf("Expected no pending invitations, got %d", len(pending))
+	}
+
+	// Act & Assert - 同じ招待は二度受け入れられない
+	if err := repo.AcceptInvitation(invitation, member); !errors.Is(err, repository.ErrWorkspaceInvitationNotFound) {
+		t.Errorf("Expected ErrWorkspaceInvitationNotFound, got %v", err)
+	}
+	if members, _ := repo.ListMembers("ws-1"); len(members) != 2 {
+		t.Errorf("Expected 2 members, got %d", len(members))
+	}
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+func (s *UserServer) ScheduleAccountDeletion(ctx context.Context, req *pb.ScheduleAccountDeletionRequest) (*pb.ScheduleAccountDeletionResponse, error) {
+	user, err := s.accountDeletionService.Schedule(req.UserId, req.CurrentPassword)
+	if err != nil {
+		return &pb.ScheduleAccountDeletionResponse{
+			Error:              err.Error(),
+			IncorrectPassword:  errors.Is(err, service.ErrIncorrectPassword),
+			LastWorkspaceOwner: errors.Is(err, service.ErrLastWorkspaceOwner),
+		}, nil
+	}
+
+	// 通知が届かなくても削除の予定は確定しているので、エラーは記録だけする
+	if err := s.accountDeletionService.SendNotice(user); err != nil {
+		log.Printf("Failed to send account deletion notice to user %s: %v", user.ID, err)
+	}
+
+	return &pb.ScheduleAccountDeletionResponse{
+		User: s.entityToProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.CancelAccountDeletionResponse, error) {
+	user, err := s.accountDeletionService.Cancel(req.UserId)
+	if err != nil {
+		return &pb.CancelAccountDeletionResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CancelAccountDeletionResponse{
+		User: s.entityToProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) ListDueAccountDeletions(ctx context.Context, req *pb.ListDueAccountDeletionsRequest) (*pb.ListDueAccountDeletionsResponse, error) {
+	users, err := s.accountDeletionService.Due(int(req.Limit))
+	if err != nil {
+		return &pb.ListDueAccountDeletionsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbUsers []*pb.User
+	for _, user := range users {
+		pbUsers = append(pbUsers, s.entityToProtoUser(user))
+	}
+
+	return &pb.ListDueAccountDeletionsResponse{
+		Users: pbUsers,
+	}, nil
+}
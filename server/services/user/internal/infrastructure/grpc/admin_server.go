@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// The admin RPCs trust the caller to have checked that the requesting user
+// is an administrator; the BFF does so before calling them
+
+func (s *UserServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	page, err := s.adminService.ListUsers(req.Query, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return &pb.ListUsersResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbUsers []*pb.User
+	for _, user := range page.Users {
+		pbUsers = append(pbUsers, s.entityToProtoUser(user))
+	}
+
+	return &pb.ListUsersResponse{
+		Users:         pbUsers,
+		NextPageToken: page.NextPageToken,
+		TotalCount:    int32(page.Total),
+	}, nil
+}
+
+func (s *UserServer) SetUserDisabled(ctx context.Context, req *pb.SetUserDisabledRequest) (*pb.SetUserDisabledResponse, error) {
+	user, err := s.adminService.SetDisabled(req.ActorId, req.Id, req.Disabled)
+	if err != nil {
+		return &pb.SetUserDisabledResponse{
+			Error:     err.Error(),
+			LastAdmin: errors.Is(err, service.ErrLastAdmin),
+		}, nil
+	}
+
+	return &pb.SetUserDisabledResponse{
+		User: s.entityToProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	user, err := s.adminService.SetRole(req.ActorId, req.Id, req.Role)
+	if err != nil {
+		return &pb.SetUserRoleResponse{
+			Error:     err.Error(),
+			LastAdmin: errors.Is(err, service.ErrLastAdmin),
+		}, nil
+	}
+
+	return &pb.SetUserRoleResponse{
+		User: s.entityToProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) ForcePasswordReset(ctx context.Context, req *pb.ForcePasswordResetRequest) (*pb.ForcePasswordResetResponse, error) {
+	if err := s.adminService.ForcePasswordReset(req.Id); err != nil {
+		return &pb.ForcePasswordResetResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.ForcePasswordResetResponse{
+		Success: true,
+	}, nil
+}
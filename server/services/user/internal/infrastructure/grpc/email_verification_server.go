@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+)
+
+func (s *UserServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	user, err := s.emailVerificationService.VerifyEmail(req.Token)
+	if err != nil {
+		return &pb.VerifyEmailResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.VerifyEmailResponse{
+		User: s.entityToProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) ResendVerificationEmail(ctx context.Context, req *pb.ResendVerificationEmailRequest) (*pb.ResendVerificationEmailResponse, error) {
+	err := s.emailVerificationService.ResendVerification(req.Email)
+	if err != nil {
+		return &pb.ResendVerificationEmailResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.ResendVerificationEmailResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+)
+
+func (s *UserServer) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	var keys []*pb.JWK
+	for _, jwk := range s.keys.JWKs() {
+		keys = append(keys, &pb.JWK{
+			Kid: jwk.KeyID,
+			Kty: jwk.KeyType,
+			Alg: jwk.Algorithm,
+			N:   jwk.N,
+			E:   jwk.E,
+			Crv: jwk.Curve,
+			X:   jwk.X,
+		})
+	}
+
+	return &pb.GetJWKSResponse{
+		Keys: keys,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+)
+
+func (s *UserServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	err := s.passwordResetService.RequestPasswordReset(req.Email)
+	if err != nil {
+		return &pb.RequestPasswordResetResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.RequestPasswordResetResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *UserServer) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error) {
+	err := s.passwordResetService.ConfirmPasswordReset(req.Token, req.NewPassword)
+	if err != nil {
+		return &pb.ConfirmPasswordResetResponse{
+			Success:            false,
+			Error:              err.Error(),
+			PasswordViolations: passwordViolationsToProto(err),
+		}, nil
+	}
+
+	return &pb.ConfirmPasswordResetResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+func (s *UserServer) CreatePersonalAccessToken(ctx context.Context, req *pb.CreatePersonalAccessTokenRequest) (*pb.CreatePersonalAccessTokenResponse, error) {
+	var expiresAt *time.Time
+	if req.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return &pb.CreatePersonalAccessTokenResponse{
+				Error: "invalid expires_at: " + err.Error(),
+			}, nil
+		}
+		expiresAt = &t
+	}
+
+	token, secret, err := s.personalAccessTokenService.CreateToken(req.UserId, req.Name, req.Scopes, expiresAt)
+	if err != nil {
+		return &pb.CreatePersonalAccessTokenResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreatePersonalAccessTokenResponse{
+		Token:  s.entityToProtoPersonalAccessToken(token),
+		Secret: secret,
+	}, nil
+}
+
+func (s *UserServer) ListPersonalAccessTokens(ctx context.Context, req *pb.ListPersonalAccessTokensRequest) (*pb.ListPersonalAccessTokensResponse, error) {
+	tokens, err := s.personalAccessTokenService.ListTokens(req.UserId)
+	if err != nil {
+		return &pb.ListPersonalAccessTokensResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbTokens []*pb.PersonalAccessToken
+	for _, token := range tokens {
+		pbTokens = append(pbTokens, s.entityToProtoPersonalAccessToken(token))
+	}
+
+	return &pb.ListPersonalAccessTokensResponse{
+		Tokens: pbTokens,
+	}, nil
+}
+
+func (s *UserServer) RevokePersonalAccessToken(ctx context.Context, req *pb.RevokePersonalAccessTokenRequest) (*pb.RevokePersonalAccessTokenResponse, error) {
+	err := s.personalAccessTokenService.RevokeToken(req.UserId, req.Id)
+	if err != nil {
+		return &pb.RevokePersonalAccessTokenResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.RevokePersonalAccessTokenResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *UserServer) AuthenticatePersonalAccessToken(ctx context.Context, req *pb.AuthenticatePersonalAccessTokenRequest) (*pb.AuthenticatePersonalAccessTokenResponse, error) {
+	user, token, err := s.personalAccessTokenService.Authenticate(req.Secret)
+	if err != nil {
+		return &pb.AuthenticatePersonalAccessTokenResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.AuthenticatePersonalAccessTokenResponse{
+		User:  s.entityToProtoUser(user),
+		Token: s.entityToProtoPersonalAccessToken(token),
+	}, nil
+}
+
+func (s *UserServer) entityToProtoPersonalAccessToken(token *entity.PersonalAccessToken) *pb.PersonalAccessToken {
+	return &pb.PersonalAccessToken{
+		Id:         token.ID,
+		UserId:     token.UserID,
+		Name:       token.Name,
+		Hint:       token.Hint,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:  formatOptionalTime(token.ExpiresAt),
+		LastUsedAt: formatOptionalTime(token.LastUsedAt),
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+func (s *UserServer) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	prefs, err := s.preferencesService.GetPreferences(req.UserId)
+	if err != nil {
+		return &pb.GetPreferencesResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetPreferencesResponse{
+		Preferences: preferencesToProto(prefs),
+	}, nil
+}
+
+func (s *UserServer) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	prefs, err := s.preferencesService.UpdatePreferences(req.UserId, service.PreferencesUpdate{
+		Timezone:         req.Timezone,
+		Locale:           req.Locale,
+		WeekStart:        req.WeekStart,
+		DateFormat:       req.DateFormat,
+		DefaultSort:      req.DefaultSort,
+		DefaultSortOrder: req.DefaultSortOrder,
+		DefaultProjectID: req.DefaultProjectId,
+	})
+	if err != nil {
+		return &pb.UpdatePreferencesResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdatePreferencesResponse{
+		Preferences: preferencesToProto(prefs),
+	}, nil
+}
+
+func preferencesToProto(prefs *entity.Preferences) *pb.Preferences {
+	pbPrefs := &pb.Preferences{
+		Timezone:         prefs.Timezone,
+		Locale:           prefs.Locale,
+		WeekStart:        prefs.WeekStart,
+		DateFormat:       prefs.DateFormat,
+		DefaultSort:      prefs.DefaultSort,
+		DefaultSortOrder: prefs.DefaultSortOrder,
+		DefaultProjectId: prefs.DefaultProjectID,
+	}
+	// 既定値のままなら更新日時はない
+	if !prefs.UpdatedAt.IsZero() {
+		pbPrefs.UpdatedAt = prefs.UpdatedAt.Format(time.RFC3339)
+	}
+	return pbPrefs
+}
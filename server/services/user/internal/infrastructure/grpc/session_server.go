@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+func (s *UserServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	sessions, err := s.sessionService.ListSessions(req.UserId)
+	if err != nil {
+		return &pb.ListSessionsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbSessions []*pb.Session
+	for _, session := range sessions {
+		pbSessions = append(pbSessions, s.entityToProtoSession(session))
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: pbSessions,
+	}, nil
+}
+
+func (s *UserServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	err := s.sessionService.RevokeSession(req.Id, req.UserId)
+	if err != nil {
+		return &pb.RevokeSessionResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.RevokeSessionResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *UserServer) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	revoked, err := s.sessionService.RevokeAllSessions(req.UserId, req.ExceptSessionId)
+	if err != nil {
+		return &pb.RevokeAllSessionsResponse{
+			RevokedCount: int32(revoked),
+			Error:        err.Error(),
+		}, nil
+	}
+
+	return &pb.RevokeAllSessionsResponse{
+		RevokedCount: int32(revoked),
+	}, nil
+}
+
+func (s *UserServer) CheckSession(ctx context.Context, req *pb.CheckSessionRequest) (*pb.CheckSessionResponse, error) {
+	active, err := s.sessionService.CheckSession(req.Id, req.IpAddress)
+	if err != nil {
+		return &pb.CheckSessionResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CheckSessionResponse{
+		Active: active,
+	}, nil
+}
+
+func (s *UserServer) entityToProtoSession(session *entity.Session) *pb.Session {
+	return &pb.Session{
+		Id:         session.ID,
+		UserId:     session.UserID,
+		Device:     session.Device,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+)
+
+func (s *UserServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	enrollment, err := s.twoFactorService.Enroll(req.UserId)
+	if err != nil {
+		return &pb.EnrollTOTPResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}, nil
+}
+
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	codes, err := s.twoFactorService.ConfirmEnrollment(req.UserId, req.Code)
+	if err != nil {
+		return &pb.ConfirmTOTPResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (s *UserServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	err := s.twoFactorService.Disable(req.UserId, req.Code)
+	if err != nil {
+		return &pb.DisableTOTPResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.DisableTOTPResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *UserServer) GetTwoFactorStatus(ctx context.Context, req *pb.GetTwoFactorStatusRequest) (*pb.GetTwoFactorStatusResponse, error) {
+	status, err := s.twoFactorService.Status(req.UserId)
+	if err != nil {
+		return &pb.GetTwoFactorStatusResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetTwoFactorStatusResponse{
+		Enabled:                status.Enabled,
+		RecoveryCodesRemaining: int32(status.RecoveryCodesRemaining),
+	}, nil
+}
+
+func (s *UserServer) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (*pb.VerifyTwoFactorResponse, error) {
+	user, tokens, err := s.twoFactorService.VerifyChallenge(req.TwoFactorToken, req.Code)
+	if err != nil {
+		return &pb.VerifyTwoFactorResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.VerifyTwoFactorResponse{
+		User:                  s.entityToProtoUser(user),
+		Token:                 tokens.AccessToken,
+		RefreshToken:          tokens.RefreshToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt.Format(time.RFC3339),
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format(time.RFC3339),
+	}, nil
+}
@@ -2,20 +2,50 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"log"
 	"time"
 
+	"google.golang.org/grpc/metadata"
+
 	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/auth"
 )
 
 type UserServer struct {
 	pb.UnimplementedUserServiceServer
-	userService *service.UserService
+	userService                *service.UserService
+	tokenService               *service.TokenService
+	sessionService             *service.SessionService
+	passwordResetService       *service.PasswordResetService
+	emailVerificationService   *service.EmailVerificationService
+	twoFactorService           *service.TwoFactorService
+	loginThrottle              *service.LoginThrottle
+	personalAccessTokenService *service.PersonalAccessTokenService
+	adminService               *service.AdminService
+	accountDeletionService     *service.AccountDeletionService
+	preferencesService         *service.PreferencesService
+	workspaceService           *service.WorkspaceService
+	keys                       *auth.KeySet
 }
 
-func NewUserServer(userService *service.UserService) *UserServer {
+func NewUserServer(userService *service.UserService, tokenService *service.TokenService, sessionService *service.SessionService, passwordResetService *service.PasswordResetService, emailVerificationService *service.EmailVerificationService, twoFactorService *service.TwoFactorService, loginThrottle *service.LoginThrottle, personalAccessTokenService *service.PersonalAccessTokenService, adminService *service.AdminService, accountDeletionService *service.AccountDeletionService, preferencesService *service.PreferencesService, workspaceService *service.WorkspaceService, keys *auth.KeySet) *UserServer {
 	return &UserServer{
-		userService: userService,
+		userService:                userService,
+		tokenService:               tokenService,
+		sessionService:             sessionService,
+		passwordResetService:       passwordResetService,
+		emailVerificationService:   emailVerificationService,
+		twoFactorService:           twoFactorService,
+		loginThrottle:              loginThrottle,
+		personalAccessTokenService: personalAccessTokenService,
+		adminService:               adminService,
+		accountDeletionService:     accountDeletionService,
+		preferencesService:         preferencesService,
+		workspaceService:           workspaceService,
+		keys:                       keys,
 	}
 }
 
@@ -23,18 +53,18 @@ func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	user, err := s.userService.CreateUser(req.Email, req.Password)
 	if err != nil {
 		return &pb.CreateUserResponse{
-			Error: err.Error(),
+			Error:              err.Error(),
+			PasswordViolations: passwordViolationsToProto(err),
 		}, nil
 	}
 
+	// 送信に失敗してもアカウントは作成済みなので、再送できるようにエラーは記録だけする
+	if err := s.emailVerificationService.SendVerification(user); err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+	}
+
 	return &pb.CreateUserResponse{
-		User: &pb.User{
-			Id:           user.ID,
-			Email:        user.Email,
-			PasswordHash: user.PasswordHash,
-			CreatedAt:    user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
-		},
+		User: s.entityToProtoUser(user),
 	}, nil
 }
 
@@ -47,68 +77,197 @@ func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.G
 	}
 
 	return &pb.GetUserResponse{
-		User: &pb.User{
-			Id:           user.ID,
-			Email:        user.Email,
-			PasswordHash: user.PasswordHash,
-			CreatedAt:    user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
-		},
+		User: s.entityToProtoUser(user),
 	}, nil
 }
 
 func (s *UserServer) AuthenticateUser(ctx context.Context, req *pb.AuthenticateUserRequest) (*pb.AuthenticateUserResponse, error) {
+	clientIP := clientIPFromContext(ctx, req.IpAddress)
+	if err := s.loginThrottle.Check(req.Email, clientIP); err != nil {
+		var blocked *service.LoginBlockedError
+		if errors.As(err, &blocked) {
+			return &pb.AuthenticateUserResponse{
+				Error:         err.Error(),
+				RetryAt:       blocked.RetryAt.Format(time.RFC3339),
+				AccountLocked: errors.Is(err, service.ErrAccountLocked),
+			}, nil
+		}
+		return &pb.AuthenticateUserResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
 	user, err := s.userService.AuthenticateUser(req.Email, req.Password)
+	if err != nil {
+		if err := s.loginThrottle.RecordFailure(req.Email, clientIP); err != nil {
+			log.Printf("Failed to record failed login: %v", err)
+		}
+		return &pb.AuthenticateUserResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	if user.Disabled() {
+		return &pb.AuthenticateUserResponse{
+			Error:           service.ErrAccountDisabled.Error(),
+			AccountDisabled: true,
+		}, nil
+	}
+
+	if err := s.emailVerificationService.CheckLogin(user); err != nil {
+		return &pb.AuthenticateUserResponse{
+			Error:            err.Error(),
+			EmailNotVerified: true,
+		}, nil
+	}
+
+	// 二要素認証が有効なら、セッションの代わりにチャレンジを返す
+	required, err := s.twoFactorService.Required(user.ID)
 	if err != nil {
 		return &pb.AuthenticateUserResponse{
 			Error: err.Error(),
 		}, nil
 	}
+	if required {
+		challenge, expiresAt, err := s.twoFactorService.StartChallenge(user, req.Device, req.IpAddress)
+		if err != nil {
+			return &pb.AuthenticateUserResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		return &pb.AuthenticateUserResponse{
+			TwoFactorRequired:  true,
+			TwoFactorToken:     challenge,
+			TwoFactorExpiresAt: expiresAt.Format(time.RFC3339),
+		}, nil
+	}
 
-	// TODO: Generate JWT token
-	token := "jwt-token-placeholder"
+	tokens, err := s.tokenService.IssueTokens(user, req.Device, req.IpAddress)
+	if err != nil {
+		return &pb.AuthenticateUserResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	// 二要素認証があるときは、その確認が済むまで失敗回数を残す
+	if err := s.loginThrottle.RecordSuccess(req.Email); err != nil {
+		log.Printf("Failed to reset failed logins of user %s: %v", user.ID, err)
+	}
 
 	return &pb.AuthenticateUserResponse{
-		User: &pb.User{
-			Id:           user.ID,
-			Email:        user.Email,
-			PasswordHash: user.PasswordHash,
-			CreatedAt:    user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
-		},
-		Token: token,
+		User:                  s.entityToProtoUser(user),
+		Token:                 tokens.AccessToken,
+		RefreshToken:          tokens.RefreshToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt.Format(time.RFC3339),
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *UserServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	user, tokens, err := s.tokenService.Refresh(req.RefreshToken, req.IpAddress)
+	if err != nil {
+		return &pb.RefreshTokenResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.RefreshTokenResponse{
+		User:                  s.entityToProtoUser(user),
+		Token:                 tokens.AccessToken,
+		RefreshToken:          tokens.RefreshToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt.Format(time.RFC3339),
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format(time.RFC3339),
 	}, nil
 }
 
 func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	user, err := s.userService.UpdateUser(req.Id, req.Email, req.Password)
+	var user *entity.User
+	var err error
+	if req.CurrentPassword != "" {
+		user, err = s.userService.ChangeCredentials(req.Id, req.CurrentPassword, req.Email, req.Password)
+	} else {
+		user, err = s.userService.UpdateUser(req.Id, req.Email, req.Password)
+	}
 	if err != nil {
 		return &pb.UpdateUserResponse{
-			Error: err.Error(),
+			Error:              err.Error(),
+			PasswordViolations: passwordViolationsToProto(err),
+			IncorrectPassword:  errors.Is(err, service.ErrIncorrectPassword),
 		}, nil
 	}
 
+	// 新しいアドレスは確認リンクが開かれたときに有効になる
+	if req.Email != "" && user.PendingEmail != "" {
+		if err := s.emailVerificationService.SendVerification(user); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+
 	return &pb.UpdateUserResponse{
-		User: &pb.User{
-			Id:           user.ID,
-			Email:        user.Email,
-			PasswordHash: user.PasswordHash,
-			CreatedAt:    user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    user.UpdatedAt.Format(time.RFC3339),
-		},
+		User: s.entityToProtoUser(user),
 	}, nil
 }
 
 func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
-	err := s.userService.DeleteUser(req.Id)
+	deleted, err := s.accountDeletionService.Delete(req.Id)
 	if err != nil {
 		return &pb.DeleteUserResponse{
-			Success: false,
-			Error:   err.Error(),
+			Success:             false,
+			Error:               err.Error(),
+			DeletedWorkspaceIds: deleted,
 		}, nil
 	}
 
 	return &pb.DeleteUserResponse{
-		Success: true,
+		Success:             true,
+		DeletedWorkspaceIds: deleted,
 	}, nil
 }
+
+// passwordViolationsToProto lists the broken password rules when err is a
+// *service.PasswordPolicyError, and nothing otherwise
+func passwordViolationsToProto(err error) []*pb.PasswordViolation {
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+
+	violations := make([]*pb.PasswordViolation, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		violations[i] = &pb.PasswordViolation{
+			Code:    violation.Code,
+			Message: violation.Message,
+		}
+	}
+	return violations
+}
+
+// clientIPMetadataKey carries the address of the end user; the BFF sets it
+// because the peer address of the call is the BFF itself
+const clientIPMetadataKey = "x-client-ip"
+
+// clientIPFromContext returns the client address from the call metadata, or
+// fallback when the caller didn't send one
+func clientIPFromContext(ctx context.Context, fallback string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return fallback
+	}
+	if values := md.Get(clientIPMetadataKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
+
+func (s *UserServer) entityToProtoUser(user *entity.User) *pb.User {
+	return &pb.User{
+		Id:                  user.ID,
+		Email:               user.Email,
+		PasswordHash:        user.PasswordHash,
+		CreatedAt:           user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           user.UpdatedAt.Format(time.RFC3339),
+		EmailVerified:       user.EmailVerified(),
+		PendingEmail:        user.PendingEmail,
+		Role:                user.Role,
+		DisabledAt:          formatOptionalTime(user.DisabledAt),
+		DeletionScheduledAt: formatOptionalTime(user.DeletionScheduledAt),
+	}
+}
@@ -2,12 +2,17 @@ package grpc
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	pb "github.com/tadasy/mytodo202507/proto"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/auth"
 )
 
 // ========================================
@@ -60,6 +65,54 @@ func (m *DetailedMockUserRepository) GetByEmail(email string) (*entity.User, err
 	return nil, repository.ErrUserNotFound
 }
 
+func (m *DetailedMockUserRepository) List(query repository.UserQuery) (*repository.UserPage, error) {
+	var emails []string
+	for email := range m.emails {
+		if strings.Contains(strings.ToLower(email), strings.ToLower(query.Search)) {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	page := &repository.UserPage{Total: len(emails)}
+	for _, email := range emails {
+		if email <= query.AfterEmail {
+			continue
+		}
+		if query.Limit > 0 && len(page.Users) == query.Limit {
+			break
+		}
+		page.Users = append(page.Users, m.emails[email])
+	}
+	return page, nil
+}
+
+func (m *DetailedMockUserRepository) ListDeletionDue(before time.Time, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range m.users {
+		if user.DeletionScheduledAt != nil && user.DeletionScheduledAt.Before(before) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].DeletionScheduledAt.Before(*users[j].DeletionScheduledAt)
+	})
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (m *DetailedMockUserRepository) CountActiveAdmins() (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.IsAdmin() && !user.Disabled() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *DetailedMockUserRepository) Update(user *entity.User) error {
 	m.updateCalls = append(m.updateCalls, user.ID)
 	if _, exists := m.users[user.ID]; !exists {
@@ -101,11 +154,307 @@ func (m *DetailedMockUserRepository) ClearCalls() {
 	m.deleteCalls = m.deleteCalls[:0]
 }
 
+// DetailedMockRefreshTokenRepository は保存されたリフレッシュトークンを検査できるモック
+type DetailedMockRefreshTokenRepository struct {
+	tokens map[string]*entity.RefreshToken
+}
+
+func (m *DetailedMockRefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *DetailedMockRefreshTokenRepository) GetByHash(hash string) (*entity.RefreshToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrRefreshTokenNotFound
+}
+
+func (m *DetailedMockRefreshTokenRepository) Rotate(old, replacement *entity.RefreshToken, at time.Time) error {
+	if old.RevokedAt != nil {
+		return repository.ErrRefreshTokenRevoked
+	}
+	old.RevokedAt = &at
+	m.tokens[replacement.TokenHash] = replacement
+	return nil
+}
+
+func (m *DetailedMockRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &at
+		}
+	}
+	return nil
+}
+
+// testKeys は署名と検証に使うテスト用の鍵
+var testKeys = func() *auth.KeySet {
+	key, err := auth.GenerateEd25519Key("test-key")
+	if err != nil {
+		panic(err)
+	}
+	keys, _ := auth.NewKeySet(key)
+	return keys
+}()
+
+// DetailedMockSessionRepository はセッションの状態を検査できるモック
+type DetailedMockSessionRepository struct {
+	sessions map[string]*entity.Session
+}
+
+func (m *DetailedMockSessionRepository) Create(session *entity.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *DetailedMockSessionRepository) GetByID(id string) (*entity.Session, error) {
+	if session, exists := m.sessions[id]; exists {
+		return session, nil
+	}
+	return nil, repository.ErrSessionNotFound
+}
+
+func (m *DetailedMockSessionRepository) ListActiveByUserID(userID string) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.Active() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *DetailedMockSessionRepository) Touch(id, ipAddress string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	session.LastSeenAt = at
+	if ipAddress != "" {
+		session.IPAddress = ipAddress
+	}
+	return nil
+}
+
+func (m *DetailedMockSessionRepository) Revoke(id string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	if session.RevokedAt == nil {
+		session.RevokedAt = &at
+	}
+	return nil
+}
+
+// DetailedMockEmailVerificationTokenRepository は確認トークンを記録するだけのモック
+type DetailedMockEmailVerificationTokenRepository struct {
+	tokens []*entity.EmailVerificationToken
+}
+
+func (m *DetailedMockEmailVerificationTokenRepository) Create(token *entity.EmailVerificationToken) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *DetailedMockEmailVerificationTokenRepository) GetByHash(hash string) (*entity.EmailVerificationToken, error) {
+	return nil, repository.ErrEmailVerificationTokenNotFound
+}
+
+func (m *DetailedMockEmailVerificationTokenRepository) MarkUsed(id string, at time.Time) error {
+	return repository.ErrEmailVerificationTokenNotFound
+}
+
+// discardMailer は送信せずに破棄する
+type discardMailer struct{}
+
+func (discardMailer) Send(msg service.MailMessage) error {
+	return nil
+}
+
+// DetailedMockTOTPCredentialRepository はTOTP秘密鍵を保持するモック
+type DetailedMockTOTPCredentialRepository struct {
+	credentials map[string]*entity.TOTPCredential
+}
+
+func (m *DetailedMockTOTPCredentialRepository) GetByUserID(userID string) (*entity.TOTPCredential, error) {
+	if credential, exists := m.credentials[userID]; exists {
+		return credential, nil
+	}
+	return nil, repository.ErrTOTPCredentialNotFound
+}
+
+func (m *DetailedMockTOTPCredentialRepository) Save(credential *entity.TOTPCredential) error {
+	m.credentials[credential.UserID] = credential
+	return nil
+}
+
+func (m *DetailedMockTOTPCredentialRepository) UseStep(userID string, step int64) error {
+	return repository.ErrTOTPStepUsed
+}
+
+func (m *DetailedMockTOTPCredentialRepository) DeleteByUserID(userID string) error {
+	delete(m.credentials, userID)
+	return nil
+}
+
+// DetailedMockRecoveryCodeRepository はリカバリーコードを持たないモック
+type DetailedMockRecoveryCodeRepository struct{}
+
+func (DetailedMockRecoveryCodeRepository) Replace(userID string, codes []*entity.RecoveryCode) error {
+	return nil
+}
+
+func (DetailedMockRecoveryCodeRepository) Use(userID, hash string, at time.Time) error {
+	return repository.ErrRecoveryCodeNotFound
+}
+
+func (DetailedMockRecoveryCodeRepository) CountUnused(userID string) (int, error) {
+	return 0, nil
+}
+
+func (DetailedMockRecoveryCodeRepository) DeleteByUserID(userID string) error {
+	return nil
+}
+
+// DetailedMockTwoFactorChallengeRepository は作成されたチャレンジを記録するモック
+type DetailedMockTwoFactorChallengeRepository struct {
+	challenges []*entity.TwoFactorChallenge
+}
+
+func (m *DetailedMockTwoFactorChallengeRepository) Create(challenge *entity.TwoFactorChallenge) error {
+	m.challenges = append(m.challenges, challenge)
+	return nil
+}
+
+func (m *DetailedMockTwoFactorChallengeRepository) GetByHash(hash string) (*entity.TwoFactorChallenge, error) {
+	for _, challenge := range m.challenges {
+		if challenge.TokenHash == hash {
+			return challenge, nil
+		}
+	}
+	return nil, repository.ErrTwoFactorChallengeNotFound
+}
+
+func (m *DetailedMockTwoFactorChallengeRepository) RecordFailure(id string) error {
+	return nil
+}
+
+func (m *DetailedMockTwoFactorChallengeRepository) MarkUsed(id string, at time.Time) error {
+	return nil
+}
+
+// DetailedMockLoginAttemptRepository はログイン失敗を数えないモック
+type DetailedMockLoginAttemptRepository struct{}
+
+func (m DetailedMockLoginAttemptRepository) Get(key string) (*entity.LoginAttempts, error) {
+	return nil, repository.ErrLoginAttemptsNotFound
+}
+
+func (m DetailedMockLoginAttemptRepository) RecordFailure(key string, at, resetBefore time.Time) (*entity.LoginAttempts, error) {
+	return &entity.LoginAttempts{Key: key, Failures: 1, LastFailureAt: at}, nil
+}
+
+func (m DetailedMockLoginAttemptRepository) Lock(key string, until time.Time) error {
+	return nil
+}
+
+func (m DetailedMockLoginAttemptRepository) Delete(key string) error {
+	return nil
+}
+
+// DetailedMockPersonalAccessTokenRepository はアクセストークンを保持するモック
+type DetailedMockPersonalAccessTokenRepository struct {
+	tokens map[string]*entity.PersonalAccessToken
+}
+
+func (m *DetailedMockPersonalAccessTokenRepository) Create(token *entity.PersonalAccessToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *DetailedMockPersonalAccessTokenRepository) GetByHash(hash string) (*entity.PersonalAccessToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrPersonalAccessTokenNotFound
+}
+
+func (m *DetailedMockPersonalAccessTokenRepository) ListByUserID(userID string) ([]*entity.PersonalAccessToken, error) {
+	var tokens []*entity.PersonalAccessToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *DetailedMockPersonalAccessTokenRepository) Revoke(userID, id string, at time.Time) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UserID != userID {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	token.RevokedAt = &at
+	return nil
+}
+
+func (m *DetailedMockPersonalAccessTokenRepository) Touch(id string, at time.Time) error {
+	if token, exists := m.tokens[id]; exists {
+		token.LastUsedAt = &at
+		return nil
+	}
+	return repository.ErrPersonalAccessTokenNotFound
+}
+
+func newPersonalAccessTokenService(userRepo repository.UserRepository) *service.PersonalAccessTokenService {
+	return service.NewPersonalAccessTokenService(userRepo,
+		&DetailedMockPersonalAccessTokenRepository{tokens: make(map[string]*entity.PersonalAccessToken)})
+}
+
+func newLoginThrottle() *service.LoginThrottle {
+	return service.NewLoginThrottle(DetailedMockLoginAttemptRepository{}, service.DefaultLoginThrottleConfig())
+}
+
+func newTwoFactorService(userRepo repository.UserRepository, tokenService *service.TokenService, totpRepo *DetailedMockTOTPCredentialRepository, challengeRepo *DetailedMockTwoFactorChallengeRepository) *service.TwoFactorService {
+	return service.NewTwoFactorService(userRepo, totpRepo, DetailedMockRecoveryCodeRepository{}, challengeRepo, tokenService, newLoginThrottle(), "MyTodo")
+}
+
+func newEmailVerificationService(userRepo repository.UserRepository, policy service.EmailVerificationPolicy) *service.EmailVerificationService {
+	return service.NewEmailVerificationService(userRepo, &DetailedMockEmailVerificationTokenRepository{},
+		discardMailer{}, "http://localhost:5173/verify-email", policy)
+}
+
+func newUserServer(userService *service.UserService, userRepo repository.UserRepository) *UserServer {
+	sessionRepo := &DetailedMockSessionRepository{sessions: make(map[string]*entity.Session)}
+	refreshRepo := &DetailedMockRefreshTokenRepository{tokens: make(map[string]*entity.RefreshToken)}
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, auth.NewSigner(testKeys))
+	return NewUserServer(userService,
+		tokenService,
+		service.NewSessionService(sessionRepo, refreshRepo),
+		nil,
+		newEmailVerificationService(userRepo, service.EmailVerificationPolicyNone),
+		newTwoFactorService(userRepo, tokenService,
+			&DetailedMockTOTPCredentialRepository{credentials: make(map[string]*entity.TOTPCredential)},
+			&DetailedMockTwoFactorChallengeRepository{}),
+		newLoginThrottle(),
+		newPersonalAccessTokenService(userRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		testKeys)
+}
+
 func TestUserServer_CreateUser_ServiceIntegration(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	req := &pb.CreateUserRequest{
@@ -143,7 +492,7 @@ func TestUserServer_GetUser_CallSequence(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	// Create user first
@@ -179,7 +528,7 @@ func TestUserServer_AuthenticateUser_CallSequence(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	email := "test@example.com"
@@ -220,7 +569,7 @@ func TestUserServer_ServiceDependency(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 
 	// Act & Assert - userServiceフィールドがプライベートなので直接アクセステストは不可
 	// 代わりに動作確認でDependency Injectionをテスト
@@ -242,7 +591,7 @@ func TestUserServer_ServiceDependency(t *testing.T) {
 	// 異なるサービスインスタンスでテスト
 	repo2 := NewDetailedMockUserRepository()
 	service2 := service.NewUserService(repo2)
-	server2 := NewUserServer(service2)
+	server2 := newUserServer(service2, repo2)
 	
 	// 最初のサーバーで作ったユーザーは2つ目のサーバーでは見えない
 	getReq := &pb.GetUserRequest{Id: resp.User.Id}
@@ -259,7 +608,7 @@ func TestUserServer_ErrorHandling(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 
 	// Act & Assert - Service層のエラーがgRPC応答に正しく変換されることを確認
@@ -281,31 +630,143 @@ func TestUserServer_ErrorHandling(t *testing.T) {
 	}
 }
 
-func TestUserServer_TokenPlaceholder(t *testing.T) {
+func TestUserServer_IssuesSignedTokens(t *testing.T) {
 	// Arrange
 	mockRepo := NewDetailedMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := NewUserServer(userService)
+	sessionRepo := &DetailedMockSessionRepository{sessions: make(map[string]*entity.Session)}
+	refreshRepo := &DetailedMockRefreshTokenRepository{tokens: make(map[string]*entity.RefreshToken)}
+	tokenService := service.NewTokenService(mockRepo, sessionRepo, refreshRepo, auth.NewSigner(testKeys))
+	server := NewUserServer(userService,
+		tokenService,
+		service.NewSessionService(sessionRepo, refreshRepo),
+		nil,
+		newEmailVerificationService(mockRepo, service.EmailVerificationPolicyNone),
+		newTwoFactorService(mockRepo, tokenService,
+			&DetailedMockTOTPCredentialRepository{credentials: make(map[string]*entity.TOTPCredential)},
+			&DetailedMockTwoFactorChallengeRepository{}),
+		newLoginThrottle(),
+		newPersonalAccessTokenService(mockRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		testKeys)
 	ctx := context.Background()
-	
+
 	email := "test@example.com"
 	password := "password123"
-	userService.CreateUser(email, password)
-	
+	user, _ := userService.CreateUser(email, password)
+
 	req := &pb.AuthenticateUserRequest{
-		Email:    email,
-		Password: password,
+		Email:     email,
+		Password:  password,
+		Device:    "Mozilla/5.0",
+		IpAddress: "203.0.113.7",
 	}
 
 	// Act
 	resp, err := server.AuthenticateUser(ctx, req)
 
-	// Assert - 現在の実装ではプレースホルダーが返される
+	// Assert - アクセストークンは現在の鍵で署名され、kidで鍵を示す
+	if err != nil || resp.Error != "" {
+		t.Fatalf("AuthenticateUser should succeed: %v %s", err, resp.Error)
+	}
+	claims := &auth.Claims{}
+	token, err := jwt.ParseWithClaims(resp.Token, claims, func(token *jwt.Token) (interface{}, error) {
+		return testKeys.Current().PublicKey(), nil
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
 	if err != nil {
-		t.Errorf("AuthenticateUser should not return gRPC error: %v", err)
+		t.Fatalf("Token should be a valid JWT: %v", err)
+	}
+	if token.Header["kid"] != "test-key" {
+		t.Errorf("Expected kid test-key, got %v", token.Header["kid"])
+	}
+	if claims.UserID != user.ID || claims.Email != email || claims.Subject != user.ID || claims.EmailVerified {
+		t.Errorf("Unexpected claims: %+v", claims)
+	}
+	// jtiはサインインで作られたセッション
+	session, exists := sessionRepo.sessions[claims.ID]
+	if !exists {
+		t.Fatalf("jti %q should identify the new session", claims.ID)
+	}
+	if session.Device != "Mozilla/5.0" || session.IPAddress != "203.0.113.7" {
+		t.Errorf("Session should record the device and address, got %+v", session)
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, resp.ExpiresAt)
+	if !claims.ExpiresAt.Time.Equal(expiresAt) {
+		t.Errorf("expires_at %s should match the exp claim %s", resp.ExpiresAt, claims.ExpiresAt.Time)
+	}
+
+	// Assert - リフレッシュトークンはハッシュだけが保存される
+	if len(refreshRepo.tokens) != 1 {
+		t.Fatalf("Expected 1 stored refresh token, got %d", len(refreshRepo.tokens))
+	}
+	for hash, stored := range refreshRepo.tokens {
+		if hash == resp.RefreshToken || hash != entity.HashRefreshToken(resp.RefreshToken) {
+			t.Errorf("Stored hash should be the SHA-256 of the refresh token")
+		}
+		if stored.UserID != user.ID || stored.FamilyID != claims.ID {
+			t.Errorf("Refresh token should belong to the user's session")
+		}
+	}
+}
+
+func TestUserServer_AuthenticateUser_TwoFactorChallenge(t *testing.T) {
+	// Arrange - 確認済みのTOTP秘密鍵を持つユーザー
+	mockRepo := NewDetailedMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	sessionRepo := &DetailedMockSessionRepository{sessions: make(map[string]*entity.Session)}
+	refreshRepo := &DetailedMockRefreshTokenRepository{tokens: make(map[string]*entity.RefreshToken)}
+	totpRepo := &DetailedMockTOTPCredentialRepository{credentials: make(map[string]*entity.TOTPCredential)}
+	challengeRepo := &DetailedMockTwoFactorChallengeRepository{}
+	tokenService := service.NewTokenService(mockRepo, sessionRepo, refreshRepo, auth.NewSigner(testKeys))
+	server := NewUserServer(userService,
+		tokenService,
+		service.NewSessionService(sessionRepo, refreshRepo),
+		nil,
+		newEmailVerificationService(mockRepo, service.EmailVerificationPolicyNone),
+		newTwoFactorService(mockRepo, tokenService, totpRepo, challengeRepo),
+		newLoginThrottle(),
+		newPersonalAccessTokenService(mockRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		testKeys)
+
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	credential, _ := entity.NewTOTPCredential(user.ID)
+	confirmedAt := time.Now()
+	credential.ConfirmedAt = &confirmedAt
+	totpRepo.Save(credential)
+
+	// Act
+	resp, err := server.AuthenticateUser(context.Background(), &pb.AuthenticateUserRequest{
+		Email:     "test@example.com",
+		Password:  "password123",
+		Device:    "Mozilla/5.0",
+		IpAddress: "203.0.113.7",
+	})
+
+	// Assert - トークンは発行されず、端末情報を持つチャレンジが作られる
+	if err != nil || resp.Error != "" {
+		t.Fatalf("AuthenticateUser should succeed: %v %s", err, resp.Error)
+	}
+	if !resp.TwoFactorRequired || resp.TwoFactorToken == "" || resp.Token != "" || resp.RefreshToken != "" {
+		t.Errorf("Expected a two-factor challenge instead of tokens: %+v", resp)
+	}
+	if len(sessionRepo.sessions) != 0 {
+		t.Errorf("No session should start before the second factor, got %d", len(sessionRepo.sessions))
+	}
+	if len(challengeRepo.challenges) != 1 {
+		t.Fatalf("Expected 1 challenge, got %d", len(challengeRepo.challenges))
+	}
+	challenge := challengeRepo.challenges[0]
+	if challenge.TokenHash != entity.HashTwoFactorChallengeToken(resp.TwoFactorToken) {
+		t.Errorf("Only the hash of the challenge token should be stored")
 	}
-	if resp.Token != "jwt-token-placeholder" {
-		t.Errorf("Expected placeholder token, got %s", resp.Token)
+	if challenge.Device != "Mozilla/5.0" || challenge.IPAddress != "203.0.113.7" {
+		t.Errorf("Challenge should remember the device: %+v", challenge)
 	}
-	// TODO: 実際のJWT実装時にこのテストを更新
 }
@@ -2,6 +2,9 @@ package grpc_test
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,7 +12,9 @@ import (
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/repository"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/auth"
 	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // ========================================
@@ -51,6 +56,54 @@ func (m *MockUserRepository) GetByEmail(email string) (*entity.User, error) {
 	return nil, repository.ErrUserNotFound
 }
 
+func (m *MockUserRepository) List(query repository.UserQuery) (*repository.UserPage, error) {
+	var emails []string
+	for email := range m.emails {
+		if strings.Contains(strings.ToLower(email), strings.ToLower(query.Search)) {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	page := &repository.UserPage{Total: len(emails)}
+	for _, email := range emails {
+		if email <= query.AfterEmail {
+			continue
+		}
+		if query.Limit > 0 && len(page.Users) == query.Limit {
+			break
+		}
+		page.Users = append(page.Users, m.emails[email])
+	}
+	return page, nil
+}
+
+func (m *MockUserRepository) ListDeletionDue(before time.Time, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range m.users {
+		if user.DeletionScheduledAt != nil && user.DeletionScheduledAt.Before(before) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].DeletionScheduledAt.Before(*users[j].DeletionScheduledAt)
+	})
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (m *MockUserRepository) CountActiveAdmins() (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.IsAdmin() && !user.Disabled() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *MockUserRepository) Update(user *entity.User) error {
 	if _, exists := m.users[user.ID]; !exists {
 		return repository.ErrUserNotFound
@@ -78,11 +131,529 @@ func (m *MockUserRepository) Delete(id string) error {
 	return repository.ErrUserNotFound
 }
 
+// MockRefreshTokenRepository は外部振る舞いテスト用のリフレッシュトークンのモック
+type MockRefreshTokenRepository struct {
+	tokens map[string]*entity.RefreshToken
+}
+
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{
+		tokens: make(map[string]*entity.RefreshToken),
+	}
+}
+
+func (m *MockRefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(hash string) (*entity.RefreshToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrRefreshTokenNotFound
+}
+
+func (m *MockRefreshTokenRepository) Rotate(old, replacement *entity.RefreshToken, at time.Time) error {
+	if old.RevokedAt != nil {
+		return repository.ErrRefreshTokenRevoked
+	}
+	old.RevokedAt = &at
+	m.tokens[replacement.TokenHash] = replacement
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &at
+		}
+	}
+	return nil
+}
+
+// MockSessionRepository は外部振る舞いテスト用のセッションのモック
+type MockSessionRepository struct {
+	sessions map[string]*entity.Session
+}
+
+func (m *MockSessionRepository) Create(session *entity.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *MockSessionRepository) GetByID(id string) (*entity.Session, error) {
+	if session, exists := m.sessions[id]; exists {
+		return session, nil
+	}
+	return nil, repository.ErrSessionNotFound
+}
+
+func (m *MockSessionRepository) ListActiveByUserID(userID string) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.Active() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *MockSessionRepository) Touch(id, ipAddress string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	session.LastSeenAt = at
+	if ipAddress != "" {
+		session.IPAddress = ipAddress
+	}
+	return nil
+}
+
+func (m *MockSessionRepository) Revoke(id string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return repository.ErrSessionNotFound
+	}
+	if session.RevokedAt == nil {
+		session.RevokedAt = &at
+	}
+	return nil
+}
+
+// MockPasswordResetTokenRepository は外部振る舞いテスト用のリセットトークンのモック
+type MockPasswordResetTokenRepository struct {
+	tokens map[string]*entity.PasswordResetToken
+}
+
+func (m *MockPasswordResetTokenRepository) Create(token *entity.PasswordResetToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *MockPasswordResetTokenRepository) GetByHash(hash string) (*entity.PasswordResetToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrPasswordResetTokenNotFound
+}
+
+func (m *MockPasswordResetTokenRepository) MarkUsed(id string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			if token.UsedAt != nil {
+				return repository.ErrPasswordResetTokenUsed
+			}
+			token.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrPasswordResetTokenNotFound
+}
+
+// MockEmailVerificationTokenRepository は外部振る舞いテスト用の確認トークンのモック
+type MockEmailVerificationTokenRepository struct {
+	tokens map[string]*entity.EmailVerificationToken
+}
+
+func (m *MockEmailVerificationTokenRepository) Create(token *entity.EmailVerificationToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *MockEmailVerificationTokenRepository) GetByHash(hash string) (*entity.EmailVerificationToken, error) {
+	if token, exists := m.tokens[hash]; exists {
+		return token, nil
+	}
+	return nil, repository.ErrEmailVerificationTokenNotFound
+}
+
+func (m *MockEmailVerificationTokenRepository) MarkUsed(id string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			if token.UsedAt != nil {
+				return repository.ErrEmailVerificationTokenUsed
+			}
+			token.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrEmailVerificationTokenNotFound
+}
+
+// MockTOTPCredentialRepository は外部振る舞いテスト用のTOTP秘密鍵のモック
+type MockTOTPCredentialRepository struct {
+	credentials map[string]*entity.TOTPCredential
+}
+
+func (m *MockTOTPCredentialRepository) GetByUserID(userID string) (*entity.TOTPCredential, error) {
+	if credential, exists := m.credentials[userID]; exists {
+		copied := *credential
+		return &copied, nil
+	}
+	return nil, repository.ErrTOTPCredentialNotFound
+}
+
+func (m *MockTOTPCredentialRepository) Save(credential *entity.TOTPCredential) error {
+	copied := *credential
+	m.credentials[credential.UserID] = &copied
+	return nil
+}
+
+func (m *MockTOTPCredentialRepository) UseStep(userID string, step int64) error {
+	credential, exists := m.credentials[userID]
+	if !exists {
+		return repository.ErrTOTPCredentialNotFound
+	}
+	if credential.LastUsedStep >= step {
+		return repository.ErrTOTPStepUsed
+	}
+	credential.LastUsedStep = step
+	return nil
+}
+
+func (m *MockTOTPCredentialRepository) DeleteByUserID(userID string) error {
+	delete(m.credentials, userID)
+	return nil
+}
+
+// MockRecoveryCodeRepository は外部振る舞いテスト用のリカバリーコードのモック
+type MockRecoveryCodeRepository struct {
+	codes map[string][]*entity.RecoveryCode
+}
+
+func (m *MockRecoveryCodeRepository) Replace(userID string, codes []*entity.RecoveryCode) error {
+	m.codes[userID] = codes
+	return nil
+}
+
+func (m *MockRecoveryCodeRepository) Use(userID, hash string, at time.Time) error {
+	for _, code := range m.codes[userID] {
+		if code.CodeHash == hash && code.UsedAt == nil {
+			code.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrRecoveryCodeNotFound
+}
+
+func (m *MockRecoveryCodeRepository) CountUnused(userID string) (int, error) {
+	count := 0
+	for _, code := range m.codes[userID] {
+		if code.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockRecoveryCodeRepository) DeleteByUserID(userID string) error {
+	delete(m.codes, userID)
+	return nil
+}
+
+// MockTwoFactorChallengeRepository は外部振る舞いテスト用のチャレンジのモック
+type MockTwoFactorChallengeRepository struct {
+	challenges map[string]*entity.TwoFactorChallenge
+}
+
+func (m *MockTwoFactorChallengeRepository) Create(challenge *entity.TwoFactorChallenge) error {
+	m.challenges[challenge.TokenHash] = challenge
+	return nil
+}
+
+func (m *MockTwoFactorChallengeRepository) GetByHash(hash string) (*entity.TwoFactorChallenge, error) {
+	if challenge, exists := m.challenges[hash]; exists {
+		return challenge, nil
+	}
+	return nil, repository.ErrTwoFactorChallengeNotFound
+}
+
+func (m *MockTwoFactorChallengeRepository) RecordFailure(id string) error {
+	for _, challenge := range m.challenges {
+		if challenge.ID == id {
+			challenge.Attempts++
+			return nil
+		}
+	}
+	return repository.ErrTwoFactorChallengeNotFound
+}
+
+func (m *MockTwoFactorChallengeRepository) MarkUsed(id string, at time.Time) error {
+	for _, challenge := range m.challenges {
+		if challenge.ID == id {
+			if challenge.UsedAt != nil {
+				return repository.ErrTwoFactorChallengeUsed
+			}
+			challenge.UsedAt = &at
+			return nil
+		}
+	}
+	return repository.ErrTwoFactorChallengeNotFound
+}
+
+// MockLoginAttemptRepository は外部振る舞いテスト用のログイン失敗回数のモック
+type MockLoginAttemptRepository struct {
+	attempts map[string]*entity.LoginAttempts
+}
+
+func (m *MockLoginAttemptRepository) Get(key string) (*entity.LoginAttempts, error) {
+	if attempts, exists := m.attempts[key]; exists {
+		copied := *attempts
+		return &copied, nil
+	}
+	return nil, repository.ErrLoginAttemptsNotFound
+}
+
+func (m *MockLoginAttemptRepository) RecordFailure(key string, at, resetBefore time.Time) (*entity.LoginAttempts, error) {
+	attempts, exists := m.attempts[key]
+	if !exists || attempts.LastFailureAt.Before(resetBefore) {
+		attempts = &entity.LoginAttempts{Key: key}
+		m.attempts[key] = attempts
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = at
+	return m.Get(key)
+}
+
+func (m *MockLoginAttemptRepository) Lock(key string, until time.Time) error {
+	if attempts, exists := m.attempts[key]; exists {
+		attempts.LockedUntil = &until
+	}
+	return nil
+}
+
+func (m *MockLoginAttemptRepository) Delete(key string) error {
+	delete(m.attempts, key)
+	return nil
+}
+
+// MockPersonalAccessTokenRepository は外部振る舞いテスト用のアクセストークンのモック
+type MockPersonalAccessTokenRepository struct {
+	tokens map[string]*entity.PersonalAccessToken
+}
+
+func (m *MockPersonalAccessTokenRepository) Create(token *entity.PersonalAccessToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockPersonalAccessTokenRepository) GetByHash(hash string) (*entity.PersonalAccessToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrPersonalAccessTokenNotFound
+}
+
+func (m *MockPersonalAccessTokenRepository) ListByUserID(userID string) ([]*entity.PersonalAccessToken, error) {
+	var tokens []*entity.PersonalAccessToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *MockPersonalAccessTokenRepository) Revoke(userID, id string, at time.Time) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UserID != userID {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	token.RevokedAt = &at
+	return nil
+}
+
+func (m *MockPersonalAccessTokenRepository) Touch(id string, at time.Time) error {
+	if token, exists := m.tokens[id]; exists {
+		token.LastUsedAt = &at
+		return nil
+	}
+	return repository.ErrPersonalAccessTokenNotFound
+}
+
+// MockPreferencesRepository は設定用のモック
+type MockPreferencesRepository struct {
+	prefs map[string]*entity.Preferences
+}
+
+func (m *MockPreferencesRepository) Get(userID string) (*entity.Preferences, error) {
+	if prefs, exists := m.prefs[userID]; exists {
+		copied := *prefs
+		return &copied, nil
+	}
+	return nil, repository.ErrPreferencesNotFound
+}
+
+func (m *MockPreferencesRepository) Save(prefs *entity.Preferences) error {
+	copied := *prefs
+	m.prefs[prefs.UserID] = &copied
+	return nil
+}
+
+// MockWorkspaceRepository はワークスペース用のモック
+type MockWorkspaceRepository struct {
+	workspaces  map[string]*entity.Workspace
+	members     map[string]map[string]*entity.WorkspaceMember
+	invitations map[string]*entity.WorkspaceInvitation
+}
+
+func NewMockWorkspaceRepository() *MockWorkspaceRepository {
+	return &MockWorkspaceRepository{
+		workspaces:  make(map[string]*entity.Workspace),
+		members:     make(map[string]map[string]*entity.WorkspaceMember),
+		invitations: make(map[string]*entity.WorkspaceInvitation),
+	}
+}
+
+func (m *MockWorkspaceRepository) Create(workspace *entity.Workspace, owner *entity.WorkspaceMember) error {
+	m.workspaces[workspace.ID] = workspace
+	m.members[workspace.ID] = map[string]*entity.WorkspaceMember{owner.UserID: owner}
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetByID(id string) (*entity.Workspace, error) {
+	if workspace, exists := m.workspaces[id]; exists {
+		return workspace, nil
+	}
+	return nil, repository.ErrWorkspaceNotFound
+}
+
+func (m *MockWorkspaceRepository) ListByUserID(userID string) ([]*entity.WorkspaceMembership, error) {
+	var memberships []*entity.WorkspaceMembership
+	for id, members := range m.members {
+		if member, exists := members[userID]; exists {
+			memberships = append(memberships, &entity.WorkspaceMembership{Workspace: m.workspaces[id], Role: member.Role})
+		}
+	}
+	return memberships, nil
+}
+
+func (m *MockWorkspaceRepository) Delete(id string) error {
+	delete(m.workspaces, id)
+	delete(m.members, id)
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetMember(workspaceID, userID string) (*entity.WorkspaceMember, error) {
+	if member, exists := m.members[workspaceID][userID]; exists {
+		copied := *member
+		return &copied, nil
+	}
+	return nil, repository.ErrWorkspaceMemberNotFound
+}
+
+func (m *MockWorkspaceRepository) ListMembers(workspaceID string) ([]*entity.WorkspaceMember, error) {
+	var members []*entity.WorkspaceMember
+	for _, member := range m.members[workspaceID] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (m *MockWorkspaceRepository) UpdateMember(member *entity.WorkspaceMember) error {
+	copied := *member
+	m.members[member.WorkspaceID][member.UserID] = &copied
+	return nil
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(workspaceID, userID string) error {
+	if _, exists := m.members[workspaceID][userID]; !exists {
+		return repository.ErrWorkspaceMemberNotFound
+	}
+	delete(m.members[workspaceID], userID)
+	return nil
+}
+
+func (m *MockWorkspaceRepository) CreateInvitation(invitation *entity.WorkspaceInvitation) error {
+	m.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetInvitation(id string) (*entity.WorkspaceInvitation, error) {
+	if invitation, exists := m.invitations[id]; exists {
+		copied := *invitation
+		return &copied, nil
+	}
+	return nil, repository.ErrWorkspaceInvitationNotFound
+}
+
+func (m *MockWorkspaceRepository) ListPendingInvitations(email string, now time.Time) ([]*entity.WorkspaceInvitation, error) {
+	var invitations []*entity.WorkspaceInvitation
+	for _, invitation := range m.invitations {
+		if invitation.Email == email && invitation.Pending(now) {
+			invitations = append(invitations, invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (m *MockWorkspaceRepository) AcceptInvitation(invitation *entity.WorkspaceInvitation, member *entity.WorkspaceMember) error {
+	m.invitations[invitation.ID].AcceptedAt = invitation.AcceptedAt
+	if _, exists := m.members[member.WorkspaceID][member.UserID]; !exists {
+		m.members[member.WorkspaceID][member.UserID] = member
+	}
+	return nil
+}
+
+// MockMailer は送信されたメールを記録する
+type MockMailer struct {
+	messages []service.MailMessage
+}
+
+func (m *MockMailer) Send(msg service.MailMessage) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func newUserServer(userService *service.UserService, userRepo repository.UserRepository) *grpc.UserServer {
+	return newUserServerWithMailer(userService, userRepo, &MockMailer{}, service.EmailVerificationPolicyNone)
+}
+
+func newUserServerWithMailer(userService *service.UserService, userRepo repository.UserRepository, mailer service.Mailer, policy service.EmailVerificationPolicy) *grpc.UserServer {
+	attemptRepo := &MockLoginAttemptRepository{attempts: make(map[string]*entity.LoginAttempts)}
+	return buildUserServer(userService, userRepo, mailer, policy, attemptRepo)
+}
+
+func buildUserServer(userService *service.UserService, userRepo repository.UserRepository, mailer service.Mailer, policy service.EmailVerificationPolicy, attemptRepo repository.LoginAttemptRepository) *grpc.UserServer {
+	key, _ := auth.GenerateEd25519Key("test-key")
+	keys, _ := auth.NewKeySet(key)
+	sessionRepo := &MockSessionRepository{sessions: make(map[string]*entity.Session)}
+	refreshRepo := NewMockRefreshTokenRepository()
+	resetRepo := &MockPasswordResetTokenRepository{tokens: make(map[string]*entity.PasswordResetToken)}
+	verifyRepo := &MockEmailVerificationTokenRepository{tokens: make(map[string]*entity.EmailVerificationToken)}
+	sessionService := service.NewSessionService(sessionRepo, refreshRepo)
+	tokenService := service.NewTokenService(userRepo, sessionRepo, refreshRepo, auth.NewSigner(keys))
+	resetService := service.NewPasswordResetService(userRepo, resetRepo, sessionService, mailer, "http://localhost:5173/reset-password", service.DefaultPasswordPolicy())
+	loginThrottle := service.NewLoginThrottle(attemptRepo, service.DefaultLoginThrottleConfig())
+	workspaceRepo := NewMockWorkspaceRepository()
+	return grpc.NewUserServer(userService,
+		tokenService,
+		sessionService,
+		resetService,
+		service.NewEmailVerificationService(userRepo, verifyRepo, mailer, "http://localhost:5173/verify-email", policy),
+		service.NewTwoFactorService(userRepo,
+			&MockTOTPCredentialRepository{credentials: make(map[string]*entity.TOTPCredential)},
+			&MockRecoveryCodeRepository{codes: make(map[string][]*entity.RecoveryCode)},
+			&MockTwoFactorChallengeRepository{challenges: make(map[string]*entity.TwoFactorChallenge)},
+			tokenService, loginThrottle, "MyTodo"),
+		loginThrottle,
+		service.NewPersonalAccessTokenService(userRepo, &MockPersonalAccessTokenRepository{tokens: make(map[string]*entity.PersonalAccessToken)}),
+		service.NewAdminService(userRepo, sessionService, resetService),
+		service.NewAccountDeletionService(userRepo, workspaceRepo, mailer, service.DefaultAccountDeletionGracePeriod),
+		service.NewPreferencesService(userRepo, &MockPreferencesRepository{prefs: make(map[string]*entity.Preferences)}),
+		service.NewWorkspaceService(userRepo, workspaceRepo, mailer, "http://localhost:5173/invitations"),
+		keys)
+}
+
 func TestUserServer_CreateUser(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := grpc.NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	req := &pb.CreateUserRequest{
@@ -117,11 +688,50 @@ func TestUserServer_CreateUser(t *testing.T) {
 	}
 }
 
+func TestUserServer_CreateUser_PasswordPolicy(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+
+	// Act - 短く、メールアドレスを含むパスワード
+	resp, err := server.CreateUser(ctx, &pb.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "test",
+	})
+
+	// Assert - 違反がすべて構造化されて返る
+	if err != nil {
+		t.Errorf("CreateUser should not return gRPC error: %v", err)
+	}
+	if resp.Error == "" || resp.User != nil {
+		t.Fatalf("Expected the password to be rejected, got %+v", resp)
+	}
+	var codes []string
+	for _, violation := range resp.PasswordViolations {
+		if violation.Message == "" {
+			t.Errorf("Violation %s should have a message", violation.Code)
+		}
+		codes = append(codes, violation.Code)
+	}
+	if strings.Join(codes, ",") != "too_short,contains_email" {
+		t.Errorf("Unexpected violations: %v", codes)
+	}
+
+	// 他のエラーでは違反を返さない
+	server.CreateUser(ctx, &pb.CreateUserRequest{Email: "taken@example.com", Password: "password123"})
+	taken, _ := server.CreateUser(ctx, &pb.CreateUserRequest{Email: "taken@example.com", Password: "password123"})
+	if taken.Error == "" || len(taken.PasswordViolations) != 0 {
+		t.Errorf("Expected an error without violations, got %+v", taken)
+	}
+}
+
 func TestUserServer_GetUser(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := grpc.NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	// Create a user first
@@ -156,7 +766,7 @@ func TestUserServer_GetUser_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := grpc.NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	req := &pb.GetUserRequest{
@@ -182,7 +792,7 @@ func TestUserServer_AuthenticateUser(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := grpc.NewUserServer(userService)
+	server := newUserServer(userService, mockRepo)
 	ctx := context.Background()
 	
 	email := "test@example.com"
@@ -215,16 +825,517 @@ func TestUserServer_AuthenticateUser(t *testing.T) {
 	}
 }
 
-func TestUserServer_TimeFormatting(t *testing.T) {
+func TestUserServer_AuthenticateUser_Throttle(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockUserRepository()
 	userService := service.NewUserService(mockRepo)
-	server := grpc.NewUserServer(userService)
-	ctx := context.Background()
-	
-	req := &pb.CreateUserRequest{
-		Email:    "test@example.com",
-		Password: "password123",
+	attemptRepo := &MockLoginAttemptRepository{attempts: make(map[string]*entity.LoginAttempts)}
+	server := buildUserServer(userService, mockRepo, &MockMailer{}, service.EmailVerificationPolicyNone, attemptRepo)
+	// BFFはクライアントのアドレスをメタデータで渡す
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-client-ip", "192.0.2.1"))
+	userService.CreateUser("test@example.com", "password123")
+
+	wrong := &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "wrongpassword"}
+	for i := 0; i < 4; i++ {
+		resp, _ := server.AuthenticateUser(ctx, wrong)
+		if resp.Error == "" || resp.RetryAt != "" {
+			t.Fatalf("Attempt %d: expected invalid credentials, got %+v", i+1, resp)
+		}
+	}
+
+	// Act - 失敗が続くと正しいパスワードでも待たされる
+	resp, err := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("AuthenticateUser should not return gRPC error: %v", err)
+	}
+	if resp.RetryAt == "" || resp.AccountLocked || resp.Token != "" {
+		t.Fatalf("Expected a rate limited response, got %+v", resp)
+	}
+	if _, err := time.Parse(time.RFC3339, resp.RetryAt); err != nil {
+		t.Errorf("RetryAt should be valid RFC3339 format: %v", err)
+	}
+	if attemptRepo.attempts["ip:192.0.2.1"] == nil {
+		t.Errorf("Failures should be counted for the address in the metadata")
+	}
+
+	// Act & Assert - ロック中のアカウントはロックとして区別される
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	attemptRepo.attempts["account:locked@example.com"] = &entity.LoginAttempts{
+		Key:           "account:locked@example.com",
+		Failures:      10,
+		LastFailureAt: time.Now().Add(-time.Hour),
+		LockedUntil:   &lockedUntil,
+	}
+	userService.CreateUser("locked@example.com", "password123")
+	locked, _ := server.AuthenticateUser(context.Background(), &pb.AuthenticateUserRequest{Email: "locked@example.com", Password: "password123"})
+	if !locked.AccountLocked || locked.RetryAt != lockedUntil.Format(time.RFC3339) {
+		t.Errorf("Expected the account to be locked until %v, got %+v", lockedUntil, locked)
+	}
+
+	// Act & Assert - 成功するとアカウントの失敗回数はリセットされる
+	for i := 0; i < 2; i++ {
+		server.AuthenticateUser(context.Background(), &pb.AuthenticateUserRequest{Email: "other@example.com", Password: fmt.Sprintf("wrong%d", i)})
+	}
+	userService.CreateUser("other@example.com", "password123")
+	ok, _ := server.AuthenticateUser(context.Background(), &pb.AuthenticateUserRequest{Email: "other@example.com", Password: "password123"})
+	if ok.Token == "" {
+		t.Fatalf("Expected to sign in, got %+v", ok)
+	}
+	if _, exists := attemptRepo.attempts["account:other@example.com"]; exists {
+		t.Errorf("Failures of the account should be reset after a successful login")
+	}
+}
+
+func TestUserServer_TwoFactor_Throttle(t *testing.T) {
+	// Arrange - 二要素認証を有効にしたユーザー
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	attemptRepo := &MockLoginAttemptRepository{attempts: make(map[string]*entity.LoginAttempts)}
+	server := buildUserServer(userService, mockRepo, &MockMailer{}, service.EmailVerificationPolicyNone, attemptRepo)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	enroll, _ := server.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{UserId: user.ID})
+	now := time.Now()
+	code, _ := entity.TOTPCode(enroll.Secret, now)
+	server.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{UserId: user.ID, Code: code})
+
+	// Act - パスワードが合っても、二要素が済むまで失敗回数は消えない
+	signIn, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if !signIn.TwoFactorRequired {
+		t.Fatalf("Expected a two-factor challenge: %+v", signIn)
+	}
+	server.VerifyTwoFactor(ctx, &pb.VerifyTwoFactorRequest{TwoFactorToken: signIn.TwoFactorToken, Code: "000000"})
+	signIn, _ = server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if attempts := attemptRepo.attempts["account:test@example.com"]; attempts == nil || attempts.Failures != 1 {
+		t.Fatalf("Expected the wrong code to stay counted, got %+v", attempts)
+	}
+	next, _ := entity.TOTPCode(enroll.Secret, now.Add(entity.TOTPPeriod))
+	verified, _ := server.VerifyTwoFactor(ctx, &pb.VerifyTwoFactorRequest{TwoFactorToken: signIn.TwoFactorToken, Code: next})
+	if verified.Token == "" {
+		t.Fatalf("VerifyTwoFactor should issue tokens: %+v", verified)
+	}
+	if _, exists := attemptRepo.attempts["account:test@example.com"]; exists {
+		t.Errorf("Failures of the account should be reset once the sign-in is complete")
+	}
+}
+
+func TestUserServer_PersonalAccessTokens(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).Format(time.RFC3339)
+
+	// Act - トークンを作成する
+	created, err := server.CreatePersonalAccessToken(ctx, &pb.CreatePersonalAccessTokenRequest{
+		UserId:    user.ID,
+		Name:      "CI",
+		Scopes:    []string{"todos:read"},
+		ExpiresAt: expiresAt,
+	})
+
+	// Assert
+	if err != nil {
+		t.Errorf("CreatePersonalAccessToken should not return gRPC error: %v", err)
+	}
+	if created.Error != "" || !strings.HasPrefix(created.Secret, "mytodo_pat_") {
+		t.Fatalf("Expected a token, got %+v", created)
+	}
+	if created.Token.ExpiresAt != expiresAt || created.Token.LastUsedAt != "" {
+		t.Errorf("Unexpected token: %+v", created.Token)
+	}
+
+	// Act & Assert - シークレットから所有者とスコープが分かる
+	auth, _ := server.AuthenticatePersonalAccessToken(ctx, &pb.AuthenticatePersonalAccessTokenRequest{Secret: created.Secret})
+	if auth.Error != "" || auth.User.Id != user.ID || strings.Join(auth.Token.Scopes, " ") != "todos:read" {
+		t.Fatalf("Expected the owner and scopes, got %+v", auth)
+	}
+	if auth.Token.LastUsedAt == "" {
+		t.Errorf("Last use should be recorded")
+	}
+
+	// Act & Assert - 一覧にはシークレットを含まない
+	list, _ := server.ListPersonalAccessTokens(ctx, &pb.ListPersonalAccessTokensRequest{UserId: user.ID})
+	if len(list.Tokens) != 1 || list.Tokens[0].Hint == "" || strings.Contains(list.Tokens[0].String(), created.Secret) {
+		t.Errorf("Unexpected tokens: %+v", list.Tokens)
+	}
+
+	// Act & Assert - 失効したトークンは使えない
+	revoked, _ := server.RevokePersonalAccessToken(ctx, &pb.RevokePersonalAccessTokenRequest{Id: created.Token.Id, UserId: user.ID})
+	if !revoked.Success {
+		t.Fatalf("Expected the token to be revoked, got %+v", revoked)
+	}
+	auth, _ = server.AuthenticatePersonalAccessToken(ctx, &pb.AuthenticatePersonalAccessTokenRequest{Secret: created.Secret})
+	if auth.Error == "" || auth.User != nil {
+		t.Errorf("Revoked token should be rejected, got %+v", auth)
+	}
+
+	// 不正な期限は拒否される
+	invalid, _ := server.CreatePersonalAccessToken(ctx, &pb.CreatePersonalAccessTokenRequest{
+		UserId: user.ID, Name: "CI", Scopes: []string{"todos:read"}, ExpiresAt: "tomorrow",
+	})
+	if invalid.Error == "" {
+		t.Errorf("Expected an invalid expires_at error")
+	}
+}
+
+func TestUserServer_RefreshToken(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+
+	userService.CreateUser("test@example.com", "password123")
+	auth, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+	if auth.RefreshToken == "" || auth.ExpiresAt == "" || auth.RefreshTokenExpiresAt == "" {
+		t.Fatalf("AuthenticateUser should return a refresh token and expiry times: %+v", auth)
+	}
+
+	// Act - リフレッシュすると新しいトークンが発行される
+	resp, err := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: auth.RefreshToken})
+
+	// Assert
+	if err != nil {
+		t.Errorf("RefreshToken should not return gRPC error: %v", err)
+	}
+	if resp.Error != "" {
+		t.Fatalf("Response should not contain error: %s", resp.Error)
+	}
+	if resp.Token == "" || resp.User == nil || resp.User.Email != "test@example.com" {
+		t.Errorf("Expected a new access token for the user, got %+v", resp)
+	}
+	if resp.RefreshToken == "" || resp.RefreshToken == auth.RefreshToken {
+		t.Errorf("Refresh token should be rotated")
+	}
+
+	// 使用済みのトークンを再利用すると拒否され、ファミリー全体が失効する
+	reused, _ := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: auth.RefreshToken})
+	if reused.Error == "" || reused.Token != "" {
+		t.Errorf("Reusing a refresh token should fail, got %+v", reused)
+	}
+	revoked, _ := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	if revoked.Error == "" {
+		t.Errorf("Rotated token should be revoked after reuse is detected")
+	}
+
+	// 不明なトークン
+	unknown, _ := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: "unknown"})
+	if unknown.Error == "" {
+		t.Errorf("Unknown refresh token should fail")
+	}
+}
+
+func TestUserServer_Sessions(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	signIn := func(device string) *pb.AuthenticateUserResponse {
+		resp, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{
+			Email:     "test@example.com",
+			Password:  "password123",
+			Device:    device,
+			IpAddress: "203.0.113.7",
+		})
+		return resp
+	}
+	laptop := signIn("laptop")
+	signIn("phone")
+	signIn("tablet")
+
+	// Act & Assert - 一覧
+	list, err := server.ListSessions(ctx, &pb.ListSessionsRequest{UserId: user.ID})
+	if err != nil || list.Error != "" {
+		t.Fatalf("ListSessions should succeed: %v %s", err, list.Error)
+	}
+	if len(list.Sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(list.Sessions))
+	}
+	var laptopID string
+	for _, session := range list.Sessions {
+		if session.Device == "laptop" {
+			laptopID = session.Id
+		}
+		if session.IpAddress != "203.0.113.7" || session.LastSeenAt == "" {
+			t.Errorf("Unexpected session: %+v", session)
+		}
+	}
+
+	check, _ := server.CheckSession(ctx, &pb.CheckSessionRequest{Id: laptopID})
+	if !check.Active {
+		t.Errorf("New session should be active")
+	}
+
+	// Act & Assert - 他ユーザーのセッションは失効できない
+	other, _ := server.RevokeSession(ctx, &pb.RevokeSessionRequest{Id: laptopID, UserId: "user-456"})
+	if other.Success || other.Error == "" {
+		t.Errorf("Revoking another user's session should fail")
+	}
+
+	// Act & Assert - 失効したセッションはアクセストークンもリフレッシュトークンも使えない
+	revoke, _ := server.RevokeSession(ctx, &pb.RevokeSessionRequest{Id: laptopID, UserId: user.ID})
+	if !revoke.Success {
+		t.Fatalf("RevokeSession should succeed: %s", revoke.Error)
+	}
+	check, _ = server.CheckSession(ctx, &pb.CheckSessionRequest{Id: laptopID})
+	if check.Active {
+		t.Errorf("Revoked session should not be active")
+	}
+	refresh, _ := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: laptop.RefreshToken})
+	if refresh.Error == "" {
+		t.Errorf("Refresh token of a revoked session should be rejected")
+	}
+
+	// Act & Assert - 現在のセッション以外をすべて失効
+	list, _ = server.ListSessions(ctx, &pb.ListSessionsRequest{UserId: user.ID})
+	current := list.Sessions[0].Id
+	all, _ := server.RevokeAllSessions(ctx, &pb.RevokeAllSessionsRequest{UserId: user.ID, ExceptSessionId: current})
+	if all.Error != "" || all.RevokedCount != 1 {
+		t.Errorf("Expected 1 revoked session, got %d (%s)", all.RevokedCount, all.Error)
+	}
+	list, _ = server.ListSessions(ctx, &pb.ListSessionsRequest{UserId: user.ID})
+	if len(list.Sessions) != 1 || list.Sessions[0].Id != current {
+		t.Errorf("Only the current session should remain, got %+v", list.Sessions)
+	}
+
+	unknown, _ := server.CheckSession(ctx, &pb.CheckSessionRequest{Id: "unknown"})
+	if unknown.Active || unknown.Error != "" {
+		t.Errorf("Unknown session should be inactive without error")
+	}
+}
+
+func TestUserServer_GetJWKS(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	server := newUserServer(service.NewUserService(mockRepo), mockRepo)
+
+	// Act
+	resp, err := server.GetJWKS(context.Background(), &pb.GetJWKSRequest{})
+
+	// Assert - 公開鍵だけが返される
+	if err != nil || resp.Error != "" {
+		t.Fatalf("GetJWKS should succeed: %v %s", err, resp.Error)
+	}
+	if len(resp.Keys) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(resp.Keys))
+	}
+	key := resp.Keys[0]
+	if key.Kid != "test-key" || key.Kty != "OKP" || key.Alg != "EdDSA" || key.Crv != "Ed25519" || key.X == "" {
+		t.Errorf("Unexpected JWK: %+v", key)
+	}
+}
+
+func TestUserServer_PasswordReset(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	mailer := &MockMailer{}
+	server := newUserServerWithMailer(userService, mockRepo, mailer, service.EmailVerificationPolicyNone)
+	ctx := context.Background()
+
+	userService.CreateUser("test@example.com", "old-password")
+	signIn, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{
+		Email:    "test@example.com",
+		Password: "old-password",
+	})
+
+	// Act - 未登録のアドレスでも成功し、メールは送られない
+	unknown, err := server.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Email: "nobody@example.com"})
+	if err != nil || !unknown.Success {
+		t.Errorf("Unknown addresses should succeed silently: %v %s", err, unknown.Error)
+	}
+	if len(mailer.messages) != 0 {
+		t.Fatalf("No mail should be sent for unknown addresses")
+	}
+
+	// Act - リセットリンクがメールで届く
+	resp, _ := server.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Email: "test@example.com"})
+	if !resp.Success {
+		t.Fatalf("RequestPasswordReset should succeed: %s", resp.Error)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "test@example.com" {
+		t.Fatalf("Expected a reset mail to test@example.com, got %+v", mailer.messages)
+	}
+	body := mailer.messages[0].Body
+	i := strings.Index(body, "http://localhost:5173/reset-password?token=")
+	if i < 0 {
+		t.Fatalf("Mail should contain the reset link:\n%s", body)
+	}
+	token := strings.Fields(body[i+len("http://localhost:5173/reset-password?token="):])[0]
+
+	// Act & Assert - 新しいパスワードを設定
+	confirm, _ := server.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, NewPassword: "new-password"})
+	if !confirm.Success {
+		t.Fatalf("ConfirmPasswordReset should succeed: %s", confirm.Error)
+	}
+	if _, err := userService.AuthenticateUser("test@example.com", "new-password"); err != nil {
+		t.Errorf("New password should work: %v", err)
+	}
+	if _, err := userService.AuthenticateUser("test@example.com", "old-password"); err == nil {
+		t.Errorf("Old password should no longer work")
+	}
+
+	// 既存のセッションは失効する
+	refresh, _ := server.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: signIn.RefreshToken})
+	if refresh.Error == "" {
+		t.Errorf("Sessions should be revoked after a password reset")
+	}
+
+	// トークンは一度しか使えない
+	reuse, _ := server.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, NewPassword: "another-password"})
+	if reuse.Success || reuse.Error == "" {
+		t.Errorf("Reset token should work only once")
+	}
+	invalid, _ := server.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: "invalid", NewPassword: "another-password"})
+	if invalid.Success {
+		t.Errorf("Unknown reset token should fail")
+	}
+}
+
+// linkToken はメール本文のリンクからトークンを取り出す
+func linkToken(t *testing.T, body, link string) string {
+	t.Helper()
+	i := strings.Index(body, link+"?token=")
+	if i < 0 {
+		t.Fatalf("Mail should contain %s:\n%s", link, body)
+	}
+	return strings.Fields(body[i+len(link+"?token="):])[0]
+}
+
+func TestUserServer_EmailVerification(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	mailer := &MockMailer{}
+	server := newUserServerWithMailer(userService, mockRepo, mailer, service.EmailVerificationPolicyLogin)
+	ctx := context.Background()
+
+	// Act - 登録すると確認メールが届く
+	created, _ := server.CreateUser(ctx, &pb.CreateUserRequest{Email: "test@example.com", Password: "password123"})
+	if created.Error != "" || created.User.EmailVerified {
+		t.Fatalf("Unexpected CreateUser response: %+v", created)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "test@example.com" {
+		t.Fatalf("Expected a verification mail to test@example.com, got %+v", mailer.messages)
+	}
+
+	// Assert - ログインポリシーでは確認前にログインできない
+	signIn, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if !signIn.EmailNotVerified || signIn.Token != "" {
+		t.Errorf("Unverified accounts should not be able to log in: %+v", signIn)
+	}
+
+	// Act - 再送すると新しいリンクが届く
+	resend, _ := server.ResendVerificationEmail(ctx, &pb.ResendVerificationEmailRequest{Email: "test@example.com"})
+	if !resend.Success || len(mailer.messages) != 2 {
+		t.Fatalf("ResendVerificationEmail should send another mail: %+v", resend)
+	}
+
+	// Act - 確認する
+	verified, _ := server.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: linkToken(t, mailer.messages[1].Body, "http://localhost:5173/verify-email")})
+	if verified.Error != "" || !verified.User.EmailVerified {
+		t.Fatalf("VerifyEmail should succeed: %+v", verified)
+	}
+	signIn, _ = server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if signIn.Error != "" || signIn.Token == "" {
+		t.Errorf("Verified accounts should be able to log in: %s", signIn.Error)
+	}
+
+	// Act - アドレスを変更しても確認されるまで古いアドレスのまま
+	updated, _ := server.UpdateUser(ctx, &pb.UpdateUserRequest{Id: created.User.Id, Email: "new@example.com"})
+	if updated.Error != "" || updated.User.Email != "test@example.com" || updated.User.PendingEmail != "new@example.com" {
+		t.Fatalf("Unexpected UpdateUser response: %+v", updated)
+	}
+	if len(mailer.messages) != 3 || mailer.messages[2].To != "new@example.com" {
+		t.Fatalf("Expected a verification mail to new@example.com, got %+v", mailer.messages)
+	}
+
+	verified, _ = server.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: linkToken(t, mailer.messages[2].Body, "http://localhost:5173/verify-email")})
+	if verified.Error != "" || verified.User.Email != "new@example.com" || verified.User.PendingEmail != "" {
+		t.Errorf("Pending address should take effect: %+v", verified)
+	}
+
+	invalid, _ := server.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: "invalid"})
+	if invalid.Error == "" {
+		t.Errorf("Unknown verification token should fail")
+	}
+}
+
+func TestUserServer_TwoFactor(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act - 登録を開始し、認証アプリのコードで確認する
+	enroll, _ := server.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{UserId: user.ID})
+	if enroll.Error != "" || !strings.HasPrefix(enroll.OtpauthUri, "otpauth://totp/MyTodo:") {
+		t.Fatalf("Unexpected EnrollTOTP response: %+v", enroll)
+	}
+	now := time.Now()
+	code, _ := entity.TOTPCode(enroll.Secret, now)
+	confirm, _ := server.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{UserId: user.ID, Code: code})
+	if confirm.Error != "" || len(confirm.RecoveryCodes) != entity.RecoveryCodeCount {
+		t.Fatalf("Unexpected ConfirmTOTP response: %+v", confirm)
+	}
+
+	// Act - パスワードだけではトークンが発行されない
+	signIn, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if !signIn.TwoFactorRequired || signIn.TwoFactorToken == "" || signIn.Token != "" {
+		t.Fatalf("Expected a two-factor challenge: %+v", signIn)
+	}
+
+	wrong, _ := server.VerifyTwoFactor(ctx, &pb.VerifyTwoFactorRequest{TwoFactorToken: signIn.TwoFactorToken, Code: "000000"})
+	if wrong.Error == "" || wrong.Token != "" {
+		t.Errorf("Wrong code should be rejected")
+	}
+
+	next, _ := entity.TOTPCode(enroll.Secret, now.Add(entity.TOTPPeriod))
+	verified, _ := server.VerifyTwoFactor(ctx, &pb.VerifyTwoFactorRequest{TwoFactorToken: signIn.TwoFactorToken, Code: next})
+	if verified.Error != "" || verified.Token == "" || verified.RefreshToken == "" || verified.User.Id != user.ID {
+		t.Fatalf("VerifyTwoFactor should issue tokens: %+v", verified)
+	}
+
+	// Act - リカバリーコードで無効化する
+	status, _ := server.GetTwoFactorStatus(ctx, &pb.GetTwoFactorStatusRequest{UserId: user.ID})
+	if !status.Enabled || status.RecoveryCodesRemaining != int32(entity.RecoveryCodeCount) {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	disable, _ := server.DisableTOTP(ctx, &pb.DisableTOTPRequest{UserId: user.ID, Code: confirm.RecoveryCodes[0]})
+	if !disable.Success {
+		t.Fatalf("DisableTOTP should succeed: %s", disable.Error)
+	}
+	signIn, _ = server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if signIn.TwoFactorRequired || signIn.Token == "" {
+		t.Errorf("Password should be enough after disabling: %+v", signIn)
+	}
+}
+
+func TestUserServer_TimeFormatting(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+	
+	req := &pb.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
 	}
 
 	// Act
@@ -254,3 +1365,220 @@ func TestUserServer_TimeFormatting(t *testing.T) {
 		t.Errorf("UpdatedAt should be valid RFC3339 format: %v", err)
 	}
 }
+
+func TestUserServer_Admin(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+	admin, _ := userService.CreateUser("other@example.com", "password123")
+	admin.SetRole(entity.RoleAdmin)
+	mockRepo.Update(admin)
+
+	// Act - ユーザー一覧を1件ずつ取得
+	list, err := server.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 1})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ListUsers should not return gRPC error: %v", err)
+	}
+	if list.Error != "" || list.TotalCount != 2 || len(list.Users) != 1 || list.Users[0].Email != "other@example.com" || list.NextPageToken == "" {
+		t.Fatalf("Unexpected first page: %+v", list)
+	}
+	if list.Users[0].Role != entity.RoleAdmin || list.Users[0].DisabledAt != "" {
+		t.Errorf("Expected an enabled admin, got %+v", list.Users[0])
+	}
+
+	// Act & Assert - 役割の変更
+	role, _ := server.SetUserRole(ctx, &pb.SetUserRoleRequest{ActorId: admin.ID, Id: user.ID, Role: "admin"})
+	if role.Error != "" || role.User.Role != "admin" {
+		t.Errorf("Expected the user to become an admin, got %+v", role)
+	}
+	invalid, _ := server.SetUserRole(ctx, &pb.SetUserRoleRequest{ActorId: admin.ID, Id: user.ID, Role: "owner"})
+	if invalid.Error == "" {
+		t.Errorf("Expected an error for an unknown role")
+	}
+
+	// Act & Assert - 無効化されたアカウントはログインできない
+	disabled, _ := server.SetUserDisabled(ctx, &pb.SetUserDisabledRequest{ActorId: admin.ID, Id: user.ID, Disabled: true})
+	if disabled.Error != "" || disabled.User.DisabledAt == "" {
+		t.Fatalf("Expected the user to be disabled, got %+v", disabled)
+	}
+	// 残った有効な管理者は降格できない
+	if last, _ := server.SetUserRole(ctx, &pb.SetUserRoleRequest{ActorId: user.ID, Id: admin.ID, Role: "user"}); !last.LastAdmin || last.Error == "" {
+		t.Errorf("Expected the last active admin to be kept, got %+v", last)
+	}
+	login, _ := server.AuthenticateUser(ctx, &pb.AuthenticateUserRequest{Email: "test@example.com", Password: "password123"})
+	if !login.AccountDisabled || login.Token != "" {
+		t.Errorf("Expected the login to be refused as disabled, got %+v", login)
+	}
+
+	// Act & Assert - パスワードの強制リセット
+	reset, _ := server.ForcePasswordReset(ctx, &pb.ForcePasswordResetRequest{Id: user.ID})
+	if !reset.Success {
+		t.Errorf("ForcePasswordReset should succeed: %s", reset.Error)
+	}
+	missing, _ := server.ForcePasswordReset(ctx, &pb.ForcePasswordResetRequest{Id: "missing"})
+	if missing.Success || missing.Error == "" {
+		t.Errorf("Expected an error for an unknown user")
+	}
+}
+
+func TestUserServer_AccountDeletion(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	mailer := &MockMailer{}
+	server := newUserServerWithMailer(userService, mockRepo, mailer, service.EmailVerificationPolicyNone)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act & Assert - 現在のパスワードがなければ変更できない
+	wrong, _ := server.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.ID, Password: "newpassword456", CurrentPassword: "wrong-password"})
+	if !wrong.IncorrectPassword || wrong.User != nil {
+		t.Errorf("Expected the change to be refused, got %+v", wrong)
+	}
+	changed, _ := server.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.ID, Password: "newpassword456", CurrentPassword: "password123"})
+	if changed.Error != "" {
+		t.Fatalf("UpdateUser should succeed with the current password: %s", changed.Error)
+	}
+
+	// Act & Assert - 削除の予定と通知
+	refused, _ := server.ScheduleAccountDeletion(ctx, &pb.ScheduleAccountDeletionRequest{UserId: user.ID, CurrentPassword: "password123"})
+	if !refused.IncorrectPassword {
+		t.Errorf("Expected the old password to be refused, got %+v", refused)
+	}
+	scheduled, _ := server.ScheduleAccountDeletion(ctx, &pb.ScheduleAccountDeletionRequest{UserId: user.ID, CurrentPassword: "newpassword456"})
+	if scheduled.Error != "" || scheduled.User.DeletionScheduledAt == "" {
+		t.Fatalf("Expected the deletion to be scheduled, got %+v", scheduled)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "test@example.com" {
+		t.Errorf("Expected a deletion notice, got %+v", mailer.messages)
+	}
+
+	// Act & Assert - 猶予期間中は削除対象にならず、取り消せる
+	due, _ := server.ListDueAccountDeletions(ctx, &pb.ListDueAccountDeletionsRequest{Limit: 10})
+	if due.Error != "" || len(due.Users) != 0 {
+		t.Errorf("Expected nothing to be due yet, got %+v", due)
+	}
+	cancelled, _ := server.CancelAccountDeletion(ctx, &pb.CancelAccountDeletionRequest{UserId: user.ID})
+	if cancelled.Error != "" || cancelled.User.DeletionScheduledAt != "" {
+		t.Errorf("Expected the deletion to be cancelled, got %+v", cancelled)
+	}
+
+	// Act & Assert - 削除
+	deleted, _ := server.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.ID})
+	if !deleted.Success {
+		t.Fatalf("DeleteUser should succeed: %s", deleted.Error)
+	}
+	if got, _ := server.GetUser(ctx, &pb.GetUserRequest{Id: user.ID}); got.Error == "" {
+		t.Errorf("Expected the user to be gone")
+	}
+}
+
+func TestUserServer_Preferences(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	server := newUserServer(userService, mockRepo)
+	ctx := context.Background()
+	user, _ := userService.CreateUser("test@example.com", "password123")
+
+	// Act & Assert - 保存前は既定値
+	got, _ := server.GetPreferences(ctx, &pb.GetPreferencesRequest{UserId: user.ID})
+	if got.Error != "" || got.Preferences.Timezone != "UTC" || got.Preferences.UpdatedAt != "" {
+		t.Fatalf("Expected the defaults, got %+v", got)
+	}
+
+	// Act & Assert - 指定したフィールドだけを更新する
+	timezone, project := "Europe/Berlin", "project-1"
+	updated, _ := server.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{UserId: user.ID, Timezone: &timezone, DefaultProjectId: &project})
+	if updated.Error != "" {
+		t.Fatalf("UpdatePreferences should succeed: %s", updated.Error)
+	}
+	if updated.Preferences.Timezone != timezone || updated.Preferences.DefaultProjectId != project || updated.Preferences.Locale != "en" {
+		t.Errorf("Unexpected preferences: %+v", updated.Preferences)
+	}
+
+	// Act & Assert - 不正なタイムゾーンはエラー
+	invalid := "Berlin"
+	rejected, _ := server.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{UserId: user.ID, Timezone: &invalid})
+	if rejected.Error == "" || rejected.Preferences != nil {
+		t.Errorf("Expected an error, got %+v", rejected)
+	}
+}
+
+func TestUserServer_Workspaces(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	mailer := &MockMailer{}
+	server := newUserServerWithMailer(userService, mockRepo, mailer, service.EmailVerificationPolicyNone)
+	ctx := context.Background()
+	owner, _ := userService.CreateUser("owner@example.com", "password123")
+	viewer, _ := userService.CreateUser("viewer@example.com", "password123")
+	viewer.VerifyEmail(viewer.Email, time.Now())
+	mockRepo.Update(viewer)
+
+	// Act & Assert - 作成者はオーナー
+	created, _ := server.CreateWorkspace(ctx, &pb.CreateWorkspaceRequest{UserId: owner.ID, Name: "Team"})
+	if created.Error != "" || created.Workspace.Role != entity.WorkspaceRoleOwner {
+		t.Fatalf("CreateWorkspace should succeed: %+v", created)
+	}
+	workspaceID := created.Workspace.Id
+
+	// Act & Assert - 招待するとメールが送られる
+	invited, _ := server.InviteToWorkspace(ctx, &pb.InviteToWorkspaceRequest{WorkspaceId: workspaceID, UserId: owner.ID, Email: viewer.Email, Role: entity.WorkspaceRoleViewer})
+	if invited.Error != "" {
+		t.Fatalf("InviteToWorkspace should succeed: %s", invited.Error)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != viewer.Email {
+		t.Errorf("Expected an invitation email to %s, got %v", viewer.Email, mailer.messages)
+	}
+
+	// Act & Assert - メンバーになる前は not_member
+	membership, _ := server.GetWorkspaceMembership(ctx, &pb.GetWorkspaceMembershipRequest{WorkspaceId: workspaceID, UserId: viewer.ID})
+	if !membership.NotMember || membership.Role != "" {
+		t.Errorf("Expected not_member, got %+v", membership)
+	}
+
+	// Act & Assert - 受け入れると閲覧者になる
+	invitations, _ := server.ListWorkspaceInvitations(ctx, &pb.ListWorkspaceInvitationsRequest{UserId: viewer.ID})
+	if len(invitations.Invitations) != 1 || invitations.Invitations[0].WorkspaceName != "Team" {
+		t.Fatalf("Expected the invitation to be listed, got %+v", invitations)
+	}
+	accepted, _ := server.AcceptWorkspaceInvitation(ctx, &pb.AcceptWorkspaceInvitationRequest{Id: invitations.Invitations[0].Id, UserId: viewer.ID})
+	if accepted.Error != "" || accepted.Workspace.Role != entity.WorkspaceRoleViewer {
+		t.Fatalf("AcceptWorkspaceInvitation should succeed: %+v", accepted)
+	}
+	membership, _ = server.GetWorkspaceMembership(ctx, &pb.GetWorkspaceMembershipRequest{WorkspaceId: workspaceID, UserId: viewer.ID})
+	if membership.NotMember || membership.Role != entity.WorkspaceRoleViewer {
+		t.Errorf("Expected viewer role, got %+v", membership)
+	}
+
+	// Act & Assert - メンバー一覧にはメールアドレスが含まれる
+	got, _ := server.GetWorkspace(ctx, &pb.GetWorkspaceRequest{Id: workspaceID, UserId: viewer.ID})
+	if got.Error != "" || len(got.Members) != 2 {
+		t.Fatalf("Expected 2 members, got %+v", got)
+	}
+
+	// Act & Assert - 閲覧者は役割を変えられない
+	denied, _ := server.SetWorkspaceMemberRole(ctx, &pb.SetWorkspaceMemberRoleRequest{WorkspaceId: workspaceID, UserId: viewer.ID, MemberId: viewer.ID, Role: entity.WorkspaceRoleOwner})
+	if denied.Error == "" {
+		t.Errorf("Expected an error for a viewer")
+	}
+
+	// Act & Assert - 閲覧者が抜けてもワークスペースは残る
+	left, _ := server.LeaveWorkspace(ctx, &pb.LeaveWorkspaceRequest{WorkspaceId: workspaceID, UserId: viewer.ID})
+	if !left.Success || left.WorkspaceDeleted {
+		t.Errorf("Expected the viewer to leave, got %+v", left)
+	}
+
+	// Act & Assert - 最後のメンバーが抜けると削除される
+	left, _ = server.LeaveWorkspace(ctx, &pb.LeaveWorkspaceRequest{WorkspaceId: workspaceID, UserId: owner.ID})
+	if !left.Success || !left.WorkspaceDeleted {
+		t.Errorf("Expected the workspace to be deleted, got %+v", left)
+	}
+}
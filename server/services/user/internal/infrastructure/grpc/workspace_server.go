@@ -0,0 +1,196 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "github.com/tadasy/mytodo202507/proto"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/entity"
+)
+
+func (s *UserServer) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.CreateWorkspaceResponse, error) {
+	membership, err := s.workspaceService.Create(req.UserId, req.Name)
+	if err != nil {
+		return &pb.CreateWorkspaceResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateWorkspaceResponse{
+		Workspace: workspaceToProto(membership),
+	}, nil
+}
+
+func (s *UserServer) ListWorkspaces(ctx context.Context, req *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	memberships, err := s.workspaceService.List(req.UserId)
+	if err != nil {
+		return &pb.ListWorkspacesResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbWorkspaces []*pb.Workspace
+	for _, membership := range memberships {
+		pbWorkspaces = append(pbWorkspaces, workspaceToProto(membership))
+	}
+
+	return &pb.ListWorkspacesResponse{
+		Workspaces: pbWorkspaces,
+	}, nil
+}
+
+func (s *UserServer) GetWorkspace(ctx context.Context, req *pb.GetWorkspaceRequest) (*pb.GetWorkspaceResponse, error) {
+	membership, members, err := s.workspaceService.Get(req.UserId, req.Id)
+	if err != nil {
+		return &pb.GetWorkspaceResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbMembers []*pb.WorkspaceMember
+	for _, member := range members {
+		pbMembers = append(pbMembers, workspaceMemberToProto(member))
+	}
+
+	return &pb.GetWorkspaceResponse{
+		Workspace: workspaceToProto(membership),
+		Members:   pbMembers,
+	}, nil
+}
+
+func (s *UserServer) GetWorkspaceMembership(ctx context.Context, req *pb.GetWorkspaceMembershipRequest) (*pb.GetWorkspaceMembershipResponse, error) {
+	role, err := s.workspaceService.Role(req.WorkspaceId, req.UserId)
+	if err != nil {
+		return &pb.GetWorkspaceMembershipResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetWorkspaceMembershipResponse{
+		Role:      role,
+		NotMember: role == "",
+	}, nil
+}
+
+func (s *UserServer) InviteToWorkspace(ctx context.Context, req *pb.InviteToWorkspaceRequest) (*pb.InviteToWorkspaceResponse, error) {
+	invitation, err := s.workspaceService.Invite(req.UserId, req.WorkspaceId, req.Email, req.Role)
+	if err != nil {
+		return &pb.InviteToWorkspaceResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	// 招待は作成済みで一覧から受け入れられるので、送信の失敗は記録だけする
+	if err := s.workspaceService.SendInvitation(invitation); err != nil {
+		log.Printf("Failed to send workspace invitation %s: %v", invitation.ID, err)
+	}
+
+	return &pb.InviteToWorkspaceResponse{
+		Invitation: workspaceInvitationToProto(invitation),
+	}, nil
+}
+
+func (s *UserServer) ListWorkspaceInvitations(ctx context.Context, req *pb.ListWorkspaceInvitationsRequest) (*pb.ListWorkspaceInvitationsResponse, error) {
+	invitations, err := s.workspaceService.ListInvitations(req.UserId)
+	if err != nil {
+		return &pb.ListWorkspaceInvitationsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	var pbInvitations []*pb.WorkspaceInvitation
+	for _, invitation := range invitations {
+		pbInvitations = append(pbInvitations, workspaceInvitationToProto(invitation))
+	}
+
+	return &pb.ListWorkspaceInvitationsResponse{
+		Invitations: pbInvitations,
+	}, nil
+}
+
+func (s *UserServer) AcceptWorkspaceInvitation(ctx context.Context, req *pb.AcceptWorkspaceInvitationRequest) (*pb.AcceptWorkspaceInvitationResponse, error) {
+	membership, err := s.workspaceService.Accept(req.UserId, req.Id)
+	if err != nil {
+		return &pb.AcceptWorkspaceInvitationResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.AcceptWorkspaceInvitationResponse{
+		Workspace: workspaceToProto(membership),
+	}, nil
+}
+
+func (s *UserServer) LeaveWorkspace(ctx context.Context, req *pb.LeaveWorkspaceRequest) (*pb.LeaveWorkspaceResponse, error) {
+	deleted, err := s.workspaceService.Leave(req.UserId, req.WorkspaceId)
+	if err != nil {
+		return &pb.LeaveWorkspaceResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.LeaveWorkspaceResponse{
+		Success:          true,
+		WorkspaceDeleted: deleted,
+	}, nil
+}
+
+func (s *UserServer) SetWorkspaceMemberRole(ctx context.Context, req *pb.SetWorkspaceMemberRoleRequest) (*pb.SetWorkspaceMemberRoleResponse, error) {
+	member, err := s.workspaceService.SetMemberRole(req.UserId, req.WorkspaceId, req.MemberId, req.Role)
+	if err != nil {
+		return &pb.SetWorkspaceMemberRoleResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.SetWorkspaceMemberRoleResponse{
+		Member: workspaceMemberToProto(member),
+	}, nil
+}
+
+func (s *UserServer) RemoveWorkspaceMember(ctx context.Context, req *pb.RemoveWorkspaceMemberRequest) (*pb.RemoveWorkspaceMemberResponse, error) {
+	if err := s.workspaceService.RemoveMember(req.UserId, req.WorkspaceId, req.MemberId); err != nil {
+		return &pb.RemoveWorkspaceMemberResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &pb.RemoveWorkspaceMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func workspaceToProto(membership *entity.WorkspaceMembership) *pb.Workspace {
+	return &pb.Workspace{
+		Id:        membership.Workspace.ID,
+		Name:      membership.Workspace.Name,
+		CreatedAt: membership.Workspace.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: membership.Workspace.UpdatedAt.Format(time.RFC3339),
+		Role:      membership.Role,
+	}
+}
+
+func workspaceMemberToProto(member *entity.WorkspaceMember) *pb.WorkspaceMember {
+	return &pb.WorkspaceMember{
+		UserId:   member.UserID,
+		Email:    member.Email,
+		Role:     member.Role,
+		JoinedAt: member.JoinedAt.Format(time.RFC3339),
+	}
+}
+
+func workspaceInvitationToProto(invitation *entity.WorkspaceInvitation) *pb.WorkspaceInvitation {
+	return &pb.WorkspaceInvitation{
+		Id:            invitation.ID,
+		WorkspaceId:   invitation.WorkspaceID,
+		WorkspaceName: invitation.WorkspaceName,
+		Email:         invitation.Email,
+		Role:          invitation.Role,
+		InvitedBy:     invitation.InvitedBy,
+		CreatedAt:     invitation.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:     invitation.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// SMTPMailer sends mail through an SMTP server, authenticating with PLAIN
+// when a username is given
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (m *SMTPMailer) Send(msg service.MailMessage) error {
+	data, err := formatMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, data)
+}
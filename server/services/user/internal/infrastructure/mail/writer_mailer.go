@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+)
+
+// separator ends every message written by WriterMailer
+const separator = "\r\n----\r\n"
+
+// WriterMailer writes messages to a writer such as stdout or a file instead
+// of delivering them, for local development and tests
+type WriterMailer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewWriterMailer(w io.Writer, from string) *WriterMailer {
+	return &WriterMailer{w: w, from: from}
+}
+
+func (m *WriterMailer) Send(msg service.MailMessage) error {
+	data, err := formatMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.w.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(m.w, separator)
+	return err
+}
@@ -0,0 +1,60 @@
+package mail_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tadasy/mytodo202507/server/services/user/internal/domain/service"
+	"github.com/tadasy/mytodo202507/server/services/user/internal/infrastructure/mail"
+)
+
+func TestWriterMailer_Send(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	mailer := mail.NewWriterMailer(&buf, "no-reply@example.com")
+
+	// Act
+	err := mailer.Send(service.MailMessage{
+		To:      "test@example.com",
+		Subject: "パスワードの再設定",
+		Body:    "line 1\nline 2\n",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Send should succeed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"From: no-reply@example.com\r\n",
+		"To: test@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\nline 1\r\nline 2\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Message should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriterMailer_RejectsHeaderInjection(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	mailer := mail.NewWriterMailer(&buf, "no-reply@example.com")
+
+	// Act - 改行を含むヘッダーは拒否される
+	err := mailer.Send(service.MailMessage{
+		To:      "test@example.com\r\nBcc: attacker@example.com",
+		Subject: "Hello",
+	})
+
+	// Assert
+	if err != mail.ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Nothing should be written")
+	}
+}